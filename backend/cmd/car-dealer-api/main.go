@@ -22,9 +22,10 @@ func init() {
 	initializers.ConnectToDatabase()
 
 	initializers.ConnectToRedis(context.Background())
-	initializers.ConnectToCloudinary()
+	initializers.ConnectToImageStorage()
 	initializers.InitializeVerifier()
 	initializers.InitializeRepos()
+	initializers.PromoteInitialAdmin()
 	initializers.InitializeServices()
 	initializers.InitializeHub()
 	initializers.InitializeScheduler()
@@ -0,0 +1,35 @@
+package admin
+
+import "github.com/susek555/BD2/car-dealer-api/pkg/pagination"
+
+type AuditLogFilter struct {
+	Pagination pagination.PaginationRequest `json:"pagination"`
+	Action     *string                      `json:"action"`
+	AdminID    *uint                        `json:"admin_id"`
+	TargetID   *uint                        `json:"target_id"`
+}
+
+type ChangeRoleDTO struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type RetrieveAuditLogDTO struct {
+	ID        uint    `json:"id"`
+	AdminID   uint    `json:"admin_id"`
+	Action    string  `json:"action"`
+	TargetID  uint    `json:"target_id"`
+	Reason    *string `json:"reason,omitempty"`
+	CreatedAt string  `json:"created_at"`
+}
+
+type RetrieveAuditLogsWithPagination struct {
+	Entries            []RetrieveAuditLogDTO          `json:"entries"`
+	PaginationResponse *pagination.PaginationResponse `json:"pagination"`
+}
+
+type RetrieveBidDTO struct {
+	ID        uint   `json:"id"`
+	BidderID  uint   `json:"bidder_id"`
+	Amount    uint   `json:"amount"`
+	CreatedAt string `json:"created_at"`
+}
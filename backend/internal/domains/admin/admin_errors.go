@@ -0,0 +1,40 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/susek555/BD2/car-dealer-api/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrOfferNotPublished   = errors.New("only published offers can be unpublished")
+	ErrOfferSold           = errors.New("sold offers cannot be deleted")
+	ErrOfferNotAuction     = errors.New("offer is not an auction")
+	ErrCannotBanYourself   = errors.New("you cannot ban yourself")
+	ErrCannotBanStaff      = errors.New("moderators and admins cannot be banned")
+	ErrUserAlreadyBanned   = errors.New("user is already banned")
+	ErrUserNotBanned       = errors.New("user is not banned")
+	ErrInvalidRole         = errors.New("invalid role")
+	ErrCannotChangeOwnRole = errors.New("you cannot change your own role")
+	ErrUserAlreadyHasRole  = errors.New("user already has this role")
+	ErrInvalidAdminAction  = errors.New("invalid admin action")
+)
+
+var ErrorMap = map[error]int{
+	ErrOfferNotPublished:           http.StatusConflict,
+	ErrOfferSold:                   http.StatusConflict,
+	ErrOfferNotAuction:             http.StatusBadRequest,
+	ErrCannotBanYourself:           http.StatusBadRequest,
+	ErrCannotBanStaff:              http.StatusForbidden,
+	ErrUserAlreadyBanned:           http.StatusConflict,
+	ErrUserNotBanned:               http.StatusConflict,
+	ErrInvalidAdminAction:          http.StatusBadRequest,
+	ErrInvalidRole:                 http.StatusBadRequest,
+	ErrCannotChangeOwnRole:         http.StatusBadRequest,
+	ErrUserAlreadyHasRole:          http.StatusConflict,
+	pagination.ErrPageOutOfRange:   http.StatusBadRequest,
+	pagination.ErrNegativePageSize: http.StatusBadRequest,
+	gorm.ErrRecordNotFound:         http.StatusNotFound,
+}
@@ -0,0 +1,231 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/auth"
+	"github.com/susek555/BD2/car-dealer-api/pkg/custom_errors"
+)
+
+type Handler struct {
+	service AdminServiceInterface
+}
+
+func NewHandler(service AdminServiceInterface) *Handler {
+	return &Handler{service: service}
+}
+
+// UnpublishSaleOffer godoc
+//
+//	@Summary		Unpublish any offer
+//	@Description	Takes a published offer off the market. The offer goes back to the ready state, so its owner can correct and publish it again. The action is recorded in the audit log. You have to be a moderator or an admin to perform this operation.
+//	@Tags			admin
+//	@Param			id		path	uint	true	"Sale offer ID"
+//	@Param			reason	query	string	false	"Reason saved in the audit log"
+//	@Success		204		"No content"
+//	@Failure		400		{object}	custom_errors.HTTPError	"Invalid input data"
+//	@Failure		401		{object}	custom_errors.HTTPError	"Unauthorized - user not logged in"
+//	@Failure		403		{object}	custom_errors.HTTPError	"Forbidden - user is not a moderator"
+//	@Failure		404		{object}	custom_errors.HTTPError	"Sale offer not found"
+//	@Failure		409		{object}	custom_errors.HTTPError	"Offer is not published"
+//	@Failure		500		{object}	custom_errors.HTTPError	"Internal server error"
+//	@Router			/admin/sale-offer/unpublish/{id} [put]
+//	@Security		Bearer
+func (h *Handler) UnpublishSaleOffer(c *gin.Context) {
+	h.performAction(c, h.service.UnpublishOffer)
+}
+
+// DeleteSaleOffer godoc
+//
+//	@Summary		Delete any offer
+//	@Description	Deletes the offer together with its images. Sold offers cannot be deleted. The action is recorded in the audit log. You have to be an admin to perform this operation.
+//	@Tags			admin
+//	@Param			id		path	uint	true	"Sale offer ID"
+//	@Param			reason	query	string	false	"Reason saved in the audit log"
+//	@Success		204		"No content"
+//	@Failure		400		{object}	custom_errors.HTTPError	"Invalid input data"
+//	@Failure		401		{object}	custom_errors.HTTPError	"Unauthorized - user not logged in"
+//	@Failure		403		{object}	custom_errors.HTTPError	"Forbidden - user is not an admin"
+//	@Failure		404		{object}	custom_errors.HTTPError	"Sale offer not found"
+//	@Failure		409		{object}	custom_errors.HTTPError	"Offer is already sold"
+//	@Failure		500		{object}	custom_errors.HTTPError	"Internal server error"
+//	@Router			/admin/sale-offer/{id} [delete]
+//	@Security		Bearer
+func (h *Handler) DeleteSaleOffer(c *gin.Context) {
+	h.performAction(c, h.service.DeleteOffer)
+}
+
+// DeleteReview godoc
+//
+//	@Summary		Delete any review
+//	@Description	Deletes an abusive review. The action is recorded in the audit log. You have to be a moderator or an admin to perform this operation.
+//	@Tags			admin
+//	@Param			id		path	uint	true	"Review ID"
+//	@Param			reason	query	string	false	"Reason saved in the audit log"
+//	@Success		204		"No content"
+//	@Failure		400		{object}	custom_errors.HTTPError	"Invalid input data"
+//	@Failure		401		{object}	custom_errors.HTTPError	"Unauthorized - user not logged in"
+//	@Failure		403		{object}	custom_errors.HTTPError	"Forbidden - user is not a moderator"
+//	@Failure		404		{object}	custom_errors.HTTPError	"Review not found"
+//	@Failure		500		{object}	custom_errors.HTTPError	"Internal server error"
+//	@Router			/admin/review/{id} [delete]
+//	@Security		Bearer
+func (h *Handler) DeleteReview(c *gin.Context) {
+	h.performAction(c, h.service.DeleteReview)
+}
+
+// BanUser godoc
+//
+//	@Summary		Ban a user
+//	@Description	Bans the user - they cannot log in nor refresh their access token anymore. Moderators and admins cannot be banned. The action is recorded in the audit log. You have to be an admin to perform this operation.
+//	@Tags			admin
+//	@Param			id		path	uint	true	"User ID"
+//	@Param			reason	query	string	false	"Reason saved in the audit log"
+//	@Success		204		"No content"
+//	@Failure		400		{object}	custom_errors.HTTPError	"Invalid input data"
+//	@Failure		401		{object}	custom_errors.HTTPError	"Unauthorized - user not logged in"
+//	@Failure		403		{object}	custom_errors.HTTPError	"Forbidden - user is not an admin or the banned user is a moderator"
+//	@Failure		404		{object}	custom_errors.HTTPError	"User not found"
+//	@Failure		409		{object}	custom_errors.HTTPError	"User is already banned"
+//	@Failure		500		{object}	custom_errors.HTTPError	"Internal server error"
+//	@Router			/admin/user/ban/{id} [put]
+//	@Security		Bearer
+func (h *Handler) BanUser(c *gin.Context) {
+	h.performAction(c, h.service.BanUser)
+}
+
+// UnbanUser godoc
+//
+//	@Summary		Unban a user
+//	@Description	Lifts the ban, so the user can log in again. The action is recorded in the audit log. You have to be an admin to perform this operation.
+//	@Tags			admin
+//	@Param			id		path	uint	true	"User ID"
+//	@Param			reason	query	string	false	"Reason saved in the audit log"
+//	@Success		204		"No content"
+//	@Failure		400		{object}	custom_errors.HTTPError	"Invalid input data"
+//	@Failure		401		{object}	custom_errors.HTTPError	"Unauthorized - user not logged in"
+//	@Failure		403		{object}	custom_errors.HTTPError	"Forbidden - user is not an admin"
+//	@Failure		409		{object}	custom_errors.HTTPError	"User is not banned"
+//	@Failure		500		{object}	custom_errors.HTTPError	"Internal server error"
+//	@Router			/admin/user/unban/{id} [put]
+//	@Security		Bearer
+func (h *Handler) UnbanUser(c *gin.Context) {
+	h.performAction(c, h.service.UnbanUser)
+}
+
+// ChangeUserRole godoc
+//
+//	@Summary		Change the role of a user
+//	@Description	Makes the user a regular user, a moderator or an admin. The user has to log in again for the new role to take effect. You cannot change your own role. The action is recorded in the audit log. You have to be an admin to perform this operation.
+//	@Tags			admin
+//	@Accept			json
+//	@Param			id		path	uint			true	"User ID"
+//	@Param			role	body	ChangeRoleDTO	true	"New role - User, Moderator or Admin"
+//	@Param			reason	query	string			false	"Reason saved in the audit log"
+//	@Success		204		"No content"
+//	@Failure		400		{object}	custom_errors.HTTPError	"Invalid input data, invalid role or own role"
+//	@Failure		401		{object}	custom_errors.HTTPError	"Unauthorized - user not logged in"
+//	@Failure		403		{object}	custom_errors.HTTPError	"Forbidden - user is not an admin"
+//	@Failure		404		{object}	custom_errors.HTTPError	"User not found"
+//	@Failure		409		{object}	custom_errors.HTTPError	"User already has this role"
+//	@Failure		500		{object}	custom_errors.HTTPError	"Internal server error"
+//	@Router			/admin/users/{id}/role [put]
+//	@Security		Bearer
+func (h *Handler) ChangeUserRole(c *gin.Context) {
+	var dto ChangeRoleDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	h.performAction(c, func(adminID, userID uint, reason *string) error {
+		return h.service.ChangeRole(adminID, userID, dto.Role, reason)
+	})
+}
+
+// GetAuctionBids godoc
+//
+//	@Summary		Get all bids of an auction
+//	@Description	Returns all bids placed in the auction, newest first. Viewing the bids is recorded in the audit log. You have to be a moderator or an admin to perform this operation.
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id	path		uint					true	"Auction ID"
+//	@Success		200	{array}		RetrieveBidDTO			"List of bids"
+//	@Failure		400	{object}	custom_errors.HTTPError	"Invalid input data or offer is not an auction"
+//	@Failure		401	{object}	custom_errors.HTTPError	"Unauthorized - user not logged in"
+//	@Failure		403	{object}	custom_errors.HTTPError	"Forbidden - user is not a moderator"
+//	@Failure		404	{object}	custom_errors.HTTPError	"Auction not found"
+//	@Failure		500	{object}	custom_errors.HTTPError	"Internal server error"
+//	@Router			/admin/bid/auction/{id} [get]
+//	@Security		Bearer
+func (h *Handler) GetAuctionBids(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	adminID, err := auth.GetUserID(c)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	bids, err := h.service.GetBids(adminID, uint(id))
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	c.JSON(http.StatusOK, bids)
+}
+
+// GetAuditLog godoc
+//
+//	@Summary		Get the audit log
+//	@Description	Returns a paginated list of actions taken by moderators and admins, newest first. The list can be narrowed down to an action, an admin or a target. You have to be an admin to perform this operation.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			filter	body		AuditLogFilter					true	"Audit log filter"
+//	@Success		200		{object}	RetrieveAuditLogsWithPagination	"Audit log entries"
+//	@Failure		400		{object}	custom_errors.HTTPError			"Invalid input data"
+//	@Failure		401		{object}	custom_errors.HTTPError			"Unauthorized - user not logged in"
+//	@Failure		403		{object}	custom_errors.HTTPError			"Forbidden - user is not an admin"
+//	@Failure		500		{object}	custom_errors.HTTPError			"Internal server error"
+//	@Router			/admin/audit-log [post]
+//	@Security		Bearer
+func (h *Handler) GetAuditLog(c *gin.Context) {
+	var filter AuditLogFilter
+	if err := c.ShouldBindJSON(&filter); err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	entries, err := h.service.GetAuditLog(&filter)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	c.JSON(http.StatusOK, entries)
+}
+
+// performAction runs a moderation action on the target given in the path, on behalf of the logged in admin.
+func (h *Handler) performAction(c *gin.Context, action func(adminID, targetID uint, reason *string) error) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	adminID, err := auth.GetUserID(c)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	var reason *string
+	if r := c.Query("reason"); r != "" {
+		reason = &r
+	}
+	if err := action(adminID, uint(id), reason); err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,38 @@
+package admin
+
+import (
+	"time"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+)
+
+func NewAuditLog(adminID uint, action enums.AdminAction, targetID uint, reason *string) *models.AdminAuditLog {
+	return &models.AdminAuditLog{
+		AdminID:   adminID,
+		Action:    action,
+		TargetID:  targetID,
+		Reason:    reason,
+		CreatedAt: time.Now(),
+	}
+}
+
+func MapToAuditLogDTO(entry *models.AdminAuditLog) *RetrieveAuditLogDTO {
+	return &RetrieveAuditLogDTO{
+		ID:        entry.ID,
+		AdminID:   entry.AdminID,
+		Action:    string(entry.Action),
+		TargetID:  entry.TargetID,
+		Reason:    entry.Reason,
+		CreatedAt: entry.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func MapToBidDTO(bid *models.Bid) *RetrieveBidDTO {
+	return &RetrieveBidDTO{
+		ID:        bid.ID,
+		BidderID:  bid.BidderID,
+		Amount:    bid.Amount,
+		CreatedAt: bid.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,158 @@
+package admin
+
+import (
+	"time"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"github.com/susek555/BD2/car-dealer-api/pkg/pagination"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockery --name=AdminRepositoryInterface --output=../../test/mocks --case=snake --with-expecter
+type AdminRepositoryInterface interface {
+	UnpublishOffer(offerID uint, entry *models.AdminAuditLog) error
+	DeleteOffer(offerID uint, entry *models.AdminAuditLog) error
+	DeleteReview(reviewID uint, entry *models.AdminAuditLog) error
+	BanUser(userID uint, bannedAt time.Time, entry *models.AdminAuditLog) error
+	UnbanUser(userID uint, entry *models.AdminAuditLog) error
+	ChangeRole(userID uint, role enums.Role, entry *models.AdminAuditLog) error
+	LogAction(entry *models.AdminAuditLog) error
+	GetAuditLog(filter *AuditLogFilter) ([]models.AdminAuditLog, *pagination.PaginationResponse, error)
+}
+
+// AdminRepository performs the moderation actions. Each of them is saved in one transaction
+// together with its audit log entry, so no action goes unrecorded.
+type AdminRepository struct {
+	DB *gorm.DB
+}
+
+func NewAdminRepository(db *gorm.DB) AdminRepositoryInterface {
+	return &AdminRepository{DB: db}
+}
+
+// UnpublishOffer takes the offer off the market - it goes back to the ready state, so the owner can
+// correct and publish it again.
+func (r *AdminRepository) UnpublishOffer(offerID uint, entry *models.AdminAuditLog) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var offer models.SaleOffer
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&offer, offerID).Error; err != nil {
+			return err
+		}
+		if offer.Status != enums.PUBLISHED {
+			return ErrOfferNotPublished
+		}
+		event := sale_offer.NewStatusChangedEvent(offer.ID, &entry.AdminID, offer.Status, enums.READY)
+		if err := tx.Model(&offer).Update("status", enums.READY).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&event).Error; err != nil {
+			return err
+		}
+		return tx.Create(entry).Error
+	})
+}
+
+func (r *AdminRepository) DeleteOffer(offerID uint, entry *models.AdminAuditLog) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var offer models.SaleOffer
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&offer, offerID).Error; err != nil {
+			return err
+		}
+		if offer.Status == enums.SOLD {
+			return ErrOfferSold
+		}
+		if err := tx.Delete(&offer).Error; err != nil {
+			return err
+		}
+		return tx.Create(entry).Error
+	})
+}
+
+func (r *AdminRepository) DeleteReview(reviewID uint, entry *models.AdminAuditLog) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&models.Review{}, reviewID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Create(entry).Error
+	})
+}
+
+// BanUser marks the user as banned and removes their refresh tokens, so they can neither log in
+// nor refresh the access token they already have.
+func (r *AdminRepository) BanUser(userID uint, bannedAt time.Time, entry *models.AdminAuditLog) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.User{}).
+			Where("id = ? AND banned_at IS NULL", userID).
+			Update("banned_at", bannedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrUserAlreadyBanned
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RefreshToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(entry).Error
+	})
+}
+
+func (r *AdminRepository) UnbanUser(userID uint, entry *models.AdminAuditLog) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.User{}).
+			Where("id = ? AND banned_at IS NOT NULL", userID).
+			Update("banned_at", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrUserNotBanned
+		}
+		return tx.Create(entry).Error
+	})
+}
+
+// ChangeRole gives the user the role and removes their refresh tokens, so their next access token,
+// which carries the role, is issued only after they log in again.
+func (r *AdminRepository) ChangeRole(userID uint, role enums.Role, entry *models.AdminAuditLog) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.User{}).
+			Where("id = ? AND role <> ?", userID, role).
+			Update("role", role)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrUserAlreadyHasRole
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RefreshToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(entry).Error
+	})
+}
+
+func (r *AdminRepository) LogAction(entry *models.AdminAuditLog) error {
+	return r.DB.Create(entry).Error
+}
+
+func (r *AdminRepository) GetAuditLog(filter *AuditLogFilter) ([]models.AdminAuditLog, *pagination.PaginationResponse, error) {
+	query := r.DB.Order("created_at DESC, id DESC")
+	if filter.Action != nil {
+		query = query.Where("action = ?", enums.AdminAction(*filter.Action))
+	}
+	if filter.AdminID != nil {
+		query = query.Where("admin_id = ?", *filter.AdminID)
+	}
+	if filter.TargetID != nil {
+		query = query.Where("target_id = ?", *filter.TargetID)
+	}
+	return pagination.PaginateResults[models.AdminAuditLog](&filter.Pagination, query)
+}
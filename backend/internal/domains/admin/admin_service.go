@@ -0,0 +1,157 @@
+package admin
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+)
+
+type SaleOfferRetrieverInterface interface {
+	GetByID(id uint) (*models.SaleOffer, error)
+}
+
+type UserRetrieverInterface interface {
+	GetByID(id uint) (*models.User, error)
+}
+
+type BidRetrieverInterface interface {
+	GetByAuctionID(auctionID uint) ([]models.Bid, error)
+}
+
+type ImageRemoverInterface interface {
+	DeleteByFolderName(folder string) error
+}
+
+type AdminServiceInterface interface {
+	UnpublishOffer(adminID, offerID uint, reason *string) error
+	DeleteOffer(adminID, offerID uint, reason *string) error
+	DeleteReview(adminID, reviewID uint, reason *string) error
+	BanUser(adminID, userID uint, reason *string) error
+	UnbanUser(adminID, userID uint, reason *string) error
+	ChangeRole(adminID, userID uint, role string, reason *string) error
+	GetBids(adminID, auctionID uint) ([]RetrieveBidDTO, error)
+	GetAuditLog(filter *AuditLogFilter) (*RetrieveAuditLogsWithPagination, error)
+}
+
+type AdminService struct {
+	repo               AdminRepositoryInterface
+	saleOfferRetriever SaleOfferRetrieverInterface
+	userRetriever      UserRetrieverInterface
+	bidRetriever       BidRetrieverInterface
+	imageRemover       ImageRemoverInterface
+}
+
+func NewAdminService(
+	repo AdminRepositoryInterface,
+	saleOfferRetriever SaleOfferRetrieverInterface,
+	userRetriever UserRetrieverInterface,
+	bidRetriever BidRetrieverInterface,
+	imageRemover ImageRemoverInterface,
+) AdminServiceInterface {
+	return &AdminService{
+		repo:               repo,
+		saleOfferRetriever: saleOfferRetriever,
+		userRetriever:      userRetriever,
+		bidRetriever:       bidRetriever,
+		imageRemover:       imageRemover,
+	}
+}
+
+func (s *AdminService) UnpublishOffer(adminID, offerID uint, reason *string) error {
+	return s.repo.UnpublishOffer(offerID, NewAuditLog(adminID, enums.UNPUBLISH_OFFER, offerID, reason))
+}
+
+func (s *AdminService) DeleteOffer(adminID, offerID uint, reason *string) error {
+	if err := s.repo.DeleteOffer(offerID, NewAuditLog(adminID, enums.DELETE_OFFER, offerID, reason)); err != nil {
+		return err
+	}
+	return s.imageRemover.DeleteByFolderName(fmt.Sprintf("sale-offer-%d", offerID))
+}
+
+func (s *AdminService) DeleteReview(adminID, reviewID uint, reason *string) error {
+	return s.repo.DeleteReview(reviewID, NewAuditLog(adminID, enums.DELETE_REVIEW, reviewID, reason))
+}
+
+func (s *AdminService) BanUser(adminID, userID uint, reason *string) error {
+	if adminID == userID {
+		return ErrCannotBanYourself
+	}
+	user, err := s.userRetriever.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.IsStaff() {
+		return ErrCannotBanStaff
+	}
+	if user.IsBanned() {
+		return ErrUserAlreadyBanned
+	}
+	return s.repo.BanUser(userID, time.Now(), NewAuditLog(adminID, enums.BAN_USER, userID, reason))
+}
+
+func (s *AdminService) UnbanUser(adminID, userID uint, reason *string) error {
+	return s.repo.UnbanUser(userID, NewAuditLog(adminID, enums.UNBAN_USER, userID, reason))
+}
+
+// ChangeRole makes the user a regular user, a moderator or an admin. Admins cannot change their own role,
+// so the last admin cannot lock everyone out of the admin panel.
+func (s *AdminService) ChangeRole(adminID, userID uint, role string, reason *string) error {
+	if !slices.Contains(enums.Roles, enums.Role(role)) {
+		return ErrInvalidRole
+	}
+	if adminID == userID {
+		return ErrCannotChangeOwnRole
+	}
+	user, err := s.userRetriever.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.Role == enums.Role(role) {
+		return ErrUserAlreadyHasRole
+	}
+	return s.repo.ChangeRole(userID, enums.Role(role), NewAuditLog(adminID, enums.CHANGE_ROLE, userID, reason))
+}
+
+// GetBids returns all bids of the auction, newest first. Viewing them is recorded in the audit log as well.
+func (s *AdminService) GetBids(adminID, auctionID uint) ([]RetrieveBidDTO, error) {
+	offer, err := s.saleOfferRetriever.GetByID(auctionID)
+	if err != nil {
+		return nil, err
+	}
+	if !offer.IsAuctionOffer() {
+		return nil, ErrOfferNotAuction
+	}
+	bids, err := s.bidRetriever.GetByAuctionID(auctionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.LogAction(NewAuditLog(adminID, enums.VIEW_BIDS, auctionID, nil)); err != nil {
+		return nil, err
+	}
+	slices.SortFunc(bids, func(a, b models.Bid) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	bidDTOs := make([]RetrieveBidDTO, 0, len(bids))
+	for _, bid := range bids {
+		bidDTOs = append(bidDTOs, *MapToBidDTO(&bid))
+	}
+	return bidDTOs, nil
+}
+
+func (s *AdminService) GetAuditLog(filter *AuditLogFilter) (*RetrieveAuditLogsWithPagination, error) {
+	if filter.Action != nil && !slices.Contains(enums.AdminActions, enums.AdminAction(*filter.Action)) {
+		return nil, ErrInvalidAdminAction
+	}
+	entries, paginationResponse, err := s.repo.GetAuditLog(filter)
+	if err != nil {
+		return nil, err
+	}
+	entryDTOs := make([]RetrieveAuditLogDTO, 0, len(entries))
+	for _, entry := range entries {
+		entryDTOs = append(entryDTOs, *MapToAuditLogDTO(&entry))
+	}
+	return &RetrieveAuditLogsWithPagination{Entries: entryDTOs, PaginationResponse: paginationResponse}, nil
+}
@@ -6,12 +6,14 @@ import (
 
 type CreateAuctionDTO struct {
 	sale_offer.CreateSaleOfferDTO
-	DateEnd     string `json:"date_end"`
-	BuyNowPrice *uint  `json:"buy_now_price,omitempty"`
+	DateEnd      string `json:"date_end"`
+	BuyNowPrice  *uint  `json:"buy_now_price,omitempty"`
+	ReservePrice *uint  `json:"reserve_price,omitempty"`
 }
 
 type UpdateAuctionDTO struct {
 	sale_offer.UpdateSaleOfferDTO
-	DateEnd     *string `json:"date_end,omitempty"`
-	BuyNowPrice *uint   `json:"buy_now_price,omitempty"`
+	DateEnd      *string `json:"date_end,omitempty"`
+	BuyNowPrice  *uint   `json:"buy_now_price,omitempty"`
+	ReservePrice *uint   `json:"reserve_price,omitempty"`
 }
@@ -7,4 +7,11 @@ var (
 	ErrBuyNowPriceLessThanOfferPrice = errors.New("buy now price must be greater than offer price")
 	ErrNewPriceLessThanOfferPrice    = errors.New("new price must be greater than offer price")
 	ErrBuyNowNotAvailable            = errors.New("buy now option is not available for this auction")
+	ErrBidIncrementTooLow            = errors.New("new price must exceed the current price by at least the minimal bid increment")
+	ErrInvalidIncrementTiers         = errors.New("increment tiers must be comma separated from:increment pairs starting from 0")
+	ErrReservePriceLessThanInitial   = errors.New("reserve price cannot be lower than the initial price")
+	ErrReservePriceOverBuyNowPrice   = errors.New("reserve price cannot be greater than buy now price")
+	ErrBuyNowPriceReached            = errors.New("bids have already reached the buy now price")
+	ErrAuctionNotActive              = errors.New("auction is not active anymore")
+	ErrInvalidExtensionPolicy        = errors.New("auction extension window and duration must be valid durations and the cap a non-negative number")
 )
@@ -0,0 +1,60 @@
+package auction
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExtensionPolicy describes anti-sniping - a bid placed less than Window before the end of an auction
+// pushes the end out by Extension, at most MaxExtensions times per auction. Zero Window disables extending.
+type ExtensionPolicy struct {
+	Window        time.Duration
+	Extension     time.Duration
+	MaxExtensions uint
+}
+
+var DefaultExtensionPolicy = ExtensionPolicy{
+	Window:        2 * time.Minute,
+	Extension:     2 * time.Minute,
+	MaxExtensions: 10,
+}
+
+// ParseExtensionPolicy parses the window and the extension as durations (e.g. "2m") and the cap as a number.
+// Empty values fall back to the defaults.
+func ParseExtensionPolicy(window, extension, maxExtensions string) (ExtensionPolicy, error) {
+	policy := DefaultExtensionPolicy
+	if strings.TrimSpace(window) != "" {
+		value, err := time.ParseDuration(strings.TrimSpace(window))
+		if err != nil || value < 0 {
+			return ExtensionPolicy{}, ErrInvalidExtensionPolicy
+		}
+		policy.Window = value
+	}
+	if strings.TrimSpace(extension) != "" {
+		value, err := time.ParseDuration(strings.TrimSpace(extension))
+		if err != nil || value <= 0 {
+			return ExtensionPolicy{}, ErrInvalidExtensionPolicy
+		}
+		policy.Extension = value
+	}
+	if strings.TrimSpace(maxExtensions) != "" {
+		value, err := strconv.ParseUint(strings.TrimSpace(maxExtensions), 10, 32)
+		if err != nil {
+			return ExtensionPolicy{}, ErrInvalidExtensionPolicy
+		}
+		policy.MaxExtensions = uint(value)
+	}
+	return policy, nil
+}
+
+// NewDateEnd returns the end of the auction after a bid placed at bidTime and whether it was extended.
+func (p ExtensionPolicy) NewDateEnd(dateEnd time.Time, extensionCount uint, bidTime time.Time) (time.Time, bool) {
+	if p.Window <= 0 || extensionCount >= p.MaxExtensions {
+		return dateEnd, false
+	}
+	if !bidTime.Before(dateEnd) || dateEnd.Sub(bidTime) > p.Window {
+		return dateEnd, false
+	}
+	return dateEnd.Add(p.Extension), true
+}
@@ -8,11 +8,9 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/auth"
-	"github.com/susek555/BD2/car-dealer-api/internal/domains/notification"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/scheduler"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/ws"
-	"github.com/susek555/BD2/car-dealer-api/internal/models"
 	"github.com/susek555/BD2/car-dealer-api/pkg/custom_errors"
 	"github.com/susek555/BD2/car-dealer-api/pkg/formats"
 )
@@ -20,18 +18,16 @@ import (
 type RetrieveDetailedSaleOfferDTO = sale_offer.RetrieveDetailedSaleOfferDTO
 
 type Handler struct {
-	service             AuctionServiceInterface
-	sched               scheduler.SchedulerInterface
-	hub                 ws.HubInterface
-	notificationService notification.NotificationServiceInterface
+	service AuctionServiceInterface
+	sched   scheduler.SchedulerInterface
+	hub     ws.HubInterface
 }
 
-func NewHandler(service AuctionServiceInterface, sched scheduler.SchedulerInterface, hub ws.HubInterface, notificationService notification.NotificationServiceInterface) *Handler {
+func NewHandler(service AuctionServiceInterface, sched scheduler.SchedulerInterface, hub ws.HubInterface) *Handler {
 	return &Handler{
-		service:             service,
-		sched:               sched,
-		hub:                 hub,
-		notificationService: notificationService,
+		service: service,
+		sched:   sched,
+		hub:     hub,
 	}
 }
 
@@ -198,16 +194,5 @@ func (h *Handler) BuyNow(c *gin.Context) {
 		return
 	}
 	c.Status(http.StatusOK)
-	notification := &models.Notification{
-		OfferID: uint(id),
-	}
-	err = h.notificationService.CreateBuyNowNotification(notification, strconv.FormatUint(uint64(userID), 10), offer)
-	if err != nil {
-		log.Printf("Error creating buy now notification for auction ID %d: %v", id, err)
-		return
-	}
-	h.hub.SaveNotificationForClients(strconv.FormatUint(id, 10), userID, notification)
-	h.hub.SendFourLatestNotificationsToClients(strconv.FormatUint(id, 10), strconv.FormatUint(uint64(userID), 10))
 	h.sched.ForceCloseAuction(strconv.FormatUint(id, 10), userID, offer.GetPrice())
-	h.hub.RemoveRoom(strconv.FormatUint(id, 10))
 }
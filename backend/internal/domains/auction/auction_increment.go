@@ -0,0 +1,69 @@
+package auction
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// IncrementTier defines the minimal raise of a bid for prices starting at From (up to the next tier).
+type IncrementTier struct {
+	From      uint
+	Increment uint
+}
+
+type IncrementTiers []IncrementTier
+
+var DefaultIncrementTiers = IncrementTiers{
+	{From: 0, Increment: 50},
+	{From: 5000, Increment: 100},
+	{From: 20000, Increment: 250},
+	{From: 50000, Increment: 500},
+	{From: 100000, Increment: 1000},
+	{From: 500000, Increment: 5000},
+}
+
+// ParseIncrementTiers parses tiers written as "from:increment" pairs separated by commas, e.g. "0:50,5000:100".
+// Empty input results in the default tiers.
+func ParseIncrementTiers(s string) (IncrementTiers, error) {
+	if strings.TrimSpace(s) == "" {
+		return DefaultIncrementTiers, nil
+	}
+	var tiers IncrementTiers
+	for _, pair := range strings.Split(s, ",") {
+		from, increment, found := strings.Cut(strings.TrimSpace(pair), ":")
+		if !found {
+			return nil, ErrInvalidIncrementTiers
+		}
+		fromValue, err := strconv.ParseUint(from, 10, 32)
+		if err != nil {
+			return nil, ErrInvalidIncrementTiers
+		}
+		incrementValue, err := strconv.ParseUint(increment, 10, 32)
+		if err != nil || incrementValue == 0 {
+			return nil, ErrInvalidIncrementTiers
+		}
+		tiers = append(tiers, IncrementTier{From: uint(fromValue), Increment: uint(incrementValue)})
+	}
+	slices.SortFunc(tiers, func(a, b IncrementTier) int { return cmp.Compare(a.From, b.From) })
+	if tiers[0].From != 0 {
+		return nil, ErrInvalidIncrementTiers
+	}
+	for i := 1; i < len(tiers); i++ {
+		if tiers[i].From == tiers[i-1].From {
+			return nil, ErrInvalidIncrementTiers
+		}
+	}
+	return tiers, nil
+}
+
+func (tiers IncrementTiers) MinimumIncrement(price uint) uint {
+	increment := uint(1)
+	for _, tier := range tiers {
+		if price >= tier.From {
+			increment = tier.Increment
+		}
+	}
+	return increment
+}
@@ -20,6 +20,7 @@ func (dto *CreateAuctionDTO) MapToAuction() (*models.Auction, error) {
 	}
 	auction.DateEnd = endDate
 	auction.BuyNowPrice = dto.BuyNowPrice
+	auction.ReservePrice = dto.ReservePrice
 	auction.InitialPrice = dto.Price
 	return &auction, nil
 }
@@ -45,6 +46,9 @@ func (dto *UpdateAuctionDTO) UpdatedAuctionFromDTO(offer *models.SaleOffer) (*mo
 		}
 		offer.Auction.BuyNowPrice = dto.BuyNowPrice
 	}
+	if dto.ReservePrice != nil {
+		offer.Auction.ReservePrice = dto.ReservePrice
+	}
 	return offer, nil
 }
 
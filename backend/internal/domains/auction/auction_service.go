@@ -4,21 +4,22 @@ import (
 	"time"
 
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/notification"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/outbox"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
 	"github.com/susek555/BD2/car-dealer-api/internal/enums"
 	"github.com/susek555/BD2/car-dealer-api/internal/models"
 )
 
-//go:generate mockery --name=PurchaseCreatorInterface --output=../../test/mocks --case=snake --with-expecter
-type PurchaseCreatorInterface interface {
-	Create(purchase *models.Purchase) error
+// AuctionLockerInterface serializes operations on an auction - the same locker has to be shared
+// with the bid service, so that a bid and a buy now of the same auction never run at once.
+type AuctionLockerInterface interface {
+	Lock(auctionID uint) (func(), error)
 }
 
 //go:generate mockery --name=SaleOfferServiceInterface --output=../../test/mocks --case=snake --with-expecter
 type SaleOfferServiceInterface interface {
 	PrepareForCreateSaleOffer(in *sale_offer.CreateSaleOfferDTO) (*models.SaleOffer, error)
 	PrepareForUpdateSaleOffer(in *sale_offer.UpdateSaleOfferDTO, userID uint) (*models.SaleOffer, error)
-	PrepareForBuySaleOffer(id uint, userID uint) (*models.SaleOffer, error)
 	GetDetailedByID(id uint, userID *uint) (*sale_offer.RetrieveDetailedSaleOfferDTO, error)
 	Delete(id uint, userID uint) error
 }
@@ -28,21 +29,26 @@ type AuctionServiceInterface interface {
 	Create(auction *CreateAuctionDTO) (*sale_offer.RetrieveDetailedSaleOfferDTO, error)
 	Update(auction *UpdateAuctionDTO, userID uint) (*sale_offer.RetrieveDetailedSaleOfferDTO, error)
 	BuyNow(auctionID, userID uint) (notification.SaleOfferInterface, error)
-	UpdatePrice(offerID uint, newPrice uint) error
+	ValidateNewPrice(offerID uint, newPrice uint) error
+	NewDateEnd(offerID uint, bidTime time.Time) (*time.Time, error)
 	Delete(id, userID uint) error
 }
 
 type AuctionService struct {
 	saleOfferRepo    sale_offer.SaleOfferRepositoryInterface
 	saleOfferService SaleOfferServiceInterface
-	purchaseCreator  PurchaseCreatorInterface
+	auctionLocker    AuctionLockerInterface
+	incrementTiers   IncrementTiers
+	extensionPolicy  ExtensionPolicy
 }
 
-func NewAuctionService(repo sale_offer.SaleOfferRepositoryInterface, service SaleOfferServiceInterface, purchaseCreator PurchaseCreatorInterface) AuctionServiceInterface {
+func NewAuctionService(repo sale_offer.SaleOfferRepositoryInterface, service SaleOfferServiceInterface, auctionLocker AuctionLockerInterface, incrementTiers IncrementTiers, extensionPolicy ExtensionPolicy) AuctionServiceInterface {
 	return &AuctionService{
 		saleOfferRepo:    repo,
 		saleOfferService: service,
-		purchaseCreator:  purchaseCreator,
+		auctionLocker:    auctionLocker,
+		incrementTiers:   incrementTiers,
+		extensionPolicy:  extensionPolicy,
 	}
 }
 
@@ -79,37 +85,77 @@ func (s *AuctionService) Update(in *UpdateAuctionDTO, userID uint) (*sale_offer.
 }
 
 func (s *AuctionService) BuyNow(id uint, userID uint) (notification.SaleOfferInterface, error) {
-	offer, err := s.saleOfferService.PrepareForBuySaleOffer(id, userID)
+	unlock, err := s.auctionLocker.Lock(id)
 	if err != nil {
 		return nil, err
 	}
-	if offer.Auction.BuyNowPrice == nil {
-		return nil, ErrBuyNowNotAvailable
+	defer unlock()
+	// bids are serialized with buying out by the lock, so no bid can come in between
+	hasBids, err := s.saleOfferRepo.HasBids(id)
+	if err != nil {
+		return nil, err
 	}
-	offer.Status = enums.SOLD
-	offer.Price = *offer.Auction.BuyNowPrice
-	if err := s.saleOfferRepo.Update(offer); err != nil {
+	message, err := outbox.NewMessage(sale_offer.OutboxAuctionBoughtOut, sale_offer.PurchasePayload{OfferID: id, BuyerID: userID})
+	if err != nil {
 		return nil, err
 	}
-	purchaseModel := &models.Purchase{OfferID: offer.ID, BuyerID: userID, FinalPrice: *offer.Auction.BuyNowPrice, IssueDate: time.Now()}
-	if err := s.purchaseCreator.Create(purchaseModel); err != nil {
+	offer, err := s.saleOfferRepo.Purchase(id, userID, func(offer *models.SaleOffer) (uint, error) {
+		if err := sale_offer.ValidatePurchase(offer, userID); err != nil {
+			return 0, err
+		}
+		if offer.Auction == nil || offer.Auction.BuyNowPrice == nil {
+			return 0, ErrBuyNowNotAvailable
+		}
+		// the auction is already won by a bid which will close it
+		if hasBids && offer.Price >= *offer.Auction.BuyNowPrice {
+			return 0, ErrBuyNowPriceReached
+		}
+		return *offer.Auction.BuyNowPrice, nil
+	}, message)
+	if err != nil {
 		return nil, err
 	}
 	return s.saleOfferService.GetDetailedByID(offer.ID, &offer.UserID)
 }
 
-func (s *AuctionService) UpdatePrice(offerID uint, newPrice uint) error {
+func (s *AuctionService) ValidateNewPrice(offerID uint, newPrice uint) error {
 	offer, err := s.saleOfferRepo.GetByID(offerID)
 	if err != nil {
 		return err
 	}
+	return s.validateNewPrice(offer, newPrice)
+}
+
+// NewDateEnd tells whether a bid placed at bidTime pushes out the end of the auction.
+// Returns the new end if the auction gets extended, nil otherwise - the bid stores it together with the bid.
+func (s *AuctionService) NewDateEnd(offerID uint, bidTime time.Time) (*time.Time, error) {
+	offer, err := s.saleOfferRepo.GetByID(offerID)
+	if err != nil {
+		return nil, err
+	}
+	dateEnd, extended := s.extensionPolicy.NewDateEnd(offer.Auction.DateEnd, offer.Auction.ExtensionCount, bidTime)
+	if !extended {
+		return nil, nil
+	}
+	return &dateEnd, nil
+}
+
+// validateNewPrice checks the price against the current one - the first bid may equal the initial price,
+// every next one has to raise the price by at least the increment of the current price band.
+func (s *AuctionService) validateNewPrice(offer *models.SaleOffer, newPrice uint) error {
+	if offer.Status != enums.PUBLISHED {
+		return ErrAuctionNotActive
+	}
 	if newPrice < offer.Price || newPrice < offer.Auction.InitialPrice {
 		return ErrNewPriceLessThanOfferPrice
 	}
-	offer.Price = newPrice
-	if err := s.saleOfferRepo.Update(offer); err != nil {
+	hasBids, err := s.saleOfferRepo.HasBids(offer.ID)
+	if err != nil {
 		return err
 	}
+	if hasBids && newPrice < offer.Price+s.incrementTiers.MinimumIncrement(offer.Price) {
+		return ErrBidIncrementTooLow
+	}
 	return nil
 }
 
@@ -130,6 +176,9 @@ func (s *AuctionService) PrepareForCreateAuction(in *CreateAuctionDTO) (*models.
 			return nil, ErrBuyNowPriceLessThanOfferPrice
 		}
 	}
+	if err := validateReservePrice(auction); err != nil {
+		return nil, err
+	}
 	return auction, nil
 }
 
@@ -138,5 +187,21 @@ func (s *AuctionService) PrepareForUpdateAuction(in *UpdateAuctionDTO, auction *
 	if err != nil {
 		return nil, err
 	}
+	if err := validateReservePrice(updatedAuction.Auction); err != nil {
+		return nil, err
+	}
 	return updatedAuction, nil
 }
+
+func validateReservePrice(auction *models.Auction) error {
+	if auction.ReservePrice == nil {
+		return nil
+	}
+	if *auction.ReservePrice < auction.InitialPrice {
+		return ErrReservePriceLessThanInitial
+	}
+	if auction.BuyNowPrice != nil && *auction.ReservePrice > *auction.BuyNowPrice {
+		return ErrReservePriceOverBuyNowPrice
+	}
+	return nil
+}
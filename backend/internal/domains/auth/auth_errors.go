@@ -20,6 +20,7 @@ var (
 	ErrUserIDNotFound       = errors.New("user id not found")
 	ErrUserNotFound         = errors.New("user not found")
 	ErrInvalidOldPassword   = errors.New("invalid old password")
+	ErrUserBanned           = errors.New("user is banned")
 )
 
 var ErrorMap = map[error]int{
@@ -34,4 +35,5 @@ var ErrorMap = map[error]int{
 	ErrRefreshTokenRequired: http.StatusBadRequest,
 	ErrRefreshTokenNotFound: http.StatusNotFound,
 	ErrUnauthorized:         http.StatusUnauthorized,
+	ErrUserBanned:           http.StatusForbidden,
 }
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -68,6 +69,7 @@ func (h *Handler) Register(ctx *gin.Context) {
 //	@Success		200		{object}	LoginResponse	"OK - returns tokens and data of the user"
 //	@Failure		400		{object}	LoginResponse	"Invalid input data"
 //	@Failure		401		{object}	LoginResponse	"Unauthorized"
+//	@Failure		403		{object}	LoginResponse	"User is banned"
 //	@Failure		500		{object}	LoginResponse	"Internal server error"
 //	@Router			/auth/login [post]
 //	@Security		Bearer
@@ -80,6 +82,10 @@ func (h *Handler) Login(c *gin.Context) {
 
 	access, refresh, user_, err := h.Service.Login(req)
 	loginResponse := prepareLoginResponse(access, refresh, *user_)
+	if errors.Is(err, ErrUserBanned) {
+		c.JSON(http.StatusForbidden, LoginResponse{Errors: map[string][]string{"credentials": {ErrUserBanned.Error()}}})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, LoginResponse{Errors: map[string][]string{"credentials": {ErrInvalidCredentials.Error()}}})
 		return
@@ -95,6 +101,7 @@ func prepareLoginResponse(access, refresh string, user models.User) *LoginRespon
 		Username:     user.Username,
 		UserID:       user.ID,
 		Email:        user.Email,
+		Role:         string(user.Role),
 	}
 	if user.Selector == "C" {
 		loginResponse.CompanyName = user.Company.Name
@@ -117,6 +124,7 @@ func prepareLoginResponse(access, refresh string, user models.User) *LoginRespon
 //	@Success		200		{object}	LoginResponse			"OK - returns new access token"
 //	@Failure		400		{object}	custom_errors.HTTPError	"Invalid input data"
 //	@Failure		401		{object}	custom_errors.HTTPError	"Unauthorized"
+//	@Failure		403		{object}	custom_errors.HTTPError	"User is banned"
 //	@Failure		500		{object}	custom_errors.HTTPError	"Internal server error"
 //	@Router			/auth/refresh [post]
 func (h *Handler) Refresh(c *gin.Context) {
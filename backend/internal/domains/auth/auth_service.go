@@ -70,7 +70,10 @@ func (s *AuthService) Login(in LoginInput) (string, string, *models.User, error)
 	if !passwords.Match(in.Password, u.Password) {
 		return "", "", &models.User{}, ErrInvalidCredentials
 	}
-	access, err := jwt.GenerateToken(u.Email, int64(u.ID), s.JwtKey, time.Now().Add(AccessTokenExpirationTime))
+	if u.IsBanned() {
+		return "", "", &models.User{}, ErrUserBanned
+	}
+	access, err := jwt.GenerateTokenWithRole(u.Email, int64(u.ID), string(u.Role), s.JwtKey, time.Now().Add(AccessTokenExpirationTime))
 	if err != nil {
 		return "", "", &models.User{}, err
 	}
@@ -91,8 +94,11 @@ func (s *AuthService) Refresh(provided string) (string, error) {
 	if _, err := s.RefreshTokenService.VerifyExpiration(refresh); err != nil {
 		return "", ErrRefreshTokenExpired
 	}
+	if refresh.User.IsBanned() {
+		return "", ErrUserBanned
+	}
 
-	access, err := jwt.GenerateToken(refresh.User.Email, int64(refresh.User.ID), s.JwtKey, time.Now().Add(2*time.Hour))
+	access, err := jwt.GenerateTokenWithRole(refresh.User.Email, int64(refresh.User.ID), string(refresh.User.Role), s.JwtKey, time.Now().Add(2*time.Hour))
 	if err != nil {
 		return "", err
 	}
@@ -7,6 +7,7 @@ type LoginResponse struct {
 	Username      string              `json:"username,omitempty"`
 	UserID        uint                `json:"user_id,omitempty"`
 	Email         string              `json:"email,omitempty"`
+	Role          string              `json:"role,omitempty"`
 	PersonName    string              `json:"person_name,omitempty"`
 	PersonSurname string              `json:"person_surname,omitempty"`
 	CompanyName   string              `json:"company_name,omitempty"`
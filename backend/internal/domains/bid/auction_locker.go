@@ -0,0 +1,68 @@
+package bid
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/susek555/BD2/car-dealer-api/pkg/redis_lock"
+)
+
+const (
+	AuctionLockTTL     = 10 * time.Second
+	AuctionLockTimeout = 5 * time.Second
+	auctionLockRetry   = 20 * time.Millisecond
+)
+
+//go:generate mockery --name=AuctionLockerInterface --output=../../test/mocks --case=snake --with-expecter
+type AuctionLockerInterface interface {
+	// Lock blocks until bids on the auction can be placed exclusively. The returned function releases the lock.
+	Lock(auctionID uint) (func(), error)
+}
+
+// LocalAuctionLocker serializes bids within a single process.
+type LocalAuctionLocker struct {
+	locks sync.Map
+}
+
+func NewLocalAuctionLocker() AuctionLockerInterface {
+	return &LocalAuctionLocker{}
+}
+
+func (l *LocalAuctionLocker) Lock(auctionID uint) (func(), error) {
+	m, _ := l.locks.LoadOrStore(auctionID, &sync.Mutex{})
+	mutex := m.(*sync.Mutex)
+	mutex.Lock()
+	return mutex.Unlock, nil
+}
+
+// RedisAuctionLocker serializes bids across all instances of the backend sharing the redis.
+type RedisAuctionLocker struct {
+	rdb     *redis.Client
+	ttl     time.Duration
+	timeout time.Duration
+}
+
+func NewRedisAuctionLocker(rdb *redis.Client, ttl time.Duration, timeout time.Duration) AuctionLockerInterface {
+	return &RedisAuctionLocker{
+		rdb:     rdb,
+		ttl:     ttl,
+		timeout: timeout,
+	}
+}
+
+func (l *RedisAuctionLocker) Lock(auctionID uint) (func(), error) {
+	ctx, cancel := context.WithTimeout(context.Background(), l.timeout)
+	defer cancel()
+	lock, err := redis_lock.Acquire(ctx, l.rdb, "bid:auction:"+strconv.FormatUint(uint64(auctionID), 10), l.ttl, auctionLockRetry)
+	if errors.Is(err, redis_lock.ErrNotAcquired) {
+		return nil, ErrAuctionBusy
+	}
+	if err != nil {
+		return nil, err
+	}
+	return func() { _ = lock.Release(context.Background()) }, nil
+}
@@ -1,12 +1,16 @@
 package bid
 
 import (
+	"time"
+
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/notification"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
 )
 
 type CreateBidDTO struct {
-	AuctionID uint `json:"auction_id" binding:"required"`
-	Amount    uint `json:"amount" binding:"required"`
+	AuctionID uint  `json:"auction_id" binding:"required"`
+	Amount    uint  `json:"amount" binding:"required"`
+	MaxAmount *uint `json:"max_amount,omitempty"`
 }
 
 type ProcessingBidDTO struct {
@@ -14,6 +18,12 @@ type ProcessingBidDTO struct {
 	BidderID  uint                            `json:"bidder_id" binding:"required"`
 	Amount    uint                            `json:"amount" binding:"required"`
 	Offer     notification.SaleOfferInterface `json:"auction,omitempty"`
+	// the bid of the caller, the fields above describe the leading bid after proxy bids answered it
+	PlacedBid *RetrieveBidDTO `json:"-"`
+	// proxy bids which were outbid over their maximum while processing this bid
+	ExhaustedProxyBids []models.ProxyBid `json:"-"`
+	// new end of the auction if this bid extended it, nil otherwise
+	ExtendedDateEnd *time.Time `json:"-"`
 }
 
 type RetrieveBidDTO struct {
@@ -21,3 +31,11 @@ type RetrieveBidDTO struct {
 	BidderID  uint `json:"bidder_id" binding:"required"`
 	Amount    uint `json:"amount" binding:"required"`
 }
+
+type CreatedBidDTO struct {
+	AuctionID uint `json:"auction_id"`
+	BidderID  uint `json:"bidder_id"`
+	Amount    uint `json:"amount"`
+	// true if a proxy bid of another user already outbid the created bid
+	Outbid bool `json:"outbid"`
+}
@@ -4,3 +4,5 @@ import "errors"
 
 var ErrAuctionNotPublished = errors.New("auction is not published")
 var ErrBidderIsAuctionOwner = errors.New("bidder cannot bid on their own auction")
+var ErrMaxAmountTooLow = errors.New("maximum bid cannot be lower than the bid amount")
+var ErrAuctionBusy = errors.New("auction is busy processing other bids, try again")
@@ -6,7 +6,6 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"github.com/redis/go-redis/v9"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/auth"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/notification"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/scheduler"
@@ -17,16 +16,14 @@ import (
 
 type Handler struct {
 	bidService          BidServiceInterface
-	redisClient         *redis.Client
 	hub                 ws.HubInterface
 	notificationService notification.NotificationServiceInterface
 	sched               scheduler.SchedulerInterface
 }
 
-func NewHandler(service BidServiceInterface, redisClient *redis.Client, hub ws.HubInterface, notificationService notification.NotificationServiceInterface, sched scheduler.SchedulerInterface) *Handler {
+func NewHandler(service BidServiceInterface, hub ws.HubInterface, notificationService notification.NotificationServiceInterface, sched scheduler.SchedulerInterface) *Handler {
 	return &Handler{
 		bidService:          service,
-		redisClient:         redisClient,
 		hub:                 hub,
 		notificationService: notificationService,
 		sched:               sched,
@@ -36,12 +33,14 @@ func NewHandler(service BidServiceInterface, redisClient *redis.Client, hub ws.H
 // CreateBid godoc
 //
 //	@Summary		Create a new bid
-//	@Description	Create a new bid for an auction
+//	@Description	Create a new bid for an auction. An optional max_amount registers a proxy bid - the system will then bid
+//	@Description	on behalf of the user, in minimal increments, up to that amount whenever someone else bids.
+//	@Description	A bid placed shortly before the end of the auction extends it.
 //	@Tags			bid
 //	@Accept			json
 //	@Produce		json
 //	@Param			body	body		CreateBidDTO			true	"Bid details"
-//	@Success		201		{object}	RetrieveBidDTO			"Created bid"
+//	@Success		201		{object}	CreatedBidDTO			"Created bid, outbid is set if a proxy bid already answered it"
 //	@Failure		400		{object}	custom_errors.HTTPError	"Bad request"
 //	@Failure		401		{object}	custom_errors.HTTPError	"Unauthorized"
 //	@Router			/bid [post]
@@ -62,12 +61,11 @@ func (h *Handler) CreateBid(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, custom_errors.NewHTTPError(err.Error()))
 		return
 	}
-	retrieveDTO := ProcessingToRetrieve(dto)
-	c.JSON(http.StatusCreated, retrieveDTO)
+	c.JSON(http.StatusCreated, ProcessingToCreated(dto))
 	auctionIDStr := strconv.FormatUint(uint64(dto.AuctionID), 10)
 	userIDStr := strconv.FormatUint(uint64(userID), 10)
 	notification := &models.Notification{
-		OfferID: dto.AuctionID,
+		OfferID: &dto.AuctionID,
 	}
 
 	err = h.notificationService.CreateOutbidNotification(notification, dto.Amount, dto.Offer)
@@ -75,16 +73,45 @@ func (h *Handler) CreateBid(c *gin.Context) {
 		log.Println("Error creating notification:", err)
 		return
 	}
-	h.hub.SaveNotificationForClients(auctionIDStr, userID, notification)
+	leaderIDStr := strconv.FormatUint(uint64(dto.BidderID), 10)
+	h.hub.SaveNotificationForClients(auctionIDStr, dto.BidderID, notification)
+	h.hub.Broadcast(auctionIDStr, ws.NewBidEnvelope(dto.AuctionID, dto.Amount), "")
+	h.hub.Broadcast(auctionIDStr, ws.NewNotificationEnvelope(notification), leaderIDStr)
+	h.notifyExhaustedProxyBids(dto)
+	h.announceExtension(auctionIDStr, dto)
 	if dto.Offer.HasBuyNowPrice() {
-		if in.Amount >= dto.Offer.GetPrice() {
-			h.sched.ForceCloseAuction(auctionIDStr, userID, in.Amount)
+		if dto.Amount >= dto.Offer.GetPrice() {
+			h.sched.ForceCloseAuction(auctionIDStr, dto.BidderID, dto.Amount)
 		}
 	}
-	go h.hub.SendFourLatestNotificationsToClients(auctionIDStr, userIDStr)
+	go h.hub.SendFourLatestNotificationsToClients(auctionIDStr, leaderIDStr)
 	h.hub.SubscribeUser(userIDStr, auctionIDStr)
 }
 
+func (h *Handler) notifyExhaustedProxyBids(dto *ProcessingBidDTO) {
+	for _, proxyBid := range dto.ExhaustedProxyBids {
+		notification := &models.Notification{
+			OfferID: &dto.AuctionID,
+		}
+		err := h.notificationService.CreateProxyBidExhaustedNotification(notification, proxyBid.MaxAmount, dto.Amount, dto.Offer)
+		if err != nil {
+			log.Println("Error creating notification:", err)
+			continue
+		}
+		if err := h.notificationService.SaveNotificationToClient(notification, proxyBid.BidderID); err != nil {
+			log.Println("Error saving notification:", err)
+		}
+	}
+}
+
+func (h *Handler) announceExtension(auctionIDStr string, dto *ProcessingBidDTO) {
+	if dto.ExtendedDateEnd == nil {
+		return
+	}
+	h.sched.ModifyAuction(auctionIDStr, *dto.ExtendedDateEnd)
+	h.hub.Broadcast(auctionIDStr, ws.NewAuctionExtendedEnvelope(dto.AuctionID, *dto.ExtendedDateEnd), "")
+}
+
 // GetAllBids godoc
 //
 //	@Summary		Get all bids
@@ -24,11 +24,12 @@ func MapToDTO(b *models.Bid) *RetrieveBidDTO {
 	}
 }
 
-func ProcessingToRetrieve(b *ProcessingBidDTO) *RetrieveBidDTO {
-	return &RetrieveBidDTO{
-		AuctionID: b.AuctionID,
-		BidderID:  b.BidderID,
-		Amount:    b.Amount,
+func ProcessingToCreated(b *ProcessingBidDTO) *CreatedBidDTO {
+	return &CreatedBidDTO{
+		AuctionID: b.PlacedBid.AuctionID,
+		BidderID:  b.PlacedBid.BidderID,
+		Amount:    b.PlacedBid.Amount,
+		Outbid:    b.BidderID != b.PlacedBid.BidderID,
 	}
 }
 
@@ -40,3 +41,12 @@ func (cb *CreateBidDTO) MapToBid(userID uint) *models.Bid {
 		CreatedAt: time.Now(),
 	}
 }
+
+func (cb *CreateBidDTO) MapToProxyBid(userID uint) *models.ProxyBid {
+	return &models.ProxyBid{
+		AuctionID: cb.AuctionID,
+		BidderID:  userID,
+		MaxAmount: *cb.MaxAmount,
+		CreatedAt: time.Now(),
+	}
+}
@@ -2,7 +2,9 @@ package bid
 
 import (
 	"errors"
+	"time"
 
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
 	"github.com/susek555/BD2/car-dealer-api/internal/models"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -11,6 +13,7 @@ import (
 //go:generate mockery --name=BidRepositoryInterface --output=../../test/mocks --case=snake --with-expecter
 type BidRepositoryInterface interface {
 	Create(bid *models.Bid) error
+	Place(placement *BidPlacement) error
 	GetByID(id uint) (*models.Bid, error)
 	GetByBidderID(bidderID uint) ([]models.Bid, error)
 	GetAll() ([]models.Bid, error)
@@ -19,6 +22,20 @@ type BidRepositoryInterface interface {
 	GetHighestBidByUserID(auctionID, userID uint) (*models.Bid, error)
 }
 
+// BidPlacement is everything a single bid changes: the bid itself, the answers of proxy bids,
+// the proxy bid registered with it, the new price and the possibly extended end of the auction.
+type BidPlacement struct {
+	AuctionID uint
+	// in the order they were placed, the last one is leading
+	Bids []*models.Bid
+	// proxy bid registered with the bid, nil if none
+	ProxyBid             *models.ProxyBid
+	ExhaustedProxyBidIDs []uint
+	Price                uint
+	// new end of the auction, nil if the bid does not extend it
+	DateEnd *time.Time
+}
+
 type BidRepository struct {
 	DB *gorm.DB
 }
@@ -35,36 +52,7 @@ func NewBidRepository(db *gorm.DB) BidRepositoryInterface {
 
 func (b *BidRepository) Create(bid *models.Bid) error {
 	return b.DB.Transaction(func(tx *gorm.DB) error {
-		var highest models.Bid
-		var auction models.Auction
-		err := tx.
-			Clauses(clause.Locking{Strength: "UPDATE"}).
-			Where("auction_id = ?", bid.AuctionID).
-			Order("amount DESC").
-			Preload("Auction").
-			Preload("Auction.Offer").
-			Limit(1).
-			Take(&highest).Error
-		if err != nil && err.Error() != gorm.ErrRecordNotFound.Error() {
-			return err
-		}
-		if highest.Amount >= bid.Amount {
-			return ErrBidTooLow
-		}
-		err = tx.
-			Model(&auction).
-			Where("offer_id = ?", bid.AuctionID).
-			Preload("Offer").
-			First(&auction).Error
-		if err != nil {
-			return err
-		}
-		if auction.Offer.Price > bid.Amount {
-			return ErrBidTooLow
-		}
-
-		err = tx.Create(bid).Error
-		if err != nil {
+		if err := createBid(tx, bid); err != nil {
 			return err
 		}
 		if err := tx.
@@ -78,6 +66,85 @@ func (b *BidRepository) Create(bid *models.Bid) error {
 	})
 }
 
+// Place writes the whole outcome of a bid in a single transaction, so a failure half way
+// never leaves bids without the matching price or proxy bids in a stale state.
+func (b *BidRepository) Place(placement *BidPlacement) error {
+	return b.DB.Transaction(func(tx *gorm.DB) error {
+		for _, bid := range placement.Bids {
+			if err := createBid(tx, bid); err != nil {
+				return err
+			}
+		}
+		if placement.ProxyBid != nil {
+			// a bidder can have only one proxy bid per auction, so a new maximum replaces the previous one
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "auction_id"}, {Name: "bidder_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"max_amount", "exhausted", "created_at"}),
+			}).Create(placement.ProxyBid).Error
+			if err != nil {
+				return err
+			}
+		}
+		if len(placement.ExhaustedProxyBidIDs) > 0 {
+			err := tx.Model(&models.ProxyBid{}).
+				Where("id IN ?", placement.ExhaustedProxyBidIDs).
+				Update("exhausted", true).Error
+			if err != nil {
+				return err
+			}
+		}
+		// the price is set only while the auction is published, so a bid processed concurrently
+		// with closing (or buying out) the auction cannot bring it back to life
+		result := tx.Model(&models.SaleOffer{}).
+			Where("id = ? AND status = ?", placement.AuctionID, enums.PUBLISHED).
+			Update("price", placement.Price)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrAuctionNotPublished
+		}
+		if placement.DateEnd != nil {
+			return tx.Model(&models.Auction{}).
+				Where("offer_id = ?", placement.AuctionID).
+				Updates(map[string]any{"date_end": *placement.DateEnd, "extension_count": gorm.Expr("extension_count + 1")}).Error
+		}
+		return nil
+	})
+}
+
+// createBid inserts the bid after checking, with the highest bid of the auction locked, that it outbids it.
+func createBid(tx *gorm.DB, bid *models.Bid) error {
+	var highest models.Bid
+	var auction models.Auction
+	err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("auction_id = ?", bid.AuctionID).
+		Order("amount DESC").
+		Preload("Auction").
+		Preload("Auction.Offer").
+		Limit(1).
+		Take(&highest).Error
+	if err != nil && err.Error() != gorm.ErrRecordNotFound.Error() {
+		return err
+	}
+	if highest.Amount >= bid.Amount {
+		return ErrBidTooLow
+	}
+	err = tx.
+		Model(&auction).
+		Where("offer_id = ?", bid.AuctionID).
+		Preload("Offer").
+		First(&auction).Error
+	if err != nil {
+		return err
+	}
+	if auction.Offer.Price > bid.Amount {
+		return ErrBidTooLow
+	}
+	return tx.Create(bid).Error
+}
+
 func (b *BidRepository) GetAll() ([]models.Bid, error) {
 	db := b.DB
 	var bids []models.Bid
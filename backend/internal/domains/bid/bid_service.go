@@ -1,10 +1,12 @@
 package bid
 
 import (
-	"sync"
+	"sort"
+	"time"
 
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/notification"
 	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
 	"github.com/susek555/BD2/car-dealer-api/pkg/mapping"
 	"gorm.io/gorm"
 )
@@ -16,9 +18,16 @@ type SaleOfferRetrieverInterface interface {
 
 //go:generate mockery --name=AuctionPriceUpdaterInterface --output=../../test/mocks --case=snake --with-expecter
 type AuctionPriceUpdaterInterface interface {
-	UpdatePrice(auctionID uint, newPrice uint) error
+	ValidateNewPrice(auctionID uint, newPrice uint) error
+	NewDateEnd(auctionID uint, bidTime time.Time) (*time.Time, error)
 }
 
+//go:generate mockery --name=BidIncrementPolicyInterface --output=../../test/mocks --case=snake --with-expecter
+type BidIncrementPolicyInterface interface {
+	MinimumIncrement(price uint) uint
+}
+
+//go:generate mockery --name=BidServiceInterface --output=../../test/mocks --case=snake --with-expecter
 type BidServiceInterface interface {
 	Create(bidDTO *CreateBidDTO, bidderID uint) (*ProcessingBidDTO, error)
 	GetAll() ([]RetrieveBidDTO, error)
@@ -31,24 +40,29 @@ type BidServiceInterface interface {
 
 type BidService struct {
 	Repo                BidRepositoryInterface
+	ProxyRepo           ProxyBidRepositoryInterface
 	AuctionRetriever    SaleOfferRetrieverInterface
 	AuctionPriceUpdater AuctionPriceUpdaterInterface
+	IncrementPolicy     BidIncrementPolicyInterface
+	AuctionLocker       AuctionLockerInterface
 }
 
-func NewBidService(repo BidRepositoryInterface, auctionRetriever SaleOfferRetrieverInterface, auctionPriceUpdater AuctionPriceUpdaterInterface) BidServiceInterface {
+func NewBidService(repo BidRepositoryInterface, proxyRepo ProxyBidRepositoryInterface, auctionRetriever SaleOfferRetrieverInterface, auctionPriceUpdater AuctionPriceUpdaterInterface, incrementPolicy BidIncrementPolicyInterface, auctionLocker AuctionLockerInterface) BidServiceInterface {
 	return &BidService{
 		Repo:                repo,
+		ProxyRepo:           proxyRepo,
 		AuctionRetriever:    auctionRetriever,
 		AuctionPriceUpdater: auctionPriceUpdater,
+		IncrementPolicy:     incrementPolicy,
+		AuctionLocker:       auctionLocker,
 	}
 }
 
-var auctionLocks sync.Map
-
 func (service *BidService) Create(bidDTO *CreateBidDTO, bidderID uint) (*ProcessingBidDTO, error) {
 	bid := bidDTO.MapToBid(bidderID)
-	l, _ := auctionLocks.LoadOrStore(bid.AuctionID, &sync.Mutex{})
-	m := l.(*sync.Mutex)
+	if bidDTO.MaxAmount != nil && *bidDTO.MaxAmount < bid.Amount {
+		return nil, ErrMaxAmountTooLow
+	}
 	offer, err := service.AuctionRetriever.GetDetailedByID(bid.AuctionID, nil)
 	if err != nil {
 		return nil, err
@@ -59,21 +73,152 @@ func (service *BidService) Create(bidDTO *CreateBidDTO, bidderID uint) (*Process
 	if offer.BelongsToUser(bidderID) {
 		return nil, ErrBidderIsAuctionOwner
 	}
-	m.Lock()
-	defer m.Unlock()
-	err = service.Repo.Create(bid)
+	unlock, err := service.AuctionLocker.Lock(bid.AuctionID)
 	if err != nil {
 		return nil, err
 	}
-	err = service.AuctionPriceUpdater.UpdatePrice(offer.GetID(), bid.Amount)
+	defer unlock()
+	if err := service.AuctionPriceUpdater.ValidateNewPrice(offer.GetID(), bid.Amount); err != nil {
+		return nil, err
+	}
+	activeProxies, err := service.ProxyRepo.GetActiveByAuctionID(bid.AuctionID)
 	if err != nil {
 		return nil, err
 	}
+	placement := &BidPlacement{
+		AuctionID: bid.AuctionID,
+		Bids:      []*models.Bid{bid},
+	}
+	if bidDTO.MaxAmount != nil {
+		placement.ProxyBid = bidDTO.MapToProxyBid(bidderID)
+	}
+	exhausted := service.resolveProxyBids(placement, withProxyBid(activeProxies, placement.ProxyBid))
+	for _, proxy := range exhausted {
+		// the new proxy bid is saved together with its exhausted flag
+		if proxy.ID != 0 {
+			placement.ExhaustedProxyBidIDs = append(placement.ExhaustedProxyBidIDs, proxy.ID)
+		}
+	}
+	leadingBid := placement.Bids[len(placement.Bids)-1]
+	placement.Price = leadingBid.Amount
+	placement.DateEnd, err = service.AuctionPriceUpdater.NewDateEnd(offer.GetID(), bid.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := service.Repo.Place(placement); err != nil {
+		return nil, err
+	}
 	offer, err = service.AuctionRetriever.GetDetailedByID(bid.AuctionID, nil)
 	if err != nil {
 		return nil, err
 	}
-	return MapToProcessingDTO(bid, offer), nil
+	dto := MapToProcessingDTO(leadingBid, offer)
+	dto.PlacedBid = MapToDTO(bid)
+	dto.ExhaustedProxyBids = exhausted
+	dto.ExtendedDateEnd = placement.DateEnd
+	return dto, nil
+}
+
+// withProxyBid returns the active proxy bids with the one registered with the bid, which replaces
+// the previous proxy bid of the same bidder, in the order they compete in: by maximum, then by creation time.
+func withProxyBid(activeProxies []models.ProxyBid, proxyBid *models.ProxyBid) []*models.ProxyBid {
+	proxies := make([]*models.ProxyBid, 0, len(activeProxies)+1)
+	for i := range activeProxies {
+		if proxyBid == nil || activeProxies[i].BidderID != proxyBid.BidderID {
+			proxies = append(proxies, &activeProxies[i])
+		}
+	}
+	if proxyBid == nil {
+		return proxies
+	}
+	proxies = append(proxies, proxyBid)
+	sort.SliceStable(proxies, func(i, j int) bool {
+		if proxies[i].MaxAmount != proxies[j].MaxAmount {
+			return proxies[i].MaxAmount > proxies[j].MaxAmount
+		}
+		return proxies[i].CreatedAt.Before(proxies[j].CreatedAt)
+	})
+	return proxies
+}
+
+// resolveProxyBids lets active proxy bids answer the leading bid until only one bidder can still
+// raise the price, appending their bids to the placement. Each round exhausts at least one proxy,
+// so the loop always terminates. Returns the proxy bids that were exhausted.
+func (service *BidService) resolveProxyBids(placement *BidPlacement, proxies []*models.ProxyBid) []models.ProxyBid {
+	var exhausted []models.ProxyBid
+	exhaust := func(proxy *models.ProxyBid) {
+		proxy.Exhausted = true
+		exhausted = append(exhausted, *proxy)
+	}
+	leadingBid := placement.Bids[len(placement.Bids)-1]
+	placeProxyBid := func(proxy *models.ProxyBid, amount uint) *models.Bid {
+		bid := &models.Bid{
+			AuctionID: proxy.AuctionID,
+			BidderID:  proxy.BidderID,
+			Amount:    amount,
+			CreatedAt: time.Now(),
+		}
+		placement.Bids = append(placement.Bids, bid)
+		return bid
+	}
+	for {
+		challenger := findChallenger(proxies, leadingBid, service.IncrementPolicy.MinimumIncrement(leadingBid.Amount))
+		if challenger == nil {
+			break
+		}
+		leaderProxy := findProxyOfBidder(proxies, leadingBid.BidderID)
+		leaderCeiling := leadingBid.Amount
+		if leaderProxy != nil && leaderProxy.MaxAmount > leaderCeiling {
+			leaderCeiling = leaderProxy.MaxAmount
+		}
+		// on equal maximums the proxy registered first wins
+		challengerWins := challenger.MaxAmount > leaderCeiling ||
+			(challenger.MaxAmount == leaderCeiling && leaderProxy != nil && challenger.CreatedAt.Before(leaderProxy.CreatedAt))
+		if challengerWins {
+			if leaderProxy != nil {
+				if leaderCeiling > leadingBid.Amount && leaderCeiling < challenger.MaxAmount {
+					placeProxyBid(leaderProxy, leaderCeiling)
+				}
+				exhaust(leaderProxy)
+			}
+			leadingBid = placeProxyBid(challenger, min(challenger.MaxAmount, leaderCeiling+service.IncrementPolicy.MinimumIncrement(leaderCeiling)))
+		} else {
+			if challenger.MaxAmount < leaderCeiling {
+				placeProxyBid(challenger, challenger.MaxAmount)
+			}
+			exhaust(challenger)
+			leadingBid = placeProxyBid(leaderProxy, min(leaderCeiling, challenger.MaxAmount+service.IncrementPolicy.MinimumIncrement(challenger.MaxAmount)))
+		}
+	}
+	for _, proxy := range proxies {
+		if !proxy.Exhausted && proxy.BidderID != leadingBid.BidderID {
+			exhaust(proxy)
+		}
+	}
+	return exhausted
+}
+
+// findChallenger returns the strongest active proxy (proxies are ordered by maximum, then by creation time)
+// of a bidder other than the leading one that is still able to outbid the leading bid.
+func findChallenger(proxies []*models.ProxyBid, leadingBid *models.Bid, increment uint) *models.ProxyBid {
+	for _, proxy := range proxies {
+		if proxy.Exhausted || proxy.BidderID == leadingBid.BidderID {
+			continue
+		}
+		if proxy.MaxAmount >= leadingBid.Amount+increment {
+			return proxy
+		}
+	}
+	return nil
+}
+
+func findProxyOfBidder(proxies []*models.ProxyBid, bidderID uint) *models.ProxyBid {
+	for _, proxy := range proxies {
+		if !proxy.Exhausted && proxy.BidderID == bidderID {
+			return proxy
+		}
+	}
+	return nil
 }
 
 func (service *BidService) GetAll() ([]RetrieveBidDTO, error) {
@@ -0,0 +1,28 @@
+package bid
+
+import (
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"gorm.io/gorm"
+)
+
+//go:generate mockery --name=ProxyBidRepositoryInterface --output=../../test/mocks --case=snake --with-expecter
+type ProxyBidRepositoryInterface interface {
+	GetActiveByAuctionID(auctionID uint) ([]models.ProxyBid, error)
+}
+
+type ProxyBidRepository struct {
+	DB *gorm.DB
+}
+
+func NewProxyBidRepository(db *gorm.DB) ProxyBidRepositoryInterface {
+	return &ProxyBidRepository{DB: db}
+}
+
+func (r *ProxyBidRepository) GetActiveByAuctionID(auctionID uint) ([]models.ProxyBid, error) {
+	var proxyBids []models.ProxyBid
+	err := r.DB.
+		Where("auction_id = ? AND exhausted IS FALSE", auctionID).
+		Order("max_amount DESC, created_at ASC").
+		Find(&proxyBids).Error
+	return proxyBids, err
+}
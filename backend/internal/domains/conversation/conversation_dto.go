@@ -0,0 +1,43 @@
+package conversation
+
+import "github.com/susek555/BD2/car-dealer-api/pkg/pagination"
+
+type ConversationFilter struct {
+	Pagination pagination.PaginationRequest `json:"pagination"`
+}
+
+type MessageFilter struct {
+	Pagination pagination.PaginationRequest `json:"pagination"`
+}
+
+type RetrieveConversationDTO struct {
+	ID             uint    `json:"id"`
+	OfferID        uint    `json:"offer_id"`
+	BuyerID        uint    `json:"buyer_id"`
+	BuyerUsername  string  `json:"buyer_username"`
+	SellerID       uint    `json:"seller_id"`
+	SellerUsername string  `json:"seller_username"`
+	UnreadCount    uint    `json:"unread_count"`
+	LastMessageAt  *string `json:"last_message_at,omitempty"`
+	CreatedAt      string  `json:"created_at"`
+}
+
+type RetrieveConversationsWithPagination struct {
+	Conversations      []RetrieveConversationDTO      `json:"conversations"`
+	PaginationResponse *pagination.PaginationResponse `json:"pagination"`
+}
+
+type RetrieveMessageDTO struct {
+	ID             uint    `json:"id"`
+	ConversationID uint    `json:"conversation_id"`
+	OfferID        uint    `json:"offer_id"`
+	SenderID       uint    `json:"sender_id"`
+	Body           string  `json:"body"`
+	CreatedAt      string  `json:"created_at"`
+	ReadAt         *string `json:"read_at,omitempty"`
+}
+
+type RetrieveMessagesWithPagination struct {
+	Messages           []RetrieveMessageDTO           `json:"messages"`
+	PaginationResponse *pagination.PaginationResponse `json:"pagination"`
+}
@@ -0,0 +1,32 @@
+package conversation
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/susek555/BD2/car-dealer-api/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrMissingConversation    = errors.New("either conversation_id or offer_id has to be given")
+	ErrInvalidMessage         = errors.New("message has to be between 1 and 1000 characters long")
+	ErrNotParticipant         = errors.New("you are not a participant of this conversation")
+	ErrCannotMessageOwnOffer  = errors.New("you cannot start a conversation about your own offer")
+	ErrOfferNotAvailable      = errors.New("you can only start a conversation about a published offer")
+	ErrConversationNotFound   = errors.New("conversation not found")
+	ErrConversationIDRequired = errors.New("conversation_id is required")
+)
+
+var ErrorMap = map[error]int{
+	ErrMissingConversation:         http.StatusBadRequest,
+	ErrInvalidMessage:              http.StatusBadRequest,
+	ErrNotParticipant:              http.StatusForbidden,
+	ErrCannotMessageOwnOffer:       http.StatusForbidden,
+	ErrOfferNotAvailable:           http.StatusBadRequest,
+	ErrConversationNotFound:        http.StatusNotFound,
+	ErrConversationIDRequired:      http.StatusBadRequest,
+	pagination.ErrPageOutOfRange:   http.StatusBadRequest,
+	pagination.ErrNegativePageSize: http.StatusBadRequest,
+	gorm.ErrRecordNotFound:         http.StatusNotFound,
+}
@@ -0,0 +1,92 @@
+package conversation
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/auth"
+	"github.com/susek555/BD2/car-dealer-api/pkg/custom_errors"
+)
+
+type Handler struct {
+	service ConversationServiceInterface
+}
+
+func NewHandler(service ConversationServiceInterface) *Handler {
+	return &Handler{service: service}
+}
+
+// GetConversations godoc
+//
+//	@Summary		Get my conversations
+//	@Description	Returns a paginated list of the conversations the user takes part in, as a buyer or as a seller, the most recently active first. Every conversation carries the number of messages the user has not read yet. Messages are sent, and read receipts and typing indicators exchanged, over the websocket with the send_message, read_receipt and typing messages.
+//	@Tags			conversation
+//	@Accept			json
+//	@Produce		json
+//	@Param			filter	body		ConversationFilter						true	"Pagination"
+//	@Success		200		{object}	RetrieveConversationsWithPagination	"Conversations"
+//	@Failure		400		{object}	custom_errors.HTTPError				"Invalid input data"
+//	@Failure		401		{object}	custom_errors.HTTPError				"Unauthorized - user not logged in"
+//	@Failure		500		{object}	custom_errors.HTTPError				"Internal server error"
+//	@Router			/conversation/list [post]
+//	@Security		Bearer
+func (h *Handler) GetConversations(c *gin.Context) {
+	var filter ConversationFilter
+	if err := c.ShouldBindJSON(&filter); err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	userID, err := auth.GetUserID(c)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	conversations, err := h.service.GetConversations(userID, &filter)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	c.JSON(http.StatusOK, conversations)
+}
+
+// GetMessages godoc
+//
+//	@Summary		Get the history of a conversation
+//	@Description	Returns a paginated list of the messages in the conversation, the newest first. Only the buyer and the seller can read it.
+//	@Tags			conversation
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		uint							true	"Conversation ID"
+//	@Param			filter	body		MessageFilter					true	"Pagination"
+//	@Success		200		{object}	RetrieveMessagesWithPagination	"Messages"
+//	@Failure		400		{object}	custom_errors.HTTPError			"Invalid input data"
+//	@Failure		401		{object}	custom_errors.HTTPError			"Unauthorized - user not logged in"
+//	@Failure		403		{object}	custom_errors.HTTPError			"Forbidden - user is not a participant"
+//	@Failure		404		{object}	custom_errors.HTTPError			"Conversation not found"
+//	@Failure		500		{object}	custom_errors.HTTPError			"Internal server error"
+//	@Router			/conversation/messages/{id} [post]
+//	@Security		Bearer
+func (h *Handler) GetMessages(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	var filter MessageFilter
+	if err := c.ShouldBindJSON(&filter); err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	userID, err := auth.GetUserID(c)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	messages, err := h.service.GetMessages(userID, uint(id), &filter)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	c.JSON(http.StatusOK, messages)
+}
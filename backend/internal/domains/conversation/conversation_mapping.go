@@ -0,0 +1,45 @@
+package conversation
+
+import (
+	"time"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+)
+
+func MapToConversationDTO(conversation *models.Conversation, unreadCount uint) *RetrieveConversationDTO {
+	dto := &RetrieveConversationDTO{
+		ID:          conversation.ID,
+		OfferID:     conversation.OfferID,
+		BuyerID:     conversation.BuyerID,
+		SellerID:    conversation.SellerID,
+		UnreadCount: unreadCount,
+		CreatedAt:   conversation.CreatedAt.Format(time.RFC3339),
+	}
+	if conversation.Buyer != nil {
+		dto.BuyerUsername = conversation.Buyer.Username
+	}
+	if conversation.Seller != nil {
+		dto.SellerUsername = conversation.Seller.Username
+	}
+	if conversation.LastMessageAt != nil {
+		lastMessageAt := conversation.LastMessageAt.Format(time.RFC3339)
+		dto.LastMessageAt = &lastMessageAt
+	}
+	return dto
+}
+
+func MapToMessageDTO(message *models.Message, offerID uint) *RetrieveMessageDTO {
+	dto := &RetrieveMessageDTO{
+		ID:             message.ID,
+		ConversationID: message.ConversationID,
+		OfferID:        offerID,
+		SenderID:       message.SenderID,
+		Body:           message.Body,
+		CreatedAt:      message.CreatedAt.Format(time.RFC3339),
+	}
+	if message.ReadAt != nil {
+		readAt := message.ReadAt.Format(time.RFC3339)
+		dto.ReadAt = &readAt
+	}
+	return dto
+}
@@ -0,0 +1,120 @@
+package conversation
+
+import (
+	"time"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"github.com/susek555/BD2/car-dealer-api/pkg/pagination"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockery --name=ConversationRepositoryInterface --output=../../test/mocks --case=snake --with-expecter
+type ConversationRepositoryInterface interface {
+	GetByID(id uint) (*models.Conversation, error)
+	GetOrCreate(offerID, buyerID, sellerID uint) (*models.Conversation, error)
+	GetByUserID(userID uint, filter *ConversationFilter) ([]models.Conversation, *pagination.PaginationResponse, error)
+	CountUnread(userID uint, conversationIDs []uint) (map[uint]uint, error)
+	SaveMessage(message *models.Message) error
+	GetMessages(conversationID uint, filter *MessageFilter) ([]models.Message, *pagination.PaginationResponse, error)
+	MarkRead(conversationID, readerID uint, readAt time.Time) (int64, error)
+}
+
+type ConversationRepository struct {
+	DB *gorm.DB
+}
+
+func NewConversationRepository(db *gorm.DB) ConversationRepositoryInterface {
+	return &ConversationRepository{DB: db}
+}
+
+func (r *ConversationRepository) GetByID(id uint) (*models.Conversation, error) {
+	var conversation models.Conversation
+	if err := r.DB.Preload("Buyer").Preload("Seller").First(&conversation, id).Error; err != nil {
+		return nil, err
+	}
+	return &conversation, nil
+}
+
+// GetOrCreate returns the buyer's conversation about the offer, starting it if there is none. Two first
+// messages sent at the same time end up in the same conversation thanks to the unique (offer_id, buyer_id).
+func (r *ConversationRepository) GetOrCreate(offerID, buyerID, sellerID uint) (*models.Conversation, error) {
+	conversation := models.Conversation{
+		OfferID:   offerID,
+		BuyerID:   buyerID,
+		SellerID:  sellerID,
+		CreatedAt: time.Now(),
+	}
+	err := r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "offer_id"}, {Name: "buyer_id"}},
+		DoNothing: true,
+	}).Create(&conversation).Error
+	if err != nil {
+		return nil, err
+	}
+	var existing models.Conversation
+	if err := r.DB.Where("offer_id = ? AND buyer_id = ?", offerID, buyerID).First(&existing).Error; err != nil {
+		return nil, err
+	}
+	return &existing, nil
+}
+
+// GetByUserID lists the conversations of the user, the most recently active first.
+func (r *ConversationRepository) GetByUserID(userID uint, filter *ConversationFilter) ([]models.Conversation, *pagination.PaginationResponse, error) {
+	query := r.DB.
+		Preload("Buyer").
+		Preload("Seller").
+		Where("buyer_id = ? OR seller_id = ?", userID, userID).
+		Order("COALESCE(last_message_at, created_at) DESC, id DESC")
+	return pagination.PaginateResults[models.Conversation](&filter.Pagination, query)
+}
+
+// CountUnread counts the messages the user has not read yet in each of the given conversations.
+// Conversations without unread messages are left out.
+func (r *ConversationRepository) CountUnread(userID uint, conversationIDs []uint) (map[uint]uint, error) {
+	counts := make(map[uint]uint)
+	if len(conversationIDs) == 0 {
+		return counts, nil
+	}
+	var rows []struct {
+		ConversationID uint
+		Count          uint
+	}
+	err := r.DB.Model(&models.Message{}).
+		Select("conversation_id, COUNT(*) AS count").
+		Where("conversation_id IN ? AND sender_id <> ? AND read_at IS NULL", conversationIDs, userID).
+		Group("conversation_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.ConversationID] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *ConversationRepository) SaveMessage(message *models.Message) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(message).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Conversation{}).
+			Where("id = ?", message.ConversationID).
+			Update("last_message_at", message.CreatedAt).Error
+	})
+}
+
+// GetMessages returns the history of the conversation, the newest messages first.
+func (r *ConversationRepository) GetMessages(conversationID uint, filter *MessageFilter) ([]models.Message, *pagination.PaginationResponse, error) {
+	query := r.DB.Where("conversation_id = ?", conversationID).Order("created_at DESC, id DESC")
+	return pagination.PaginateResults[models.Message](&filter.Pagination, query)
+}
+
+// MarkRead marks all the messages the reader received in the conversation as read, returning how many were unread.
+func (r *ConversationRepository) MarkRead(conversationID, readerID uint, readAt time.Time) (int64, error) {
+	result := r.DB.Model(&models.Message{}).
+		Where("conversation_id = ? AND sender_id <> ? AND read_at IS NULL", conversationID, readerID).
+		Update("read_at", readAt)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,194 @@
+package conversation
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/ws"
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"gorm.io/gorm"
+)
+
+const MaxMessageLength = 1000
+
+type SaleOfferRetrieverInterface interface {
+	GetByID(id uint) (*models.SaleOffer, error)
+}
+
+// ConversationServiceInterface serves the conversation history over REST, and the live chat frames the
+// hub receives over websockets.
+type ConversationServiceInterface interface {
+	ws.ChatHandlerInterface
+	GetConversations(userID uint, filter *ConversationFilter) (*RetrieveConversationsWithPagination, error)
+	GetMessages(userID, conversationID uint, filter *MessageFilter) (*RetrieveMessagesWithPagination, error)
+}
+
+type ConversationService struct {
+	repo               ConversationRepositoryInterface
+	saleOfferRetriever SaleOfferRetrieverInterface
+	publisher          ws.UserPublisherInterface
+}
+
+func NewConversationService(
+	repo ConversationRepositoryInterface,
+	saleOfferRetriever SaleOfferRetrieverInterface,
+	publisher ws.UserPublisherInterface,
+) ConversationServiceInterface {
+	return &ConversationService{
+		repo:               repo,
+		saleOfferRetriever: saleOfferRetriever,
+		publisher:          publisher,
+	}
+}
+
+// SendMessage saves the message and delivers it to both participants, so that the sender's other
+// connections see it too. A message about an offer starts the buyer's conversation with the seller.
+func (s *ConversationService) SendMessage(senderID uint, payload *ws.SendMessagePayload) error {
+	body := strings.TrimSpace(payload.Body)
+	if body == "" || utf8.RuneCountInString(body) > MaxMessageLength {
+		return ErrInvalidMessage
+	}
+	conversation, err := s.resolveConversation(senderID, payload)
+	if err != nil {
+		return err
+	}
+	message := &models.Message{
+		ConversationID: conversation.ID,
+		SenderID:       senderID,
+		Body:           body,
+		CreatedAt:      time.Now(),
+	}
+	if err := s.repo.SaveMessage(message); err != nil {
+		return err
+	}
+	envelope := ws.NewEnvelope(ws.MsgMessage, MapToMessageDTO(message, conversation.OfferID))
+	s.publish(conversation.OtherParticipant(senderID), envelope)
+	s.publish(senderID, envelope)
+	return nil
+}
+
+// Typing lets the other participant know that the user is writing. Nothing is saved.
+func (s *ConversationService) Typing(userID uint, payload *ws.TypingPayload) error {
+	conversation, err := s.getParticipatedConversation(userID, payload.ConversationID)
+	if err != nil {
+		return err
+	}
+	s.publish(conversation.OtherParticipant(userID), ws.NewEnvelope(ws.MsgTyping, ws.TypingPayload{
+		ConversationID: conversation.ID,
+		UserID:         userID,
+	}))
+	return nil
+}
+
+// MarkRead marks the messages the reader received in the conversation as read. The other participant gets
+// a read receipt only if something was actually unread.
+func (s *ConversationService) MarkRead(readerID uint, payload *ws.ReadReceiptPayload) error {
+	conversation, err := s.getParticipatedConversation(readerID, payload.ConversationID)
+	if err != nil {
+		return err
+	}
+	readAt := time.Now()
+	marked, err := s.repo.MarkRead(conversation.ID, readerID, readAt)
+	if err != nil {
+		return err
+	}
+	if marked == 0 {
+		return nil
+	}
+	s.publish(conversation.OtherParticipant(readerID), ws.NewEnvelope(ws.MsgReadReceipt, ws.ReadReceiptPayload{
+		ConversationID: conversation.ID,
+		ReaderID:       readerID,
+		ReadAt:         &readAt,
+	}))
+	return nil
+}
+
+func (s *ConversationService) GetConversations(userID uint, filter *ConversationFilter) (*RetrieveConversationsWithPagination, error) {
+	conversations, paginationResponse, err := s.repo.GetByUserID(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, 0, len(conversations))
+	for _, conversation := range conversations {
+		ids = append(ids, conversation.ID)
+	}
+	unread, err := s.repo.CountUnread(userID, ids)
+	if err != nil {
+		return nil, err
+	}
+	dtos := make([]RetrieveConversationDTO, 0, len(conversations))
+	for _, conversation := range conversations {
+		dtos = append(dtos, *MapToConversationDTO(&conversation, unread[conversation.ID]))
+	}
+	return &RetrieveConversationsWithPagination{Conversations: dtos, PaginationResponse: paginationResponse}, nil
+}
+
+func (s *ConversationService) GetMessages(userID, conversationID uint, filter *MessageFilter) (*RetrieveMessagesWithPagination, error) {
+	conversation, err := s.getParticipatedConversation(userID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	messages, paginationResponse, err := s.repo.GetMessages(conversation.ID, filter)
+	if err != nil {
+		return nil, err
+	}
+	dtos := make([]RetrieveMessageDTO, 0, len(messages))
+	for _, message := range messages {
+		dtos = append(dtos, *MapToMessageDTO(&message, conversation.OfferID))
+	}
+	return &RetrieveMessagesWithPagination{Messages: dtos, PaginationResponse: paginationResponse}, nil
+}
+
+func (s *ConversationService) resolveConversation(senderID uint, payload *ws.SendMessagePayload) (*models.Conversation, error) {
+	if payload.ConversationID != nil {
+		return s.getParticipatedConversation(senderID, *payload.ConversationID)
+	}
+	if payload.OfferID == nil {
+		return nil, ErrMissingConversation
+	}
+	offer, err := s.saleOfferRetriever.GetByID(*payload.OfferID)
+	if err != nil {
+		return nil, err
+	}
+	if offer.BelongsToUser(senderID) {
+		return nil, ErrCannotMessageOwnOffer
+	}
+	// conversations already started go on by conversation_id, new ones need an offer buyers can see
+	if offer.Status != enums.PUBLISHED || offer.HiddenAt != nil {
+		return nil, ErrOfferNotAvailable
+	}
+	return s.repo.GetOrCreate(offer.ID, senderID, offer.UserID)
+}
+
+func (s *ConversationService) getParticipatedConversation(userID, conversationID uint) (*models.Conversation, error) {
+	if conversationID == 0 {
+		return nil, ErrConversationIDRequired
+	}
+	conversation, err := s.repo.GetByID(conversationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrConversationNotFound
+		}
+		return nil, err
+	}
+	if !conversation.HasParticipant(userID) {
+		return nil, ErrNotParticipant
+	}
+	return conversation, nil
+}
+
+// publish delivers the envelope on a best effort basis - the message is already saved, so the recipient
+// still finds it in the history.
+func (s *ConversationService) publish(userID uint, envelope *ws.Envelope) {
+	if envelope == nil {
+		return
+	}
+	if err := s.publisher.PublishToUser(context.Background(), userID, envelope); err != nil {
+		log.Printf("conversation: cannot publish %s to userID %d: %v", envelope.MessageType, userID, err)
+	}
+}
@@ -1,18 +1,22 @@
 package image
 
 import (
+	"bytes"
 	"context"
-	"mime/multipart"
+	"path"
+	"strings"
 
 	"github.com/cloudinary/cloudinary-go/v2"
 	"github.com/cloudinary/cloudinary-go/v2/api/admin"
 	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
 )
 
+//go:generate mockery --name=ImageBucketInterface --output=../../test/mocks --case=snake --with-expecter
 type ImageBucketInterface interface {
-	Upload(folder string, file *multipart.FileHeader) (string, string, error)
+	Upload(folder, name string, content []byte) (string, string, error)
 	Delete(publicID string) error
 	DeleteByFolderName(folder string) error
+	ListFolders() ([]string, error)
 }
 
 type ImageBucket struct {
@@ -23,13 +27,10 @@ func NewImageBucket(cld *cloudinary.Cloudinary) ImageBucketInterface {
 	return &ImageBucket{CloudinaryClient: cld}
 }
 
-func (b *ImageBucket) Upload(folder string, file *multipart.FileHeader) (string, string, error) {
+func (b *ImageBucket) Upload(folder, name string, content []byte) (string, string, error) {
 	ctx := context.Background()
-	openedFile, err := file.Open()
-	if err != nil {
-		return "", "", err
-	}
-	resp, err := b.CloudinaryClient.Upload.Upload(ctx, openedFile, uploader.UploadParams{Folder: folder})
+	publicID := strings.TrimSuffix(name, path.Ext(name))
+	resp, err := b.CloudinaryClient.Upload.Upload(ctx, bytes.NewReader(content), uploader.UploadParams{Folder: folder, PublicID: publicID})
 	if err != nil {
 		return "", "", err
 	}
@@ -58,3 +59,23 @@ func (b *ImageBucket) DeleteByFolderName(folder string) error {
 	_, err = b.CloudinaryClient.Admin.DeleteFolder(ctx, admin.DeleteFolderParams{Folder: folder})
 	return err
 }
+
+// ListFolders returns the names of the root folders, following the cursor through all the pages.
+func (b *ImageBucket) ListFolders() ([]string, error) {
+	ctx := context.Background()
+	var folders []string
+	params := admin.RootFoldersParams{MaxResults: 500}
+	for {
+		resp, err := b.CloudinaryClient.Admin.RootFolders(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, folder := range resp.Folders {
+			folders = append(folders, folder.Name)
+		}
+		if resp.NextCursor == "" {
+			return folders, nil
+		}
+		params.NextCursor = resp.NextCursor
+	}
+}
@@ -0,0 +1,89 @@
+package image
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalImageBucket keeps the images on disk under Root, and they are served under BaseURL by a static route.
+type LocalImageBucket struct {
+	Root    string
+	BaseURL string
+}
+
+func NewLocalImageBucket(root, baseURL string) ImageBucketInterface {
+	return &LocalImageBucket{Root: root, BaseURL: strings.TrimRight(baseURL, "/")}
+}
+
+func (b *LocalImageBucket) Upload(folder, name string, content []byte) (string, string, error) {
+	publicID, err := objectPath(folder, name)
+	if err != nil {
+		return "", "", err
+	}
+	if err := b.save(publicID, content); err != nil {
+		return "", "", err
+	}
+	return publicID, b.BaseURL + "/" + publicID, nil
+}
+
+// Delete removes a single image, an image that is already gone is not an error.
+func (b *LocalImageBucket) Delete(publicID string) error {
+	publicID, err := cleanObjectPath(publicID)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(b.path(publicID)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (b *LocalImageBucket) DeleteByFolderName(folder string) error {
+	folder, err := cleanObjectPath(folder)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(b.path(folder))
+}
+
+// ListFolders returns the directories directly under Root, none if nothing has been uploaded yet.
+func (b *LocalImageBucket) ListFolders() ([]string, error) {
+	entries, err := os.ReadDir(b.Root)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var folders []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			folders = append(folders, entry.Name())
+		}
+	}
+	return folders, nil
+}
+
+func (b *LocalImageBucket) save(publicID string, content []byte) error {
+	dst := b.path(publicID)
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := out.Write(content); err != nil {
+		out.Close()
+		_ = os.Remove(dst)
+		return err
+	}
+	return out.Close()
+}
+
+func (b *LocalImageBucket) path(objectPath string) string {
+	return filepath.Join(b.Root, filepath.FromSlash(objectPath))
+}
@@ -0,0 +1,262 @@
+package image
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultS3Region = "us-east-1"
+	s3Service       = "s3"
+	s3Algorithm     = "AWS4-HMAC-SHA256"
+	s3Timeout       = 30 * time.Second
+)
+
+// S3Config describes an S3 compatible bucket, like AWS S3 or MinIO. Objects are addressed path style
+// (Endpoint/Bucket/key), which every compatible server accepts. PublicURL is where the objects can be
+// read by the clients, Endpoint/Bucket if empty.
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	PublicURL string
+}
+
+// S3ImageBucket talks to the bucket over its REST API, signing the requests with signature version 4.
+type S3ImageBucket struct {
+	Config     S3Config
+	HTTPClient *http.Client
+}
+
+func NewS3ImageBucket(cfg S3Config) (ImageBucketInterface, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" || cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, ErrMissingS3Config
+	}
+	if _, err := url.Parse(cfg.Endpoint); err != nil {
+		return nil, err
+	}
+	cfg.Endpoint = strings.TrimRight(cfg.Endpoint, "/")
+	if cfg.Region == "" {
+		cfg.Region = DefaultS3Region
+	}
+	if cfg.PublicURL == "" {
+		cfg.PublicURL = cfg.Endpoint + "/" + cfg.Bucket
+	}
+	cfg.PublicURL = strings.TrimRight(cfg.PublicURL, "/")
+	return &S3ImageBucket{Config: cfg, HTTPClient: &http.Client{Timeout: s3Timeout}}, nil
+}
+
+func (b *S3ImageBucket) Upload(folder, name string, content []byte) (string, string, error) {
+	publicID, err := objectPath(folder, name)
+	if err != nil {
+		return "", "", err
+	}
+	header := http.Header{}
+	header.Set("Content-Type", http.DetectContentType(content))
+	if _, err := b.do(http.MethodPut, publicID, nil, header, content); err != nil {
+		return "", "", err
+	}
+	return publicID, b.Config.PublicURL + "/" + escapeObjectPath(publicID), nil
+}
+
+// Delete removes a single object, S3 answers 204 for objects that don't exist either.
+func (b *S3ImageBucket) Delete(publicID string) error {
+	publicID, err := cleanObjectPath(publicID)
+	if err != nil {
+		return err
+	}
+	_, err = b.do(http.MethodDelete, publicID, nil, nil, nil)
+	return err
+}
+
+func (b *S3ImageBucket) DeleteByFolderName(folder string) error {
+	folder, err := cleanObjectPath(folder)
+	if err != nil {
+		return err
+	}
+	keys, err := b.list(folder + "/")
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if _, err := b.do(http.MethodDelete, key, nil, nil, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListFolders returns the first segments of the object keys, S3 has no folders of its own.
+func (b *S3ImageBucket) ListFolders() ([]string, error) {
+	keys, err := b.list("")
+	if err != nil {
+		return nil, err
+	}
+	var folders []string
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		folder, _, found := strings.Cut(key, "/")
+		if found && !seen[folder] {
+			seen[folder] = true
+			folders = append(folders, folder)
+		}
+	}
+	return folders, nil
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (b *S3ImageBucket) list(prefix string) ([]string, error) {
+	var keys []string
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		body, err := b.do(http.MethodGet, "", query, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		var result listBucketResult
+		if err := xml.Unmarshal(body, &result); err != nil {
+			return nil, err
+		}
+		for _, object := range result.Contents {
+			keys = append(keys, object.Key)
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return keys, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// do sends a signed request for the object key, or for the bucket itself when the key is empty.
+func (b *S3ImageBucket) do(method, key string, query url.Values, header http.Header, body []byte) ([]byte, error) {
+	resource := "/" + escapeObjectPath(b.Config.Bucket)
+	if key != "" {
+		resource += "/" + escapeObjectPath(key)
+	}
+	rawQuery := canonicalQuery(query)
+	target := b.Config.Endpoint + resource
+	if rawQuery != "" {
+		target += "?" + rawQuery
+	}
+	req, err := http.NewRequest(method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	SignS3Request(req, b.Config, resource, rawQuery, body, time.Now().UTC())
+	resp, err := b.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("s3 %s %s: %s: %s", method, resource, resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	return respBody, nil
+}
+
+// SignS3Request adds the signature version 4 headers for the bucket described by cfg, see
+// https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func SignS3Request(req *http.Request, cfg S3Config, canonicalURI, rawQuery string, body []byte, now time.Time) {
+	payloadHash := sha256Hex(body)
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	canonicalRequest := strings.Join([]string{
+		req.Method, canonicalURI, rawQuery, canonicalHeaders, signedHeaders, payloadHash,
+	}, "\n")
+	scope := date + "/" + cfg.Region + "/" + s3Service + "/aws4_request"
+	stringToSign := strings.Join([]string{s3Algorithm, amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+cfg.SecretKey), date)
+	key = hmacSHA256(key, cfg.Region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, cfg.AccessKey, scope, signedHeaders, signature))
+}
+
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, key := range keys {
+		for _, value := range query[key] {
+			parts = append(parts, s3Escape(key)+"="+s3Escape(value))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func escapeObjectPath(objectPath string) string {
+	segments := strings.Split(objectPath, "/")
+	for i, segment := range segments {
+		segments[i] = s3Escape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// s3Escape percent-encodes everything but the unreserved characters, as the signature expects.
+func s3Escape(value string) string {
+	var sb strings.Builder
+	for _, c := range []byte(value) {
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			sb.WriteByte(c)
+		} else {
+			fmt.Fprintf(&sb, "%%%02X", c)
+		}
+	}
+	return sb.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
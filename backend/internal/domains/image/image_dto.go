@@ -0,0 +1,25 @@
+package image
+
+type ImageOrderDTO struct {
+	ImageIDs []uint `json:"image_ids" binding:"required"`
+}
+
+type UpdateImageDTO struct {
+	Caption *string `json:"caption"`
+	IsCover *bool   `json:"is_cover"`
+}
+
+// ImageDuplicatesDTO lists the photos of other sellers' offers that look the same as the image.
+type ImageDuplicatesDTO struct {
+	ImageID uint                `json:"image_id"`
+	Url     string              `json:"url"`
+	Matches []DuplicateMatchDTO `json:"matches"`
+}
+
+type DuplicateMatchDTO struct {
+	ImageID  uint   `json:"image_id"`
+	OfferID  uint   `json:"offer_id"`
+	SellerID uint   `json:"seller_id"`
+	Url      string `json:"url"`
+	Distance int    `json:"distance"`
+}
@@ -0,0 +1,44 @@
+package image
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/notification"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/ws"
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+)
+
+const duplicatesReportComment = "%d photo(s) of the offer look like photos of other sellers' offers"
+
+//go:generate mockery --name=SystemReporterInterface --output=../../test/mocks --case=snake --with-expecter
+type SystemReporterInterface interface {
+	CreateSystemReport(offerID uint, reason enums.ReportReason, comment string) error
+}
+
+// DuplicateNotifier warns the seller that some of the photos they uploaded look like photos of other sellers,
+// and reports the offer, so that a moderator has a look at it too.
+type DuplicateNotifier struct {
+	notificationService notification.NotificationServiceInterface
+	userNotifier        *notification.UserNotifier
+	reporter            SystemReporterInterface
+}
+
+func NewDuplicateNotifier(notificationService notification.NotificationServiceInterface, hub ws.HubInterface, reporter SystemReporterInterface) *DuplicateNotifier {
+	return &DuplicateNotifier{
+		notificationService: notificationService,
+		userNotifier:        notification.NewUserNotifier(notificationService, hub),
+		reporter:            reporter,
+	}
+}
+
+// NotifyDuplicates files the report even if the seller could not be notified, and the other way round.
+func (n *DuplicateNotifier) NotifyDuplicates(offerID uint, offerName string, sellerID uint, count int) error {
+	reportErr := n.reporter.CreateSystemReport(offerID, enums.DUPLICATE_IMAGES, fmt.Sprintf(duplicatesReportComment, count))
+	notif := models.Notification{OfferID: &offerID}
+	notifyErr := n.userNotifier.Notify(&notif, sellerID, func(notif *models.Notification) error {
+		return n.notificationService.CreateDuplicateImagesNotification(notif, offerName, count)
+	})
+	return errors.Join(reportErr, notifyErr)
+}
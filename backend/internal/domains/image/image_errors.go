@@ -12,12 +12,32 @@ var (
 	ErrOfferNotOwned  = errors.New("offer does not belong to logged-in user")
 	ErrZeroImages     = errors.New("offer has no images - there is nothing to delete")
 	ErrOfferNotActive = errors.New("offer is not active - cannot upload images to it")
+
+	ErrInvalidImageStorage = errors.New("invalid image storage, expected cloudinary, local or s3")
+	ErrInvalidObjectPath   = errors.New("invalid image folder or public id")
+	ErrMissingS3Config     = errors.New("s3 storage needs an endpoint, bucket, access key and secret key")
+
+	ErrUnsupportedImageType = errors.New("only JPEG, PNG and WebP images can be uploaded")
+	ErrInvalidImage         = errors.New("file is not a valid image")
+	ErrImageTooLarge        = errors.New("image is too large - it can have at most 10MB and 40 megapixels")
+
+	ErrInvalidImageOrder = errors.New("order has to list every image of the offer exactly once")
+	ErrImageNotInOffer   = errors.New("image does not belong to the offer")
+	ErrCaptionTooLong    = errors.New("caption can have at most 200 characters")
+	ErrCoverRequired     = errors.New("offer with images needs a cover - choose another image as the cover instead")
 )
 
 var ErrorMap = map[error]int{
-	ErrTooManyImages:       http.StatusBadRequest,
-	ErrZeroImages:          http.StatusBadRequest,
-	ErrOfferNotOwned:       http.StatusForbidden,
-	gorm.ErrRecordNotFound: http.StatusNotFound,
-	ErrOfferNotActive:      http.StatusBadRequest,
+	ErrTooManyImages:        http.StatusBadRequest,
+	ErrZeroImages:           http.StatusBadRequest,
+	ErrOfferNotOwned:        http.StatusForbidden,
+	gorm.ErrRecordNotFound:  http.StatusNotFound,
+	ErrOfferNotActive:       http.StatusBadRequest,
+	ErrUnsupportedImageType: http.StatusUnsupportedMediaType,
+	ErrInvalidImage:         http.StatusBadRequest,
+	ErrImageTooLarge:        http.StatusRequestEntityTooLarge,
+	ErrInvalidImageOrder:    http.StatusBadRequest,
+	ErrImageNotInOffer:      http.StatusNotFound,
+	ErrCaptionTooLong:       http.StatusBadRequest,
+	ErrCoverRequired:        http.StatusBadRequest,
 }
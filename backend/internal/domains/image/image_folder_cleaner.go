@@ -0,0 +1,71 @@
+package image
+
+import (
+	"log"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// offerFolderPrefix starts the name of the folder holding the images of a sale offer, e.g. "sale-offer-1".
+const offerFolderPrefix = "sale-offer-"
+
+// OrphanedFolderCleaner removes the folders of the offers which no longer exist - their images stay behind
+// when deleting the folder fails after the offer has been deleted. It is run periodically by the job runner.
+type OrphanedFolderCleaner struct {
+	repo   ImageRepositoryInterface
+	bucket ImageBucketInterface
+}
+
+func NewOrphanedFolderCleaner(repo ImageRepositoryInterface, bucket ImageBucketInterface) *OrphanedFolderCleaner {
+	return &OrphanedFolderCleaner{
+		repo:   repo,
+		bucket: bucket,
+	}
+}
+
+// Sweep deletes the orphaned folders, folders not named after an offer are left alone.
+// A folder that cannot be deleted is logged and retried with the next sweep.
+func (c *OrphanedFolderCleaner) Sweep() error {
+	folders, err := c.bucket.ListFolders()
+	if err != nil {
+		return err
+	}
+	offerFolders := make(map[uint]string)
+	for _, folder := range folders {
+		if offerID, ok := parseOfferFolder(folder); ok {
+			offerFolders[offerID] = folder
+		}
+	}
+	if len(offerFolders) == 0 {
+		return nil
+	}
+	existing, err := c.repo.GetExistingOfferIDs(slices.Collect(maps.Keys(offerFolders)))
+	if err != nil {
+		return err
+	}
+	for _, offerID := range existing {
+		delete(offerFolders, offerID)
+	}
+	for offerID, folder := range offerFolders {
+		if err := c.bucket.DeleteByFolderName(folder); err != nil {
+			log.Printf("images: cannot delete folder of offer %d: %v", offerID, err)
+			continue
+		}
+		log.Printf("images: deleted folder of offer %d", offerID)
+	}
+	return nil
+}
+
+func parseOfferFolder(folder string) (uint, bool) {
+	id, found := strings.CutPrefix(folder, offerFolderPrefix)
+	if !found {
+		return 0, false
+	}
+	offerID, err := strconv.ParseUint(id, 10, 0)
+	if err != nil {
+		return 0, false
+	}
+	return uint(offerID), true
+}
@@ -1,27 +1,35 @@
 package image
 
 import (
+	"log"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
 	"github.com/susek555/BD2/car-dealer-api/pkg/custom_errors"
 )
 
+//go:generate mockery --name=DuplicateNotifierInterface --output=../../test/mocks --case=snake --with-expecter
+type DuplicateNotifierInterface interface {
+	NotifyDuplicates(offerID uint, offerName string, sellerID uint, count int) error
+}
+
 type Handler struct {
-	imageService     ImageServiceInterface
-	saleOfferService sale_offer.SaleOfferServiceInterface
+	imageService      ImageServiceInterface
+	saleOfferService  sale_offer.SaleOfferServiceInterface
+	duplicateNotifier DuplicateNotifierInterface
 }
 
-func NewHandler(imgSvc ImageServiceInterface, offerSvc sale_offer.SaleOfferServiceInterface) *Handler {
-	return &Handler{imageService: imgSvc, saleOfferService: offerSvc}
+func NewHandler(imgSvc ImageServiceInterface, offerSvc sale_offer.SaleOfferServiceInterface, duplicateNotifier DuplicateNotifierInterface) *Handler {
+	return &Handler{imageService: imgSvc, saleOfferService: offerSvc, duplicateNotifier: duplicateNotifier}
 }
 
 // UploadImages godoc
 //
 //	@Summary		Upload images for sale offer
-//	@Description	Uploads images for a sale offer. You can upload multiple images at once, but 10 is the limit. Only offers with photos can be published later on (sale-offer/publish).
+//	@Description	Uploads images for a sale offer. You can upload multiple images at once, but 10 is the limit. Only offers with photos can be published later on (sale-offer/publish). Every image is stripped of its metadata and stored with medium and thumbnail renditions. If some of the photos look like photos of other sellers' offers, the seller gets a notification about it.
 //	@Tags			image
 //	@Accept			multipart/form-data
 //	@Produce		json
@@ -32,6 +40,8 @@ func NewHandler(imgSvc ImageServiceInterface, offerSvc sale_offer.SaleOfferServi
 //	@Failure		401		{object}	custom_errors.HTTPError					"Unauthorized - user must be logged in to upload images for sale offers"
 //	@Failure		403		{object}	custom_errors.HTTPError					"Forbidden - user can only upload images for his own offers"
 //	@Failure		404		{object}	custom_errors.HTTPError					"Sale offer not found"
+//	@Failure		413		{object}	custom_errors.HTTPError					"Image larger than 10MB or 40 megapixels"
+//	@Failure		415		{object}	custom_errors.HTTPError					"File is not a JPEG, PNG or WebP image"
 //	@Failure		500		{object}	custom_errors.HTTPError					"Internal server error"
 //	@Router			/image/{id} [patch]
 //	@Security		Bearer
@@ -49,7 +59,8 @@ func (h *Handler) UploadImages(c *gin.Context) {
 		return
 	}
 	files := form.File["images"]
-	if err = h.imageService.Store(uint(offerID), files, id); err != nil {
+	duplicates, err := h.imageService.Store(uint(offerID), files, id)
+	if err != nil {
 		custom_errors.HandleError(c, err, ErrorMap)
 		return
 	}
@@ -59,6 +70,43 @@ func (h *Handler) UploadImages(c *gin.Context) {
 		return
 	}
 	c.JSON(http.StatusOK, offerDTO)
+	if len(duplicates) > 0 {
+		if err := h.duplicateNotifier.NotifyDuplicates(offerDTO.ID, offerDTO.Name, id, len(duplicates)); err != nil {
+			log.Printf("Error notifying about duplicate images of offer ID %d: %v", offerDTO.ID, err)
+		}
+	}
+}
+
+// GetDuplicates godoc
+//
+//	@Summary		Get photos of a sale offer that look copied
+//	@Description	Lists the photos of the offer that closely match photos of other sellers' offers, with the matching photos and how many bits of their perceptual hashes differ. Available to the owner of the offer and to moderators.
+//	@Tags			image
+//	@Produce		json
+//	@Param			offerID	path		int						true	"Sale offer ID"
+//	@Success		200		{array}		ImageDuplicatesDTO		"Photos with their matches, empty if none look copied"
+//	@Failure		400		{object}	custom_errors.HTTPError	"Invalid request"
+//	@Failure		401		{object}	custom_errors.HTTPError	"Unauthorized - user must be logged in"
+//	@Failure		403		{object}	custom_errors.HTTPError	"Forbidden - only the owner of the offer and moderators can see the report"
+//	@Failure		404		{object}	custom_errors.HTTPError	"Sale offer not found"
+//	@Failure		500		{object}	custom_errors.HTTPError	"Internal server error"
+//	@Router			/image/duplicates/{offerID} [get]
+//	@Security		Bearer
+func (h *Handler) GetDuplicates(c *gin.Context) {
+	id, _ := c.Get("userID")
+	userID := id.(uint)
+	role := enums.Role(c.GetString("userRole"))
+	offerID, err := strconv.ParseUint(c.Param("offerID"), 10, 32)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	duplicates, err := h.imageService.GetDuplicates(uint(offerID), userID, role)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	c.JSON(http.StatusOK, duplicates)
 }
 
 // DeleteImage godoc
@@ -118,3 +166,92 @@ func (h *Handler) DeleteImages(c *gin.Context) {
 	}
 	c.Status(http.StatusNoContent)
 }
+
+// ReorderImages godoc
+//
+//	@Summary		Reorder images of a sale offer
+//	@Description	Sets the order of the offer's gallery. The list has to contain the id of every image of the offer exactly once. The user must be the owner of the given offer.
+//	@Tags			image
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int										true	"Sale offer ID"
+//	@Param			order	body		ImageOrderDTO							true	"Image ids in the new order"
+//	@Success		200		{object}	sale_offer.RetrieveDetailedSaleOfferDTO	"Updated sale offer with images"
+//	@Failure		400		{object}	custom_errors.HTTPError					"Invalid request or the order doesn't list every image"
+//	@Failure		401		{object}	custom_errors.HTTPError					"Unauthorized - user must be logged in to reorder images"
+//	@Failure		403		{object}	custom_errors.HTTPError					"Forbidden - user can only reorder images of his own offers"
+//	@Failure		404		{object}	custom_errors.HTTPError					"Sale offer not found"
+//	@Failure		500		{object}	custom_errors.HTTPError					"Internal server error"
+//	@Router			/image/offer/{id}/order [put]
+//	@Security		Bearer
+func (h *Handler) ReorderImages(c *gin.Context) {
+	id, _ := c.Get("userID")
+	userID := id.(uint)
+	offerID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	var in ImageOrderDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, custom_errors.NewHTTPError(err.Error()))
+		return
+	}
+	if err := h.imageService.Reorder(uint(offerID), in.ImageIDs, userID); err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	h.respondWithOffer(c, uint(offerID), userID)
+}
+
+// UpdateImage godoc
+//
+//	@Summary		Update caption or cover of an image
+//	@Description	Sets the caption of an image (an empty caption removes it) and/or makes it the cover of the offer. The cover can't be unset, choose another image as the cover instead. The user must be the owner of the given offer.
+//	@Tags			image
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int										true	"Sale offer ID"
+//	@Param			image_id	path		int										true	"Image ID"
+//	@Param			image		body		UpdateImageDTO							true	"Caption and cover flag"
+//	@Success		200			{object}	sale_offer.RetrieveDetailedSaleOfferDTO	"Updated sale offer with images"
+//	@Failure		400			{object}	custom_errors.HTTPError					"Invalid request, caption too long or unsetting the cover"
+//	@Failure		401			{object}	custom_errors.HTTPError					"Unauthorized - user must be logged in to update images"
+//	@Failure		403			{object}	custom_errors.HTTPError					"Forbidden - user can only update images of his own offers"
+//	@Failure		404			{object}	custom_errors.HTTPError					"Sale offer or image not found"
+//	@Failure		500			{object}	custom_errors.HTTPError					"Internal server error"
+//	@Router			/image/offer/{id}/images/{image_id} [patch]
+//	@Security		Bearer
+func (h *Handler) UpdateImage(c *gin.Context) {
+	id, _ := c.Get("userID")
+	userID := id.(uint)
+	offerID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	imageID, err := strconv.ParseUint(c.Param("image_id"), 10, 32)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	var in UpdateImageDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, custom_errors.NewHTTPError(err.Error()))
+		return
+	}
+	if err := h.imageService.Update(uint(offerID), uint(imageID), &in, userID); err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	h.respondWithOffer(c, uint(offerID), userID)
+}
+
+func (h *Handler) respondWithOffer(c *gin.Context, offerID uint, userID uint) {
+	offerDTO, err := h.saleOfferService.GetDetailedByID(offerID, &userID)
+	if err != nil {
+		custom_errors.HandleError(c, err, sale_offer.ErrorMap)
+		return
+	}
+	c.JSON(http.StatusOK, offerDTO)
+}
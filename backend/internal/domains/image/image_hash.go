@@ -0,0 +1,62 @@
+package image
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math/bits"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+const (
+	// MaxDuplicateDistance is the number of bits two hashes can differ in for the photos to count as the same one.
+	MaxDuplicateDistance = 3
+	hashBands            = MaxDuplicateDistance + 1
+	hashBandBits         = 64 / hashBands
+	hashBandMask         = 1<<hashBandBits - 1
+)
+
+// DHash is the difference hash of the image: the image is scaled down to 9x8 grey pixels and every bit tells
+// whether a pixel is brighter than its right neighbour. Re-encoding, resizing or a small edit of a photo
+// barely change the hash, while different photos differ in about half of the bits.
+func DHash(img image.Image) uint64 {
+	small := image.NewRGBA(image.Rect(0, 0, 9, 8))
+	draw.Draw(small, small.Bounds(), image.White, image.Point{}, draw.Src)
+	xdraw.BiLinear.Scale(small, small.Bounds(), img, img.Bounds(), xdraw.Over, nil)
+	var hash uint64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			hash <<= 1
+			if brightness(small.RGBAAt(x, y)) > brightness(small.RGBAAt(x+1, y)) {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+func brightness(c color.RGBA) uint8 {
+	return color.GrayModel.Convert(c).(color.Gray).Y
+}
+
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// HashBands splits the hash into MaxDuplicateDistance+1 parts. Hashes at most MaxDuplicateDistance bits apart
+// can differ in at most that many parts, so they always share one - the database looks the candidates up
+// by the indexed bands and only those are compared bit by bit.
+func HashBands(hash uint64) [hashBands]uint64 {
+	var bands [hashBands]uint64
+	for i := range bands {
+		bands[i] = (hash >> (i * hashBandBits)) & hashBandMask
+	}
+	return bands
+}
+
+// hashBandColumn is the SQL expression of a band, it has to match the expression indexes on images.
+func hashBandColumn(band int) string {
+	return fmt.Sprintf("(perceptual_hash >> %d) & %d", band*hashBandBits, hashBandMask)
+}
@@ -0,0 +1,301 @@
+package image
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+const (
+	plateAnalysisWidth = 800
+	plateEdgeThreshold = 48
+	plateMinAspect     = 2.0
+	plateMaxAspect     = 6.5
+	plateMinFill       = 0.35
+	plateMinTransition = 6
+)
+
+//go:generate mockery --name=PlateDetectorInterface --output=../../test/mocks --case=snake --with-expecter
+type PlateDetectorInterface interface {
+	Detect(img image.Image) []image.Rectangle
+}
+
+// PlateDetector finds licence plates the classic way, without any model: characters on a plate make a dense
+// row of strong vertical edges, so the edges are smeared together into blobs and the blobs with the shape of
+// a plate and enough light/dark transitions are kept. It works on a downscaled greyscale copy, which makes it
+// fast and deterministic, at the cost of some false positives on grilles and badges. Blurring those is harmless.
+type PlateDetector struct{}
+
+func NewPlateDetector() PlateDetectorInterface {
+	return &PlateDetector{}
+}
+
+type edgeMap struct {
+	w, h  int
+	edges []bool
+}
+
+func (m *edgeMap) at(x, y int) bool {
+	return m.edges[y*m.w+x]
+}
+
+func (d *PlateDetector) Detect(img image.Image) []image.Rectangle {
+	bounds := img.Bounds()
+	if bounds.Dx() < 8 || bounds.Dy() < 8 {
+		return nil
+	}
+	gray, scale := analysisCopy(img)
+	edges := verticalEdges(gray)
+	blobs := smear(edges, max(2, edges.w/80), 2)
+	var plates []image.Rectangle
+	for _, blob := range components(blobs) {
+		if !looksLikePlate(blob, edges) {
+			continue
+		}
+		plates = append(plates, scaleRect(expandToBorder(blob.bounds, edges), scale, bounds))
+	}
+	return plates
+}
+
+// analysisCopy scales the image down to plateAnalysisWidth and flattens it onto white, so transparent
+// backgrounds don't produce edges.
+func analysisCopy(img image.Image) (*image.Gray, float64) {
+	bounds := img.Bounds()
+	scale := 1.0
+	w, h := bounds.Dx(), bounds.Dy()
+	if w > plateAnalysisWidth {
+		scale = float64(w) / plateAnalysisWidth
+		w, h = plateAnalysisWidth, max(1, int(float64(h)/scale))
+	}
+	flat := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(flat, flat.Bounds(), image.White, image.Point{}, draw.Src)
+	xdraw.ApproxBiLinear.Scale(flat, flat.Bounds(), img, bounds, xdraw.Over, nil)
+	gray := image.NewGray(flat.Bounds())
+	draw.Draw(gray, gray.Bounds(), flat, image.Point{}, draw.Src)
+	return gray, scale
+}
+
+func verticalEdges(gray *image.Gray) *edgeMap {
+	w, h := gray.Rect.Dx(), gray.Rect.Dy()
+	m := &edgeMap{w: w, h: h, edges: make([]bool, w*h)}
+	for y := 0; y < h; y++ {
+		row := gray.Pix[y*gray.Stride : y*gray.Stride+w]
+		for x := 1; x < w-1; x++ {
+			diff := int(row[x+1]) - int(row[x-1])
+			m.edges[y*w+x] = diff > plateEdgeThreshold || diff < -plateEdgeThreshold
+		}
+	}
+	return m
+}
+
+// smear joins edges that are at most gapX apart horizontally and gapY apart vertically.
+func smear(m *edgeMap, gapX, gapY int) *edgeMap {
+	out := &edgeMap{w: m.w, h: m.h, edges: make([]bool, len(m.edges))}
+	copy(out.edges, m.edges)
+	for y := 0; y < m.h; y++ {
+		last := -1
+		for x := 0; x < m.w; x++ {
+			if !m.at(x, y) {
+				continue
+			}
+			if last >= 0 && x-last <= gapX {
+				for fill := last + 1; fill < x; fill++ {
+					out.edges[y*m.w+fill] = true
+				}
+			}
+			last = x
+		}
+	}
+	rows := make([]bool, len(out.edges))
+	copy(rows, out.edges)
+	for x := 0; x < m.w; x++ {
+		last := -1
+		for y := 0; y < m.h; y++ {
+			if !rows[y*m.w+x] {
+				continue
+			}
+			if last >= 0 && y-last <= gapY {
+				for fill := last + 1; fill < y; fill++ {
+					out.edges[fill*m.w+x] = true
+				}
+			}
+			last = y
+		}
+	}
+	return out
+}
+
+type blob struct {
+	bounds image.Rectangle
+	pixels int
+}
+
+func components(m *edgeMap) []blob {
+	seen := make([]bool, len(m.edges))
+	var blobs []blob
+	var stack []int
+	for start, edge := range m.edges {
+		if !edge || seen[start] {
+			continue
+		}
+		b := blob{bounds: image.Rect(start%m.w, start/m.w, start%m.w+1, start/m.w+1)}
+		seen[start] = true
+		stack = append(stack[:0], start)
+		for len(stack) > 0 {
+			i := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			x, y := i%m.w, i/m.w
+			b.pixels++
+			b.bounds = b.bounds.Union(image.Rect(x, y, x+1, y+1))
+			for _, n := range [4][2]int{{x - 1, y}, {x + 1, y}, {x, y - 1}, {x, y + 1}} {
+				if n[0] < 0 || n[1] < 0 || n[0] >= m.w || n[1] >= m.h {
+					continue
+				}
+				j := n[1]*m.w + n[0]
+				if m.edges[j] && !seen[j] {
+					seen[j] = true
+					stack = append(stack, j)
+				}
+			}
+		}
+		blobs = append(blobs, b)
+	}
+	return blobs
+}
+
+// looksLikePlate checks the shape of the blob and that its middle rows cross enough character strokes.
+func looksLikePlate(b blob, edges *edgeMap) bool {
+	w, h := b.bounds.Dx(), b.bounds.Dy()
+	if w < edges.w/25 || w > edges.w*2/5 || h < 6 || h > edges.h/6 {
+		return false
+	}
+	aspect := float64(w) / float64(h)
+	if aspect < plateMinAspect || aspect > plateMaxAspect {
+		return false
+	}
+	if float64(b.pixels)/float64(w*h) < plateMinFill {
+		return false
+	}
+	strokeRows := 0
+	for y := b.bounds.Min.Y + h/4; y < b.bounds.Max.Y-h/4; y++ {
+		transitions := 0
+		for x := b.bounds.Min.X + 1; x < b.bounds.Max.X; x++ {
+			if edges.at(x, y) && !edges.at(x-1, y) {
+				transitions++
+			}
+		}
+		if transitions >= plateMinTransition {
+			strokeRows++
+		}
+	}
+	return strokeRows*2 >= h/2
+}
+
+// expandToBorder widens the characters to the edges of the plate, when there is a border within reach.
+func expandToBorder(r image.Rectangle, edges *edgeMap) image.Rectangle {
+	reach := 2 * r.Dy()
+	isBorder := func(x int) bool {
+		count := 0
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			if edges.at(x, y) {
+				count++
+			}
+		}
+		return count*10 >= r.Dy()*6
+	}
+	for x := r.Min.X - 1; x >= max(0, r.Min.X-reach); x-- {
+		if isBorder(x) {
+			r.Min.X = x
+			break
+		}
+	}
+	for x := r.Max.X; x < min(edges.w, r.Max.X+reach); x++ {
+		if isBorder(x) {
+			r.Max.X = x + 1
+			break
+		}
+	}
+	return r
+}
+
+// scaleRect maps a rectangle of the analysis copy back onto the image, with a margin around it.
+func scaleRect(r image.Rectangle, scale float64, bounds image.Rectangle) image.Rectangle {
+	margin := r.Dy() / 4
+	r = r.Inset(-margin)
+	scaled := image.Rect(
+		int(float64(r.Min.X)*scale), int(float64(r.Min.Y)*scale),
+		int(float64(r.Max.X)*scale+scale), int(float64(r.Max.Y)*scale+scale),
+	)
+	return scaled.Add(bounds.Min).Intersect(bounds)
+}
+
+// BlurRegions returns a copy of the image with the regions blurred beyond recognition. Three passes of a box
+// blur come close to a gaussian one, and only pixels inside the regions are used, so nothing leaks in or out.
+func BlurRegions(img image.Image, regions []image.Rectangle) image.Image {
+	if len(regions) == 0 {
+		return img
+	}
+	bounds := img.Bounds()
+	out := image.NewNRGBA(bounds)
+	draw.Draw(out, bounds, img, bounds.Min, draw.Src)
+	for _, region := range regions {
+		region = region.Intersect(bounds)
+		if region.Empty() {
+			continue
+		}
+		radius := max(2, region.Dy()/4)
+		for pass := 0; pass < 3; pass++ {
+			boxBlur(out, region, radius, true)
+			boxBlur(out, region, radius, false)
+		}
+	}
+	return out
+}
+
+func boxBlur(img *image.NRGBA, region image.Rectangle, radius int, horizontal bool) {
+	length, lines := region.Dx(), region.Dy()
+	if !horizontal {
+		length, lines = lines, length
+	}
+	line := make([]color.NRGBA, length)
+	for l := 0; l < lines; l++ {
+		at := func(i int) (int, int) {
+			if horizontal {
+				return region.Min.X + i, region.Min.Y + l
+			}
+			return region.Min.X + l, region.Min.Y + i
+		}
+		for i := range line {
+			line[i] = img.NRGBAAt(at(i))
+		}
+		var sum [4]int
+		count := 0
+		for i := 0; i < min(radius, length); i++ {
+			addPixel(&sum, line[i], 1)
+			count++
+		}
+		for i := 0; i < length; i++ {
+			if j := i + radius; j < length {
+				addPixel(&sum, line[j], 1)
+				count++
+			}
+			if j := i - radius - 1; j >= 0 {
+				addPixel(&sum, line[j], -1)
+				count--
+			}
+			x, y := at(i)
+			img.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(sum[0] / count), G: uint8(sum[1] / count), B: uint8(sum[2] / count), A: uint8(sum[3] / count),
+			})
+		}
+	}
+}
+
+func addPixel(sum *[4]int, c color.NRGBA, sign int) {
+	sum[0] += sign * int(c.R)
+	sum[1] += sign * int(c.G)
+	sum[2] += sign * int(c.B)
+	sum[3] += sign * int(c.A)
+}
@@ -0,0 +1,273 @@
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+const (
+	MaxImageSize    = 10 << 20
+	MaxImagePixels  = 40_000_000
+	MediumSize      = 1280
+	ThumbnailSize   = 320
+	jpegQuality     = 85
+	jpegExtension   = ".jpg"
+	pngExtension    = ".png"
+	exifOrientation = 0x0112
+)
+
+// ProcessedImage holds the encoded renditions of an uploaded photo, all of them without any metadata, and
+// the perceptual hash of the photo as it was uploaded.
+type ProcessedImage struct {
+	Extension string
+	Original  []byte
+	Medium    []byte
+	Thumbnail []byte
+	Hash      uint64
+}
+
+//go:generate mockery --name=ImageProcessorInterface --output=../../test/mocks --case=snake --with-expecter
+type ImageProcessorInterface interface {
+	Process(file *multipart.FileHeader, blurPlates bool) (*ProcessedImage, error)
+}
+
+// ImageProcessor checks that an upload is a real JPEG, PNG or WebP image and re-encodes it, which drops the
+// EXIF data (including the GPS position of where the photo was taken). JPEG orientation is applied to the
+// pixels first, so the photo doesn't end up rotated once the tag is gone. Medium and thumbnail renditions
+// fit within MediumSize and ThumbnailSize squares, smaller images are never enlarged. When asked to, the
+// licence plates found by the detector are blurred before any rendition is made.
+type ImageProcessor struct {
+	detector PlateDetectorInterface
+}
+
+func NewImageProcessor(detector PlateDetectorInterface) ImageProcessorInterface {
+	return &ImageProcessor{detector: detector}
+}
+
+func (p *ImageProcessor) Process(file *multipart.FileHeader, blurPlates bool) (*ProcessedImage, error) {
+	if file.Size > MaxImageSize {
+		return nil, ErrImageTooLarge
+	}
+	data, err := readFile(file)
+	if err != nil {
+		return nil, err
+	}
+	img, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+	hash := DHash(img)
+	if blurPlates {
+		img = BlurRegions(img, p.detector.Detect(img))
+	}
+	extension := jpegExtension
+	if !isOpaque(img) {
+		extension = pngExtension
+	}
+	medium := fit(img, MediumSize)
+	thumbnail := fit(medium, ThumbnailSize)
+	processed := &ProcessedImage{Extension: extension, Hash: hash}
+	if processed.Original, err = encode(img, extension); err != nil {
+		return nil, err
+	}
+	if processed.Medium, err = encode(medium, extension); err != nil {
+		return nil, err
+	}
+	if processed.Thumbnail, err = encode(thumbnail, extension); err != nil {
+		return nil, err
+	}
+	return processed, nil
+}
+
+func readFile(file *multipart.FileHeader) ([]byte, error) {
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+	data, err := io.ReadAll(io.LimitReader(src, MaxImageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxImageSize {
+		return nil, ErrImageTooLarge
+	}
+	return data, nil
+}
+
+// decode picks the decoder from the sniffed content, never from the file name or the declared content type.
+func decode(data []byte) (image.Image, error) {
+	var (
+		decodeConfig func(io.Reader) (image.Config, error)
+		decodeImage  func(io.Reader) (image.Image, error)
+	)
+	switch http.DetectContentType(data) {
+	case "image/jpeg":
+		decodeConfig, decodeImage = jpeg.DecodeConfig, jpeg.Decode
+	case "image/png":
+		decodeConfig, decodeImage = png.DecodeConfig, png.Decode
+	case "image/webp":
+		decodeConfig, decodeImage = webp.DecodeConfig, webp.Decode
+	default:
+		return nil, ErrUnsupportedImageType
+	}
+	cfg, err := decodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, ErrInvalidImage
+	}
+	if cfg.Width*cfg.Height > MaxImagePixels {
+		return nil, ErrImageTooLarge
+	}
+	img, err := decodeImage(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	return orient(img, jpegOrientation(data)), nil
+}
+
+func encode(img image.Image, extension string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	if extension == pngExtension {
+		err = png.Encode(&buf, img)
+	} else {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	}
+	return buf.Bytes(), err
+}
+
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	return false
+}
+
+// fit scales the image down so that neither side is longer than size.
+func fit(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= size && h <= size {
+		return img
+	}
+	if w >= h {
+		w, h = size, max(1, h*size/w)
+	} else {
+		w, h = max(1, w*size/h), size
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, xdraw.Src, nil)
+	return dst
+}
+
+// jpegOrientation reads the EXIF orientation tag of a JPEG, 1 (as stored) when there is none.
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		if marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return 1
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return tiffOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for n := 0; n < entries; n++ {
+		entry := ifd + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == exifOrientation {
+			orientation := int(order.Uint16(tiff[entry+8:]))
+			if orientation < 1 || orientation > 8 {
+				return 1
+			}
+			return orientation
+		}
+	}
+	return 1
+}
+
+// orient turns the pixels the way the EXIF orientation says the photo should be displayed.
+func orient(img image.Image, orientation int) image.Image {
+	if orientation == 1 {
+		return img
+	}
+	bounds := img.Bounds()
+	src := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = w-1-x, y
+			case 3:
+				sx, sy = w-1-x, h-1-y
+			case 4:
+				sx, sy = x, h-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, h-1-x
+			case 7:
+				sx, sy = w-1-y, h-1-x
+			case 8:
+				sx, sy = w-1-y, x
+			}
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], src.Pix[src.PixOffset(sx, sy):src.PixOffset(sx, sy)+4])
+		}
+	}
+	return dst
+}
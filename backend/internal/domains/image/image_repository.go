@@ -1,17 +1,25 @@
 package image
 
 import (
+	"strings"
+
 	"github.com/susek555/BD2/car-dealer-api/internal/models"
 	"gorm.io/gorm"
 )
 
+//go:generate mockery --name=ImageRepositoryInterface --output=../../test/mocks --case=snake --with-expecter
 type ImageRepositoryInterface interface {
 	Create(image *models.Image) error
 	BatchCreate(images []models.Image) error
 	GetByURL(url string) (*models.Image, error)
 	GetByOfferID(offerID uint) ([]models.Image, error)
+	UpdateOrder(offerID uint, imageIDs []uint) error
+	SetCover(offerID uint, imageID uint) error
+	UpdateCaption(imageID uint, caption *string) error
+	GetSimilar(hash uint64, excludedUserID uint) ([]models.Image, error)
 	Delete(id uint) error
 	DeleteByOfferID(offerID uint) error
+	GetExistingOfferIDs(offerIDs []uint) ([]uint, error)
 }
 
 type ImageRepository struct {
@@ -38,7 +46,54 @@ func (r *ImageRepository) GetByURL(url string) (*models.Image, error) {
 
 func (r *ImageRepository) GetByOfferID(offerID uint) ([]models.Image, error) {
 	var images []models.Image
-	err := r.DB.Where("offer_id = ?", offerID).Find(&images).Error
+	err := r.DB.Where("offer_id = ?", offerID).Order("position, id").Find(&images).Error
+	return images, err
+}
+
+// UpdateOrder sets the position of every image to its index in imageIDs.
+func (r *ImageRepository) UpdateOrder(offerID uint, imageIDs []uint) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		for position, id := range imageIDs {
+			err := tx.Model(&models.Image{}).Where("id = ? AND offer_id = ?", id, offerID).Update("position", position).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SetCover moves the cover flag of the offer to the image, an offer has at most one cover.
+func (r *ImageRepository) SetCover(offerID uint, imageID uint) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Image{}).Where("offer_id = ? AND is_cover", offerID).Update("is_cover", false).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&models.Image{}).Where("id = ? AND offer_id = ?", imageID, offerID).Update("is_cover", true).Error
+	})
+}
+
+func (r *ImageRepository) UpdateCaption(imageID uint, caption *string) error {
+	return r.DB.Model(&models.Image{}).Where("id = ?", imageID).Update("caption", caption).Error
+}
+
+// GetSimilar finds the images of other users' offers sharing a hash band with the hash, together with their offers.
+// These are only candidates, the hashes still have to be compared bit by bit.
+func (r *ImageRepository) GetSimilar(hash uint64, excludedUserID uint) ([]models.Image, error) {
+	bands := HashBands(hash)
+	conditions := make([]string, len(bands))
+	args := make([]any, len(bands))
+	for i, band := range bands {
+		conditions[i] = hashBandColumn(i) + " = ?"
+		args[i] = band
+	}
+	var images []models.Image
+	err := r.DB.Joins("Offer").
+		Where(`"Offer".user_id <> ?`, excludedUserID).
+		Where("("+strings.Join(conditions, " OR ")+")", args...).
+		Order("images.id").
+		Find(&images).Error
 	return images, err
 }
 
@@ -51,3 +106,10 @@ func (r *ImageRepository) Delete(id uint) error {
 func (r *ImageRepository) DeleteByOfferID(offerID uint) error {
 	return r.DB.Where("offer_id = ?", offerID).Delete(&models.Image{}).Error
 }
+
+// GetExistingOfferIDs returns those of the given offer IDs which still belong to a sale offer.
+func (r *ImageRepository) GetExistingOfferIDs(offerIDs []uint) ([]uint, error) {
+	var existing []uint
+	err := r.DB.Model(&models.SaleOffer{}).Where("id IN ?", offerIDs).Pluck("id", &existing).Error
+	return existing, err
+}
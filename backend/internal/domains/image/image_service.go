@@ -3,30 +3,41 @@ package image
 import (
 	"fmt"
 	"mime/multipart"
+	"slices"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
 	"github.com/susek555/BD2/car-dealer-api/internal/enums"
 	"github.com/susek555/BD2/car-dealer-api/internal/models"
 )
 
+const MaxCaptionLength = 200
+
+//go:generate mockery --name=OfferAccessEvaluatorInterface --output=../../test/mocks --case=snake --with-expecter
 type OfferAccessEvaluatorInterface interface {
 	CanBeModifiedByUser(offer sale_offer.SaleOfferEntityInterface, userID *uint) error
 }
 
+//go:generate mockery --name=OfferRepositoryInterface --output=../../test/mocks --case=snake --with-expecter
 type OfferRepositoryInterface interface {
 	GetByID(offerID uint) (*models.SaleOffer, error)
 	UpdateStatus(offer *models.SaleOffer, status enums.Status) error
 }
 
 type ImageServiceInterface interface {
-	Store(offerID uint, image []*multipart.FileHeader, userID uint) error
+	Store(offerID uint, image []*multipart.FileHeader, userID uint) ([]ImageDuplicatesDTO, error)
 	DeleteByURL(url string, userID uint) error
 	DeleteByOfferID(offerID uint, userID uint) error
+	Reorder(offerID uint, imageIDs []uint, userID uint) error
+	Update(offerID uint, imageID uint, in *UpdateImageDTO, userID uint) error
+	GetDuplicates(offerID uint, userID uint, role enums.Role) ([]ImageDuplicatesDTO, error)
 }
 
 type ImageService struct {
 	repo       ImageRepositoryInterface
 	bucket     ImageBucketInterface
+	processor  ImageProcessorInterface
 	offerRepo  OfferRepositoryInterface
 	accessEval OfferAccessEvaluatorInterface
 }
@@ -34,31 +45,41 @@ type ImageService struct {
 func NewImageService(
 	r ImageRepositoryInterface,
 	b ImageBucketInterface,
+	p ImageProcessorInterface,
 	offerRepo OfferRepositoryInterface,
 	accessEval OfferAccessEvaluatorInterface,
 ) ImageServiceInterface {
-	return &ImageService{repo: r, bucket: b, offerRepo: offerRepo, accessEval: accessEval}
+	return &ImageService{repo: r, bucket: b, processor: p, offerRepo: offerRepo, accessEval: accessEval}
 }
 
-func (s *ImageService) Store(offerID uint, images []*multipart.FileHeader, userID uint) error {
+// Store adds the images to the offer and returns those of them that look like photos of other sellers' offers.
+func (s *ImageService) Store(offerID uint, images []*multipart.FileHeader, userID uint) ([]ImageDuplicatesDTO, error) {
 	offer, err := s.offerRepo.GetByID(offerID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if err := s.accessEval.CanBeModifiedByUser(offer, &userID); err != nil {
-		return err
+		return nil, err
 	}
 	storedImages, err := s.repo.GetByOfferID(offerID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if s.wouldExceedImageLimit(storedImages, len(images), 10) {
-		return ErrTooManyImages
+		return nil, ErrTooManyImages
 	}
-	if err := s.saveImagesToStorageAndDB(offerID, images); err != nil {
-		return err
+	processed, err := s.processImages(images, offer.BlurPlates)
+	if err != nil {
+		return nil, err
 	}
-	return s.setOfferStatus(offer)
+	newImages, err := s.saveImagesToStorageAndDB(offerID, processed, nextPosition(storedImages), models.CoverImage(storedImages) == nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.setOfferStatus(offer); err != nil {
+		return nil, err
+	}
+	return s.findDuplicates(newImages, offer.UserID)
 }
 
 func (s *ImageService) DeleteByURL(url string, userID uint) error {
@@ -76,11 +97,18 @@ func (s *ImageService) DeleteByURL(url string, userID uint) error {
 	if err := s.repo.Delete(image.ID); err != nil {
 		return err
 	}
-	if err := s.bucket.Delete(image.PublicID); err != nil {
-		if restoreErr := s.repo.Create(image); restoreErr != nil {
-			return restoreErr
+	for _, publicID := range image.PublicIDs() {
+		if err := s.bucket.Delete(publicID); err != nil {
+			if restoreErr := s.repo.Create(image); restoreErr != nil {
+				return restoreErr
+			}
+			return err
+		}
+	}
+	if image.IsCover {
+		if err := s.promoteCover(offer.ID); err != nil {
+			return err
 		}
-		return err
 	}
 	return s.setOfferStatus(offer)
 }
@@ -113,6 +141,157 @@ func (s *ImageService) DeleteByOfferID(offerID uint, userID uint) error {
 	return s.setOfferStatus(offer)
 }
 
+// Reorder sets the gallery order, imageIDs has to list every image of the offer.
+func (s *ImageService) Reorder(offerID uint, imageIDs []uint, userID uint) error {
+	images, err := s.getModifiableImages(offerID, userID)
+	if err != nil {
+		return err
+	}
+	if !isPermutation(images, imageIDs) {
+		return ErrInvalidImageOrder
+	}
+	return s.repo.UpdateOrder(offerID, imageIDs)
+}
+
+func (s *ImageService) Update(offerID uint, imageID uint, in *UpdateImageDTO, userID uint) error {
+	images, err := s.getModifiableImages(offerID, userID)
+	if err != nil {
+		return err
+	}
+	image := findImage(images, imageID)
+	if image == nil {
+		return ErrImageNotInOffer
+	}
+	if in.IsCover != nil && !*in.IsCover && image.IsCover {
+		return ErrCoverRequired
+	}
+	if in.Caption != nil {
+		caption := strings.TrimSpace(*in.Caption)
+		if utf8.RuneCountInString(caption) > MaxCaptionLength {
+			return ErrCaptionTooLong
+		}
+		var value *string
+		if caption != "" {
+			value = &caption
+		}
+		if err := s.repo.UpdateCaption(imageID, value); err != nil {
+			return err
+		}
+	}
+	if in.IsCover != nil && *in.IsCover && !image.IsCover {
+		return s.repo.SetCover(offerID, imageID)
+	}
+	return nil
+}
+
+// GetDuplicates is the report of the offer's photos that look like photos of other sellers' offers, for the
+// seller of the offer and for the moderators.
+func (s *ImageService) GetDuplicates(offerID uint, userID uint, role enums.Role) ([]ImageDuplicatesDTO, error) {
+	offer, err := s.offerRepo.GetByID(offerID)
+	if err != nil {
+		return nil, err
+	}
+	if !offer.BelongsToUser(userID) && role != enums.MODERATOR && role != enums.ADMIN {
+		return nil, ErrOfferNotOwned
+	}
+	images, err := s.repo.GetByOfferID(offerID)
+	if err != nil {
+		return nil, err
+	}
+	return s.findDuplicates(images, offer.UserID)
+}
+
+// findDuplicates compares the images with the candidates sharing a hash band, images stored before hashing
+// was introduced have no hash and are skipped.
+func (s *ImageService) findDuplicates(images []models.Image, sellerID uint) ([]ImageDuplicatesDTO, error) {
+	duplicates := []ImageDuplicatesDTO{}
+	for _, image := range images {
+		if image.PerceptualHash == nil {
+			continue
+		}
+		hash := uint64(*image.PerceptualHash)
+		candidates, err := s.repo.GetSimilar(hash, sellerID)
+		if err != nil {
+			return nil, err
+		}
+		var matches []DuplicateMatchDTO
+		for _, candidate := range candidates {
+			if candidate.PerceptualHash == nil {
+				continue
+			}
+			distance := HammingDistance(hash, uint64(*candidate.PerceptualHash))
+			if distance <= MaxDuplicateDistance {
+				matches = append(matches, mapToDuplicateMatchDTO(&candidate, distance))
+			}
+		}
+		if len(matches) == 0 {
+			continue
+		}
+		slices.SortStableFunc(matches, func(a, b DuplicateMatchDTO) int { return a.Distance - b.Distance })
+		duplicates = append(duplicates, ImageDuplicatesDTO{ImageID: image.ID, Url: image.Url, Matches: matches})
+	}
+	return duplicates, nil
+}
+
+func mapToDuplicateMatchDTO(image *models.Image, distance int) DuplicateMatchDTO {
+	match := DuplicateMatchDTO{ImageID: image.ID, OfferID: image.OfferID, Url: image.Url, Distance: distance}
+	if image.Offer != nil {
+		match.SellerID = image.Offer.UserID
+	}
+	return match
+}
+
+func (s *ImageService) getModifiableImages(offerID uint, userID uint) ([]models.Image, error) {
+	offer, err := s.offerRepo.GetByID(offerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.accessEval.CanBeModifiedByUser(offer, &userID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByOfferID(offerID)
+}
+
+// promoteCover makes the first remaining image the cover once the cover is deleted.
+func (s *ImageService) promoteCover(offerID uint) error {
+	images, err := s.repo.GetByOfferID(offerID)
+	if err != nil || len(images) == 0 {
+		return err
+	}
+	return s.repo.SetCover(offerID, images[0].ID)
+}
+
+func isPermutation(images []models.Image, imageIDs []uint) bool {
+	if len(images) != len(imageIDs) {
+		return false
+	}
+	seen := make(map[uint]bool, len(imageIDs))
+	for _, id := range imageIDs {
+		if seen[id] || findImage(images, id) == nil {
+			return false
+		}
+		seen[id] = true
+	}
+	return true
+}
+
+func findImage(images []models.Image, imageID uint) *models.Image {
+	for i := range images {
+		if images[i].ID == imageID {
+			return &images[i]
+		}
+	}
+	return nil
+}
+
+func nextPosition(images []models.Image) uint {
+	var next uint
+	for _, image := range images {
+		next = max(next, image.Position+1)
+	}
+	return next
+}
+
 func (s *ImageService) wouldExceedImageLimit(images []models.Image, nImages int, maxImages int) bool {
 	return len(images)+nImages > maxImages
 }
@@ -121,27 +300,65 @@ func (s *ImageService) hasImages(images []models.Image) bool {
 	return len(images) > 0
 }
 
-func (s *ImageService) saveImagesToStorageAndDB(offerID uint, images []*multipart.FileHeader) error {
+// processImages validates every file before anything is uploaded, so a single bad file rejects the whole batch.
+func (s *ImageService) processImages(images []*multipart.FileHeader, blurPlates bool) ([]*ProcessedImage, error) {
+	processed := make([]*ProcessedImage, 0, len(images))
+	for _, image := range images {
+		p, err := s.processor.Process(image, blurPlates)
+		if err != nil {
+			return nil, err
+		}
+		processed = append(processed, p)
+	}
+	return processed, nil
+}
+
+// saveImagesToStorageAndDB appends the images to the gallery, the first one becomes the cover if the offer has none.
+func (s *ImageService) saveImagesToStorageAndDB(offerID uint, images []*ProcessedImage, position uint, needsCover bool) ([]models.Image, error) {
 	var (
 		uploadedPublicIDs []string
 		storedImages      []models.Image
 	)
 	folder := fmt.Sprintf("sale-offer-%d/", offerID)
 	for _, image := range images {
-		publicID, url, err := s.bucket.Upload(folder, image)
+		imageModel, err := s.uploadRenditions(folder, image)
+		if imageModel != nil {
+			uploadedPublicIDs = append(uploadedPublicIDs, imageModel.PublicIDs()...)
+		}
 		if err != nil {
 			s.partialCleanup(uploadedPublicIDs, storedImages)
-			return nil
+			return nil, err
 		}
-		uploadedPublicIDs = append(uploadedPublicIDs, publicID)
-		imageModel := &models.Image{OfferID: offerID, PublicID: publicID, Url: url}
+		hash := int64(image.Hash)
+		imageModel.OfferID = offerID
+		imageModel.Position = position + uint(len(storedImages))
+		imageModel.IsCover = needsCover && len(storedImages) == 0
+		imageModel.PerceptualHash = &hash
 		if err := s.repo.Create(imageModel); err != nil {
 			s.partialCleanup(uploadedPublicIDs, storedImages)
-			return nil
+			return nil, err
 		}
 		storedImages = append(storedImages, *imageModel)
 	}
-	return nil
+	return storedImages, nil
+}
+
+// uploadRenditions stores the original, medium and thumbnail under one name. On failure the returned image
+// still lists what was uploaded, so it can be cleaned up.
+func (s *ImageService) uploadRenditions(folder string, image *ProcessedImage) (*models.Image, error) {
+	name := newObjectName()
+	imageModel := &models.Image{}
+	var err error
+	if imageModel.PublicID, imageModel.Url, err = s.bucket.Upload(folder, name+image.Extension, image.Original); err != nil {
+		return nil, err
+	}
+	if imageModel.MediumPublicID, imageModel.MediumUrl, err = s.bucket.Upload(folder, name+"_medium"+image.Extension, image.Medium); err != nil {
+		return imageModel, err
+	}
+	if imageModel.ThumbnailPublicID, imageModel.ThumbnailUrl, err = s.bucket.Upload(folder, name+"_thumbnail"+image.Extension, image.Thumbnail); err != nil {
+		return imageModel, err
+	}
+	return imageModel, nil
 }
 
 func (s *ImageService) setOfferStatus(offer *models.SaleOffer) error {
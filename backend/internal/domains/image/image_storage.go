@@ -0,0 +1,65 @@
+package image
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	CloudinaryStorage = "cloudinary"
+	LocalStorage      = "local"
+	S3Storage         = "s3"
+
+	// LocalImageRoute is the path under which the local bucket's files are served.
+	LocalImageRoute = "/images"
+)
+
+// ParseImageStorage reads which bucket stores the images, CloudinaryStorage if empty.
+func ParseImageStorage(value string) (string, error) {
+	storage := strings.ToLower(strings.TrimSpace(value))
+	switch storage {
+	case "":
+		return CloudinaryStorage, nil
+	case CloudinaryStorage, LocalStorage, S3Storage:
+		return storage, nil
+	default:
+		return "", ErrInvalidImageStorage
+	}
+}
+
+// cleanObjectPath turns a folder or public id into a slash separated path inside the bucket, so "sale-offer-1/"
+// and "sale-offer-1" name the same folder. Paths that would leave the bucket or name the bucket itself are rejected.
+func cleanObjectPath(value string) (string, error) {
+	trimmed := strings.Trim(strings.TrimSpace(value), "/")
+	if !filepath.IsLocal(filepath.FromSlash(trimmed)) {
+		return "", ErrInvalidObjectPath
+	}
+	cleaned := path.Clean(trimmed)
+	if cleaned == "." {
+		return "", ErrInvalidObjectPath
+	}
+	return cleaned, nil
+}
+
+// objectPath joins the folder and the name of an uploaded file, the name can't point to another folder.
+func objectPath(folder, name string) (string, error) {
+	folder, err := cleanObjectPath(folder)
+	if err != nil {
+		return "", err
+	}
+	if name == "" || name != path.Base(name) || name == "." || name == ".." {
+		return "", ErrInvalidObjectPath
+	}
+	return folder + "/" + name, nil
+}
+
+// newObjectName picks a random name for an uploaded photo, its renditions are stored under the same name
+// with a suffix.
+func newObjectName() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
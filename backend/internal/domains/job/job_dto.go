@@ -0,0 +1,11 @@
+package job
+
+type JobStatusDTO struct {
+	Name      string  `json:"name"`
+	Kind      string  `json:"kind"`
+	Schedule  string  `json:"schedule,omitempty"`
+	NextRunAt *string `json:"next_run_at,omitempty"`
+	LastRunAt *string `json:"last_run_at,omitempty"`
+	LastError *string `json:"last_error,omitempty"`
+	Attempts  uint    `json:"attempts"`
+}
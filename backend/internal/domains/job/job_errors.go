@@ -0,0 +1,10 @@
+package job
+
+import "errors"
+
+var (
+	ErrInvalidSchedule = errors.New("invalid job schedule")
+	ErrDuplicateJob    = errors.New("job with this name is already registered")
+)
+
+var ErrorMap = map[error]int{}
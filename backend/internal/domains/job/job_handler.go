@@ -0,0 +1,37 @@
+package job
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/susek555/BD2/car-dealer-api/pkg/custom_errors"
+)
+
+type Handler struct {
+	retriever JobStatusRetrieverInterface
+}
+
+func NewHandler(retriever JobStatusRetrieverInterface) *Handler {
+	return &Handler{retriever: retriever}
+}
+
+// GetJobs godoc
+//
+//	@Summary		List background jobs
+//	@Description	Returns all registered background jobs with their schedule, next and last run, the last error and the number of failed attempts in a row. Long running workers (e.g. the auction scheduler) are listed as well. You have to be an admin to perform this operation.
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{array}		JobStatusDTO			"List of jobs"
+//	@Failure		401	{object}	custom_errors.HTTPError	"Unauthorized - user not logged in"
+//	@Failure		403	{object}	custom_errors.HTTPError	"Forbidden - user is not an admin"
+//	@Failure		500	{object}	custom_errors.HTTPError	"Internal server error"
+//	@Router			/admin/jobs [get]
+//	@Security		Bearer
+func (h *Handler) GetJobs(c *gin.Context) {
+	jobs, err := h.retriever.GetStatuses()
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	c.JSON(http.StatusOK, jobs)
+}
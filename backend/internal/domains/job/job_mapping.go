@@ -0,0 +1,31 @@
+package job
+
+import (
+	"time"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+)
+
+func MapToJobStatusDTO(job *Job, state *models.Job) *JobStatusDTO {
+	dto := &JobStatusDTO{
+		Name:     job.Name,
+		Kind:     KindScheduled,
+		Schedule: job.Schedule.String(),
+	}
+	if state == nil {
+		return dto
+	}
+	dto.NextRunAt = formatTime(state.NextRunAt)
+	dto.LastRunAt = formatTime(state.LastRunAt)
+	dto.LastError = state.LastError
+	dto.Attempts = state.Attempts
+	return dto
+}
+
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format(time.RFC3339)
+	return &formatted
+}
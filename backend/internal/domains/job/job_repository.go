@@ -0,0 +1,30 @@
+package job
+
+import (
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"gorm.io/gorm"
+)
+
+//go:generate mockery --name=JobRepositoryInterface --output=../../test/mocks --case=snake --with-expecter
+type JobRepositoryInterface interface {
+	GetAll() ([]models.Job, error)
+	Save(job *models.Job) error
+}
+
+type JobRepository struct {
+	DB *gorm.DB
+}
+
+func NewJobRepository(db *gorm.DB) JobRepositoryInterface {
+	return &JobRepository{DB: db}
+}
+
+func (r *JobRepository) GetAll() ([]models.Job, error) {
+	var jobs []models.Job
+	err := r.DB.Order("name").Find(&jobs).Error
+	return jobs, err
+}
+
+func (r *JobRepository) Save(job *models.Job) error {
+	return r.DB.Save(job).Error
+}
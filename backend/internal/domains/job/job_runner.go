@@ -0,0 +1,262 @@
+package job
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+)
+
+const (
+	LeaderLeaseKey     = "jobs:leader"
+	TickInterval       = 5 * time.Second
+	MaxAttempts        = 5
+	baseBackoff        = 30 * time.Second
+	maxBackoff         = time.Hour
+	workerRestartDelay = 5 * time.Second
+)
+
+const (
+	KindScheduled = "scheduled"
+	KindWorker    = "worker"
+)
+
+type LeaderElectorInterface interface {
+	Campaign(ctx context.Context, lead func(ctx context.Context))
+}
+
+// Job is a named piece of periodic (or one-shot) work. Jobs are run one after another by the leader,
+// so they should finish quickly - long running loops belong in a Worker.
+type Job struct {
+	Name     string
+	Schedule Schedule
+	Run      func(ctx context.Context) error
+}
+
+// Worker is a long running loop (e.g. the auction scheduler) started whenever this instance becomes
+// the leader. Its context is cancelled when the leadership is lost.
+type Worker struct {
+	Name string
+	Run  func(ctx context.Context)
+}
+
+//go:generate mockery --name=JobStatusRetrieverInterface --output=../../test/mocks --case=snake --with-expecter
+type JobStatusRetrieverInterface interface {
+	GetStatuses() ([]JobStatusDTO, error)
+}
+
+// Runner runs the registered jobs and workers on a single, elected instance. The next run of every job
+// is kept in the database, so schedules survive restarts and changes of the leader.
+type Runner struct {
+	repo     JobRepositoryInterface
+	elector  LeaderElectorInterface
+	interval time.Duration
+	mu       sync.Mutex
+	jobs     []Job
+	workers  []Worker
+}
+
+func NewRunner(repo JobRepositoryInterface, elector LeaderElectorInterface, interval time.Duration) *Runner {
+	return &Runner{
+		repo:     repo,
+		elector:  elector,
+		interval: interval,
+	}
+}
+
+func (r *Runner) Register(job Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.isRegistered(job.Name) {
+		return ErrDuplicateJob
+	}
+	r.jobs = append(r.jobs, job)
+	return nil
+}
+
+func (r *Runner) RegisterWorker(worker Worker) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.isRegistered(worker.Name) {
+		return ErrDuplicateJob
+	}
+	r.workers = append(r.workers, worker)
+	return nil
+}
+
+// Run blocks until ctx is done. Without an elector the runner leads straight away.
+func (r *Runner) Run(ctx context.Context) {
+	if r.elector == nil {
+		r.lead(ctx)
+		return
+	}
+	r.elector.Campaign(ctx, r.lead)
+}
+
+func (r *Runner) lead(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, worker := range r.registeredWorkers() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.keepWorkerRunning(ctx, worker)
+		}()
+	}
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		if err := r.RunDue(ctx, time.Now()); err != nil {
+			log.Printf("jobs: cannot load job states: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// keepWorkerRunning restarts the worker if it stops while this instance still leads.
+func (r *Runner) keepWorkerRunning(ctx context.Context, worker Worker) {
+	for {
+		log.Printf("jobs: starting worker %s", worker.Name)
+		worker.Run(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(workerRestartDelay):
+			log.Printf("jobs: worker %s stopped unexpectedly, restarting", worker.Name)
+		}
+	}
+}
+
+// RunDue runs every job whose next run is not after now. A job that fails is retried with exponential
+// backoff, after MaxAttempts failures it waits for its next regular run.
+func (r *Runner) RunDue(ctx context.Context, now time.Time) error {
+	states, err := r.loadStates()
+	if err != nil {
+		return err
+	}
+	for _, job := range r.registeredJobs() {
+		state, ok := states[job.Name]
+		if !ok {
+			state = &models.Job{Name: job.Name, NextRunAt: nextRun(job.Schedule, now)}
+			if err := r.repo.Save(state); err != nil {
+				log.Printf("jobs: cannot schedule job %s: %v", job.Name, err)
+				continue
+			}
+		}
+		if state.NextRunAt == nil || state.NextRunAt.After(now) {
+			continue
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		r.runJob(ctx, job, state, now)
+	}
+	return nil
+}
+
+func (r *Runner) runJob(ctx context.Context, job Job, state *models.Job, now time.Time) {
+	err := job.Run(ctx)
+	finishedAt := time.Now()
+	state.LastRunAt = &finishedAt
+	if err == nil {
+		state.Attempts = 0
+		state.LastError = nil
+		state.NextRunAt = nextRun(job.Schedule, now)
+	} else {
+		message := err.Error()
+		state.LastError = &message
+		state.Attempts++
+		log.Printf("jobs: job %s failed on attempt %d: %v", job.Name, state.Attempts, err)
+		if state.Attempts < MaxAttempts {
+			retryAt := finishedAt.Add(Backoff(state.Attempts - 1))
+			state.NextRunAt = &retryAt
+		} else {
+			state.Attempts = 0
+			state.NextRunAt = nextRun(job.Schedule, now)
+		}
+	}
+	if err := r.repo.Save(state); err != nil {
+		log.Printf("jobs: cannot save state of job %s: %v", job.Name, err)
+	}
+}
+
+// GetStatuses lists the registered jobs with their persisted state, followed by the workers.
+func (r *Runner) GetStatuses() ([]JobStatusDTO, error) {
+	states, err := r.loadStates()
+	if err != nil {
+		return nil, err
+	}
+	jobs, workers := r.registeredJobs(), r.registeredWorkers()
+	statuses := make([]JobStatusDTO, 0, len(jobs)+len(workers))
+	for _, job := range jobs {
+		statuses = append(statuses, *MapToJobStatusDTO(&job, states[job.Name]))
+	}
+	for _, worker := range workers {
+		statuses = append(statuses, JobStatusDTO{Name: worker.Name, Kind: KindWorker})
+	}
+	return statuses, nil
+}
+
+func (r *Runner) loadStates() (map[string]*models.Job, error) {
+	stored, err := r.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	states := make(map[string]*models.Job, len(stored))
+	for i := range stored {
+		states[stored[i].Name] = &stored[i]
+	}
+	return states, nil
+}
+
+func (r *Runner) registeredJobs() []Job {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Job(nil), r.jobs...)
+}
+
+func (r *Runner) registeredWorkers() []Worker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Worker(nil), r.workers...)
+}
+
+func (r *Runner) isRegistered(name string) bool {
+	for _, job := range r.jobs {
+		if job.Name == name {
+			return true
+		}
+	}
+	for _, worker := range r.workers {
+		if worker.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func nextRun(schedule Schedule, after time.Time) *time.Time {
+	next, ok := schedule.Next(after)
+	if !ok {
+		return nil
+	}
+	return &next
+}
+
+// Backoff returns the delay before retrying a job which failed the given number of times before.
+func Backoff(previousAttempts uint) time.Duration {
+	delay := baseBackoff
+	for range previousAttempts {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return delay
+}
@@ -0,0 +1,73 @@
+package job
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Schedule tells when a job should run next. ok is false when the job should not run anymore.
+type Schedule interface {
+	Next(after time.Time) (next time.Time, ok bool)
+	String() string
+}
+
+type cronSchedule struct {
+	spec     string
+	schedule cron.Schedule
+}
+
+// Cron parses a standard 5-field cron expression or a descriptor such as "@hourly" or "@every 10m".
+func Cron(spec string) (Schedule, error) {
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+	}
+	return &cronSchedule{spec: spec, schedule: schedule}, nil
+}
+
+func (s *cronSchedule) Next(after time.Time) (time.Time, bool) {
+	return s.schedule.Next(after), true
+}
+
+func (s *cronSchedule) String() string {
+	return s.spec
+}
+
+type intervalSchedule struct {
+	interval time.Duration
+}
+
+func Every(interval time.Duration) Schedule {
+	return &intervalSchedule{interval: interval}
+}
+
+func (s *intervalSchedule) Next(after time.Time) (time.Time, bool) {
+	return after.Add(s.interval), true
+}
+
+func (s *intervalSchedule) String() string {
+	return "@every " + s.interval.String()
+}
+
+type onceSchedule struct {
+	at time.Time
+}
+
+// Once runs the job a single time at the given moment. Like with the other schedules, the next run
+// is always after the given time, so a moment which passed before the job got scheduled is skipped.
+func Once(at time.Time) Schedule {
+	return &onceSchedule{at: at}
+}
+
+func (s *onceSchedule) Next(after time.Time) (time.Time, bool) {
+	if !s.at.After(after) {
+		return time.Time{}, false
+	}
+	return s.at, true
+}
+
+func (s *onceSchedule) String() string {
+	return "once at " + s.at.Format(time.RFC3339)
+}
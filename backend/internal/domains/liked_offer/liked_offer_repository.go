@@ -5,11 +5,13 @@ import (
 	"gorm.io/gorm"
 )
 
+//go:generate mockery --name=LikedOfferRepositoryInterface --output=../../test/mocks --case=snake --with-expecter
 type LikedOfferRepositoryInterface interface {
 	Create(offer *models.LikedOffer) error
 	Delete(offerID, userID uint) error
 	GetByUserID(id uint) ([]models.LikedOffer, error)
 	IsOfferLikedByUser(userID uint, offerID uint) error
+	GetUserIDsWithPriceDropAlertsOff(offerID uint) ([]uint, error)
 }
 
 type LikedOfferRepository struct {
@@ -40,3 +42,12 @@ func (r *LikedOfferRepository) IsOfferLikedByUser(offerID, userID uint) error {
 	err := r.DB.Where("offer_id = ? AND user_id = ?", offerID, userID).First(&likedOffer).Error
 	return err
 }
+
+func (r *LikedOfferRepository) GetUserIDsWithPriceDropAlertsOff(offerID uint) ([]uint, error) {
+	var userIDs []uint
+	err := r.DB.Model(&models.LikedOffer{}).
+		Joins("JOIN users ON users.id = liked_offers.user_id").
+		Where("liked_offers.offer_id = ? AND users.price_drop_alerts IS FALSE", offerID).
+		Pluck("liked_offers.user_id", &userIDs).Error
+	return userIDs, err
+}
@@ -0,0 +1,44 @@
+package liked_offer
+
+import (
+	"strconv"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/notification"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/ws"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+)
+
+// PriceDropNotifier lets users who liked an offer know that its price was lowered.
+type PriceDropNotifier struct {
+	likedOfferRepo      LikedOfferRepositoryInterface
+	notificationService notification.NotificationServiceInterface
+	hub                 ws.HubInterface
+}
+
+func NewPriceDropNotifier(likedOfferRepo LikedOfferRepositoryInterface, notificationService notification.NotificationServiceInterface, hub ws.HubInterface) *PriceDropNotifier {
+	return &PriceDropNotifier{
+		likedOfferRepo:      likedOfferRepo,
+		notificationService: notificationService,
+		hub:                 hub,
+	}
+}
+
+// NotifyPriceDrop sends the notification to everyone following the offer, except the seller
+// and the users who turned price drop alerts off.
+func (n *PriceDropNotifier) NotifyPriceDrop(offer notification.SaleOfferInterface, sellerID uint, oldPrice uint) error {
+	optedOut, err := n.likedOfferRepo.GetUserIDsWithPriceDropAlertsOff(offer.GetID())
+	if err != nil {
+		return err
+	}
+	offerID := offer.GetID()
+	notif := models.Notification{OfferID: &offerID}
+	if err := n.notificationService.CreatePriceDropNotification(&notif, oldPrice, offer); err != nil {
+		return err
+	}
+	channel := strconv.FormatUint(uint64(offerID), 10)
+	if err := n.hub.SaveNotificationForClientsExcept(channel, append(optedOut, sellerID), &notif); err != nil {
+		return err
+	}
+	n.hub.SendFourLatestNotificationsToClients(channel, strconv.FormatUint(uint64(sellerID), 10))
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/susek555/BD2/car-dealer-api/internal/models"
 	"github.com/susek555/BD2/car-dealer-api/pkg/pagination"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ClientNotificationRepositoryInterface interface {
@@ -29,9 +30,10 @@ func NewClientNotificationRepository(db *gorm.DB) ClientNotificationRepositoryIn
 	}
 }
 
+// Create gives the notification to the user, giving the same notification to the same user again does nothing.
 func (r *ClientNotificationRepository) Create(clientNotification *models.ClientNotification) error {
 	db := r.DB
-	if err := db.Create(clientNotification).Error; err != nil {
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(clientNotification).Error; err != nil {
 		return err
 	}
 	return nil
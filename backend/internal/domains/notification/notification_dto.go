@@ -4,7 +4,7 @@ import "github.com/susek555/BD2/car-dealer-api/pkg/pagination"
 
 type RetrieveNotificationDTO struct {
 	ID          uint   `json:"id"`
-	OfferID     uint   `json:"offer_id"`
+	OfferID     *uint  `json:"offer_id"`
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	CreatedAt   string `json:"created_at"`
@@ -8,3 +8,25 @@ var BuyOfferTitleTemplate = "The offer for %s %s has been bought"
 var BuyOfferDescriptionTemplate = "The offer has been bought by %s for %v"
 var BuyNowTitleTemplate = "The auction for %s %s has been bought"
 var BuyNowDescriptionTemplate = "The auction has been bought by %s for %v"
+var ProxyBidExhaustedTitleTemplate = "Your maximum bid on %s %s has been exceeded"
+var ProxyBidExhaustedDescriptionTemplate = "Your maximum bid of %v is no longer the highest. New price: %v"
+var ReserveNotMetTitleTemplate = "Reserve price not met for %s %s"
+var ReserveNotMetDescriptionTemplate = "The auction for %s %s has ended without a sale. Highest bid: %v"
+var SavedSearchMatchTitleTemplate = "New offer matching your search \"%s\""
+var SavedSearchMatchDescriptionTemplate = "%s %s is now available for %v"
+var PriceDropTitleTemplate = "Price dropped for %s %s"
+var PriceDropDescriptionTemplate = "The price dropped from %v to %v"
+var ListingExpiryReminderTitleTemplate = "Your listing of %s %s expires soon"
+var ListingExpiryReminderDescriptionTemplate = "Your listing expires in %d days"
+var ListingExpiredTitleTemplate = "Your listing of %s %s has expired"
+var ListingExpiredDescriptionTemplate = "The offer is no longer visible to buyers - renew it to publish it again"
+var ReportResolvedTitleTemplate = "Your report about %s has been reviewed"
+var ReportActionTakenDescription = "A moderator has taken action on the reported content - thank you for letting us know"
+var ReportDismissedDescription = "A moderator found that the reported content does not break the rules"
+var ReviewReplyTitleTemplate = "%s replied to your review"
+var ReviewReplyDescriptionTemplate = "\"%s\""
+var DuplicateImagesTitleTemplate = "Photos of your %s look like photos from other offers"
+var DuplicateImagesDescriptionTemplate = "%d of the uploaded photos closely match photos of other sellers - the offer has been passed to the moderators for a review"
+
+// the longest part of a reply quoted in the notification, the description is limited to 200 characters
+const maxQuotedReplyLength = 150
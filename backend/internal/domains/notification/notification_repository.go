@@ -21,8 +21,13 @@ func NewNotificationRepository(db *gorm.DB) NotificationRepositoryInterface {
 	}
 }
 
+// Create saves the notification. A notification sent for an outbox message is created only once -
+// when the message is delivered again, the notification created the first time is loaded instead.
 func (r *NotificationRepository) Create(notification *models.Notification) error {
 	db := r.DB
+	if notification.OutboxMessageID != nil {
+		return db.Where("outbox_message_id = ?", *notification.OutboxMessageID).FirstOrCreate(notification).Error
+	}
 	if err := db.Create(notification).Error; err != nil {
 		return err
 	}
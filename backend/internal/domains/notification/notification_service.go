@@ -15,6 +15,15 @@ type NotificationServiceInterface interface {
 	CreateEndAuctionNotification(notification *models.Notification, winner string, winningBid uint, offer SaleOfferInterface) error
 	CreateBuyNotification(notification *models.Notification, buyerID string, offer SaleOfferInterface) error
 	CreateBuyNowNotification(notification *models.Notification, buyerID string, offer SaleOfferInterface) error
+	CreateProxyBidExhaustedNotification(notification *models.Notification, maxAmount uint, amount uint, offer SaleOfferInterface) error
+	CreateReserveNotMetNotification(notification *models.Notification, highestBid uint, offer SaleOfferInterface) error
+	CreateSavedSearchMatchNotification(notification *models.Notification, searchName string, offer SaleOfferInterface) error
+	CreatePriceDropNotification(notification *models.Notification, oldPrice uint, offer SaleOfferInterface) error
+	CreateListingExpiryReminderNotification(notification *models.Notification, daysLeft uint, offer SaleOfferInterface) error
+	CreateListingExpiredNotification(notification *models.Notification, offer SaleOfferInterface) error
+	CreateReportResolvedNotification(notification *models.Notification, target string, actionTaken bool) error
+	CreateReviewReplyNotification(notification *models.Notification, replier string, reply string) error
+	CreateDuplicateImagesNotification(notification *models.Notification, offerName string, count int) error
 	GetNotificationByID(id uint) (*models.Notification, error)
 	GetFilteredNotifications(filter *NotificationFilter) (*RetrieveNotificationsWithPagination, error)
 	UpdateSeenStatus(notificationID, userID uint, seen bool) error
@@ -66,6 +75,75 @@ func (s *NotificationService) CreateBuyNowNotification(notification *models.Noti
 	return s.NotificationRepository.Create(notification)
 }
 
+func (s *NotificationService) CreateProxyBidExhaustedNotification(notification *models.Notification, maxAmount uint, amount uint, offer SaleOfferInterface) error {
+	notification.CreatedAt = time.Now().UTC()
+	notification.Title = fmt.Sprintf(ProxyBidExhaustedTitleTemplate, offer.GetBrand(), offer.GetModel())
+	notification.Description = fmt.Sprintf(ProxyBidExhaustedDescriptionTemplate, maxAmount, amount)
+	return s.NotificationRepository.Create(notification)
+}
+
+func (s *NotificationService) CreateReserveNotMetNotification(notification *models.Notification, highestBid uint, offer SaleOfferInterface) error {
+	notification.CreatedAt = time.Now().UTC()
+	notification.Title = fmt.Sprintf(ReserveNotMetTitleTemplate, offer.GetBrand(), offer.GetModel())
+	notification.Description = fmt.Sprintf(ReserveNotMetDescriptionTemplate, offer.GetBrand(), offer.GetModel(), highestBid)
+	return s.NotificationRepository.Create(notification)
+}
+
+func (s *NotificationService) CreateSavedSearchMatchNotification(notification *models.Notification, searchName string, offer SaleOfferInterface) error {
+	notification.CreatedAt = time.Now().UTC()
+	notification.Title = fmt.Sprintf(SavedSearchMatchTitleTemplate, searchName)
+	notification.Description = fmt.Sprintf(SavedSearchMatchDescriptionTemplate, offer.GetBrand(), offer.GetModel(), offer.GetPrice())
+	return s.NotificationRepository.Create(notification)
+}
+
+func (s *NotificationService) CreatePriceDropNotification(notification *models.Notification, oldPrice uint, offer SaleOfferInterface) error {
+	notification.CreatedAt = time.Now().UTC()
+	notification.Title = fmt.Sprintf(PriceDropTitleTemplate, offer.GetBrand(), offer.GetModel())
+	notification.Description = fmt.Sprintf(PriceDropDescriptionTemplate, oldPrice, offer.GetPrice())
+	return s.NotificationRepository.Create(notification)
+}
+
+func (s *NotificationService) CreateListingExpiryReminderNotification(notification *models.Notification, daysLeft uint, offer SaleOfferInterface) error {
+	notification.CreatedAt = time.Now().UTC()
+	notification.Title = fmt.Sprintf(ListingExpiryReminderTitleTemplate, offer.GetBrand(), offer.GetModel())
+	notification.Description = fmt.Sprintf(ListingExpiryReminderDescriptionTemplate, daysLeft)
+	return s.NotificationRepository.Create(notification)
+}
+
+func (s *NotificationService) CreateListingExpiredNotification(notification *models.Notification, offer SaleOfferInterface) error {
+	notification.CreatedAt = time.Now().UTC()
+	notification.Title = fmt.Sprintf(ListingExpiredTitleTemplate, offer.GetBrand(), offer.GetModel())
+	notification.Description = ListingExpiredDescriptionTemplate
+	return s.NotificationRepository.Create(notification)
+}
+
+func (s *NotificationService) CreateReportResolvedNotification(notification *models.Notification, target string, actionTaken bool) error {
+	notification.CreatedAt = time.Now().UTC()
+	notification.Title = fmt.Sprintf(ReportResolvedTitleTemplate, target)
+	notification.Description = ReportDismissedDescription
+	if actionTaken {
+		notification.Description = ReportActionTakenDescription
+	}
+	return s.NotificationRepository.Create(notification)
+}
+
+func (s *NotificationService) CreateReviewReplyNotification(notification *models.Notification, replier string, reply string) error {
+	notification.CreatedAt = time.Now().UTC()
+	notification.Title = fmt.Sprintf(ReviewReplyTitleTemplate, replier)
+	if quoted := []rune(reply); len(quoted) > maxQuotedReplyLength {
+		reply = string(quoted[:maxQuotedReplyLength]) + "..."
+	}
+	notification.Description = fmt.Sprintf(ReviewReplyDescriptionTemplate, reply)
+	return s.NotificationRepository.Create(notification)
+}
+
+func (s *NotificationService) CreateDuplicateImagesNotification(notification *models.Notification, offerName string, count int) error {
+	notification.CreatedAt = time.Now().UTC()
+	notification.Title = fmt.Sprintf(DuplicateImagesTitleTemplate, offerName)
+	notification.Description = fmt.Sprintf(DuplicateImagesDescriptionTemplate, count)
+	return s.NotificationRepository.Create(notification)
+}
+
 func (s *NotificationService) GetNotificationByID(id uint) (*models.Notification, error) {
 	notification, err := s.NotificationRepository.GetByID(id)
 	if err != nil {
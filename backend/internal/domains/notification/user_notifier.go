@@ -0,0 +1,39 @@
+package notification
+
+import (
+	"strconv"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+)
+
+// LatestNotificationsSenderInterface pushes the latest notifications of a user to their open connections,
+// ws.HubInterface implements it.
+type LatestNotificationsSenderInterface interface {
+	SendFourLatestNotificationsToUser(userID string)
+}
+
+// UserNotifier delivers notifications meant for a single user.
+type UserNotifier struct {
+	service NotificationServiceInterface
+	sender  LatestNotificationsSenderInterface
+}
+
+func NewUserNotifier(service NotificationServiceInterface, sender LatestNotificationsSenderInterface) *UserNotifier {
+	return &UserNotifier{
+		service: service,
+		sender:  sender,
+	}
+}
+
+// Notify fills in the notification with create, saves it for the user and pushes it to their open connections.
+// A notification linked to an outbox message is created only once, however many times the message is delivered.
+func (n *UserNotifier) Notify(notification *models.Notification, userID uint, create func(notification *models.Notification) error) error {
+	if err := create(notification); err != nil {
+		return err
+	}
+	if err := n.service.SaveNotificationToClient(notification, userID); err != nil {
+		return err
+	}
+	n.sender.SendFourLatestNotificationsToUser(strconv.FormatUint(uint64(userID), 10))
+	return nil
+}
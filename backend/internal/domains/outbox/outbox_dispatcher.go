@@ -0,0 +1,100 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	DispatchInterval  = 2 * time.Second
+	DispatchBatchSize = 100
+	MaxAttempts       = 10
+	baseBackoff       = 5 * time.Second
+	maxBackoff        = 30 * time.Minute
+)
+
+// Handler delivers a single message. Messages are delivered at least once - a handler may be called
+// again for a message it has already handled if marking it as delivered fails, so it should use
+// the message ID to recognize a repeated delivery.
+type Handler func(messageID uint, payload string) error
+
+type Dispatcher struct {
+	repo        OutboxRepositoryInterface
+	handlers    map[string]Handler
+	interval    time.Duration
+	batchSize   int
+	maxAttempts uint
+}
+
+func NewDispatcher(repo OutboxRepositoryInterface, interval time.Duration) *Dispatcher {
+	return &Dispatcher{
+		repo:        repo,
+		handlers:    make(map[string]Handler),
+		interval:    interval,
+		batchSize:   DispatchBatchSize,
+		maxAttempts: MaxAttempts,
+	}
+}
+
+func (d *Dispatcher) Register(kind string, handler Handler) {
+	d.handlers[kind] = handler
+}
+
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		if err := d.DispatchPending(); err != nil {
+			log.Printf("outbox: cannot load pending messages: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchPending delivers the messages that are due. A failed message is retried later with exponential
+// backoff until it runs out of attempts - then it stays in the outbox with its last error for inspection.
+func (d *Dispatcher) DispatchPending() error {
+	messages, err := d.repo.GetPending(time.Now(), d.maxAttempts, d.batchSize)
+	if err != nil {
+		return err
+	}
+	for _, message := range messages {
+		if err := d.deliver(message.ID, message.Kind, message.Payload); err != nil {
+			log.Printf("outbox: delivering message %d (%s) failed on attempt %d: %v", message.ID, message.Kind, message.Attempts+1, err)
+			if err := d.repo.MarkFailed(message.ID, err.Error(), time.Now().Add(Backoff(message.Attempts))); err != nil {
+				log.Printf("outbox: cannot mark message %d as failed: %v", message.ID, err)
+			}
+			continue
+		}
+		if err := d.repo.MarkDelivered(message.ID); err != nil {
+			log.Printf("outbox: cannot mark message %d as delivered: %v", message.ID, err)
+		}
+	}
+	return nil
+}
+
+func (d *Dispatcher) deliver(messageID uint, kind string, payload string) error {
+	handler, ok := d.handlers[kind]
+	if !ok {
+		return fmt.Errorf("no handler registered for %q", kind)
+	}
+	return handler(messageID, payload)
+}
+
+// Backoff returns the delay before the next delivery of a message which failed the given number of times before.
+func Backoff(previousAttempts uint) time.Duration {
+	delay := baseBackoff
+	for range previousAttempts {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return delay
+}
@@ -0,0 +1,21 @@
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+)
+
+// NewMessage prepares a message to be saved within the transaction of the change it announces.
+func NewMessage(kind string, payload any) (*models.OutboxMessage, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &models.OutboxMessage{
+		Kind:          kind,
+		Payload:       string(data),
+		NextAttemptAt: time.Now(),
+	}, nil
+}
@@ -0,0 +1,45 @@
+package outbox
+
+import (
+	"time"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"gorm.io/gorm"
+)
+
+//go:generate mockery --name=OutboxRepositoryInterface --output=../../test/mocks --case=snake --with-expecter
+type OutboxRepositoryInterface interface {
+	GetPending(now time.Time, maxAttempts uint, limit int) ([]models.OutboxMessage, error)
+	MarkDelivered(id uint) error
+	MarkFailed(id uint, lastError string, nextAttemptAt time.Time) error
+}
+
+type OutboxRepository struct {
+	DB *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepositoryInterface {
+	return &OutboxRepository{DB: db}
+}
+
+func (r *OutboxRepository) GetPending(now time.Time, maxAttempts uint, limit int) ([]models.OutboxMessage, error) {
+	var messages []models.OutboxMessage
+	err := r.DB.
+		Where("delivered_at IS NULL AND next_attempt_at <= ? AND attempts < ?", now, maxAttempts).
+		Order("id").
+		Limit(limit).
+		Find(&messages).Error
+	return messages, err
+}
+
+func (r *OutboxRepository) MarkDelivered(id uint) error {
+	return r.DB.Model(&models.OutboxMessage{}).Where("id = ?", id).Update("delivered_at", time.Now()).Error
+}
+
+func (r *OutboxRepository) MarkFailed(id uint, lastError string, nextAttemptAt time.Time) error {
+	return r.DB.Model(&models.OutboxMessage{}).Where("id = ?", id).Updates(map[string]any{
+		"attempts":        gorm.Expr("attempts + 1"),
+		"last_error":      lastError,
+		"next_attempt_at": nextAttemptAt,
+	}).Error
+}
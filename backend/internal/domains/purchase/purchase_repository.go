@@ -24,6 +24,6 @@ func (r *PurchaseRepository) Create(purchase *models.Purchase) error {
 
 func (r *PurchaseRepository) GetByID(id uint) (*models.Purchase, error) {
 	var purchase models.Purchase
-	err := r.DB.First(&purchase, id).Error
+	err := r.DB.Preload("Offer").First(&purchase, id).Error
 	return &purchase, err
 }
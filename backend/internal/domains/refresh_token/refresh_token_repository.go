@@ -1,6 +1,8 @@
 package refresh_token
 
 import (
+	"time"
+
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/generic"
 	"github.com/susek555/BD2/car-dealer-api/internal/models"
 	"gorm.io/gorm"
@@ -12,6 +14,7 @@ type RefreshTokenRepositoryInterface interface {
 	FindByUserEmail(email string) ([]models.RefreshToken, error)
 	FindByUserID(id uint) ([]models.RefreshToken, error)
 	DeleteByUserID(id uint) error
+	DeleteExpired(now time.Time) (int64, error)
 }
 
 type RefreshTokenRepository struct {
@@ -79,3 +82,12 @@ func (repo *RefreshTokenRepository) DeleteByUserID(id uint) error {
 		Error
 	return err
 }
+
+// DeleteExpired removes the tokens which expired before now and returns how many were removed.
+func (repo *RefreshTokenRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := repo.repository.
+		DB.
+		Where("expiry_date < ?", now).
+		Delete(&models.RefreshToken{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,43 @@
+package report
+
+import "github.com/susek555/BD2/car-dealer-api/pkg/pagination"
+
+type CreateReportDTO struct {
+	OfferID  *uint   `json:"offer_id"`
+	ReviewID *uint   `json:"review_id"`
+	Reason   string  `json:"reason" binding:"required"`
+	Comment  *string `json:"comment"`
+}
+
+type ResolveReportDTO struct {
+	Outcome string  `json:"outcome" binding:"required"`
+	Note    *string `json:"note"`
+}
+
+// ReportFilter narrows down the moderation queue. Only open reports are listed unless Resolved is set.
+type ReportFilter struct {
+	Pagination pagination.PaginationRequest `json:"pagination"`
+	Resolved   bool                         `json:"resolved"`
+	Reason     *string                      `json:"reason"`
+	OfferID    *uint                        `json:"offer_id"`
+	ReviewID   *uint                        `json:"review_id"`
+}
+
+type RetrieveReportDTO struct {
+	ID         uint    `json:"id"`
+	ReporterID *uint   `json:"reporter_id,omitempty"`
+	OfferID    *uint   `json:"offer_id,omitempty"`
+	ReviewID   *uint   `json:"review_id,omitempty"`
+	Reason     string  `json:"reason"`
+	Comment    *string `json:"comment,omitempty"`
+	Outcome    *string `json:"outcome,omitempty"`
+	Note       *string `json:"note,omitempty"`
+	ResolvedBy *uint   `json:"resolved_by,omitempty"`
+	ResolvedAt *string `json:"resolved_at,omitempty"`
+	CreatedAt  string  `json:"created_at"`
+}
+
+type RetrieveReportsWithPagination struct {
+	Reports            []RetrieveReportDTO            `json:"reports"`
+	PaginationResponse *pagination.PaginationResponse `json:"pagination"`
+}
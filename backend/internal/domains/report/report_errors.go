@@ -0,0 +1,33 @@
+package report
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/susek555/BD2/car-dealer-api/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidReportTarget    = errors.New("exactly one of offer_id and review_id has to be given")
+	ErrInvalidReportReason    = errors.New("invalid report reason")
+	ErrInvalidReportOutcome   = errors.New("invalid report outcome")
+	ErrCommentTooLong         = errors.New("comment cannot be longer than 500 characters")
+	ErrCannotReportOwnContent = errors.New("you cannot report your own offer or review")
+	ErrAlreadyReported        = errors.New("you have already reported this content")
+	ErrReportAlreadyResolved  = errors.New("report is already resolved")
+	ErrInvalidHideThreshold   = errors.New("invalid hide threshold")
+)
+
+var ErrorMap = map[error]int{
+	ErrInvalidReportTarget:         http.StatusBadRequest,
+	ErrInvalidReportReason:         http.StatusBadRequest,
+	ErrInvalidReportOutcome:        http.StatusBadRequest,
+	ErrCommentTooLong:              http.StatusBadRequest,
+	ErrCannotReportOwnContent:      http.StatusForbidden,
+	ErrAlreadyReported:             http.StatusConflict,
+	ErrReportAlreadyResolved:       http.StatusConflict,
+	pagination.ErrPageOutOfRange:   http.StatusBadRequest,
+	pagination.ErrNegativePageSize: http.StatusBadRequest,
+	gorm.ErrRecordNotFound:         http.StatusNotFound,
+}
@@ -0,0 +1,123 @@
+package report
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/auth"
+	"github.com/susek555/BD2/car-dealer-api/pkg/custom_errors"
+)
+
+type Handler struct {
+	service ReportServiceInterface
+}
+
+func NewHandler(service ReportServiceInterface) *Handler {
+	return &Handler{service: service}
+}
+
+// CreateReport godoc
+//
+//	@Summary		Report an offer or a review
+//	@Description	Reports a scam offer or a fake review to the moderators. Exactly one of offer_id and review_id has to be given. Every user can report the same content only once, and nobody can report their own content. An offer reported by several users is hidden from buyers until the reports are resolved.
+//	@Tags			report
+//	@Accept			json
+//	@Produce		json
+//	@Param			report	body		CreateReportDTO			true	"Report"
+//	@Success		201		{object}	RetrieveReportDTO		"Created report"
+//	@Failure		400		{object}	custom_errors.HTTPError	"Invalid input data"
+//	@Failure		401		{object}	custom_errors.HTTPError	"Unauthorized - user not logged in"
+//	@Failure		403		{object}	custom_errors.HTTPError	"Forbidden - the content belongs to the user"
+//	@Failure		404		{object}	custom_errors.HTTPError	"Offer or review not found"
+//	@Failure		409		{object}	custom_errors.HTTPError	"Content already reported by the user"
+//	@Failure		500		{object}	custom_errors.HTTPError	"Internal server error"
+//	@Router			/report [post]
+//	@Security		Bearer
+func (h *Handler) CreateReport(c *gin.Context) {
+	var in CreateReportDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	userID, err := auth.GetUserID(c)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	report, err := h.service.Create(userID, &in)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	c.JSON(http.StatusCreated, report)
+}
+
+// GetQueue godoc
+//
+//	@Summary		Get the moderation queue
+//	@Description	Returns a paginated list of reports, oldest first. Only open reports are listed unless resolved is set. You have to be a moderator or an admin to perform this operation.
+//	@Tags			report
+//	@Accept			json
+//	@Produce		json
+//	@Param			filter	body		ReportFilter					true	"Report filter"
+//	@Success		200		{object}	RetrieveReportsWithPagination	"Reports"
+//	@Failure		400		{object}	custom_errors.HTTPError			"Invalid input data"
+//	@Failure		401		{object}	custom_errors.HTTPError			"Unauthorized - user not logged in"
+//	@Failure		403		{object}	custom_errors.HTTPError			"Forbidden - user is not a moderator"
+//	@Failure		500		{object}	custom_errors.HTTPError			"Internal server error"
+//	@Router			/report/queue [post]
+//	@Security		Bearer
+func (h *Handler) GetQueue(c *gin.Context) {
+	var filter ReportFilter
+	if err := c.ShouldBindJSON(&filter); err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	reports, err := h.service.GetQueue(&filter)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	c.JSON(http.StatusOK, reports)
+}
+
+// ResolveReport godoc
+//
+//	@Summary		Resolve a report
+//	@Description	Closes the report with an outcome ("Action taken" or "Dismissed") and notifies the reporter. The action is recorded in the audit log. You have to be a moderator or an admin to perform this operation.
+//	@Tags			report
+//	@Accept			json
+//	@Param			id			path	uint				true	"Report ID"
+//	@Param			resolution	body	ResolveReportDTO	true	"Outcome of the report"
+//	@Success		204			"No content"
+//	@Failure		400			{object}	custom_errors.HTTPError	"Invalid input data"
+//	@Failure		401			{object}	custom_errors.HTTPError	"Unauthorized - user not logged in"
+//	@Failure		403			{object}	custom_errors.HTTPError	"Forbidden - user is not a moderator"
+//	@Failure		404			{object}	custom_errors.HTTPError	"Report not found"
+//	@Failure		409			{object}	custom_errors.HTTPError	"Report is already resolved"
+//	@Failure		500			{object}	custom_errors.HTTPError	"Internal server error"
+//	@Router			/report/resolve/{id} [put]
+//	@Security		Bearer
+func (h *Handler) ResolveReport(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	var in ResolveReportDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	moderatorID, err := auth.GetUserID(c)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	if err := h.service.Resolve(moderatorID, uint(id), &in); err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
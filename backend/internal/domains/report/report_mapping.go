@@ -0,0 +1,42 @@
+package report
+
+import (
+	"time"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+)
+
+func MapToReport(reporterID uint, in *CreateReportDTO) *models.Report {
+	return &models.Report{
+		ReporterID: &reporterID,
+		OfferID:    in.OfferID,
+		ReviewID:   in.ReviewID,
+		Reason:     enums.ReportReason(in.Reason),
+		Comment:    in.Comment,
+		CreatedAt:  time.Now(),
+	}
+}
+
+func MapToReportDTO(report *models.Report) *RetrieveReportDTO {
+	dto := &RetrieveReportDTO{
+		ID:         report.ID,
+		ReporterID: report.ReporterID,
+		OfferID:    report.OfferID,
+		ReviewID:   report.ReviewID,
+		Reason:     string(report.Reason),
+		Comment:    report.Comment,
+		Note:       report.Note,
+		ResolvedBy: report.ResolvedBy,
+		CreatedAt:  report.CreatedAt.Format(time.RFC3339),
+	}
+	if report.Outcome != nil {
+		outcome := string(*report.Outcome)
+		dto.Outcome = &outcome
+	}
+	if report.ResolvedAt != nil {
+		resolvedAt := report.ResolvedAt.Format(time.RFC3339)
+		dto.ResolvedAt = &resolvedAt
+	}
+	return dto
+}
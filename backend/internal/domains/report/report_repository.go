@@ -0,0 +1,160 @@
+package report
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"github.com/susek555/BD2/car-dealer-api/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+//go:generate mockery --name=ReportRepositoryInterface --output=../../test/mocks --case=snake --with-expecter
+type ReportRepositoryInterface interface {
+	Create(report *models.Report, hideThreshold uint) error
+	GetByID(id uint) (*models.Report, error)
+	GetQueue(filter *ReportFilter) ([]models.Report, *pagination.PaginationResponse, error)
+	Resolve(report *models.Report, hideThreshold uint, message *models.OutboxMessage, entry *models.AdminAuditLog) error
+}
+
+// ReportRepository keeps the hidden state of reported offers in sync with their reports - an offer
+// is hidden from buyers while it has at least hideThreshold open ones, or once a moderator acted on one.
+type ReportRepository struct {
+	DB *gorm.DB
+}
+
+func NewReportRepository(db *gorm.DB) ReportRepositoryInterface {
+	return &ReportRepository{DB: db}
+}
+
+// Create saves the report, the unique indexes on reports let every user report the same content only once.
+func (r *ReportRepository) Create(report *models.Report, hideThreshold uint) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(report).Error; err != nil {
+			if isUniqueViolation(err) {
+				return ErrAlreadyReported
+			}
+			return err
+		}
+		if report.OfferID == nil {
+			return nil
+		}
+		return hideIfReported(tx, *report.OfferID, hideThreshold)
+	})
+}
+
+func (r *ReportRepository) GetByID(id uint) (*models.Report, error) {
+	var report models.Report
+	if err := r.DB.First(&report, id).Error; err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// GetQueue lists the reports oldest first, so the ones waiting the longest are handled first.
+func (r *ReportRepository) GetQueue(filter *ReportFilter) ([]models.Report, *pagination.PaginationResponse, error) {
+	query := r.DB.Order("created_at ASC, id ASC")
+	if filter.Resolved {
+		query = query.Where("resolved_at IS NOT NULL")
+	} else {
+		query = query.Where("resolved_at IS NULL")
+	}
+	if filter.Reason != nil {
+		query = query.Where("reason = ?", enums.ReportReason(*filter.Reason))
+	}
+	if filter.OfferID != nil {
+		query = query.Where("offer_id = ?", *filter.OfferID)
+	}
+	if filter.ReviewID != nil {
+		query = query.Where("review_id = ?", *filter.ReviewID)
+	}
+	return pagination.PaginateResults[models.Report](&filter.Pagination, query)
+}
+
+// Resolve saves the outcome of an open report together with the notification for the reporter, nil for
+// the reports filed by the system, and the audit log entry. A reported offer stays hidden when action was
+// taken on the report, a dismissed report shows the offer again once it no longer has enough open reports
+// to stay hidden.
+func (r *ReportRepository) Resolve(report *models.Report, hideThreshold uint, message *models.OutboxMessage, entry *models.AdminAuditLog) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(report).
+			Where("resolved_at IS NULL").
+			Select("outcome", "note", "resolved_by", "resolved_at").
+			Updates(report)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrReportAlreadyResolved
+		}
+		if report.OfferID != nil {
+			if err := syncOfferVisibility(tx, *report.OfferID, *report.Outcome, hideThreshold); err != nil {
+				return err
+			}
+		}
+		if message != nil {
+			if err := tx.Create(message).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(entry).Error
+	})
+}
+
+func syncOfferVisibility(tx *gorm.DB, offerID uint, outcome enums.ReportOutcome, hideThreshold uint) error {
+	if outcome == enums.ACTION_TAKEN {
+		return hide(tx, offerID)
+	}
+	// an offer a moderator has acted on stays hidden, however many of its other reports get dismissed
+	var actionsTaken int64
+	if err := tx.Model(&models.Report{}).
+		Where("offer_id = ? AND outcome = ?", offerID, enums.ACTION_TAKEN).
+		Count(&actionsTaken).Error; err != nil {
+		return err
+	}
+	openReports, err := countOpenReports(tx, offerID)
+	if err != nil {
+		return err
+	}
+	if actionsTaken > 0 || openReports >= int64(hideThreshold) {
+		return nil
+	}
+	return tx.Model(&models.SaleOffer{}).
+		Where("id = ? AND hidden_at IS NOT NULL", offerID).
+		Update("hidden_at", nil).Error
+}
+
+func hideIfReported(tx *gorm.DB, offerID uint, hideThreshold uint) error {
+	openReports, err := countOpenReports(tx, offerID)
+	if err != nil {
+		return err
+	}
+	if openReports < int64(hideThreshold) {
+		return nil
+	}
+	return hide(tx, offerID)
+}
+
+func hide(tx *gorm.DB, offerID uint) error {
+	return tx.Model(&models.SaleOffer{}).
+		Where("id = ? AND hidden_at IS NULL", offerID).
+		Update("hidden_at", time.Now()).Error
+}
+
+func countOpenReports(tx *gorm.DB, offerID uint) (int64, error) {
+	var openReports int64
+	err := tx.Model(&models.Report{}).
+		Where("offer_id = ? AND resolved_at IS NULL", offerID).
+		Count(&openReports).Error
+	return openReports, err
+}
+
+// uniqueViolation is the SQLSTATE of an insert breaking a unique index.
+const uniqueViolation = "23505"
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
@@ -0,0 +1,61 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/notification"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/outbox"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/ws"
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+)
+
+const OutboxReportResolved = "report_resolved"
+
+type ReportResolvedPayload struct {
+	ReportID   uint                `json:"report_id"`
+	ReporterID uint                `json:"reporter_id"`
+	OfferID    *uint               `json:"offer_id,omitempty"`
+	ReviewID   *uint               `json:"review_id,omitempty"`
+	Outcome    enums.ReportOutcome `json:"outcome"`
+}
+
+// ReportResolvedNotifier lets the reporter know that a moderator has looked at their report.
+type ReportResolvedNotifier struct {
+	notificationService notification.NotificationServiceInterface
+	userNotifier        *notification.UserNotifier
+}
+
+func NewReportResolvedNotifier(notificationService notification.NotificationServiceInterface, hub ws.HubInterface) *ReportResolvedNotifier {
+	return &ReportResolvedNotifier{
+		notificationService: notificationService,
+		userNotifier:        notification.NewUserNotifier(notificationService, hub),
+	}
+}
+
+func (n *ReportResolvedNotifier) Register(dispatcher *outbox.Dispatcher) {
+	dispatcher.Register(OutboxReportResolved, n.DeliverReportResolved)
+}
+
+// DeliverReportResolved sends the notification without linking it to the offer - the action taken
+// on the report may have been deleting the offer.
+func (n *ReportResolvedNotifier) DeliverReportResolved(messageID uint, payload string) error {
+	var p ReportResolvedPayload
+	if err := json.Unmarshal([]byte(payload), &p); err != nil {
+		return err
+	}
+	var target string
+	switch {
+	case p.OfferID != nil:
+		target = fmt.Sprintf("offer #%d", *p.OfferID)
+	case p.ReviewID != nil:
+		target = fmt.Sprintf("review #%d", *p.ReviewID)
+	default:
+		return ErrInvalidReportTarget
+	}
+	notif := models.Notification{OutboxMessageID: &messageID}
+	return n.userNotifier.Notify(&notif, p.ReporterID, func(notif *models.Notification) error {
+		return n.notificationService.CreateReportResolvedNotification(notif, target, p.Outcome == enums.ACTION_TAKEN)
+	})
+}
@@ -0,0 +1,188 @@
+package report
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/admin"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/outbox"
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+)
+
+const (
+	DefaultHideThreshold = 3
+	MaxCommentLength     = 500
+)
+
+type SaleOfferRetrieverInterface interface {
+	GetByID(id uint) (*models.SaleOffer, error)
+}
+
+type ReviewRetrieverInterface interface {
+	GetByID(id uint) (*models.Review, error)
+}
+
+type ReportServiceInterface interface {
+	Create(reporterID uint, in *CreateReportDTO) (*RetrieveReportDTO, error)
+	GetQueue(filter *ReportFilter) (*RetrieveReportsWithPagination, error)
+	Resolve(moderatorID, reportID uint, in *ResolveReportDTO) error
+	CreateSystemReport(offerID uint, reason enums.ReportReason, comment string) error
+}
+
+type ReportService struct {
+	repo               ReportRepositoryInterface
+	saleOfferRetriever SaleOfferRetrieverInterface
+	reviewRetriever    ReviewRetrieverInterface
+	hideThreshold      uint
+}
+
+func NewReportService(
+	repo ReportRepositoryInterface,
+	saleOfferRetriever SaleOfferRetrieverInterface,
+	reviewRetriever ReviewRetrieverInterface,
+	hideThreshold uint,
+) ReportServiceInterface {
+	return &ReportService{
+		repo:               repo,
+		saleOfferRetriever: saleOfferRetriever,
+		reviewRetriever:    reviewRetriever,
+		hideThreshold:      hideThreshold,
+	}
+}
+
+// ParseHideThreshold reads the number of open reports after which an offer is hidden, DefaultHideThreshold if empty.
+func ParseHideThreshold(value string) (uint, error) {
+	if strings.TrimSpace(value) == "" {
+		return DefaultHideThreshold, nil
+	}
+	threshold, err := strconv.ParseUint(strings.TrimSpace(value), 10, 32)
+	if err != nil || threshold == 0 {
+		return 0, ErrInvalidHideThreshold
+	}
+	return uint(threshold), nil
+}
+
+func (s *ReportService) Create(reporterID uint, in *CreateReportDTO) (*RetrieveReportDTO, error) {
+	if (in.OfferID == nil) == (in.ReviewID == nil) {
+		return nil, ErrInvalidReportTarget
+	}
+	if !slices.Contains(enums.ReportReasons, enums.ReportReason(in.Reason)) {
+		return nil, ErrInvalidReportReason
+	}
+	if in.Comment != nil && utf8.RuneCountInString(*in.Comment) > MaxCommentLength {
+		return nil, ErrCommentTooLong
+	}
+	if err := s.checkNotOwnContent(reporterID, in); err != nil {
+		return nil, err
+	}
+	report := MapToReport(reporterID, in)
+	if err := s.repo.Create(report, s.hideThreshold); err != nil {
+		return nil, err
+	}
+	return MapToReportDTO(report), nil
+}
+
+// CreateSystemReport puts the offer in the moderation queue on behalf of the system. While such a report
+// is open, filing another one for the same reason does nothing.
+func (s *ReportService) CreateSystemReport(offerID uint, reason enums.ReportReason, comment string) error {
+	if !slices.Contains(enums.SystemReportReasons, reason) {
+		return ErrInvalidReportReason
+	}
+	if utf8.RuneCountInString(comment) > MaxCommentLength {
+		return ErrCommentTooLong
+	}
+	report := &models.Report{
+		OfferID:   &offerID,
+		Reason:    reason,
+		Comment:   &comment,
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.Create(report, s.hideThreshold); err != nil && !errors.Is(err, ErrAlreadyReported) {
+		return err
+	}
+	return nil
+}
+
+func (s *ReportService) checkNotOwnContent(reporterID uint, in *CreateReportDTO) error {
+	if in.OfferID != nil {
+		offer, err := s.saleOfferRetriever.GetByID(*in.OfferID)
+		if err != nil {
+			return err
+		}
+		if offer.UserID == reporterID {
+			return ErrCannotReportOwnContent
+		}
+		return nil
+	}
+	review, err := s.reviewRetriever.GetByID(*in.ReviewID)
+	if err != nil {
+		return err
+	}
+	if review.ReviewerID == reporterID {
+		return ErrCannotReportOwnContent
+	}
+	return nil
+}
+
+func (s *ReportService) GetQueue(filter *ReportFilter) (*RetrieveReportsWithPagination, error) {
+	if filter.Reason != nil && !isReportReason(enums.ReportReason(*filter.Reason)) {
+		return nil, ErrInvalidReportReason
+	}
+	reports, paginationResponse, err := s.repo.GetQueue(filter)
+	if err != nil {
+		return nil, err
+	}
+	reportDTOs := make([]RetrieveReportDTO, 0, len(reports))
+	for _, report := range reports {
+		reportDTOs = append(reportDTOs, *MapToReportDTO(&report))
+	}
+	return &RetrieveReportsWithPagination{Reports: reportDTOs, PaginationResponse: paginationResponse}, nil
+}
+
+func isReportReason(reason enums.ReportReason) bool {
+	return slices.Contains(enums.ReportReasons, reason) || slices.Contains(enums.SystemReportReasons, reason)
+}
+
+// Resolve closes the report with the outcome and lets the reporter, if there is one, know about it.
+func (s *ReportService) Resolve(moderatorID, reportID uint, in *ResolveReportDTO) error {
+	outcome := enums.ReportOutcome(in.Outcome)
+	if !slices.Contains(enums.ReportOutcomes, outcome) {
+		return ErrInvalidReportOutcome
+	}
+	if in.Note != nil && utf8.RuneCountInString(*in.Note) > MaxCommentLength {
+		return ErrCommentTooLong
+	}
+	report, err := s.repo.GetByID(reportID)
+	if err != nil {
+		return err
+	}
+	if report.IsResolved() {
+		return ErrReportAlreadyResolved
+	}
+	now := time.Now()
+	report.Outcome = &outcome
+	report.Note = in.Note
+	report.ResolvedBy = &moderatorID
+	report.ResolvedAt = &now
+	var message *models.OutboxMessage
+	// the system, which filed the reports without a reporter, needs no notification
+	if report.ReporterID != nil {
+		message, err = outbox.NewMessage(OutboxReportResolved, ReportResolvedPayload{
+			ReportID:   report.ID,
+			ReporterID: *report.ReporterID,
+			OfferID:    report.OfferID,
+			ReviewID:   report.ReviewID,
+			Outcome:    outcome,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	entry := admin.NewAuditLog(moderatorID, enums.RESOLVE_REPORT, report.ID, in.Note)
+	return s.repo.Resolve(report, s.hideThreshold, message, entry)
+}
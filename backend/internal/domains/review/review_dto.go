@@ -3,18 +3,25 @@ package review
 import "github.com/susek555/BD2/car-dealer-api/pkg/pagination"
 
 type RetrieveReviewDTO struct {
-	ID          uint    `json:"id"`
-	Description string  `json:"description"`
-	Rating      uint    `json:"rating"`
-	Reviewer    UserDTO `json:"reviewer"`
-	Reviewee    UserDTO `json:"reviewee"`
-	ReviewDate  string  `json:"review_date"`
+	ID              uint    `json:"id"`
+	Description     string  `json:"description"`
+	Rating          uint    `json:"rating"`
+	Reviewer        UserDTO `json:"reviewer"`
+	Reviewee        UserDTO `json:"reviewee"`
+	ReviewDate      string  `json:"review_date"`
+	OfferID         *uint   `json:"offer_id,omitempty"`
+	Verified        bool    `json:"verified"`
+	Reply           *string `json:"reply,omitempty"`
+	RepliedAt       *string `json:"replied_at,omitempty"`
+	HelpfulVotes    uint    `json:"helpful_votes"`
+	NotHelpfulVotes uint    `json:"not_helpful_votes"`
 }
 
 type CreateReviewDTO struct {
 	Description string `json:"description"`
 	Rating      uint   `json:"rating"`
 	RevieweeID  uint   `json:"reviewee_id"`
+	OfferID     uint   `json:"offer_id"`
 }
 
 type UpdateReviewDTO struct {
@@ -23,6 +30,14 @@ type UpdateReviewDTO struct {
 	Rating      uint   `json:"rating"`
 }
 
+type ReplyDTO struct {
+	Reply string `json:"reply" binding:"required"`
+}
+
+type VoteDTO struct {
+	Helpful *bool `json:"helpful" binding:"required"`
+}
+
 type UserDTO struct {
 	ID       uint   `json:"id"`
 	Username string `json:"username"`
@@ -6,15 +6,42 @@ import (
 )
 
 var (
-	ErrNoReviewsFound = errors.New("no reviews found for this reviewee")
-	ErrNotReviewer    = errors.New("you are not the reviewer of this review")
-	ErrNoReviewFound  = errors.New("no review found")
-	ErrInvalidRating  = errors.New("invalid rating, must be between 1 and 5")
+	ErrNoReviewsFound  = errors.New("no reviews found for this reviewee")
+	ErrNotReviewer     = errors.New("you are not the reviewer of this review")
+	ErrNoReviewFound   = errors.New("no review found")
+	ErrInvalidRating   = errors.New("invalid rating, must be between 1 and 5")
+	ErrInvalidOrderKey = errors.New("invalid order_key, must be one of: rating, review_date, helpfulness")
+
+	ErrPurchaseRequired    = errors.New("offer_id of the purchase being reviewed is required")
+	ErrSelfReview          = errors.New("you cannot review yourself")
+	ErrPurchaseNotFound    = errors.New("no purchase found for this offer")
+	ErrNotPurchaseParty    = errors.New("only the buyer and the seller can review each other after a purchase")
+	ErrReviewWindowClosed  = errors.New("the purchase is too old to be reviewed")
+	ErrInvalidReviewWindow = errors.New("invalid review window")
+
+	ErrNotReviewee         = errors.New("only the reviewed user can reply to the review")
+	ErrAlreadyReplied      = errors.New("the review already has a reply")
+	ErrInvalidReply        = errors.New("reply must be between 1 and 500 characters long")
+	ErrCannotVoteOwnReview = errors.New("you cannot vote on a review written by or about you")
+	ErrVoteNotFound        = errors.New("you have not voted on this review")
 )
 
 var ErrorMap = map[error]int{
-	ErrNoReviewsFound: http.StatusNotFound,
-	ErrNotReviewer:    http.StatusForbidden,
-	ErrNoReviewFound:  http.StatusNotFound,
-	ErrInvalidRating:  http.StatusBadRequest,
+	ErrNoReviewsFound:  http.StatusNotFound,
+	ErrNotReviewer:     http.StatusForbidden,
+	ErrNoReviewFound:   http.StatusNotFound,
+	ErrInvalidRating:   http.StatusBadRequest,
+	ErrInvalidOrderKey: http.StatusBadRequest,
+
+	ErrPurchaseRequired:   http.StatusBadRequest,
+	ErrSelfReview:         http.StatusBadRequest,
+	ErrPurchaseNotFound:   http.StatusNotFound,
+	ErrNotPurchaseParty:   http.StatusForbidden,
+	ErrReviewWindowClosed: http.StatusForbidden,
+
+	ErrNotReviewee:         http.StatusForbidden,
+	ErrAlreadyReplied:      http.StatusConflict,
+	ErrInvalidReply:        http.StatusBadRequest,
+	ErrCannotVoteOwnReview: http.StatusForbidden,
+	ErrVoteNotFound:        http.StatusNotFound,
 }
@@ -5,6 +5,16 @@ import (
 	"gorm.io/gorm"
 )
 
+// HelpfulnessOrderKey orders the reviews by the number of helpful votes minus the not helpful ones.
+const HelpfulnessOrderKey = "helpfulness"
+
+// OrderKeysMap maps the order keys accepted from clients to the expressions the reviews are sorted by.
+var OrderKeysMap = map[string]string{
+	"rating":            "reviews.rating",
+	"review_date":       "reviews.review_date",
+	HelpfulnessOrderKey: "(reviews.helpful_votes - reviews.not_helpful_votes)",
+}
+
 type ReviewFilter struct {
 	Pagination  pagination.PaginationRequest `json:"pagination"`
 	OrderKey    *string                      `json:"order_key"`
@@ -12,6 +22,7 @@ type ReviewFilter struct {
 	Ratings     *[]uint                      `json:"ratings"`
 	ReviewerID  *uint                        `json:"reviewer_id"`
 	RevieweeID  *uint                        `json:"reviewee_id"`
+	Verified    *bool                        `json:"verified"`
 }
 
 func NewReviewFilter() *ReviewFilter {
@@ -22,7 +33,10 @@ func NewReviewFilter() *ReviewFilter {
 
 func (f *ReviewFilter) ApplyReviewFilters(query *gorm.DB) (*gorm.DB, error) {
 	if f.OrderKey != nil {
-		order := *f.OrderKey
+		order, ok := OrderKeysMap[*f.OrderKey]
+		if !ok {
+			return nil, ErrInvalidOrderKey
+		}
 		if f.IsOrderDesc != nil && *f.IsOrderDesc {
 			order += " DESC"
 		}
@@ -38,5 +52,12 @@ func (f *ReviewFilter) ApplyReviewFilters(query *gorm.DB) (*gorm.DB, error) {
 	if f.RevieweeID != nil {
 		query = query.Where("reviewee_id = ?", *f.RevieweeID)
 	}
+	if f.Verified != nil {
+		if *f.Verified {
+			query = query.Where("reviews.offer_id IS NOT NULL")
+		} else {
+			query = query.Where("reviews.offer_id IS NULL")
+		}
+	}
 	return query, nil
 }
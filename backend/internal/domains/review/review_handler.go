@@ -66,13 +66,15 @@ func (h *Handler) GetReviewByID(c *gin.Context) {
 //
 //	@ID				createReview
 //	@Summary		Create a new review
-//	@Description	Persists a new review entity and returns the created review.
+//	@Description	Persists a new review entity and returns the created review. A review is written for a purchase (offer_id) - only its buyer and seller can review each other, within a limited time after the purchase.
 //	@Tags			reviews
 //	@Accept			json
 //	@Produce		json
 //	@Param			body	body		CreateReviewDTO			true	"Review payload"
 //	@Success		201		{object}	RetrieveReviewDTO		"Created – review stored"
 //	@Failure		400		{object}	custom_errors.HTTPError	"Bad Request – valIDation or persistence error"
+//	@Failure		403		{object}	custom_errors.HTTPError	"Forbidden – not a party of the purchase or the purchase is too old"
+//	@Failure		404		{object}	custom_errors.HTTPError	"Not Found – no purchase of the offer"
 //	@Router			/review [post]
 func (h *Handler) CreateReview(c *gin.Context) {
 	var reviewInput CreateReviewDTO
@@ -152,6 +154,117 @@ func (h *Handler) DeleteReview(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// ReplyToReview godoc
+//
+//	@ID				replyToReview
+//	@Summary		Reply to a review
+//	@Description	Saves the public reply of the reviewed user and notifies the reviewer. Every review can be replied to only once.
+//	@Tags			reviews
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"Review ID"
+//	@Param			body	body		ReplyDTO				true	"Reply payload"
+//	@Success		200		{object}	RetrieveReviewDTO		"OK – review with the reply"
+//	@Failure		400		{object}	custom_errors.HTTPError	"Bad Request – invalID ID format or reply"
+//	@Failure		403		{object}	custom_errors.HTTPError	"Forbidden – the review is not about the user"
+//	@Failure		404		{object}	custom_errors.HTTPError	"Not Found – review not found"
+//	@Failure		409		{object}	custom_errors.HTTPError	"Conflict – the review already has a reply"
+//	@Router			/review/reply/{id} [put]
+//	@Security		Bearer
+func (h *Handler) ReplyToReview(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, custom_errors.NewHTTPError(err.Error()))
+		return
+	}
+	userID, err := auth.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, custom_errors.NewHTTPError(err.Error()))
+		return
+	}
+	var replyInput ReplyDTO
+	if err := c.ShouldBindJSON(&replyInput); err != nil {
+		c.JSON(http.StatusBadRequest, custom_errors.NewHTTPError(err.Error()))
+		return
+	}
+	reviewOutput, err := h.service.Reply(userID, uint(id), &replyInput)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	c.JSON(http.StatusOK, reviewOutput)
+}
+
+// VoteReview godoc
+//
+//	@ID				voteReview
+//	@Summary		Vote on the helpfulness of a review
+//	@Description	Saves whether the user found the review helpful, replacing their previous vote. Users cannot vote on reviews written by or about them.
+//	@Tags			reviews
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"Review ID"
+//	@Param			body	body		VoteDTO					true	"Vote payload"
+//	@Success		200		{object}	RetrieveReviewDTO		"OK – review with the updated votes"
+//	@Failure		400		{object}	custom_errors.HTTPError	"Bad Request – invalID ID format or vote"
+//	@Failure		403		{object}	custom_errors.HTTPError	"Forbidden – the review is written by or about the user"
+//	@Failure		404		{object}	custom_errors.HTTPError	"Not Found – review not found"
+//	@Router			/review/vote/{id} [put]
+//	@Security		Bearer
+func (h *Handler) VoteReview(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, custom_errors.NewHTTPError(err.Error()))
+		return
+	}
+	userID, err := auth.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, custom_errors.NewHTTPError(err.Error()))
+		return
+	}
+	var voteInput VoteDTO
+	if err := c.ShouldBindJSON(&voteInput); err != nil {
+		c.JSON(http.StatusBadRequest, custom_errors.NewHTTPError(err.Error()))
+		return
+	}
+	reviewOutput, err := h.service.Vote(userID, uint(id), &voteInput)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	c.JSON(http.StatusOK, reviewOutput)
+}
+
+// DeleteReviewVote godoc
+//
+//	@ID				deleteReviewVote
+//	@Summary		Withdraw a helpfulness vote
+//	@Description	Removes the vote of the user on the review.
+//	@Tags			reviews
+//	@Param			id	path		int						true	"Review ID"
+//	@Success		204	{string}	string					"No Content – vote removed"
+//	@Failure		400	{object}	custom_errors.HTTPError	"Bad Request – invalID ID format"
+//	@Failure		404	{object}	custom_errors.HTTPError	"Not Found – the user has not voted on the review"
+//	@Router			/review/vote/{id} [delete]
+//	@Security		Bearer
+func (h *Handler) DeleteReviewVote(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, custom_errors.NewHTTPError(err.Error()))
+		return
+	}
+	userID, err := auth.GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, custom_errors.NewHTTPError(err.Error()))
+		return
+	}
+	if err := h.service.DeleteVote(userID, uint(id)); err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
 // GetReviewsByReviewerID godoc
 //
 //	@ID				getReviewsByReviewerID
@@ -281,12 +394,13 @@ func (h *Handler) GetFilteredReviews(c *gin.Context) {
 //
 //	@ID				getAverageRatingByRevieweeID
 //	@Summary		Get average rating for a reviewee
-//	@Description	Returns the average rating value calculated over all reviews for the given reviewee.
+//	@Description	Returns the average rating value calculated over all reviews for the given reviewee, or over the verified ones only.
 //	@Tags			reviews
 //	@Produce		json
-//	@Param			id	path		int						true	"Reviewee ID"
-//	@Success		200	{number}	float64					"OK – average rating (rounded to two decimals)"
-//	@Failure		400	{object}	custom_errors.HTTPError	"Bad Request – invalID ID format or query failed"
+//	@Param			id			path		int						true	"Reviewee ID"
+//	@Param			verified	query		bool					false	"Count only reviews of completed purchases"
+//	@Success		200			{number}	float64					"OK – average rating (rounded to two decimals)"
+//	@Failure		400			{object}	custom_errors.HTTPError	"Bad Request – invalID ID format or query failed"
 //	@Router			/review/average-rating/{id} [get]
 func (h *Handler) GetAverageRatingByRevieweeID(c *gin.Context) {
 	ID, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -294,8 +408,13 @@ func (h *Handler) GetAverageRatingByRevieweeID(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, custom_errors.NewHTTPError(err.Error()))
 		return
 	}
+	verifiedOnly, err := parseVerifiedOnly(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, custom_errors.NewHTTPError(err.Error()))
+		return
+	}
 	revieweeID := uint(ID)
-	averageRating, err := h.service.GetAverageRatingByRevieweeID(revieweeID)
+	averageRating, err := h.service.GetAverageRatingByRevieweeID(revieweeID, verifiedOnly)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, custom_errors.NewHTTPError(err.Error()))
 		return
@@ -307,12 +426,13 @@ func (h *Handler) GetAverageRatingByRevieweeID(c *gin.Context) {
 //
 //	@ID				getFrequencyOfRatingByRevieweeID
 //	@Summary		Get distribution of ratings for a reviewee
-//	@Description	Returns a map from rating value (1–5) to percentage frequency among all reviews for the given reviewee.
+//	@Description	Returns a map from rating value (1–5) to percentage frequency among all reviews for the given reviewee, or among the verified ones only.
 //	@Tags			reviews
 //	@Produce		json
-//	@Param			id	path		int						true	"Reviewee ID"
-//	@Success		200	{object}	map[int]int				"OK – percentage frequencies for ratings 1 through 5"
-//	@Failure		400	{object}	custom_errors.HTTPError	"Bad Request – invalID ID format or query failed"
+//	@Param			id			path		int						true	"Reviewee ID"
+//	@Param			verified	query		bool					false	"Count only reviews of completed purchases"
+//	@Success		200			{object}	map[int]int				"OK – percentage frequencies for ratings 1 through 5"
+//	@Failure		400			{object}	custom_errors.HTTPError	"Bad Request – invalID ID format or query failed"
 //	@Router			/review/frequency/{id} [get]
 func (h *Handler) GetFrequencyOfRatingByRevieweeID(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -320,11 +440,24 @@ func (h *Handler) GetFrequencyOfRatingByRevieweeID(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, custom_errors.NewHTTPError(err.Error()))
 		return
 	}
+	verifiedOnly, err := parseVerifiedOnly(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, custom_errors.NewHTTPError(err.Error()))
+		return
+	}
 	revieweeID := uint(id)
-	frequency, err := h.service.GetFrequencyOfRatingByRevieweeID(revieweeID)
+	frequency, err := h.service.GetFrequencyOfRatingByRevieweeID(revieweeID, verifiedOnly)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, custom_errors.NewHTTPError(err.Error()))
 		return
 	}
 	c.JSON(http.StatusOK, frequency)
 }
+
+func parseVerifiedOnly(c *gin.Context) (bool, error) {
+	verified := c.Query("verified")
+	if verified == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(verified)
+}
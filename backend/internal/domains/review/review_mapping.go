@@ -10,12 +10,17 @@ func (ri *CreateReviewDTO) MapToObject(reviewerID uint) (*models.Review, error)
 	if !valIDateRating(ri.Rating) {
 		return nil, ErrInvalidRating
 	}
+	if ri.OfferID == 0 {
+		return nil, ErrPurchaseRequired
+	}
+	offerID := ri.OfferID
 	return &models.Review{
 		Description: ri.Description,
 		Rating:      ri.Rating,
 		ReviewerID:  reviewerID,
 		RevieweeID:  ri.RevieweeID,
 		ReviewDate:  time.Now(),
+		OfferID:     &offerID,
 	}, nil
 }
 
@@ -43,6 +48,15 @@ func MapToDTO(r *models.Review) *RetrieveReviewDTO {
 	reviewer := MapToUserDTO(r.Reviewer)
 	reviewDTO.Reviewer = reviewer
 	reviewDTO.ReviewDate = r.ReviewDate.Format(time.RFC3339)
+	reviewDTO.OfferID = r.OfferID
+	reviewDTO.Verified = r.IsVerified()
+	reviewDTO.Reply = r.Reply
+	if r.RepliedAt != nil {
+		repliedAt := r.RepliedAt.Format(time.RFC3339)
+		reviewDTO.RepliedAt = &repliedAt
+	}
+	reviewDTO.HelpfulVotes = r.HelpfulVotes
+	reviewDTO.NotHelpfulVotes = r.NotHelpfulVotes
 	return &reviewDTO
 }
 
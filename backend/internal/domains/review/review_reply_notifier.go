@@ -0,0 +1,47 @@
+package review
+
+import (
+	"encoding/json"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/notification"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/outbox"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/ws"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+)
+
+const OutboxReviewReplied = "review_replied"
+
+type ReviewRepliedPayload struct {
+	ReviewID   uint   `json:"review_id"`
+	ReviewerID uint   `json:"reviewer_id"`
+	Replier    string `json:"replier"`
+	Reply      string `json:"reply"`
+}
+
+// ReviewReplyNotifier lets the reviewer know that the reviewed user replied to their review.
+type ReviewReplyNotifier struct {
+	notificationService notification.NotificationServiceInterface
+	userNotifier        *notification.UserNotifier
+}
+
+func NewReviewReplyNotifier(notificationService notification.NotificationServiceInterface, hub ws.HubInterface) *ReviewReplyNotifier {
+	return &ReviewReplyNotifier{
+		notificationService: notificationService,
+		userNotifier:        notification.NewUserNotifier(notificationService, hub),
+	}
+}
+
+func (n *ReviewReplyNotifier) Register(dispatcher *outbox.Dispatcher) {
+	dispatcher.Register(OutboxReviewReplied, n.DeliverReviewReplied)
+}
+
+func (n *ReviewReplyNotifier) DeliverReviewReplied(messageID uint, payload string) error {
+	var p ReviewRepliedPayload
+	if err := json.Unmarshal([]byte(payload), &p); err != nil {
+		return err
+	}
+	notif := models.Notification{OutboxMessageID: &messageID}
+	return n.userNotifier.Notify(&notif, p.ReviewerID, func(notif *models.Notification) error {
+		return n.notificationService.CreateReviewReplyNotification(notif, p.Replier, p.Reply)
+	})
+}
@@ -3,11 +3,13 @@ package review
 import (
 	"errors"
 	"math"
+	"time"
 
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/generic"
 	"github.com/susek555/BD2/car-dealer-api/internal/models"
 	"github.com/susek555/BD2/car-dealer-api/pkg/pagination"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 //go:generate mockery --name=ReviewRepositoryInterface --output=../../test/mocks --case=snake --with-expecter
@@ -17,8 +19,11 @@ type ReviewRepositoryInterface interface {
 	GetByRevieweeID(reviewedID uint) ([]models.Review, error)
 	GetByReviewerIDAndRevieweeID(reviewerID uint, reviewedID uint) (*models.Review, error)
 	GetFiltered(filter *ReviewFilter) ([]models.Review, *pagination.PaginationResponse, error)
-	GetAverageRatingByRevieweeID(revieweeID uint) (float64, error)
-	GetFrequencyOfRatingByRevieweeID(revieweeID uint) (map[int]int, error)
+	GetAverageRatingByRevieweeID(revieweeID uint, verifiedOnly bool) (float64, error)
+	GetFrequencyOfRatingByRevieweeID(revieweeID uint, verifiedOnly bool) (map[int]int, error)
+	SaveReply(reviewID uint, reply string, repliedAt time.Time, message *models.OutboxMessage) error
+	SaveVote(vote *models.ReviewVote) error
+	DeleteVote(reviewID, userID uint) error
 }
 
 type ReviewRepository struct {
@@ -85,9 +90,10 @@ func (repo *ReviewRepository) GetFiltered(filter *ReviewFilter) ([]models.Review
 	return reviews, paginationResponse, nil
 }
 
+// Update changes what the reviewer wrote - the reply and the votes are left as they are.
 func (repo *ReviewRepository) Update(review *models.Review) error {
 	db := repo.repository.DB
-	err := db.Save(review).Error
+	err := db.Model(review).Select("description", "rating", "review_date").Updates(review).Error
 	if err != nil {
 		return err
 	}
@@ -143,13 +149,10 @@ func (repo *ReviewRepository) GetByReviewerIDAndRevieweeID(reviewerID uint, revi
 	return &review, err
 }
 
-func (repo *ReviewRepository) GetAverageRatingByRevieweeID(revieweeID uint) (float64, error) {
+func (repo *ReviewRepository) GetAverageRatingByRevieweeID(revieweeID uint, verifiedOnly bool) (float64, error) {
 	var average float64
-	err := repo.repository.
-		DB.
-		Model(&models.Review{}).
+	err := repo.buildRevieweeQuery(revieweeID, verifiedOnly).
 		Select("AVG(rating)").
-		Where("reviewee_id = ?", revieweeID).
 		Scan(&average).
 		Error
 	if err != nil {
@@ -167,13 +170,25 @@ func (repo *ReviewRepository) buildBaseQuery() *gorm.DB {
 	return query
 }
 
-func (repo *ReviewRepository) GetFrequencyOfRatingByRevieweeID(revieweeID uint) (map[int]int, error) {
+// buildRevieweeQuery selects the reviews of the reviewee, only the verified ones if verifiedOnly is set.
+func (repo *ReviewRepository) buildRevieweeQuery(revieweeID uint, verifiedOnly bool) *gorm.DB {
+	query := repo.repository.
+		DB.
+		Model(&models.Review{}).
+		Where("reviewee_id = ?", revieweeID)
+	if verifiedOnly {
+		query = query.Where("offer_id IS NOT NULL")
+	}
+	return query
+}
+
+func (repo *ReviewRepository) GetFrequencyOfRatingByRevieweeID(revieweeID uint, verifiedOnly bool) (map[int]int, error) {
 	freqMap := repo.prepareFreqMap()
-	raw, err := repo.getFrequencies(revieweeID)
+	raw, err := repo.getFrequencies(revieweeID, verifiedOnly)
 	if err != nil {
 		return freqMap, err
 	}
-	total, err := repo.getTotalReviews(revieweeID)
+	total, err := repo.getTotalReviews(revieweeID, verifiedOnly)
 	if err != nil {
 		return freqMap, err
 	}
@@ -197,13 +212,10 @@ func (repo *ReviewRepository) prepareFreqMap() map[int]int {
 	return freqMap
 }
 
-func (repo *ReviewRepository) getFrequencies(revieweeID uint) ([]RatingFrequency, error) {
+func (repo *ReviewRepository) getFrequencies(revieweeID uint, verifiedOnly bool) ([]RatingFrequency, error) {
 	var frequencies []RatingFrequency
-	err := repo.repository.
-		DB.
-		Model(&models.Review{}).
+	err := repo.buildRevieweeQuery(revieweeID, verifiedOnly).
 		Select("rating, COUNT(*) AS frequency").
-		Where("reviewee_id = ?", revieweeID).
 		Group("rating").
 		Scan(&frequencies).
 		Error
@@ -213,12 +225,9 @@ func (repo *ReviewRepository) getFrequencies(revieweeID uint) ([]RatingFrequency
 	return frequencies, nil
 }
 
-func (repo *ReviewRepository) getTotalReviews(revieweeID uint) (int64, error) {
+func (repo *ReviewRepository) getTotalReviews(revieweeID uint, verifiedOnly bool) (int64, error) {
 	var total int64
-	err := repo.repository.
-		DB.
-		Model(&models.Review{}).
-		Where("reviewee_id = ?", revieweeID).
+	err := repo.buildRevieweeQuery(revieweeID, verifiedOnly).
 		Count(&total).
 		Error
 	if err != nil {
@@ -226,3 +235,68 @@ func (repo *ReviewRepository) getTotalReviews(revieweeID uint) (int64, error) {
 	}
 	return total, nil
 }
+
+// SaveReply saves the only reply to the review together with the message notifying the reviewer.
+func (repo *ReviewRepository) SaveReply(reviewID uint, reply string, repliedAt time.Time, message *models.OutboxMessage) error {
+	return repo.repository.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Review{}).
+			Where("id = ? AND reply IS NULL", reviewID).
+			Updates(map[string]any{"reply": reply, "replied_at": repliedAt})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrAlreadyReplied
+		}
+		return tx.Create(message).Error
+	})
+}
+
+// SaveVote saves the vote, replacing the previous vote of the user on the same review.
+func (repo *ReviewRepository) SaveVote(vote *models.ReviewVote) error {
+	return repo.repository.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockReview(tx, vote.ReviewID); err != nil {
+			return err
+		}
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "review_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"helpful", "created_at"}),
+		}).Create(vote).Error
+		if err != nil {
+			return err
+		}
+		return countVotes(tx, vote.ReviewID)
+	})
+}
+
+func (repo *ReviewRepository) DeleteVote(reviewID, userID uint) error {
+	return repo.repository.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockReview(tx, reviewID); err != nil {
+			return err
+		}
+		result := tx.Where("review_id = ? AND user_id = ?", reviewID, userID).Delete(&models.ReviewVote{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrVoteNotFound
+		}
+		return countVotes(tx, reviewID)
+	})
+}
+
+// lockReview makes votes on the same review wait for each other, so that they are counted one by one.
+func lockReview(tx *gorm.DB, reviewID uint) error {
+	var review models.Review
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&review, reviewID).Error
+}
+
+// countVotes recounts the votes of the review, so the counters used for ordering cannot drift.
+func countVotes(tx *gorm.DB, reviewID uint) error {
+	return tx.Model(&models.Review{}).
+		Where("id = ?", reviewID).
+		Updates(map[string]any{
+			"helpful_votes":     gorm.Expr("(SELECT COUNT(*) FROM review_votes WHERE review_id = ? AND helpful)", reviewID),
+			"not_helpful_votes": gorm.Expr("(SELECT COUNT(*) FROM review_votes WHERE review_id = ? AND NOT helpful)", reviewID),
+		}).Error
+}
@@ -1,9 +1,27 @@
 package review
 
 import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/outbox"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
 	"github.com/susek555/BD2/car-dealer-api/pkg/mapping"
+	"gorm.io/gorm"
 )
 
+// MaxReplyLength is the longest reply to a review, in characters.
+const MaxReplyLength = 500
+
+// DefaultReviewWindow is how long after a purchase its buyer and seller can review each other.
+const DefaultReviewWindow = 30 * 24 * time.Hour
+
+type PurchaseRetrieverInterface interface {
+	GetByID(offerID uint) (*models.Purchase, error)
+}
+
 type ReviewServiceInterface interface {
 	Create(userID uint, review *CreateReviewDTO) (*RetrieveReviewDTO, error)
 	GetAll() ([]RetrieveReviewDTO, error)
@@ -14,25 +32,50 @@ type ReviewServiceInterface interface {
 	GetByRevieweeID(reviewedID uint) ([]RetrieveReviewDTO, error)
 	GetByReviewerIDAndRevieweeID(reviewerID uint, revieweeID uint) (*RetrieveReviewDTO, error)
 	GetFiltered(filter *ReviewFilter) (*RetrieveReviewsWithPagination, error)
-	GetAverageRatingByRevieweeID(revieweeID uint) (float64, error)
-	GetFrequencyOfRatingByRevieweeID(revieweeID uint) (map[int]int, error)
+	GetAverageRatingByRevieweeID(revieweeID uint, verifiedOnly bool) (float64, error)
+	GetFrequencyOfRatingByRevieweeID(revieweeID uint, verifiedOnly bool) (map[int]int, error)
+	Reply(userID, reviewID uint, in *ReplyDTO) (*RetrieveReviewDTO, error)
+	Vote(userID, reviewID uint, in *VoteDTO) (*RetrieveReviewDTO, error)
+	DeleteVote(userID, reviewID uint) error
 }
 
 type ReviewService struct {
-	Repo ReviewRepositoryInterface
+	Repo              ReviewRepositoryInterface
+	PurchaseRetriever PurchaseRetrieverInterface
+	ReviewWindow      time.Duration
 }
 
-func NewReviewService(repo ReviewRepositoryInterface) ReviewServiceInterface {
+func NewReviewService(repo ReviewRepositoryInterface, purchaseRetriever PurchaseRetrieverInterface, reviewWindow time.Duration) ReviewServiceInterface {
 	return &ReviewService{
-		Repo: repo,
+		Repo:              repo,
+		PurchaseRetriever: purchaseRetriever,
+		ReviewWindow:      reviewWindow,
 	}
 }
 
+// ParseReviewWindow reads the review window (e.g. "720h"), DefaultReviewWindow if empty.
+func ParseReviewWindow(value string) (time.Duration, error) {
+	if strings.TrimSpace(value) == "" {
+		return DefaultReviewWindow, nil
+	}
+	window, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil || window <= 0 {
+		return 0, ErrInvalidReviewWindow
+	}
+	return window, nil
+}
+
 func (service *ReviewService) Create(userID uint, review *CreateReviewDTO) (*RetrieveReviewDTO, error) {
 	reviewObj, err := review.MapToObject(userID)
 	if err != nil {
 		return nil, err
 	}
+	if reviewObj.ReviewerID == reviewObj.RevieweeID {
+		return nil, ErrSelfReview
+	}
+	if err := service.checkPurchase(reviewObj, time.Now()); err != nil {
+		return nil, err
+	}
 	err = service.Repo.Create(reviewObj)
 	if err != nil {
 		return nil, err
@@ -41,6 +84,28 @@ func (service *ReviewService) Create(userID uint, review *CreateReviewDTO) (*Ret
 	return reviewDTO, nil
 }
 
+// checkPurchase makes sure the reviewer and the reviewee are the buyer and the seller of the purchase
+// (either way round) and that the review window after the purchase is still open.
+func (service *ReviewService) checkPurchase(review *models.Review, now time.Time) error {
+	purchase, err := service.PurchaseRetriever.GetByID(*review.OfferID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrPurchaseNotFound
+	}
+	if err != nil {
+		return err
+	}
+	buyerID, sellerID := purchase.BuyerID, purchase.Offer.UserID
+	isBuyerReview := review.ReviewerID == buyerID && review.RevieweeID == sellerID
+	isSellerReview := review.ReviewerID == sellerID && review.RevieweeID == buyerID
+	if !isBuyerReview && !isSellerReview {
+		return ErrNotPurchaseParty
+	}
+	if now.After(purchase.IssueDate.Add(service.ReviewWindow)) {
+		return ErrReviewWindowClosed
+	}
+	return nil
+}
+
 func (service *ReviewService) GetAll() ([]RetrieveReviewDTO, error) {
 	reviews, err := service.Repo.GetAll()
 	if err != nil {
@@ -71,8 +136,8 @@ func (service *ReviewService) GetFiltered(filter *ReviewFilter) (*RetrieveReview
 	}, nil
 }
 
-func (service *ReviewService) GetAverageRatingByRevieweeID(revieweeID uint) (float64, error) {
-	averageRating, err := service.Repo.GetAverageRatingByRevieweeID(revieweeID)
+func (service *ReviewService) GetAverageRatingByRevieweeID(revieweeID uint, verifiedOnly bool) (float64, error) {
+	averageRating, err := service.Repo.GetAverageRatingByRevieweeID(revieweeID, verifiedOnly)
 	if err != nil && err.Error() == "no reviews found" {
 		return 0, nil
 	} else if err != nil {
@@ -82,10 +147,11 @@ func (service *ReviewService) GetAverageRatingByRevieweeID(revieweeID uint) (flo
 }
 
 func (service *ReviewService) Update(reviewerID uint, review *UpdateReviewDTO) (*RetrieveReviewDTO, error) {
-	revieweeID, err := service.getRevieweeID(review.ID)
+	existing, err := service.Repo.GetByID(review.ID)
 	if err != nil {
 		return nil, err
 	}
+	revieweeID := existing.RevieweeID
 	_, err = service.Repo.GetByReviewerIDAndRevieweeID(reviewerID, revieweeID)
 	if err != nil {
 		return nil, ErrNotReviewer
@@ -94,6 +160,8 @@ func (service *ReviewService) Update(reviewerID uint, review *UpdateReviewDTO) (
 	if err != nil {
 		return nil, err
 	}
+	// the review stays linked to the purchase it was written for
+	reviewObj.OfferID = existing.OfferID
 	err = service.Repo.Update(reviewObj)
 	if err != nil {
 		return nil, err
@@ -140,18 +208,76 @@ func (service *ReviewService) GetByReviewerIDAndRevieweeID(reviewerID uint, revi
 	return reviewDTO, nil
 }
 
-func (service *ReviewService) getRevieweeID(reviewID uint) (uint, error) {
-	review, err := service.Repo.GetByID(reviewID)
+func (service *ReviewService) GetFrequencyOfRatingByRevieweeID(revieweeID uint, verifiedOnly bool) (map[int]int, error) {
+	frequency, err := service.Repo.GetFrequencyOfRatingByRevieweeID(revieweeID, verifiedOnly)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	return review.RevieweeID, nil
+	return frequency, nil
 }
 
-func (service *ReviewService) GetFrequencyOfRatingByRevieweeID(revieweeID uint) (map[int]int, error) {
-	frequency, err := service.Repo.GetFrequencyOfRatingByRevieweeID(revieweeID)
+// Reply saves the public reply of the reviewed user - every review can be replied to once. The reviewer
+// is notified about the reply.
+func (service *ReviewService) Reply(userID, reviewID uint, in *ReplyDTO) (*RetrieveReviewDTO, error) {
+	reply := strings.TrimSpace(in.Reply)
+	if reply == "" || utf8.RuneCountInString(reply) > MaxReplyLength {
+		return nil, ErrInvalidReply
+	}
+	review, err := service.getReview(reviewID)
 	if err != nil {
 		return nil, err
 	}
-	return frequency, nil
+	if review.RevieweeID != userID {
+		return nil, ErrNotReviewee
+	}
+	if review.Reply != nil {
+		return nil, ErrAlreadyReplied
+	}
+	message, err := outbox.NewMessage(OutboxReviewReplied, ReviewRepliedPayload{
+		ReviewID:   review.ID,
+		ReviewerID: review.ReviewerID,
+		Replier:    review.Reviewee.Username,
+		Reply:      reply,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := service.Repo.SaveReply(review.ID, reply, time.Now(), message); err != nil {
+		return nil, err
+	}
+	return service.GetByID(review.ID)
+}
+
+// Vote saves whether the user found the review helpful. Users cannot vote on reviews written by
+// or about them, voting again replaces the previous vote.
+func (service *ReviewService) Vote(userID, reviewID uint, in *VoteDTO) (*RetrieveReviewDTO, error) {
+	review, err := service.getReview(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.ReviewerID == userID || review.RevieweeID == userID {
+		return nil, ErrCannotVoteOwnReview
+	}
+	vote := &models.ReviewVote{
+		ReviewID:  review.ID,
+		UserID:    userID,
+		Helpful:   *in.Helpful,
+		CreatedAt: time.Now(),
+	}
+	if err := service.Repo.SaveVote(vote); err != nil {
+		return nil, err
+	}
+	return service.GetByID(review.ID)
+}
+
+func (service *ReviewService) DeleteVote(userID, reviewID uint) error {
+	return service.Repo.DeleteVote(reviewID, userID)
+}
+
+func (service *ReviewService) getReview(reviewID uint) (*models.Review, error) {
+	review, err := service.Repo.GetByID(reviewID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNoReviewFound
+	}
+	return review, err
 }
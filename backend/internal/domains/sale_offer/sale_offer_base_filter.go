@@ -2,13 +2,18 @@ package sale_offer
 
 import (
 	"slices"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/susek555/BD2/car-dealer-api/internal/enums"
 	"github.com/susek555/BD2/car-dealer-api/pkg/pagination"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+const searchVectorExpression = "offer_search_vector(description, brand, model, vin, registration_number)"
+
 var OrderKeysMap = map[string]string{
 	"Price":           "price",
 	"Mileage":         "mileage",
@@ -76,6 +81,7 @@ func (of *BaseOfferFilter) ApplyOfferFilters(query *gorm.DB) (*gorm.DB, error) {
 		return nil, err
 	}
 	query = applyOfferTypeFilter(query, of.OfferType)
+	query = applySearchQueryFilter(query, of.Query)
 	query = applyInSliceFilter(query, "brand", of.Manufacturers)
 	query = applyInSliceFilter(query, "color", of.Colors)
 	query = applyInSliceFilter(query, "drive", of.Drives)
@@ -88,7 +94,7 @@ func (of *BaseOfferFilter) ApplyOfferFilters(query *gorm.DB) (*gorm.DB, error) {
 	query = applyInRangeFilter(query, "engine_capacity", of.EngineCapacityRange)
 	query = applyDateInRangeFilter(query, "registration_date", of.CarRegistrationDateRange)
 	query = applyDateInRangeFilter(query, "date_of_issue", of.OfferCreationDateRange)
-	query = applyOrderFilter(query, of.OrderKey, of.IsOrderDesc)
+	query = applyOrderFilter(query, of.OrderKey, of.IsOrderDesc, of.Query)
 	return query, nil
 }
 
@@ -110,6 +116,29 @@ func applyOfferTypeFilter(query *gorm.DB, offerType *OfferType) *gorm.DB {
 	}
 }
 
+func applySearchQueryFilter(query *gorm.DB, searchQuery *string) *gorm.DB {
+	tsQuery := buildPrefixTsQuery(searchQuery)
+	if tsQuery == "" {
+		return query
+	}
+	return query.Where(searchVectorExpression+" @@ to_tsquery('simple', immutable_unaccent(?))", tsQuery)
+}
+
+// buildPrefixTsQuery turns free text typed by the user into a tsquery in which every word
+// has to match (as a prefix) - only letters and digits are kept, so the result is always valid tsquery syntax.
+func buildPrefixTsQuery(searchQuery *string) string {
+	if searchQuery == nil {
+		return ""
+	}
+	words := strings.FieldsFunc(strings.ToLower(*searchQuery), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
 func applyInSliceFilter[T any](query *gorm.DB, column string, values *[]T) *gorm.DB {
 	if values != nil && len(*values) > 0 {
 		query = query.Where(column+" IN ?", *values)
@@ -138,7 +167,7 @@ func applyDateInRangeFilter(query *gorm.DB, column string, minmax *MinMax[string
 	return query
 }
 
-func applyOrderFilter(query *gorm.DB, orderKey *string, isOrderDesc *bool) *gorm.DB {
+func applyOrderFilter(query *gorm.DB, orderKey *string, isOrderDesc *bool, searchQuery *string) *gorm.DB {
 	var orderDirection string
 	if isOrderDesc == nil || *isOrderDesc {
 		orderDirection = "DESC"
@@ -148,9 +177,21 @@ func applyOrderFilter(query *gorm.DB, orderKey *string, isOrderDesc *bool) *gorm
 	if orderKey != nil {
 		return query.Order(OrderKeysMap[*orderKey] + " " + orderDirection + ", margin " + orderDirection)
 	}
+	if tsQuery := buildPrefixTsQuery(searchQuery); tsQuery != "" {
+		query = query.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "ts_rank(" + searchVectorExpression + ", to_tsquery('simple', immutable_unaccent(?))) DESC",
+			Vars:               []any{tsQuery},
+			WithoutParentheses: true,
+		}})
+	}
 	return query.Order("margin " + orderDirection)
 }
 
+// Validate checks the filter without running it, e.g. before it is stored for later use.
+func (of *BaseOfferFilter) Validate() error {
+	return of.validateParams()
+}
+
 func (of *BaseOfferFilter) validateParams() error {
 	validators := []func() error{of.validateEnums, of.validateRanges, of.validateDates, of.validateOrderKey}
 	for _, validate := range validators {
@@ -26,6 +26,7 @@ type CreateSaleOfferDTO struct {
 	Drive              enums.Drive        `json:"drive" validate:"required"`
 	ManufacturerName   string             `json:"manufacturer" validate:"required"`
 	ModelName          string             `json:"model" validate:"required"`
+	BlurPlates         bool               `json:"blur_plates"`
 }
 
 type UpdateSaleOfferDTO struct {
@@ -49,6 +50,7 @@ type UpdateSaleOfferDTO struct {
 	Drive              *enums.Drive        `json:"drive"`
 	ManufacturerName   *string             `json:"manufacturer"`
 	ModelName          *string             `json:"model"`
+	BlurPlates         *bool               `json:"blur_plates"`
 }
 
 type UserContext struct {
@@ -98,13 +100,40 @@ type RetrieveDetailedSaleOfferDTO struct {
 	Brand              string             `json:"brand"`
 	Model              string             `json:"model"`
 	ImagesUrls         []string           `json:"images_urls"`
+	Images             []ImageDTO         `json:"images"`
 	IsAuction          bool               `json:"is_auction"`
 	DateEnd            *string            `json:"date_end,omitempty"`
 	BuyNowPrice        *uint              `json:"buy_now_price,omitempty"`
 	IssueDate          *string            `json:"issue_date,omitempty"`
+	PriceHistory       []PriceChangeDTO   `json:"price_history"`
+	// set by Update when the price was lowered, so that followers can be notified
+	DroppedFromPrice *uint `json:"-"`
 	UserContext
 }
 
+type ImageDTO struct {
+	ID           uint    `json:"id"`
+	Url          string  `json:"url"`
+	MediumUrl    string  `json:"medium_url,omitempty"`
+	ThumbnailUrl string  `json:"thumbnail_url,omitempty"`
+	Caption      *string `json:"caption,omitempty"`
+	IsCover      bool    `json:"is_cover"`
+}
+
+type PriceChangeDTO struct {
+	OldPrice  uint   `json:"old_price"`
+	NewPrice  uint   `json:"new_price"`
+	ChangedAt string `json:"changed_at"`
+}
+
+type OfferEventDTO struct {
+	Kind      enums.OfferEventKind `json:"kind"`
+	OldValue  *string              `json:"old_value,omitempty"`
+	NewValue  *string              `json:"new_value,omitempty"`
+	ActorID   *uint                `json:"actor_id,omitempty"`
+	CreatedAt string               `json:"created_at"`
+}
+
 func (dto *RetrieveDetailedSaleOfferDTO) GetBrand() string {
 	return dto.Brand
 }
@@ -29,6 +29,9 @@ var (
 	ErrOfferNotPublished            = errors.New("offer is not published - cannot buy it")
 	ErrOfferIsAuction               = errors.New("offer is an auction - cannot buy it directly, use bids instead")
 	ErrOfferHasBids                 = errors.New("offer already has some bids - it cannot be updated/deleted")
+	ErrOfferNotExpired              = errors.New("offer is not expired - only expired offers can be renewed")
+	ErrInvalidListingPolicy         = errors.New("invalid listing policy - lifetime must be positive and longer than the reminder")
+	ErrOfferStatusChanged           = errors.New("offer has changed its status in the meantime - reload it and try again")
 )
 
 var ErrorMap = map[error]int{
@@ -50,4 +53,6 @@ var ErrorMap = map[error]int{
 	ErrOfferAlreadySold:             http.StatusConflict,
 	ErrOfferNotPublished:            http.StatusBadRequest,
 	ErrOfferIsAuction:               http.StatusBadRequest,
+	ErrOfferNotExpired:              http.StatusBadRequest,
+	ErrOfferStatusChanged:           http.StatusConflict,
 }
@@ -0,0 +1,66 @@
+package sale_offer
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+)
+
+func NewStatusChangedEvent(offerID uint, actorID *uint, from, to enums.Status) models.OfferEvent {
+	return newOfferEvent(offerID, actorID, enums.STATUS_CHANGED, string(from), string(to))
+}
+
+func NewPriceChangedEvent(offerID uint, actorID *uint, from, to uint) models.OfferEvent {
+	return newOfferEvent(offerID, actorID, enums.PRICE_CHANGED, strconv.FormatUint(uint64(from), 10), strconv.FormatUint(uint64(to), 10))
+}
+
+func NewDescriptionChangedEvent(offerID uint, actorID *uint, from, to string) models.OfferEvent {
+	return newOfferEvent(offerID, actorID, enums.DESCRIPTION_CHANGED, from, to)
+}
+
+func newOfferEvent(offerID uint, actorID *uint, kind enums.OfferEventKind, from, to string) models.OfferEvent {
+	return models.OfferEvent{
+		OfferID:   offerID,
+		ActorID:   actorID,
+		Kind:      kind,
+		OldValue:  &from,
+		NewValue:  &to,
+		CreatedAt: time.Now(),
+	}
+}
+
+// collectUpdateEvents compares the offer before and after the update made by the user.
+func collectUpdateEvents(before *models.SaleOffer, after *models.SaleOffer, userID uint) []models.OfferEvent {
+	var events []models.OfferEvent
+	// prices of auctions are driven by bids, only changes made by the seller are recorded
+	if before.Price != after.Price && !after.IsAuction {
+		events = append(events, NewPriceChangedEvent(after.ID, &userID, before.Price, after.Price))
+	}
+	if before.Description != after.Description {
+		events = append(events, NewDescriptionChangedEvent(after.ID, &userID, before.Description, after.Description))
+	}
+	if before.Status != after.Status {
+		events = append(events, NewStatusChangedEvent(after.ID, &userID, before.Status, after.Status))
+	}
+	return events
+}
+
+// isPublicEvent tells whether the event can be shown to users other than the owner - price changes
+// and the offer entering or leaving the market. Drafts and description edits stay private.
+func isPublicEvent(event *models.OfferEvent) bool {
+	switch event.Kind {
+	case enums.PRICE_CHANGED:
+		return true
+	case enums.STATUS_CHANGED:
+		if event.NewValue == nil {
+			return false
+		}
+		switch enums.Status(*event.NewValue) {
+		case enums.PUBLISHED, enums.SOLD, enums.EXPIRED:
+			return true
+		}
+	}
+	return false
+}
@@ -9,21 +9,34 @@ import (
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/auth"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/notification"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/ws"
-	"github.com/susek555/BD2/car-dealer-api/internal/models"
 	"github.com/susek555/BD2/car-dealer-api/pkg/custom_errors"
 )
 
+//go:generate mockery --name=PublishedOfferMatcherInterface --output=../../test/mocks --case=snake --with-expecter
+
+type PublishedOfferMatcherInterface interface {
+	NotifyMatchingSearches(offer notification.SaleOfferInterface) error
+}
+
+//go:generate mockery --name=PriceDropNotifierInterface --output=../../test/mocks --case=snake --with-expecter
+
+type PriceDropNotifierInterface interface {
+	NotifyPriceDrop(offer notification.SaleOfferInterface, sellerID uint, oldPrice uint) error
+}
+
 type Handler struct {
-	service             SaleOfferServiceInterface
-	hub                 ws.HubInterface
-	notificationService notification.NotificationServiceInterface
+	service           SaleOfferServiceInterface
+	hub               ws.HubInterface
+	searchMatcher     PublishedOfferMatcherInterface
+	priceDropNotifier PriceDropNotifierInterface
 }
 
-func NewHandler(s SaleOfferServiceInterface, hub ws.HubInterface, notificationService notification.NotificationServiceInterface) *Handler {
+func NewHandler(s SaleOfferServiceInterface, hub ws.HubInterface, searchMatcher PublishedOfferMatcherInterface, priceDropNotifier PriceDropNotifierInterface) *Handler {
 	return &Handler{
-		service:             s,
-		hub:                 hub,
-		notificationService: notificationService,
+		service:           s,
+		hub:               hub,
+		searchMatcher:     searchMatcher,
+		priceDropNotifier: priceDropNotifier,
 	}
 }
 
@@ -101,6 +114,11 @@ func (h *Handler) UpdateSaleOffer(c *gin.Context) {
 		return
 	}
 	c.JSON(http.StatusOK, retrieveDTO)
+	if retrieveDTO.DroppedFromPrice != nil {
+		if err := h.priceDropNotifier.NotifyPriceDrop(retrieveDTO, id, *retrieveDTO.DroppedFromPrice); err != nil {
+			log.Printf("Error notifying about price drop of offer ID %d: %v", retrieveDTO.ID, err)
+		}
+	}
 }
 
 func (h *Handler) PublishSaleOffer(c *gin.Context) {
@@ -116,6 +134,44 @@ func (h *Handler) PublishSaleOffer(c *gin.Context) {
 		return
 	}
 	c.JSON(http.StatusOK, retrieveDTO)
+	// every saved search is evaluated against the offer, which is too slow to keep the seller waiting
+	go h.notifyMatchingSearches(retrieveDTO)
+}
+
+func (h *Handler) notifyMatchingSearches(offer *RetrieveDetailedSaleOfferDTO) {
+	if err := h.searchMatcher.NotifyMatchingSearches(offer); err != nil {
+		log.Printf("Error notifying saved searches about offer ID %d: %v", offer.ID, err)
+	}
+}
+
+// RenewSaleOffer godoc
+//
+//	@Summary		Renew an expired sale offer
+//	@Description	Publishes an expired regular (non-auction) offer again for another listing lifetime. Only the owner can renew the offer.
+//	@Tags			sale-offer
+//	@Produce		json
+//	@Param			id	path		uint							true	"Sale offer ID"
+//	@Success		200	{object}	RetrieveDetailedSaleOfferDTO	"Renewed sale offer"
+//	@Failure		400	{object}	custom_errors.HTTPError			"Offer is not expired or is an auction"
+//	@Failure		401	{object}	custom_errors.HTTPError			"Unauthorized - user must be logged in"
+//	@Failure		403	{object}	custom_errors.HTTPError			"Offer does not belong to the user"
+//	@Failure		404	{object}	custom_errors.HTTPError			"Sale offer not found"
+//	@Failure		500	{object}	custom_errors.HTTPError			"Internal server error"
+//	@Router			/sale-offer/renew/{id} [put]
+//	@Security		Bearer
+func (h *Handler) RenewSaleOffer(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	retrieveDTO, err := h.service.Renew(uint(id), userID.(uint))
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	c.JSON(http.StatusOK, retrieveDTO)
 }
 
 // Buy godoc
@@ -150,17 +206,6 @@ func (h *Handler) Buy(c *gin.Context) {
 		return
 	}
 	c.JSON(http.StatusOK, offer)
-	notification := &models.Notification{
-		OfferID: uint(offerID),
-	}
-	err = h.notificationService.CreateBuyNotification(notification, strconv.FormatUint(uint64(userID), 10), offer)
-	if err != nil {
-		log.Printf("Error creating buy notification for offer ID %d: %v", offerID, err)
-		return
-	}
-	h.hub.SaveNotificationForClients(strconv.FormatUint(offerID, 10), userID, notification)
-	h.hub.SendFourLatestNotificationsToClients(strconv.FormatUint(offerID, 10), strconv.FormatUint(uint64(userID), 10))
-	h.hub.RemoveRoom(strconv.FormatUint(offerID, 10))
 }
 
 // DeleteSaleOffer godoc
@@ -222,6 +267,33 @@ func (h *Handler) GetDetailedSaleOfferByID(c *gin.Context) {
 	c.JSON(http.StatusOK, offerDTO)
 }
 
+// GetSaleOfferHistory godoc
+//
+//	@Summary		Get sale offer history
+//	@Description	Returns the timeline of changes made to the sale offer - price edits, status transitions and description edits.
+//	@Description	The owner of the offer sees all the events, other users only see price changes and the offer being published, sold or expired.
+//	@Tags			sale-offer
+//	@Produce		json
+//	@Param			id	path		uint					true	"Sale offer ID"
+//	@Success		200	{array}		OfferEventDTO			"Offer history"
+//	@Failure		400	{object}	custom_errors.HTTPError	"Invalid input data"
+//	@Failure		404	{object}	custom_errors.HTTPError	"Sale offer not found"
+//	@Failure		500	{object}	custom_errors.HTTPError	"Internal server error"
+//	@Router			/sale-offer/id/{id}/history [get]
+func (h *Handler) GetSaleOfferHistory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	history, err := h.service.GetHistory(uint(id), getOptionalUserID(c))
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	c.JSON(http.StatusOK, history)
+}
+
 // GetFilteredSaleOffers godoc
 //
 //	@Summary		Get filtered sale offers
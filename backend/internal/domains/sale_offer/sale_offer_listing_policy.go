@@ -0,0 +1,46 @@
+package sale_offer
+
+import (
+	"strings"
+	"time"
+)
+
+// ListingPolicy describes how long a regular (non-auction) offer stays published and how long before
+// expiring its owner is reminded about it.
+type ListingPolicy struct {
+	Lifetime       time.Duration
+	ReminderBefore time.Duration
+}
+
+var DefaultListingPolicy = ListingPolicy{
+	Lifetime:       30 * 24 * time.Hour,
+	ReminderBefore: 3 * 24 * time.Hour,
+}
+
+// ParseListingPolicy parses the lifetime and the reminder as durations (e.g. "720h"). Empty values fall back to the defaults.
+func ParseListingPolicy(lifetime, reminderBefore string) (ListingPolicy, error) {
+	policy := DefaultListingPolicy
+	if strings.TrimSpace(lifetime) != "" {
+		value, err := time.ParseDuration(strings.TrimSpace(lifetime))
+		if err != nil || value <= 0 {
+			return ListingPolicy{}, ErrInvalidListingPolicy
+		}
+		policy.Lifetime = value
+	}
+	if strings.TrimSpace(reminderBefore) != "" {
+		value, err := time.ParseDuration(strings.TrimSpace(reminderBefore))
+		if err != nil || value < 0 {
+			return ListingPolicy{}, ErrInvalidListingPolicy
+		}
+		policy.ReminderBefore = value
+	}
+	if policy.ReminderBefore >= policy.Lifetime {
+		return ListingPolicy{}, ErrInvalidListingPolicy
+	}
+	return policy, nil
+}
+
+// ExpiresAt returns when an offer published at publishedAt expires.
+func (p ListingPolicy) ExpiresAt(publishedAt time.Time) time.Time {
+	return publishedAt.Add(p.Lifetime)
+}
@@ -1,6 +1,7 @@
 package sale_offer
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -70,6 +71,51 @@ func MapViewToDetailedDTO(offerView *views.SaleOfferView) *RetrieveDetailedSaleO
 	return dto
 }
 
+func MapToImageDTO(image *models.Image) *ImageDTO {
+	return &ImageDTO{
+		ID:           image.ID,
+		Url:          image.Url,
+		MediumUrl:    image.MediumUrl,
+		ThumbnailUrl: image.ThumbnailUrl,
+		Caption:      image.Caption,
+		IsCover:      image.IsCover,
+	}
+}
+
+// MapToPriceChangeDTO maps a PRICE_CHANGED event, whose values are the prices written as numbers.
+func MapToPriceChangeDTO(event *models.OfferEvent) *PriceChangeDTO {
+	return &PriceChangeDTO{
+		OldPrice:  parsePrice(event.OldValue),
+		NewPrice:  parsePrice(event.NewValue),
+		ChangedAt: event.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func parsePrice(value *string) uint {
+	if value == nil {
+		return 0
+	}
+	price, err := strconv.ParseUint(*value, 10, 0)
+	if err != nil {
+		return 0
+	}
+	return uint(price)
+}
+
+// MapToOfferEventDTO maps the event, revealing who made the change only to the owner of the offer.
+func MapToOfferEventDTO(event *models.OfferEvent, withActor bool) *OfferEventDTO {
+	dto := &OfferEventDTO{
+		Kind:      event.Kind,
+		OldValue:  event.OldValue,
+		NewValue:  event.NewValue,
+		CreatedAt: event.CreatedAt.Format(time.RFC3339),
+	}
+	if withActor {
+		dto.ActorID = event.ActorID
+	}
+	return dto
+}
+
 func (dto *CreateSaleOfferDTO) validateParams() error {
 	if !IsParamValid(dto.Color, enums.Colors) {
 		return ErrInvalidColor
@@ -0,0 +1,76 @@
+package sale_offer
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/notification"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/outbox"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/ws"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+)
+
+const (
+	OutboxOfferBought      = "offer_bought"
+	OutboxAuctionBoughtOut = "auction_bought_out"
+)
+
+type PurchasePayload struct {
+	OfferID uint `json:"offer_id"`
+	BuyerID uint `json:"buyer_id"`
+}
+
+// PurchaseNotifier lets everyone following an offer know that it was bought, either outright
+// or, in case of an auction, at its buy now price.
+type PurchaseNotifier struct {
+	notificationService notification.NotificationServiceInterface
+	hub                 ws.HubInterface
+	saleOfferService    SaleOfferServiceInterface
+}
+
+func NewPurchaseNotifier(notificationService notification.NotificationServiceInterface, hub ws.HubInterface, saleOfferService SaleOfferServiceInterface) *PurchaseNotifier {
+	return &PurchaseNotifier{
+		notificationService: notificationService,
+		hub:                 hub,
+		saleOfferService:    saleOfferService,
+	}
+}
+
+func (n *PurchaseNotifier) Register(dispatcher *outbox.Dispatcher) {
+	dispatcher.Register(OutboxOfferBought, n.DeliverOfferBought)
+	dispatcher.Register(OutboxAuctionBoughtOut, n.DeliverAuctionBoughtOut)
+}
+
+func (n *PurchaseNotifier) DeliverOfferBought(messageID uint, payload string) error {
+	return n.deliver(messageID, payload, n.notificationService.CreateBuyNotification)
+}
+
+func (n *PurchaseNotifier) DeliverAuctionBoughtOut(messageID uint, payload string) error {
+	return n.deliver(messageID, payload, n.notificationService.CreateBuyNowNotification)
+}
+
+func (n *PurchaseNotifier) deliver(messageID uint, payload string, create func(notification *models.Notification, buyerID string, offer notification.SaleOfferInterface) error) error {
+	var p PurchasePayload
+	if err := json.Unmarshal([]byte(payload), &p); err != nil {
+		return err
+	}
+	offerDTO, err := n.saleOfferService.GetDetailedByID(p.OfferID, nil)
+	if err != nil {
+		return err
+	}
+	notif := models.Notification{OfferID: &p.OfferID, OutboxMessageID: &messageID}
+	buyerID := strconv.FormatUint(uint64(p.BuyerID), 10)
+	if err := create(&notif, buyerID, offerDTO); err != nil {
+		return err
+	}
+	offerID := strconv.FormatUint(uint64(p.OfferID), 10)
+	if err := n.hub.SaveNotificationForClients(offerID, p.BuyerID, &notif); err != nil {
+		return err
+	}
+	n.hub.Broadcast(offerID, ws.NewNotificationEnvelope(&notif), buyerID)
+	n.hub.Broadcast(offerID, ws.NewOfferSoldEnvelope(p.OfferID, offerDTO.GetPrice()), "")
+	n.hub.SendFourLatestNotificationsToClients(offerID, buyerID)
+	// the offer is sold, there is nothing left to follow live
+	n.hub.RemoveRoom(offerID)
+	return nil
+}
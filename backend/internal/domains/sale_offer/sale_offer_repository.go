@@ -1,20 +1,28 @@
 package sale_offer
 
 import (
+	"time"
+
 	"github.com/susek555/BD2/car-dealer-api/internal/enums"
 	"github.com/susek555/BD2/car-dealer-api/internal/models"
 	"github.com/susek555/BD2/car-dealer-api/internal/views"
 	"github.com/susek555/BD2/car-dealer-api/pkg/pagination"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 //go:generate mockery --name=SaleOfferRepositoryInterface --output=../../test/mocks --case=snake --with-expecter
 type SaleOfferRepositoryInterface interface {
 	Create(offer *models.SaleOffer) error
 	Update(offer *models.SaleOffer) error
+	UpdateWithHistory(offer *models.SaleOffer, events []models.OfferEvent) error
 	UpdateStatus(offer *models.SaleOffer, status enums.Status) error
+	Purchase(id uint, buyerID uint, finalPrice func(offer *models.SaleOffer) (uint, error), message *models.OutboxMessage) (*models.SaleOffer, error)
 	GetByID(id uint) (*models.SaleOffer, error)
+	HasBids(id uint) (bool, error)
 	GetViewByID(id uint) (*views.SaleOfferView, error)
+	GetPriceHistory(id uint) ([]models.OfferEvent, error)
+	GetEvents(id uint) ([]models.OfferEvent, error)
 	GetFiltered(filter OfferFilterInterface, pagRequest *pagination.PaginationRequest) ([]views.SaleOfferView, *pagination.PaginationResponse, error)
 	GetAllActiveAuctions() ([]views.SaleOfferView, error)
 	Delete(id uint) error
@@ -32,13 +40,106 @@ func (r *SaleOfferRepository) Create(offer *models.SaleOffer) error {
 	return r.DB.Create(offer).Error
 }
 
+// Update saves the offer as long as its status is still the one it was read with.
 func (r *SaleOfferRepository) Update(offer *models.SaleOffer) error {
-	return r.DB.Session(&gorm.Session{FullSaveAssociations: true}).Save(offer).Error
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		return save(tx, offer, offer.Status)
+	})
+}
+
+// UpdateWithHistory saves the offer together with the audit events of the changes in the same transaction.
+func (r *SaleOfferRepository) UpdateWithHistory(offer *models.SaleOffer, events []models.OfferEvent) error {
+	return r.updateWithHistory(offer, offer.Status, events)
 }
 
+// UpdateStatus changes the status on behalf of the offer's owner and records the transition.
 func (r *SaleOfferRepository) UpdateStatus(offer *models.SaleOffer, status enums.Status) error {
+	if offer.Status == status {
+		return r.Update(offer)
+	}
+	previous := offer.Status
+	event := NewStatusChangedEvent(offer.ID, &offer.UserID, previous, status)
 	offer.Status = status
-	return r.Update(offer)
+	return r.updateWithHistory(offer, previous, []models.OfferEvent{event})
+}
+
+func (r *SaleOfferRepository) updateWithHistory(offer *models.SaleOffer, expected enums.Status, events []models.OfferEvent) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := save(tx, offer, expected); err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+		return tx.Create(&events).Error
+	})
+}
+
+// save writes the whole offer read earlier by the service. The row is locked first and the write is
+// refused when the offer has been sold, expired or unpublished in the meantime, or when an auction got
+// its first bid. Columns maintained outside of the owner's edits (hiding after a report, the expiry
+// reminder flag) are taken from the locked row instead of the stale copy.
+func save(tx *gorm.DB, offer *models.SaleOffer, expected enums.Status) error {
+	var current models.SaleOffer
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, offer.ID).Error; err != nil {
+		return err
+	}
+	if current.Status != expected {
+		return ErrOfferStatusChanged
+	}
+	if current.IsAuction {
+		var bids int64
+		if err := tx.Model(&models.Bid{}).Where("auction_id = ?", offer.ID).Count(&bids).Error; err != nil {
+			return err
+		}
+		if bids > 0 {
+			return ErrOfferHasBids
+		}
+	}
+	offer.HiddenAt = current.HiddenAt
+	if offer.Status == expected {
+		offer.ExpiryReminderSent = current.ExpiryReminderSent
+	}
+	return tx.Session(&gorm.Session{FullSaveAssociations: true}).Save(offer).Error
+}
+
+// Purchase sells the offer in a single transaction. The offer row is locked (SELECT ... FOR UPDATE)
+// before finalPrice validates it, so out of concurrent buyers only the first one sees it published.
+// The outbox message announcing the sale is saved in the same transaction.
+func (r *SaleOfferRepository) Purchase(id uint, buyerID uint, finalPrice func(offer *models.SaleOffer) (uint, error), message *models.OutboxMessage) (*models.SaleOffer, error) {
+	var offer models.SaleOffer
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&offer, id).Error; err != nil {
+			return err
+		}
+		if offer.IsAuction {
+			var auction models.Auction
+			if err := tx.First(&auction, "offer_id = ?", id).Error; err != nil {
+				return err
+			}
+			offer.Auction = &auction
+		}
+		price, err := finalPrice(&offer)
+		if err != nil {
+			return err
+		}
+		event := NewStatusChangedEvent(offer.ID, &buyerID, offer.Status, enums.SOLD)
+		if err := tx.Model(&offer).Updates(map[string]any{"status": enums.SOLD, "price": price}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&event).Error; err != nil {
+			return err
+		}
+		purchase := &models.Purchase{OfferID: offer.ID, BuyerID: buyerID, FinalPrice: price, IssueDate: time.Now()}
+		if err := tx.Create(purchase).Error; err != nil {
+			return err
+		}
+		return tx.Create(message).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &offer, nil
 }
 
 func (r *SaleOfferRepository) GetByID(id uint) (*models.SaleOffer, error) {
@@ -49,12 +150,33 @@ func (r *SaleOfferRepository) GetByID(id uint) (*models.SaleOffer, error) {
 	return &offer, err
 }
 
+// HasBids tells whether anyone bid on the auction yet - the price alone cannot tell,
+// as the first bid may be equal to the initial price.
+func (r *SaleOfferRepository) HasBids(id uint) (bool, error) {
+	var count int64
+	err := r.DB.Model(&models.Bid{}).Where("auction_id = ?", id).Count(&count).Error
+	return count > 0, err
+}
+
 func (r *SaleOfferRepository) GetViewByID(id uint) (*views.SaleOfferView, error) {
 	var offerView views.SaleOfferView
 	err := r.DB.Table("sale_offer_view").First(&offerView, id).Error
 	return &offerView, err
 }
 
+// GetPriceHistory returns the price changes made by the seller, taken from the offer's audit events.
+func (r *SaleOfferRepository) GetPriceHistory(id uint) ([]models.OfferEvent, error) {
+	var changes []models.OfferEvent
+	err := r.DB.Where("offer_id = ? AND kind = ?", id, enums.PRICE_CHANGED).Order("created_at, id").Find(&changes).Error
+	return changes, err
+}
+
+func (r *SaleOfferRepository) GetEvents(id uint) ([]models.OfferEvent, error) {
+	var events []models.OfferEvent
+	err := r.DB.Where("offer_id = ?", id).Order("created_at, id").Find(&events).Error
+	return events, err
+}
+
 func (r *SaleOfferRepository) GetByUserID(id uint, pagRequest *pagination.PaginationRequest) ([]views.SaleOfferView, *pagination.PaginationResponse, error) {
 	saleOffers, paginationResponse, err := pagination.PaginateResults[views.SaleOfferView](pagRequest, r.DB.Table("sale_offer_view").Where("user_id = ?", id))
 	if err != nil {
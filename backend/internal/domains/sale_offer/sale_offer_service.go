@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/manufacturer"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/outbox"
 	"github.com/susek555/BD2/car-dealer-api/internal/enums"
 	"github.com/susek555/BD2/car-dealer-api/internal/models"
 	"github.com/susek555/BD2/car-dealer-api/internal/views"
@@ -45,6 +46,7 @@ type SaleOfferManagerInterface interface {
 	Create(in *CreateSaleOfferDTO) (*RetrieveDetailedSaleOfferDTO, error)
 	Update(in *UpdateSaleOfferDTO, userID uint) (*RetrieveDetailedSaleOfferDTO, error)
 	Publish(id uint, userID uint) (*RetrieveDetailedSaleOfferDTO, error)
+	Renew(id uint, userID uint) (*RetrieveDetailedSaleOfferDTO, error)
 	Buy(id uint, userID uint) (*RetrieveDetailedSaleOfferDTO, error)
 	Delete(id uint, userID uint) error
 }
@@ -52,6 +54,7 @@ type SaleOfferManagerInterface interface {
 type SaleOfferRetrieverInterface interface {
 	GetByID(id uint, userID *uint) (*RetrieveSaleOfferDTO, error)
 	GetDetailedByID(id uint, userID *uint) (*RetrieveDetailedSaleOfferDTO, error)
+	GetHistory(id uint, userID *uint) ([]OfferEventDTO, error)
 	GetFiltered(filter *PublishedOffersOnlyFilter, pagRequest *pagination.PaginationRequest) (*RetrieveOffersWithPagination, error)
 	GetUsersOffers(filter *UsersOffersOnlyFilter, pagRequest *pagination.PaginationRequest) (*RetrieveOffersWithPagination, error)
 	GetLikedOffers(filter *LikedOffersOnlyFilter, pagRequest *pagination.PaginationRequest) (*RetrieveOffersWithPagination, error)
@@ -72,6 +75,7 @@ type SaleOfferService struct {
 	imageRemover    ImageRemoverInterface
 	accessEvaluator OfferAccessEvaluatorInterface
 	purchaseRepo    PurchaseRepositoryInterface
+	listingPolicy   ListingPolicy
 }
 
 func NewSaleOfferService(
@@ -82,6 +86,7 @@ func NewSaleOfferService(
 	imageRemover ImageRemoverInterface,
 	accessEvaluator OfferAccessEvaluatorInterface,
 	purchaseRepo PurchaseRepositoryInterface,
+	listingPolicy ListingPolicy,
 ) SaleOfferServiceInterface {
 	return &SaleOfferService{
 		saleOfferRepo:   saleOfferRepository,
@@ -91,6 +96,7 @@ func NewSaleOfferService(
 		imageRemover:    imageRemover,
 		accessEvaluator: accessEvaluator,
 		purchaseRepo:    purchaseRepo,
+		listingPolicy:   listingPolicy,
 	}
 }
 
@@ -106,14 +112,28 @@ func (s *SaleOfferService) Create(in *CreateSaleOfferDTO) (*RetrieveDetailedSale
 }
 
 func (s *SaleOfferService) Update(in *UpdateSaleOfferDTO, userID uint) (*RetrieveDetailedSaleOfferDTO, error) {
-	updatedOffer, err := s.PrepareForUpdateSaleOffer(in, userID)
+	updatedOffer, previous, err := s.prepareForUpdate(in, userID)
 	if err != nil {
 		return nil, err
 	}
-	if err = s.saleOfferRepo.Update(updatedOffer); err != nil {
+	oldPrice := previous.Price
+	events := collectUpdateEvents(previous, updatedOffer, userID)
+	if len(events) == 0 {
+		err = s.saleOfferRepo.Update(updatedOffer)
+	} else {
+		err = s.saleOfferRepo.UpdateWithHistory(updatedOffer, events)
+	}
+	if err != nil {
 		return nil, err
 	}
-	return s.GetDetailedByID(updatedOffer.ID, &updatedOffer.UserID)
+	offerDTO, err := s.GetDetailedByID(updatedOffer.ID, &updatedOffer.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !updatedOffer.IsAuction && updatedOffer.Price < oldPrice {
+		offerDTO.DroppedFromPrice = &oldPrice
+	}
+	return offerDTO, nil
 }
 
 func (s *SaleOfferService) Publish(id uint, userID uint) (*RetrieveDetailedSaleOfferDTO, error) {
@@ -127,25 +147,50 @@ func (s *SaleOfferService) Publish(id uint, userID uint) (*RetrieveDetailedSaleO
 	if offer.Status != enums.READY {
 		return nil, ErrOfferNotReadyToPublish
 	}
+	s.setExpiry(offer)
 	if err := s.saleOfferRepo.UpdateStatus(offer, enums.PUBLISHED); err != nil {
 		return nil, err
 	}
 	return s.GetDetailedByID(id, &userID)
 }
 
-func (s *SaleOfferService) Buy(id uint, userID uint) (*RetrieveDetailedSaleOfferDTO, error) {
-	offer, err := s.PrepareForBuySaleOffer(id, userID)
+// Renew publishes an expired regular offer again for another listing lifetime.
+func (s *SaleOfferService) Renew(id uint, userID uint) (*RetrieveDetailedSaleOfferDTO, error) {
+	offer, err := s.saleOfferRepo.GetByID(id)
 	if err != nil {
 		return nil, err
 	}
+	if !offer.BelongsToUser(userID) {
+		return nil, ErrOfferNotOwned
+	}
 	if offer.IsAuction {
 		return nil, ErrOfferIsAuction
 	}
-	if err := s.saleOfferRepo.UpdateStatus(offer, enums.SOLD); err != nil {
+	if offer.Status != enums.EXPIRED {
+		return nil, ErrOfferNotExpired
+	}
+	s.setExpiry(offer)
+	if err := s.saleOfferRepo.UpdateStatus(offer, enums.PUBLISHED); err != nil {
 		return nil, err
 	}
-	purchaseModel := &models.Purchase{OfferID: offer.ID, BuyerID: userID, FinalPrice: offer.Price, IssueDate: time.Now()}
-	if err := s.purchaseRepo.Create(purchaseModel); err != nil {
+	return s.GetDetailedByID(id, &userID)
+}
+
+func (s *SaleOfferService) Buy(id uint, userID uint) (*RetrieveDetailedSaleOfferDTO, error) {
+	message, err := outbox.NewMessage(OutboxOfferBought, PurchasePayload{OfferID: id, BuyerID: userID})
+	if err != nil {
+		return nil, err
+	}
+	offer, err := s.saleOfferRepo.Purchase(id, userID, func(offer *models.SaleOffer) (uint, error) {
+		if err := ValidatePurchase(offer, userID); err != nil {
+			return 0, err
+		}
+		if offer.IsAuction {
+			return 0, ErrOfferIsAuction
+		}
+		return offer.Price, nil
+	}, message)
+	if err != nil {
 		return nil, err
 	}
 	return s.GetDetailedByID(offer.ID, &userID)
@@ -181,6 +226,27 @@ func (s *SaleOfferService) GetDetailedByID(id uint, userID *uint) (*RetrieveDeta
 	return s.mapOfferWithAdditionalFieldsDetailed(offer, userID)
 }
 
+// GetHistory returns the audit trail of the offer. Users other than the owner only see the public events.
+func (s *SaleOfferService) GetHistory(id uint, userID *uint) ([]OfferEventDTO, error) {
+	offer, err := s.saleOfferRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.saleOfferRepo.GetEvents(id)
+	if err != nil {
+		return nil, err
+	}
+	isOwner := userID != nil && offer.BelongsToUser(*userID)
+	history := make([]OfferEventDTO, 0, len(events))
+	for _, event := range events {
+		if !isOwner && !isPublicEvent(&event) {
+			continue
+		}
+		history = append(history, *MapToOfferEventDTO(&event, isOwner))
+	}
+	return history, nil
+}
+
 func (s *SaleOfferService) GetFiltered(filter *PublishedOffersOnlyFilter, pagRequest *pagination.PaginationRequest) (*RetrieveOffersWithPagination, error) {
 	return s.getOffersWithFilter(filter, filter.UserID, pagRequest)
 }
@@ -212,23 +278,30 @@ func (s *SaleOfferService) PrepareForCreateSaleOffer(in *CreateSaleOfferDTO) (*m
 }
 
 func (s *SaleOfferService) PrepareForUpdateSaleOffer(in *UpdateSaleOfferDTO, userID uint) (*models.SaleOffer, error) {
+	updatedOffer, _, err := s.prepareForUpdate(in, userID)
+	return updatedOffer, err
+}
+
+// prepareForUpdate applies the changes to the stored offer and also returns a copy of the offer from before the update.
+func (s *SaleOfferService) prepareForUpdate(in *UpdateSaleOfferDTO, userID uint) (*models.SaleOffer, *models.SaleOffer, error) {
 	offer, err := s.saleOfferRepo.GetByID(in.ID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if err := s.accessEvaluator.CanBeModifiedByUser(offer, &userID); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	previous := *offer
 	modelID, err := s.determineNewModelID(offer, in)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	updatedOffer, err := in.UpdateOfferFromDTO(offer)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	updatedOffer.Car.ModelID = modelID
-	return updatedOffer, nil
+	return updatedOffer, &previous, nil
 }
 
 func (s *SaleOfferService) PrepareForBuySaleOffer(id uint, userID uint) (*models.SaleOffer, error) {
@@ -236,13 +309,32 @@ func (s *SaleOfferService) PrepareForBuySaleOffer(id uint, userID uint) (*models
 	if err != nil {
 		return nil, err
 	}
+	if err := ValidatePurchase(offer, userID); err != nil {
+		return nil, err
+	}
+	return offer, nil
+}
+
+// ValidatePurchase checks whether the user can buy the offer. To be race-free it has to be called
+// on an offer locked for the purchase.
+func ValidatePurchase(offer *models.SaleOffer, userID uint) error {
 	if offer.BelongsToUser(userID) {
-		return nil, ErrOfferOwnedByUser
+		return ErrOfferOwnedByUser
 	}
 	if offer.Status != enums.PUBLISHED {
-		return nil, ErrOfferNotPublished
+		return ErrOfferNotPublished
 	}
-	return offer, nil
+	return nil
+}
+
+// setExpiry starts a new listing lifetime for a regular offer that is about to be published.
+func (s *SaleOfferService) setExpiry(offer *models.SaleOffer) {
+	if offer.IsAuction {
+		return
+	}
+	expiresAt := s.listingPolicy.ExpiresAt(time.Now())
+	offer.ExpiresAt = &expiresAt
+	offer.ExpiryReminderSent = false
 }
 
 func (s *SaleOfferService) getModelID(manufacturerName, modelName string) (uint, error) {
@@ -293,12 +385,12 @@ func (s *SaleOfferService) mapOfferWithAdditionalFields(offer *views.SaleOfferVi
 		return nil, err
 	}
 	offerDTO.UserContext = *userContext
-	urls, err := s.getOfferImagesURLs(offer)
+	images, err := s.imageRetriever.GetByOfferID(offer.ID)
 	if err != nil {
 		return nil, err
 	}
-	if len(urls) > 0 {
-		offerDTO.MainURL = urls[0]
+	if cover := models.CoverImage(images); cover != nil {
+		offerDTO.MainURL = cover.ThumbnailOrOriginalUrl()
 	}
 	offerDTO.IssueDate = s.getIssueDate(offer, userID)
 	return offerDTO, nil
@@ -311,12 +403,18 @@ func (s *SaleOfferService) mapOfferWithAdditionalFieldsDetailed(offer *views.Sal
 		return nil, err
 	}
 	offerDTO.UserContext = *userContext
-	urls, err := s.getOfferImagesURLs(offer)
+	images, err := s.imageRetriever.GetByOfferID(offer.ID)
 	if err != nil {
 		return nil, err
 	}
-	offerDTO.ImagesUrls = urls
+	offerDTO.ImagesUrls = mapping.MapSliceToDTOs(images, func(m *models.Image) *string { return &m.Url })
+	offerDTO.Images = mapping.MapSliceToDTOs(images, MapToImageDTO)
 	offerDTO.IssueDate = s.getIssueDate(offer, userID)
+	priceHistory, err := s.saleOfferRepo.GetPriceHistory(offer.ID)
+	if err != nil {
+		return nil, err
+	}
+	offerDTO.PriceHistory = mapping.MapSliceToDTOs(priceHistory, MapToPriceChangeDTO)
 	return offerDTO, nil
 }
 
@@ -332,14 +430,6 @@ func (s *SaleOfferService) getUserContextFields(offer *views.SaleOfferView, user
 	return &UserContext{IsLiked: isLiked, CanModify: canModify}, nil
 }
 
-func (s *SaleOfferService) getOfferImagesURLs(offer *views.SaleOfferView) ([]string, error) {
-	images, err := s.imageRetriever.GetByOfferID(offer.ID)
-	if err != nil {
-		return nil, err
-	}
-	return mapping.MapSliceToDTOs(images, func(m *models.Image) *string { return &m.Url }), nil
-}
-
 func (s *SaleOfferService) getIssueDate(offer *views.SaleOfferView, userID *uint) *string {
 	if offer.Status == enums.SOLD && userID != nil {
 		purchase, _ := s.purchaseRepo.GetByID(offer.ID)
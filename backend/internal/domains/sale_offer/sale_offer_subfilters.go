@@ -28,6 +28,8 @@ func (f *PublishedOffersOnlyFilter) GetBase() *BaseOfferFilter {
 
 func applyPublishedOffersOnly(query *gorm.DB, userID *uint) *gorm.DB {
 	query = query.Where("sale_offer_view.status = ?", enums.PUBLISHED)
+	// offers reported by several users stay hidden until a moderator looks at them
+	query = query.Where("sale_offer_view.hidden_at IS NULL")
 	if userID != nil {
 		query = query.Where("sale_offer_view.user_id != ?", *userID)
 	}
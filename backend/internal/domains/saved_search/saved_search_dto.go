@@ -0,0 +1,21 @@
+package saved_search
+
+import "github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
+
+type CreateSavedSearchDTO struct {
+	Name   string                     `json:"name"`
+	Filter sale_offer.BaseOfferFilter `json:"filter"`
+}
+
+type UpdateSavedSearchDTO struct {
+	ID     uint                       `json:"id"`
+	Name   string                     `json:"name"`
+	Filter sale_offer.BaseOfferFilter `json:"filter"`
+}
+
+type RetrieveSavedSearchDTO struct {
+	ID        uint                       `json:"id"`
+	Name      string                     `json:"name"`
+	Filter    sale_offer.BaseOfferFilter `json:"filter"`
+	CreatedAt string                     `json:"created_at"`
+}
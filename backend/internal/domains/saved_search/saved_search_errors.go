@@ -0,0 +1,31 @@
+package saved_search
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSavedSearchNotOwned   = errors.New("saved search does not belong to logged in user")
+	ErrTooManySavedSearches  = errors.New("saved searches limit reached - delete one of them before saving another")
+	ErrInvalidSavedSearchDTO = errors.New("invalid saved search - name is required")
+)
+
+var ErrorMap = map[error]int{
+	ErrSavedSearchNotOwned:             http.StatusForbidden,
+	ErrTooManySavedSearches:            http.StatusConflict,
+	ErrInvalidSavedSearchDTO:           http.StatusBadRequest,
+	sale_offer.ErrInvalidColor:         http.StatusBadRequest,
+	sale_offer.ErrInvalidFuelType:      http.StatusBadRequest,
+	sale_offer.ErrInvalidTransmission:  http.StatusBadRequest,
+	sale_offer.ErrInvalidDrive:         http.StatusBadRequest,
+	sale_offer.ErrInvalidSaleOfferType: http.StatusBadRequest,
+	sale_offer.ErrInvalidRange:         http.StatusBadRequest,
+	sale_offer.ErrInvalidDateFormat:    http.StatusBadRequest,
+	sale_offer.ErrInvalidOrderKey:      http.StatusBadRequest,
+	sale_offer.ErrInvalidManufacturer:  http.StatusBadRequest,
+	gorm.ErrRecordNotFound:             http.StatusNotFound,
+}
@@ -0,0 +1,178 @@
+package saved_search
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/auth"
+	"github.com/susek555/BD2/car-dealer-api/pkg/custom_errors"
+)
+
+type Handler struct {
+	service SavedSearchServiceInterface
+}
+
+func NewHandler(service SavedSearchServiceInterface) *Handler {
+	return &Handler{service: service}
+}
+
+// CreateSavedSearch godoc
+//
+//	@Summary		Save a search
+//	@Description	Saves the filter under a name. Whenever a newly published offer matches it, the user gets a notification. The filter follows the same constraints as in /sale-offer/filtered. You have to be logged in to perform this operation.
+//	@Tags			saved-search
+//	@Accept			json
+//	@Produce		json
+//	@Param			search	body		CreateSavedSearchDTO	true	"Saved search form"
+//	@Success		201		{object}	RetrieveSavedSearchDTO	"Created - returns the saved search"
+//	@Failure		400		{object}	custom_errors.HTTPError	"Invalid input data"
+//	@Failure		401		{object}	custom_errors.HTTPError	"Unauthorized - user not logged in"
+//	@Failure		409		{object}	custom_errors.HTTPError	"Saved searches limit reached"
+//	@Failure		500		{object}	custom_errors.HTTPError	"Internal server error"
+//	@Router			/saved-search [post]
+//	@Security		Bearer
+func (h *Handler) CreateSavedSearch(c *gin.Context) {
+	userID, err := auth.GetUserID(c)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	var in CreateSavedSearchDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	search, err := h.service.Create(userID, &in)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	c.JSON(http.StatusCreated, search)
+}
+
+// UpdateSavedSearch godoc
+//
+//	@Summary		Update a saved search
+//	@Description	Changes the name and the filter of a saved search. You have to be logged in and be the owner of the search.
+//	@Tags			saved-search
+//	@Accept			json
+//	@Produce		json
+//	@Param			search	body		UpdateSavedSearchDTO	true	"Saved search form"
+//	@Success		200		{object}	RetrieveSavedSearchDTO	"Updated - returns the saved search"
+//	@Failure		400		{object}	custom_errors.HTTPError	"Invalid input data"
+//	@Failure		401		{object}	custom_errors.HTTPError	"Unauthorized - user not logged in"
+//	@Failure		403		{object}	custom_errors.HTTPError	"Forbidden - search belongs to another user"
+//	@Failure		404		{object}	custom_errors.HTTPError	"Saved search not found"
+//	@Failure		500		{object}	custom_errors.HTTPError	"Internal server error"
+//	@Router			/saved-search [put]
+//	@Security		Bearer
+func (h *Handler) UpdateSavedSearch(c *gin.Context) {
+	userID, err := auth.GetUserID(c)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	var in UpdateSavedSearchDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	search, err := h.service.Update(userID, &in)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	c.JSON(http.StatusOK, search)
+}
+
+// GetMySavedSearches godoc
+//
+//	@Summary		List my saved searches
+//	@Description	Returns all searches saved by the logged-in user, the newest first.
+//	@Tags			saved-search
+//	@Produce		json
+//	@Success		200	{array}		RetrieveSavedSearchDTO	"List of saved searches"
+//	@Failure		401	{object}	custom_errors.HTTPError	"Unauthorized - user not logged in"
+//	@Failure		500	{object}	custom_errors.HTTPError	"Internal server error"
+//	@Router			/saved-search [get]
+//	@Security		Bearer
+func (h *Handler) GetMySavedSearches(c *gin.Context) {
+	userID, err := auth.GetUserID(c)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	searches, err := h.service.GetByUserID(userID)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	c.JSON(http.StatusOK, searches)
+}
+
+// GetSavedSearchByID godoc
+//
+//	@Summary		Get saved search by ID
+//	@Description	Returns the saved search with given ID. You have to be logged in and be the owner of the search.
+//	@Tags			saved-search
+//	@Produce		json
+//	@Param			id	path		uint					true	"Saved search ID"
+//	@Success		200	{object}	RetrieveSavedSearchDTO	"Saved search"
+//	@Failure		400	{object}	custom_errors.HTTPError	"Invalid input data"
+//	@Failure		401	{object}	custom_errors.HTTPError	"Unauthorized - user not logged in"
+//	@Failure		403	{object}	custom_errors.HTTPError	"Forbidden - search belongs to another user"
+//	@Failure		404	{object}	custom_errors.HTTPError	"Saved search not found"
+//	@Failure		500	{object}	custom_errors.HTTPError	"Internal server error"
+//	@Router			/saved-search/{id} [get]
+//	@Security		Bearer
+func (h *Handler) GetSavedSearchByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	userID, err := auth.GetUserID(c)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	search, err := h.service.GetByID(userID, uint(id))
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	c.JSON(http.StatusOK, search)
+}
+
+// DeleteSavedSearch godoc
+//
+//	@Summary		Delete a saved search
+//	@Description	Deletes the saved search, so that no more notifications are sent for it. You have to be logged in and be the owner of the search.
+//	@Tags			saved-search
+//	@Param			id	path	uint	true	"Saved search ID"
+//	@Success		204	"No content"
+//	@Failure		400	{object}	custom_errors.HTTPError	"Invalid input data"
+//	@Failure		401	{object}	custom_errors.HTTPError	"Unauthorized - user not logged in"
+//	@Failure		403	{object}	custom_errors.HTTPError	"Forbidden - search belongs to another user"
+//	@Failure		404	{object}	custom_errors.HTTPError	"Saved search not found"
+//	@Failure		500	{object}	custom_errors.HTTPError	"Internal server error"
+//	@Router			/saved-search/{id} [delete]
+//	@Security		Bearer
+func (h *Handler) DeleteSavedSearch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	userID, err := auth.GetUserID(c)
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	if err := h.service.Delete(userID, uint(id)); err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
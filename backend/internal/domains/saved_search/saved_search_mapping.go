@@ -0,0 +1,74 @@
+package saved_search
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+)
+
+func (dto *CreateSavedSearchDTO) MapToObject(userID uint) (*models.SavedSearch, error) {
+	if strings.TrimSpace(dto.Name) == "" {
+		return nil, ErrInvalidSavedSearchDTO
+	}
+	filter, err := marshalFilter(dto.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return &models.SavedSearch{
+		UserID:    userID,
+		Name:      dto.Name,
+		Filter:    filter,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func (dto *UpdateSavedSearchDTO) MapToObject(userID uint) (*models.SavedSearch, error) {
+	if strings.TrimSpace(dto.Name) == "" {
+		return nil, ErrInvalidSavedSearchDTO
+	}
+	filter, err := marshalFilter(dto.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return &models.SavedSearch{
+		ID:     dto.ID,
+		UserID: userID,
+		Name:   dto.Name,
+		Filter: filter,
+	}, nil
+}
+
+func MapToDTO(s *models.SavedSearch) *RetrieveSavedSearchDTO {
+	dto := RetrieveSavedSearchDTO{
+		ID:        s.ID,
+		Name:      s.Name,
+		CreatedAt: s.CreatedAt.Format(time.RFC3339),
+	}
+	if filter, err := UnmarshalFilter(s.Filter); err == nil {
+		dto.Filter = *filter
+	}
+	return &dto
+}
+
+// UnmarshalFilter restores a stored filter. Its constraints are the defaults, manufacturers have to be
+// filled in before the filter is applied.
+func UnmarshalFilter(raw string) (*sale_offer.BaseOfferFilter, error) {
+	filter := sale_offer.NewOfferFilter()
+	if err := json.Unmarshal([]byte(raw), filter); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+func marshalFilter(filter sale_offer.BaseOfferFilter) (string, error) {
+	// the owner is set when the search is matched, so it is never stored
+	filter.UserID = nil
+	data, err := json.Marshal(filter)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
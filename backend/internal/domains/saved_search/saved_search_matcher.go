@@ -0,0 +1,97 @@
+package saved_search
+
+import (
+	"log"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/notification"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/ws"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+)
+
+// Matcher lets owners of saved searches know about newly published offers matching them.
+type Matcher struct {
+	repo                SavedSearchRepositoryInterface
+	manRetriever        ManufacturerRetrieverInterface
+	notificationService notification.NotificationServiceInterface
+	userNotifier        *notification.UserNotifier
+}
+
+func NewMatcher(repo SavedSearchRepositoryInterface, manRetriever ManufacturerRetrieverInterface, notificationService notification.NotificationServiceInterface, hub ws.HubInterface) *Matcher {
+	return &Matcher{
+		repo:                repo,
+		manRetriever:        manRetriever,
+		notificationService: notificationService,
+		userNotifier:        notification.NewUserNotifier(notificationService, hub),
+	}
+}
+
+// NotifyMatchingSearches evaluates every saved search against the published offer, all of them in
+// batched queries. Each user is notified at most once per offer, even if several of their searches
+// match it. Failing to notify one user is only logged, so that it does not cost the others their notification.
+func (m *Matcher) NotifyMatchingSearches(offer notification.SaleOfferInterface) error {
+	searches, err := m.repo.GetAll()
+	if err != nil {
+		return err
+	}
+	manufacturers, err := getManufacturerNames(m.manRetriever)
+	if err != nil {
+		return err
+	}
+	filters := make(map[uint]sale_offer.OfferFilterInterface, len(searches))
+	for _, search := range searches {
+		if offer.BelongsToUser(search.UserID) {
+			continue
+		}
+		filter, err := buildFilter(&search, manufacturers)
+		if err != nil {
+			// a search that became invalid (e.g. its manufacturer was removed) must not block the others
+			log.Printf("saved search: cannot match search ID %d against offer ID %d: %v", search.ID, offer.GetID(), err)
+			continue
+		}
+		filters[search.ID] = filter
+	}
+	if len(filters) == 0 {
+		return nil
+	}
+	matchedIDs, err := m.repo.GetMatchingSearchIDs(filters, offer.GetID())
+	if err != nil {
+		return err
+	}
+	matched := make(map[uint]bool, len(matchedIDs))
+	for _, id := range matchedIDs {
+		matched[id] = true
+	}
+	notified := make(map[uint]bool)
+	for _, search := range searches {
+		if !matched[search.ID] || notified[search.UserID] {
+			continue
+		}
+		offerID := offer.GetID()
+		notif := models.Notification{OfferID: &offerID}
+		err = m.userNotifier.Notify(&notif, search.UserID, func(notif *models.Notification) error {
+			return m.notificationService.CreateSavedSearchMatchNotification(notif, search.Name, offer)
+		})
+		if err != nil {
+			// the other users are still notified, the next matching search of this user tries again
+			log.Printf("saved search: cannot notify user ID %d about offer ID %d: %v", search.UserID, offer.GetID(), err)
+			continue
+		}
+		notified[search.UserID] = true
+	}
+	return nil
+}
+
+// buildFilter restores the stored filter as the owner's search of published offers.
+func buildFilter(search *models.SavedSearch, manufacturers []string) (sale_offer.OfferFilterInterface, error) {
+	filter, err := UnmarshalFilter(search.Filter)
+	if err != nil {
+		return nil, err
+	}
+	filter.UserID = &search.UserID
+	filter.Constraints.Manufacturers = manufacturers
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return &sale_offer.PublishedOffersOnlyFilter{BaseOfferFilter: *filter}, nil
+}
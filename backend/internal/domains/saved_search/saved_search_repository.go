@@ -0,0 +1,96 @@
+package saved_search
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"gorm.io/gorm"
+)
+
+//go:generate mockery --name=SavedSearchRepositoryInterface --output=../../test/mocks --case=snake --with-expecter
+
+type SavedSearchRepositoryInterface interface {
+	Create(search *models.SavedSearch) error
+	Update(search *models.SavedSearch) error
+	Delete(id uint) error
+	GetByID(id uint) (*models.SavedSearch, error)
+	GetByUserID(userID uint) ([]models.SavedSearch, error)
+	CountByUserID(userID uint) (int64, error)
+	GetAll() ([]models.SavedSearch, error)
+	GetMatchingSearchIDs(filters map[uint]sale_offer.OfferFilterInterface, offerID uint) ([]uint, error)
+}
+
+// matchBatchSize is the number of saved searches matched against an offer in a single query.
+const matchBatchSize = 100
+
+type SavedSearchRepository struct {
+	DB *gorm.DB
+}
+
+func NewSavedSearchRepository(db *gorm.DB) SavedSearchRepositoryInterface {
+	return &SavedSearchRepository{DB: db}
+}
+
+func (r *SavedSearchRepository) Create(search *models.SavedSearch) error {
+	return r.DB.Create(search).Error
+}
+
+func (r *SavedSearchRepository) Update(search *models.SavedSearch) error {
+	return r.DB.Model(search).Select("name", "filter").Updates(search).Error
+}
+
+func (r *SavedSearchRepository) Delete(id uint) error {
+	return r.DB.Delete(&models.SavedSearch{}, id).Error
+}
+
+func (r *SavedSearchRepository) GetByID(id uint) (*models.SavedSearch, error) {
+	var search models.SavedSearch
+	err := r.DB.First(&search, id).Error
+	return &search, err
+}
+
+func (r *SavedSearchRepository) GetByUserID(userID uint) ([]models.SavedSearch, error) {
+	var searches []models.SavedSearch
+	err := r.DB.Where("user_id = ?", userID).Order("created_at DESC").Find(&searches).Error
+	return searches, err
+}
+
+func (r *SavedSearchRepository) CountByUserID(userID uint) (int64, error) {
+	var count int64
+	err := r.DB.Model(&models.SavedSearch{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+func (r *SavedSearchRepository) GetAll() ([]models.SavedSearch, error) {
+	var searches []models.SavedSearch
+	err := r.DB.Find(&searches).Error
+	return searches, err
+}
+
+// GetMatchingSearchIDs runs every filter (keyed by the ID of its saved search) restricted to a single offer
+// and returns the IDs of the searches which would list the offer in their results. The filters are
+// combined with UNION ALL, so a whole batch of searches is matched in one query.
+func (r *SavedSearchRepository) GetMatchingSearchIDs(filters map[uint]sale_offer.OfferFilterInterface, offerID uint) ([]uint, error) {
+	queries := make([]any, 0, len(filters))
+	for searchID, filter := range filters {
+		query, err := filter.ApplyOfferFilters(r.DB.Table("sale_offer_view").
+			Select("CAST(? AS INTEGER) AS search_id", searchID).
+			Where("sale_offer_view.id = ?", offerID))
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, query)
+	}
+	var matched []uint
+	for batch := range slices.Chunk(queries, matchBatchSize) {
+		union := strings.Repeat("(?) UNION ALL ", len(batch)-1) + "(?)"
+		var ids []uint
+		if err := r.DB.Raw(union, batch...).Scan(&ids).Error; err != nil {
+			return nil, err
+		}
+		matched = append(matched, ids...)
+	}
+	return matched, nil
+}
@@ -0,0 +1,127 @@
+package saved_search
+
+import (
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/manufacturer"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"github.com/susek555/BD2/car-dealer-api/pkg/mapping"
+)
+
+// every saved search is matched against each published offer, so their number is limited
+const MaxSavedSearchesPerUser = 20
+
+type ManufacturerRetrieverInterface interface {
+	GetAll() ([]models.Manufacturer, error)
+}
+
+type SavedSearchServiceInterface interface {
+	Create(userID uint, in *CreateSavedSearchDTO) (*RetrieveSavedSearchDTO, error)
+	Update(userID uint, in *UpdateSavedSearchDTO) (*RetrieveSavedSearchDTO, error)
+	Delete(userID, id uint) error
+	GetByID(userID, id uint) (*RetrieveSavedSearchDTO, error)
+	GetByUserID(userID uint) ([]RetrieveSavedSearchDTO, error)
+}
+
+type SavedSearchService struct {
+	Repo         SavedSearchRepositoryInterface
+	ManRetriever ManufacturerRetrieverInterface
+}
+
+func NewSavedSearchService(repo SavedSearchRepositoryInterface, manRetriever ManufacturerRetrieverInterface) SavedSearchServiceInterface {
+	return &SavedSearchService{
+		Repo:         repo,
+		ManRetriever: manRetriever,
+	}
+}
+
+func (s *SavedSearchService) Create(userID uint, in *CreateSavedSearchDTO) (*RetrieveSavedSearchDTO, error) {
+	if err := s.validateFilter(&in.Filter); err != nil {
+		return nil, err
+	}
+	count, err := s.Repo.CountByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= MaxSavedSearchesPerUser {
+		return nil, ErrTooManySavedSearches
+	}
+	search, err := in.MapToObject(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Repo.Create(search); err != nil {
+		return nil, err
+	}
+	return MapToDTO(search), nil
+}
+
+func (s *SavedSearchService) Update(userID uint, in *UpdateSavedSearchDTO) (*RetrieveSavedSearchDTO, error) {
+	stored, err := s.getOwned(userID, in.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateFilter(&in.Filter); err != nil {
+		return nil, err
+	}
+	search, err := in.MapToObject(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Repo.Update(search); err != nil {
+		return nil, err
+	}
+	search.CreatedAt = stored.CreatedAt
+	return MapToDTO(search), nil
+}
+
+func (s *SavedSearchService) Delete(userID, id uint) error {
+	if _, err := s.getOwned(userID, id); err != nil {
+		return err
+	}
+	return s.Repo.Delete(id)
+}
+
+func (s *SavedSearchService) GetByID(userID, id uint) (*RetrieveSavedSearchDTO, error) {
+	search, err := s.getOwned(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return MapToDTO(search), nil
+}
+
+func (s *SavedSearchService) GetByUserID(userID uint) ([]RetrieveSavedSearchDTO, error) {
+	searches, err := s.Repo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return mapping.MapSliceToDTOs(searches, MapToDTO), nil
+}
+
+func (s *SavedSearchService) getOwned(userID, id uint) (*models.SavedSearch, error) {
+	search, err := s.Repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if search.UserID != userID {
+		return nil, ErrSavedSearchNotOwned
+	}
+	return search, nil
+}
+
+func (s *SavedSearchService) validateFilter(filter *sale_offer.BaseOfferFilter) error {
+	manufacturers, err := getManufacturerNames(s.ManRetriever)
+	if err != nil {
+		return err
+	}
+	filter.Constraints = sale_offer.NewOfferFilter().Constraints
+	filter.Constraints.Manufacturers = manufacturers
+	return filter.Validate()
+}
+
+func getManufacturerNames(manRetriever ManufacturerRetrieverInterface) ([]string, error) {
+	manufacturers, err := manRetriever.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return mapping.MapSliceToDTOs(manufacturers, manufacturer.MapToName), nil
+}
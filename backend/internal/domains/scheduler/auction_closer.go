@@ -1,12 +1,12 @@
 package scheduler
 
 import (
+	"errors"
 	"log"
-	"strconv"
 	"time"
 
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/notification"
-	"github.com/susek555/BD2/car-dealer-api/internal/domains/ws"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/outbox"
 	"github.com/susek555/BD2/car-dealer-api/internal/enums"
 	"github.com/susek555/BD2/car-dealer-api/internal/models"
 	"github.com/susek555/BD2/car-dealer-api/internal/views"
@@ -21,12 +21,7 @@ const (
 	ReasonBidOverBuyNow
 )
 
-type BidRetrieverInterface interface {
-	GetHighestBid(auctionID uint) (*models.Bid, error)
-}
-
 type SaleOfferRepositoryInterface interface {
-	UpdateStatus(offer *models.SaleOffer, status enums.Status) error
 	GetByID(id uint) (*models.SaleOffer, error)
 	GetAllActiveAuctions() ([]views.SaleOfferView, error)
 }
@@ -34,10 +29,6 @@ type SaleOfferRepositoryInterface interface {
 type SaleOfferRetrieverInterface interface {
 	GetDetailedByID(id uint, userID *uint) (notification.SaleOfferInterface, error)
 }
-type PurchaseCreatorInterface interface {
-	Create(purchase *models.Purchase) error
-}
-
 type CloseCmd struct {
 	AuctionID uint
 	Reason    CloseReason
@@ -46,91 +37,65 @@ type CloseCmd struct {
 }
 
 type AuctionCloserInterface interface {
-	CloseAuction(cmd CloseCmd)
+	CloseAuction(cmd CloseCmd) error
 }
 
 type auctionCloser struct {
-	bidRepo             BidRetrieverInterface
-	saleRepo            SaleOfferRepositoryInterface
-	purchaseCreator     PurchaseCreatorInterface
-	notificationService notification.NotificationServiceInterface
-	hub                 ws.HubInterface
-	saleOfferService    SaleOfferRetrieverInterface
+	settlementRepo AuctionSettlementRepositoryInterface
 }
 
-func NewAuctionCloser(bidRepo BidRetrieverInterface, saleRepo SaleOfferRepositoryInterface, purchaseCreator PurchaseCreatorInterface, notificationService notification.NotificationServiceInterface, hub ws.HubInterface, saleOfferService SaleOfferRetrieverInterface) AuctionCloserInterface {
-	return &auctionCloser{
-		bidRepo:             bidRepo,
-		saleRepo:            saleRepo,
-		purchaseCreator:     purchaseCreator,
-		notificationService: notificationService,
-		hub:                 hub,
-		saleOfferService:    saleOfferService,
-	}
+func NewAuctionCloser(settlementRepo AuctionSettlementRepositoryInterface) AuctionCloserInterface {
+	return &auctionCloser{settlementRepo: settlementRepo}
 }
 
-func (c *auctionCloser) CloseAuction(cmd CloseCmd) {
+// CloseAuction settles the auction - the status, the purchase and the outbox messages announcing
+// the result are saved in one transaction, the notifications are sent later by the outbox dispatcher.
+// ErrAuctionNotEnded means the auction was extended, any other error that nothing was saved and
+// closing should be retried.
+func (c *auctionCloser) CloseAuction(cmd CloseCmd) error {
 	auctionID := cmd.AuctionID
 	log.Printf("closer: closing auction %d (reason %d)", auctionID, cmd.Reason)
 
-	offer, err := c.saleRepo.GetByID(auctionID)
-	if err != nil {
-		log.Printf("closer: cannot load offer %d: %v", auctionID, err)
-		return
+	settlement, err := c.settlementRepo.Settle(cmd, time.Now())
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		log.Printf("closer: auction %d was deleted — skip", auctionID)
+		return nil
+	case errors.Is(err, ErrAuctionAlreadyClosed), errors.Is(err, ErrAuctionNotPublished):
+		log.Printf("closer: auction %d is no longer open — skip", auctionID)
+		return nil
+	case errors.Is(err, ErrAuctionNotEnded):
+		log.Printf("closer: auction %d was extended", auctionID)
+		return err
+	case err != nil:
+		log.Printf("closer: cannot settle auction %d: %v", auctionID, err)
+		return err
 	}
-	if offer.Status == enums.SOLD || offer.Status == enums.EXPIRED {
-		log.Printf("closer: auction %d already %s — skip", auctionID, offer.Status)
-		return
-	}
-	var winnerID string
-	var amount uint
+	log.Printf("closer: auction %d closed as %s", auctionID, settlement.Status)
+	return nil
+}
 
-	if cmd.WinnerID != nil && cmd.Amount != nil {
-		winnerID = strconv.FormatUint(uint64(*cmd.WinnerID), 10)
-		amount = *cmd.Amount
-	} else {
-		highest, err := c.bidRepo.GetHighestBid(auctionID)
-		if err != nil {
-			if err == gorm.ErrRecordNotFound {
-				_ = c.saleRepo.UpdateStatus(offer, enums.EXPIRED)
-				return
-			}
-			log.Printf("closer: GetHighestBid err: %v", err)
-			return
-		}
-		winnerID = strconv.FormatUint(uint64(highest.BidderID), 10)
-		amount = highest.Amount
+// NewSettlement decides how the auction ends given its winning bid, nil if nobody bid. The auction
+// is sold to the winner unless their bid is below the reserve price.
+func NewSettlement(offer *models.SaleOffer, winner *models.Bid, now time.Time) (*Settlement, error) {
+	if winner == nil {
+		return &Settlement{OfferID: offer.ID, Status: enums.EXPIRED}, nil
 	}
-
-	if winnerID != "" {
-		winnerIDint, err := strconv.Atoi(winnerID)
+	if offer.Auction != nil && offer.Auction.ReservePrice != nil && winner.Amount < *offer.Auction.ReservePrice {
+		message, err := outbox.NewMessage(OutboxReserveNotMet, ReserveNotMetPayload{OfferID: offer.ID, SellerID: offer.UserID, HighestBid: winner.Amount})
 		if err != nil {
-			log.Printf("closer: invalid winnerID %q: %v", winnerID, err)
-			return
+			return nil, err
 		}
-		purchaseModel := &models.Purchase{OfferID: auctionID, BuyerID: uint(winnerIDint), FinalPrice: amount, IssueDate: time.Now()}
-		_ = c.purchaseCreator.Create(purchaseModel)
-	}
-
-	err = c.saleRepo.UpdateStatus(offer, enums.SOLD)
-	if err != nil {
-		log.Printf("closer: UpdateStatus err: %v", err)
-		return
+		return &Settlement{OfferID: offer.ID, Status: enums.EXPIRED, Messages: []*models.OutboxMessage{message}}, nil
 	}
-
-	n := models.Notification{OfferID: auctionID}
-	offerDTO, err := c.saleOfferService.GetDetailedByID(auctionID, nil)
+	message, err := outbox.NewMessage(OutboxAuctionEnded, AuctionEndedPayload{OfferID: offer.ID, WinnerID: winner.BidderID, Amount: winner.Amount})
 	if err != nil {
-		log.Printf("closer: GetDetailedByID err: %v", err)
-		_ = c.saleRepo.UpdateStatus(offer, enums.EXPIRED)
-		return
-	}
-	if err := c.notificationService.CreateEndAuctionNotification(&n, winnerID, amount, offerDTO); err != nil {
-		log.Printf("closer: notif err: %v", err)
-		_ = c.saleRepo.UpdateStatus(offer, enums.EXPIRED)
-		return
+		return nil, err
 	}
-	idStr := strconv.FormatUint(uint64(auctionID), 10)
-	c.hub.SaveNotificationForClients(idStr, 0, &n)
-	c.hub.SendFourLatestNotificationsToClients(idStr, "0")
+	return &Settlement{
+		OfferID:  offer.ID,
+		Status:   enums.SOLD,
+		Purchase: &models.Purchase{OfferID: offer.ID, BuyerID: winner.BidderID, FinalPrice: winner.Amount, IssueDate: now},
+		Messages: []*models.OutboxMessage{message},
+	}, nil
 }
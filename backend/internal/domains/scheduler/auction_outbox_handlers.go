@@ -0,0 +1,92 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/notification"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/outbox"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/ws"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+)
+
+const (
+	OutboxAuctionEnded  = "auction_ended"
+	OutboxReserveNotMet = "auction_reserve_not_met"
+)
+
+type AuctionEndedPayload struct {
+	OfferID  uint `json:"offer_id"`
+	WinnerID uint `json:"winner_id"`
+	Amount   uint `json:"amount"`
+}
+
+type ReserveNotMetPayload struct {
+	OfferID    uint `json:"offer_id"`
+	SellerID   uint `json:"seller_id"`
+	HighestBid uint `json:"highest_bid"`
+}
+
+// AuctionResultNotifier delivers the outbox messages written when auctions are closed.
+type AuctionResultNotifier struct {
+	notificationService notification.NotificationServiceInterface
+	hub                 ws.HubInterface
+	saleOfferService    SaleOfferRetrieverInterface
+}
+
+func NewAuctionResultNotifier(notificationService notification.NotificationServiceInterface, hub ws.HubInterface, saleOfferService SaleOfferRetrieverInterface) *AuctionResultNotifier {
+	return &AuctionResultNotifier{
+		notificationService: notificationService,
+		hub:                 hub,
+		saleOfferService:    saleOfferService,
+	}
+}
+
+func (n *AuctionResultNotifier) Register(dispatcher *outbox.Dispatcher) {
+	dispatcher.Register(OutboxAuctionEnded, n.DeliverAuctionEnded)
+	dispatcher.Register(OutboxReserveNotMet, n.DeliverReserveNotMet)
+}
+
+func (n *AuctionResultNotifier) DeliverAuctionEnded(messageID uint, payload string) error {
+	var p AuctionEndedPayload
+	if err := json.Unmarshal([]byte(payload), &p); err != nil {
+		return err
+	}
+	offerDTO, err := n.saleOfferService.GetDetailedByID(p.OfferID, nil)
+	if err != nil {
+		return err
+	}
+	notif := models.Notification{OfferID: &p.OfferID, OutboxMessageID: &messageID}
+	if err := n.notificationService.CreateEndAuctionNotification(&notif, strconv.FormatUint(uint64(p.WinnerID), 10), p.Amount, offerDTO); err != nil {
+		return err
+	}
+	idStr := strconv.FormatUint(uint64(p.OfferID), 10)
+	if err := n.hub.SaveNotificationForClients(idStr, 0, &notif); err != nil {
+		return err
+	}
+	n.hub.Broadcast(idStr, ws.NewNotificationEnvelope(&notif), "")
+	n.hub.Broadcast(idStr, ws.NewOfferSoldEnvelope(p.OfferID, p.Amount), "")
+	n.hub.SendFourLatestNotificationsToClients(idStr, "0")
+	return nil
+}
+
+// DeliverReserveNotMet lets the seller know that the highest bid did not reach the reserve price.
+func (n *AuctionResultNotifier) DeliverReserveNotMet(messageID uint, payload string) error {
+	var p ReserveNotMetPayload
+	if err := json.Unmarshal([]byte(payload), &p); err != nil {
+		return err
+	}
+	offerDTO, err := n.saleOfferService.GetDetailedByID(p.OfferID, nil)
+	if err != nil {
+		return err
+	}
+	notif := models.Notification{OfferID: &p.OfferID, OutboxMessageID: &messageID}
+	if err := n.notificationService.CreateReserveNotMetNotification(&notif, p.HighestBid, offerDTO); err != nil {
+		return err
+	}
+	if err := n.notificationService.SaveNotificationToClient(&notif, p.SellerID); err != nil {
+		return err
+	}
+	n.hub.SendFourLatestNotificationsToClients(strconv.FormatUint(uint64(p.OfferID), 10), "0")
+	return nil
+}
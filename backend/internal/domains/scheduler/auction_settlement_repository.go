@@ -0,0 +1,119 @@
+package scheduler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrAuctionAlreadyClosed = errors.New("auction is already closed")
+	ErrAuctionNotPublished  = errors.New("auction is not published")
+	// the auction was extended after its timer had been set, it has to be closed at its new end
+	ErrAuctionNotEnded = errors.New("auction has not ended yet")
+)
+
+// Settlement is everything that changes when an auction is closed - it is saved all at once or not at all.
+type Settlement struct {
+	OfferID  uint
+	Status   enums.Status
+	Purchase *models.Purchase
+	Messages []*models.OutboxMessage
+}
+
+//go:generate mockery --name=AuctionSettlementRepositoryInterface --output=../../test/mocks --case=snake --with-expecter
+type AuctionSettlementRepositoryInterface interface {
+	Settle(cmd CloseCmd, now time.Time) (*Settlement, error)
+}
+
+type AuctionSettlementRepository struct {
+	DB *gorm.DB
+}
+
+func NewAuctionSettlementRepository(db *gorm.DB) AuctionSettlementRepositoryInterface {
+	return &AuctionSettlementRepository{DB: db}
+}
+
+// Settle closes the auction in a single transaction and returns what was saved. The offer row is locked
+// before anything is read, and placing a bid updates the same row, so the winner is the highest bid
+// committed before the auction closed, and no bid commits after it.
+func (r *AuctionSettlementRepository) Settle(cmd CloseCmd, now time.Time) (*Settlement, error) {
+	var settlement *Settlement
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		var offer models.SaleOffer
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&offer, cmd.AuctionID).Error; err != nil {
+			return err
+		}
+		if offer.Status == enums.SOLD || offer.Status == enums.EXPIRED {
+			return ErrAuctionAlreadyClosed
+		}
+		// besides closed auctions, this skips the ones taken off the market by a moderator
+		if offer.Status != enums.PUBLISHED {
+			return ErrAuctionNotPublished
+		}
+		var auction models.Auction
+		if err := tx.First(&auction, "offer_id = ?", offer.ID).Error; err != nil {
+			return err
+		}
+		if cmd.Reason == ReasonTimer && auction.DateEnd.After(now) {
+			return ErrAuctionNotEnded
+		}
+		offer.Auction = &auction
+		winner, err := winningBid(tx, cmd)
+		if err != nil {
+			return err
+		}
+		settlement, err = NewSettlement(&offer, winner, now)
+		if err != nil {
+			return err
+		}
+		return save(tx, &offer, settlement)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return settlement, nil
+}
+
+// winningBid returns the highest bid of the auction, nil if there is none. The winner of a force close
+// wins unless someone outbid them before the auction was closed.
+func winningBid(tx *gorm.DB, cmd CloseCmd) (*models.Bid, error) {
+	var highest models.Bid
+	err := tx.Where("auction_id = ?", cmd.AuctionID).Order("amount DESC").Take(&highest).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if cmd.WinnerID != nil && cmd.Amount != nil && *cmd.Amount > highest.Amount {
+		return &models.Bid{AuctionID: cmd.AuctionID, BidderID: *cmd.WinnerID, Amount: *cmd.Amount}, nil
+	}
+	if err != nil {
+		return nil, nil
+	}
+	return &highest, nil
+}
+
+func save(tx *gorm.DB, offer *models.SaleOffer, settlement *Settlement) error {
+	event := sale_offer.NewStatusChangedEvent(offer.ID, nil, offer.Status, settlement.Status)
+	if err := tx.Model(offer).Update("status", settlement.Status).Error; err != nil {
+		return err
+	}
+	if err := tx.Create(&event).Error; err != nil {
+		return err
+	}
+	if settlement.Purchase != nil {
+		if err := tx.Create(settlement.Purchase).Error; err != nil {
+			return err
+		}
+	}
+	for _, message := range settlement.Messages {
+		if err := tx.Create(message).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/susek555/BD2/car-dealer-api/pkg/redis_lock"
+)
+
+const LeaderLeaseTTL = 15 * time.Second
+
+//go:generate mockery --name=LeaderElectorInterface --output=../../test/mocks --case=snake --with-expecter
+type LeaderElectorInterface interface {
+	// Campaign blocks until ctx is done. Every time this instance becomes the leader, lead is called
+	// with a context which is cancelled as soon as the leadership is lost.
+	Campaign(ctx context.Context, lead func(ctx context.Context))
+}
+
+type RedisLeaderElector struct {
+	rdb           *redis.Client
+	key           string
+	ttl           time.Duration
+	retryInterval time.Duration
+}
+
+func NewRedisLeaderElector(rdb *redis.Client, key string, ttl time.Duration) LeaderElectorInterface {
+	return &RedisLeaderElector{
+		rdb:           rdb,
+		key:           key,
+		ttl:           ttl,
+		retryInterval: ttl / 3,
+	}
+}
+
+func (e *RedisLeaderElector) Campaign(ctx context.Context, lead func(ctx context.Context)) {
+	for {
+		lock, err := redis_lock.Acquire(ctx, e.rdb, e.key, e.ttl, e.retryInterval)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("scheduler: leader election error: %v", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(e.retryInterval):
+			}
+			continue
+		}
+		log.Println("scheduler: acquired leadership")
+		e.holdLeadership(ctx, lock, lead)
+		log.Println("scheduler: leadership released")
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// holdLeadership runs lead while renewing the lease in the background. If the lease cannot be renewed
+// (redis is unreachable or the key expired) lead's context is cancelled, so two leaders never overlap
+// for longer than a single renewal interval.
+func (e *RedisLeaderElector) holdLeadership(ctx context.Context, lock *redis_lock.Lock, lead func(ctx context.Context)) {
+	leaderCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		ticker := time.NewTicker(e.ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-leaderCtx.Done():
+				return
+			case <-ticker.C:
+				if err := lock.Renew(leaderCtx); err != nil {
+					if !errors.Is(err, context.Canceled) {
+						log.Printf("scheduler: lost leadership: %v", err)
+					}
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+	lead(leaderCtx)
+	cancel()
+	if err := lock.Release(context.Background()); err != nil {
+		log.Printf("scheduler: cannot release leadership: %v", err)
+	}
+}
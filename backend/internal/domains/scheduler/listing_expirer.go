@@ -0,0 +1,76 @@
+package scheduler
+
+import (
+	"errors"
+	"log"
+	"math"
+	"time"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/outbox"
+)
+
+// ListingExpirer expires regular offers past their validity date and reminds their owners shortly
+// before it happens. It is run periodically by the job runner, notifications are delivered by the outbox dispatcher.
+type ListingExpirer struct {
+	repo           ListingExpiryRepositoryInterface
+	reminderBefore time.Duration
+}
+
+func NewListingExpirer(repo ListingExpiryRepositoryInterface, reminderBefore time.Duration) *ListingExpirer {
+	return &ListingExpirer{
+		repo:           repo,
+		reminderBefore: reminderBefore,
+	}
+}
+
+// Sweep expires the listings which are due at now and sends reminders for those expiring soon.
+// A listing that cannot be handled is logged and retried with the next sweep.
+func (e *ListingExpirer) Sweep(now time.Time) error {
+	if err := e.expireListings(now); err != nil {
+		return err
+	}
+	return e.remindAboutListings(now)
+}
+
+func (e *ListingExpirer) expireListings(now time.Time) error {
+	offers, err := e.repo.GetListingsToExpire(now)
+	if err != nil {
+		return err
+	}
+	for _, offer := range offers {
+		message, err := outbox.NewMessage(OutboxListingExpired, ListingExpiredPayload{OfferID: offer.ID, SellerID: offer.UserID})
+		if err != nil {
+			return err
+		}
+		if err := e.repo.Expire(offer.ID, now, message); err != nil && !errors.Is(err, ErrListingNotDue) {
+			log.Printf("listings: cannot expire offer %d: %v", offer.ID, err)
+			continue
+		}
+		log.Printf("listings: offer %d expired", offer.ID)
+	}
+	return nil
+}
+
+func (e *ListingExpirer) remindAboutListings(now time.Time) error {
+	offers, err := e.repo.GetListingsToRemind(now.Add(e.reminderBefore))
+	if err != nil {
+		return err
+	}
+	for _, offer := range offers {
+		payload := ListingExpiryReminderPayload{OfferID: offer.ID, SellerID: offer.UserID, DaysLeft: daysLeft(now, *offer.ExpiresAt)}
+		message, err := outbox.NewMessage(OutboxListingExpiryReminder, payload)
+		if err != nil {
+			return err
+		}
+		if err := e.repo.MarkReminded(offer.ID, message); err != nil && !errors.Is(err, ErrListingNotDue) {
+			log.Printf("listings: cannot remind about offer %d: %v", offer.ID, err)
+		}
+	}
+	return nil
+}
+
+// daysLeft rounds up, so a listing expiring in 2.5 days is reported as expiring in 3.
+func daysLeft(now time.Time, expiresAt time.Time) uint {
+	days := math.Ceil(expiresAt.Sub(now).Hours() / 24)
+	return uint(max(days, 0))
+}
@@ -0,0 +1,86 @@
+package scheduler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrListingNotDue = errors.New("listing is not due anymore")
+
+//go:generate mockery --name=ListingExpiryRepositoryInterface --output=../../test/mocks --case=snake --with-expecter
+type ListingExpiryRepositoryInterface interface {
+	GetListingsToExpire(now time.Time) ([]models.SaleOffer, error)
+	GetListingsToRemind(expiringBefore time.Time) ([]models.SaleOffer, error)
+	Expire(offerID uint, now time.Time, message *models.OutboxMessage) error
+	MarkReminded(offerID uint, message *models.OutboxMessage) error
+}
+
+type ListingExpiryRepository struct {
+	DB *gorm.DB
+}
+
+func NewListingExpiryRepository(db *gorm.DB) ListingExpiryRepositoryInterface {
+	return &ListingExpiryRepository{DB: db}
+}
+
+func (r *ListingExpiryRepository) GetListingsToExpire(now time.Time) ([]models.SaleOffer, error) {
+	var offers []models.SaleOffer
+	err := r.publishedListings().Where("expires_at <= ?", now).Find(&offers).Error
+	return offers, err
+}
+
+func (r *ListingExpiryRepository) GetListingsToRemind(expiringBefore time.Time) ([]models.SaleOffer, error) {
+	var offers []models.SaleOffer
+	err := r.publishedListings().
+		Where("expiry_reminder_sent IS FALSE AND expires_at <= ?", expiringBefore).
+		Find(&offers).Error
+	return offers, err
+}
+
+// Expire marks the listing as expired together with its audit event and the outbox message announcing it.
+// The offer row is locked, so a listing bought or renewed in the meantime is left untouched.
+func (r *ListingExpiryRepository) Expire(offerID uint, now time.Time, message *models.OutboxMessage) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var offer models.SaleOffer
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&offer, offerID).Error; err != nil {
+			return err
+		}
+		if offer.IsAuction || offer.Status != enums.PUBLISHED || offer.ExpiresAt == nil || offer.ExpiresAt.After(now) {
+			return ErrListingNotDue
+		}
+		event := sale_offer.NewStatusChangedEvent(offer.ID, nil, offer.Status, enums.EXPIRED)
+		if err := tx.Model(&offer).Update("status", enums.EXPIRED).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&event).Error; err != nil {
+			return err
+		}
+		return tx.Create(message).Error
+	})
+}
+
+// MarkReminded saves that the owner was reminded about the expiry and the outbox message with the reminder.
+func (r *ListingExpiryRepository) MarkReminded(offerID uint, message *models.OutboxMessage) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.SaleOffer{}).
+			Where("id = ? AND status = ? AND expiry_reminder_sent IS FALSE", offerID, enums.PUBLISHED).
+			Update("expiry_reminder_sent", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrListingNotDue
+		}
+		return tx.Create(message).Error
+	})
+}
+
+func (r *ListingExpiryRepository) publishedListings() *gorm.DB {
+	return r.DB.Model(&models.SaleOffer{}).Where("status = ? AND is_auction IS FALSE AND expires_at IS NOT NULL", enums.PUBLISHED)
+}
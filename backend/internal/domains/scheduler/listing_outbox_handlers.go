@@ -0,0 +1,76 @@
+package scheduler
+
+import (
+	"encoding/json"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/notification"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/outbox"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/ws"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+)
+
+const (
+	OutboxListingExpired        = "listing_expired"
+	OutboxListingExpiryReminder = "listing_expiry_reminder"
+)
+
+type ListingExpiredPayload struct {
+	OfferID  uint `json:"offer_id"`
+	SellerID uint `json:"seller_id"`
+}
+
+type ListingExpiryReminderPayload struct {
+	OfferID  uint `json:"offer_id"`
+	SellerID uint `json:"seller_id"`
+	DaysLeft uint `json:"days_left"`
+}
+
+// ListingExpiryNotifier delivers the outbox messages written by the listing expirer to the sellers.
+type ListingExpiryNotifier struct {
+	notificationService notification.NotificationServiceInterface
+	userNotifier        *notification.UserNotifier
+	saleOfferService    SaleOfferRetrieverInterface
+}
+
+func NewListingExpiryNotifier(notificationService notification.NotificationServiceInterface, hub ws.HubInterface, saleOfferService SaleOfferRetrieverInterface) *ListingExpiryNotifier {
+	return &ListingExpiryNotifier{
+		notificationService: notificationService,
+		userNotifier:        notification.NewUserNotifier(notificationService, hub),
+		saleOfferService:    saleOfferService,
+	}
+}
+
+func (n *ListingExpiryNotifier) Register(dispatcher *outbox.Dispatcher) {
+	dispatcher.Register(OutboxListingExpired, n.DeliverListingExpired)
+	dispatcher.Register(OutboxListingExpiryReminder, n.DeliverListingExpiryReminder)
+}
+
+func (n *ListingExpiryNotifier) DeliverListingExpired(messageID uint, payload string) error {
+	var p ListingExpiredPayload
+	if err := json.Unmarshal([]byte(payload), &p); err != nil {
+		return err
+	}
+	offerDTO, err := n.saleOfferService.GetDetailedByID(p.OfferID, nil)
+	if err != nil {
+		return err
+	}
+	notif := models.Notification{OfferID: &p.OfferID, OutboxMessageID: &messageID}
+	return n.userNotifier.Notify(&notif, p.SellerID, func(notif *models.Notification) error {
+		return n.notificationService.CreateListingExpiredNotification(notif, offerDTO)
+	})
+}
+
+func (n *ListingExpiryNotifier) DeliverListingExpiryReminder(messageID uint, payload string) error {
+	var p ListingExpiryReminderPayload
+	if err := json.Unmarshal([]byte(payload), &p); err != nil {
+		return err
+	}
+	offerDTO, err := n.saleOfferService.GetDetailedByID(p.OfferID, nil)
+	if err != nil {
+		return err
+	}
+	notif := models.Notification{OfferID: &p.OfferID, OutboxMessageID: &messageID}
+	return n.userNotifier.Notify(&notif, p.SellerID, func(notif *models.Notification) error {
+		return n.notificationService.CreateListingExpiryReminderNotification(notif, p.DaysLeft, offerDTO)
+	})
+}
@@ -3,22 +3,34 @@ package scheduler
 import (
 	"container/heap"
 	"context"
+	"encoding/json"
+	"errors"
 	"log"
 	"strconv"
 	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
-	"github.com/susek555/BD2/car-dealer-api/internal/domains/notification"
-	"github.com/susek555/BD2/car-dealer-api/internal/domains/ws"
 )
 
+// how long to wait before closing an auction again after closing it failed
+const closeRetryDelay = 30 * time.Second
+
+// events are queued on this list so that the leader receives them from every instance - unlike a pub/sub
+// message, an event sent while no instance leads waits there for the next leader
+const eventsQueue = "scheduler.events"
+
+// how long the leader blocks waiting for an event before checking whether it still leads
+const eventsPollTimeout = time.Second
+
 type Scheduler struct {
 	mu            sync.Mutex
 	heap          timerHeap
 	eventsCh      chan AuctionEvent
 	closer        AuctionCloserInterface
 	saleOfferRepo SaleOfferRepositoryInterface
+	redisClient   *redis.Client
+	elector       LeaderElectorInterface
 }
 
 //go:generate mockery --name=SchedulerInterface --output=../../test/mocks --case=snake --with-expecter
@@ -26,25 +38,32 @@ type SchedulerInterface interface {
 	AddAuction(auctionID string, end time.Time)
 	ModifyAuction(auctionID string, end time.Time)
 	Run(ctx context.Context)
+	Lead(ctx context.Context)
 	LoadAuctions() error
 	ForceCloseAuction(auctionID string, buyerID uint, amount uint)
 }
 
 func NewScheduler(
-	repo BidRetrieverInterface,
 	redisClient *redis.Client,
-	notificationService notification.NotificationServiceInterface,
 	saleOfferRepo SaleOfferRepositoryInterface,
-	purchaseCreator PurchaseCreatorInterface,
-	saleOfferService SaleOfferRetrieverInterface,
-	hub ws.HubInterface,
+	settlementRepo AuctionSettlementRepositoryInterface,
+	elector LeaderElectorInterface,
 ) SchedulerInterface {
-	closer := NewAuctionCloser(repo, saleOfferRepo, purchaseCreator, notificationService, hub, saleOfferService)
+	closer := NewAuctionCloser(settlementRepo)
+	return NewSchedulerWithCloser(closer, saleOfferRepo, redisClient, elector)
+}
+
+// NewSchedulerWithCloser creates a scheduler around the given closer. Without a redis client the scheduler
+// runs in a single instance mode - events are handled in process and Run starts straight away. With a redis
+// client but no elector, the leadership is left to the caller (e.g. the job runner calling Lead).
+func NewSchedulerWithCloser(closer AuctionCloserInterface, saleOfferRepo SaleOfferRepositoryInterface, redisClient *redis.Client, elector LeaderElectorInterface) *Scheduler {
 	return &Scheduler{
 		heap:          make(timerHeap, 0),
 		eventsCh:      make(chan AuctionEvent, 1024),
 		closer:        closer,
 		saleOfferRepo: saleOfferRepo,
+		redisClient:   redisClient,
+		elector:       elector,
 	}
 }
 
@@ -54,11 +73,14 @@ func (s *Scheduler) LoadAuctions() error {
 		log.Println("scheduler: error loading auctions:", err)
 		return err
 	}
+	s.mu.Lock()
+	s.heap = make(timerHeap, 0)
+	s.mu.Unlock()
 	for _, offer := range offers {
 		auctionID := strconv.FormatUint(uint64(offer.ID), 10)
 		if offer.DateEnd.Local().Before(time.Now()) {
 			log.Printf("scheduler: skipping auction %s with end time %s, already ended", auctionID, offer.DateEnd)
-			s.closer.CloseAuction(CloseCmd{
+			s.close(CloseCmd{
 				AuctionID: offer.ID,
 				Reason:    ReasonTimer,
 			})
@@ -78,30 +100,102 @@ func (s *Scheduler) LoadAuctions() error {
 
 func (s *Scheduler) AddAuction(auctionID string, endAt time.Time) {
 	id, _ := strconv.Atoi(auctionID)
-	s.eventsCh <- AuctionEvent{
+	s.dispatch(AuctionEvent{
 		Kind: EventAddTimer,
 		At:   endAt,
 		Cmd: CloseCmd{
 			AuctionID: uint(id),
 			Reason:    ReasonTimer,
 		},
-	}
+	})
 }
 
 func (s *Scheduler) ModifyAuction(auctionID string, endAt time.Time) {
 	id, _ := strconv.Atoi(auctionID)
-	s.eventsCh <- AuctionEvent{
+	s.dispatch(AuctionEvent{
 		Kind: EventModifyTimer,
 		At:   endAt,
 		Cmd: CloseCmd{
 			AuctionID: uint(id),
 			Reason:    ReasonTimer,
 		},
-	}
+	})
 	log.Printf("scheduler: modified auction %s to end at %s", auctionID, endAt)
 }
 
+// dispatch hands the event over to the leader. In the single instance mode the event goes straight to
+// the local loop, otherwise it is queued so that whichever instance leads now or next receives it.
+func (s *Scheduler) dispatch(ev AuctionEvent) {
+	if s.redisClient == nil {
+		s.eventsCh <- ev
+		return
+	}
+	data, err := json.Marshal(ev)
+	if err != nil {
+		log.Printf("scheduler: cannot encode event: %v", err)
+		return
+	}
+	if err := s.redisClient.RPush(context.Background(), eventsQueue, data).Err(); err != nil {
+		log.Printf("scheduler: cannot queue event for auction %d: %v", ev.Cmd.AuctionID, err)
+	}
+}
+
+// Run processes the auction timers. With an elector only the leading instance does so - it loads
+// the active auctions every time it takes the leadership over and stops as soon as it loses it.
+// Without an elector but with a redis client the caller is expected to be the leader already.
 func (s *Scheduler) Run(ctx context.Context) {
+	switch {
+	case s.elector != nil:
+		s.elector.Campaign(ctx, s.Lead)
+	case s.redisClient != nil:
+		s.Lead(ctx)
+	default:
+		s.run(ctx, s.eventsCh)
+	}
+}
+
+// Lead loads the active auctions and processes their timers together with the events queued
+// by every instance, until ctx is done. It is meant to be run by the current leader only.
+func (s *Scheduler) Lead(ctx context.Context) {
+	if err := s.LoadAuctions(); err != nil {
+		return
+	}
+	// unbuffered, so an event is taken off the queue only when the loop is ready to handle it
+	events := make(chan AuctionEvent)
+	go s.forwardEvents(ctx, events)
+	s.run(ctx, events)
+}
+
+// forwardEvents moves the queued events to the loop. An event popped just as the leadership
+// is lost goes back to the front of the queue for the next leader.
+func (s *Scheduler) forwardEvents(ctx context.Context, events chan<- AuctionEvent) {
+	for ctx.Err() == nil {
+		result, err := s.redisClient.BLPop(ctx, eventsPollTimeout, eventsQueue).Result()
+		if err != nil {
+			if !errors.Is(err, redis.Nil) && ctx.Err() == nil {
+				log.Printf("scheduler: cannot receive events: %v", err)
+				time.Sleep(eventsPollTimeout)
+			}
+			continue
+		}
+		data := result[1]
+		var ev AuctionEvent
+		if err := json.Unmarshal([]byte(data), &ev); err != nil {
+			log.Printf("scheduler: cannot decode event: %v", err)
+			continue
+		}
+		select {
+		case events <- ev:
+		case <-ctx.Done():
+			if err := s.redisClient.LPush(context.Background(), eventsQueue, data).Err(); err != nil {
+				log.Printf("scheduler: cannot requeue event for auction %d: %v", ev.Cmd.AuctionID, err)
+			}
+			return
+		}
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, events <-chan AuctionEvent) {
 	var timer *time.Timer = time.NewTimer(time.Hour * 24 * 365)
 
 	for {
@@ -118,9 +212,11 @@ func (s *Scheduler) Run(ctx context.Context) {
 			timer.Stop()
 			return
 
-		case ev := <-s.eventsCh:
+		case ev := <-events:
 			switch ev.Kind {
 			case EventAddTimer:
+				// the event may have been queued before the auctions were loaded
+				s.dropTimer(strconv.Itoa(int(ev.Cmd.AuctionID)))
 				s.mu.Lock()
 				heap.Push(&s.heap, &Item{
 					AuctionID: strconv.Itoa(int(ev.Cmd.AuctionID)),
@@ -130,7 +226,7 @@ func (s *Scheduler) Run(ctx context.Context) {
 
 			case EventForceClose:
 				s.removeFromHeap(strconv.Itoa(int(ev.Cmd.AuctionID)))
-				s.closer.CloseAuction(ev.Cmd)
+				s.close(ev.Cmd)
 
 			case EventModifyTimer:
 				s.removeFromHeap(strconv.Itoa(int(ev.Cmd.AuctionID)))
@@ -153,7 +249,7 @@ func (s *Scheduler) Run(ctx context.Context) {
 			s.mu.Unlock()
 
 			id, _ := strconv.Atoi(next.AuctionID)
-			s.closer.CloseAuction(CloseCmd{
+			s.close(CloseCmd{
 				AuctionID: uint(id),
 				Reason:    ReasonTimer,
 			})
@@ -161,7 +257,37 @@ func (s *Scheduler) Run(ctx context.Context) {
 	}
 }
 
+// close closes the auction and, if that fails, schedules another attempt - the auction ends
+// with the highest bid then, which is also the winning bid of a force close. An auction extended
+// after its timer was set is closed at its new end.
+func (s *Scheduler) close(cmd CloseCmd) {
+	err := s.closer.CloseAuction(cmd)
+	if err == nil {
+		return
+	}
+	retryAt := time.Now().Add(closeRetryDelay)
+	if errors.Is(err, ErrAuctionNotEnded) {
+		if offer, err := s.saleOfferRepo.GetByID(cmd.AuctionID); err == nil && offer.Auction != nil {
+			retryAt = offer.Auction.DateEnd
+		}
+	}
+	log.Printf("scheduler: closing auction %d at %s", cmd.AuctionID, retryAt)
+	s.mu.Lock()
+	heap.Push(&s.heap, &Item{
+		AuctionID: strconv.Itoa(int(cmd.AuctionID)),
+		EndAt:     retryAt,
+	})
+	s.mu.Unlock()
+}
+
 func (s *Scheduler) removeFromHeap(auctionID string) {
+	if !s.dropTimer(auctionID) {
+		log.Printf("scheduler: auction %s not found in heap", auctionID)
+	}
+}
+
+// dropTimer removes the timer of the auction if there is one.
+func (s *Scheduler) dropTimer(auctionID string) bool {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -169,15 +295,15 @@ func (s *Scheduler) removeFromHeap(auctionID string) {
 		if item.AuctionID == auctionID {
 			heap.Remove(&s.heap, i)
 			log.Printf("scheduler: removed auction %s from heap", auctionID)
-			return
+			return true
 		}
 	}
-	log.Printf("scheduler: auction %s not found in heap", auctionID)
+	return false
 }
 
 func (s *Scheduler) ForceCloseAuction(auctionID string, buyerID uint, amount uint) {
 	auctionIDInt, _ := strconv.Atoi(auctionID)
-	s.eventsCh <- AuctionEvent{
+	s.dispatch(AuctionEvent{
 		Kind: EventForceClose,
 		Cmd: CloseCmd{
 			AuctionID: uint(auctionIDInt),
@@ -185,6 +311,6 @@ func (s *Scheduler) ForceCloseAuction(auctionID string, buyerID uint, amount uin
 			WinnerID:  &buyerID,
 			Amount:    &amount,
 		},
-	}
+	})
 	log.Printf("scheduler: force closing auction %s by buyer %d with amount %d", auctionID, buyerID, amount)
 }
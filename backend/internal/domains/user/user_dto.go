@@ -32,6 +32,10 @@ type UpdateUserDTO struct {
 	PersonSurname *string `json:"person_surname"`
 }
 
+type UserSettingsDTO struct {
+	PriceDropAlerts bool `json:"price_drop_alerts"`
+}
+
 type UpdateResponse struct {
 	Errors map[string][]string `json:"errors"`
 }
@@ -160,3 +160,54 @@ func (h *Handler) DeleteUser(c *gin.Context) {
 	}
 	c.Status(http.StatusNoContent)
 }
+
+// GetSettings godoc
+//
+//	@Summary		Get my settings
+//	@Description	Returns the settings of the logged-in user.
+//	@Tags			users
+//	@Produce		json
+//	@Success		200	{object}	UserSettingsDTO			"User settings"
+//	@Failure		401	{object}	custom_errors.HTTPError	"Unauthorized - user must be logged in"
+//	@Failure		404	{object}	custom_errors.HTTPError	"User not found"
+//	@Failure		500	{object}	custom_errors.HTTPError	"Internal server error"
+//	@Router			/users/settings [get]
+//	@Security		Bearer
+func (h *Handler) GetSettings(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	settings, err := h.service.GetSettings(userID.(uint))
+	if err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateSettings godoc
+//
+//	@Summary		Update my settings
+//	@Description	Changes the settings of the logged-in user, e.g. turns off notifications about price drops of liked offers.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			settings	body		UserSettingsDTO			true	"User settings"
+//	@Success		200			{object}	UserSettingsDTO			"Updated settings"
+//	@Failure		400			{object}	custom_errors.HTTPError	"Invalid input data"
+//	@Failure		401			{object}	custom_errors.HTTPError	"Unauthorized - user must be logged in"
+//	@Failure		404			{object}	custom_errors.HTTPError	"User not found"
+//	@Failure		500			{object}	custom_errors.HTTPError	"Internal server error"
+//	@Router			/users/settings [put]
+//	@Security		Bearer
+func (h *Handler) UpdateSettings(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	var settings UserSettingsDTO
+	if err := c.ShouldBindJSON(&settings); err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	if err := h.service.UpdateSettings(userID.(uint), &settings); err != nil {
+		custom_errors.HandleError(c, err, ErrorMap)
+		return
+	}
+	c.JSON(http.StatusOK, settings)
+}
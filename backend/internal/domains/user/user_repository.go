@@ -13,6 +13,7 @@ type UserRepositoryInterface interface {
 	GetByCompanyNip(nip string) (models.User, error)
 	GetByUsername(username string) (models.User, error)
 	UpdatePassword(userID uint, newPassword string) error
+	UpdatePriceDropAlerts(userID uint, enabled bool) error
 }
 
 type UserRepository struct {
@@ -97,6 +98,10 @@ func (r *UserRepository) UpdatePassword(userID uint, newPassword string) error {
 	return r.DB.Model(&models.User{}).Where("id = ?", userID).Update("password", newPassword).Error
 }
 
+func (r *UserRepository) UpdatePriceDropAlerts(userID uint, enabled bool) error {
+	return r.DB.Model(&models.User{}).Where("id = ?", userID).Update("price_drop_alerts", enabled).Error
+}
+
 func (r *UserRepository) Delete(id uint) error {
 	return r.DB.Delete(&models.User{}, id).Error
 }
@@ -13,6 +13,8 @@ type UserServiceInterface interface {
 	GetByUsername(username string) (*RetrieveUserDTO, error)
 	Update(*UpdateUserDTO) map[string][]string
 	Delete(id uint) error
+	GetSettings(id uint) (*UserSettingsDTO, error)
+	UpdateSettings(id uint, settings *UserSettingsDTO) error
 }
 
 type UserService struct {
@@ -114,3 +116,18 @@ func (s *UserService) Update(in *UpdateUserDTO) map[string][]string {
 func (s *UserService) Delete(id uint) error {
 	return s.repo.Delete(id)
 }
+
+func (s *UserService) GetSettings(id uint) (*UserSettingsDTO, error) {
+	user, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return &UserSettingsDTO{PriceDropAlerts: user.PriceDropAlerts}, nil
+}
+
+func (s *UserService) UpdateSettings(id uint, settings *UserSettingsDTO) error {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return err
+	}
+	return s.repo.UpdatePriceDropAlerts(id, settings.PriceDropAlerts)
+}
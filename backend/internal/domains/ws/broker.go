@@ -3,19 +3,66 @@ package ws
 import (
 	"context"
 	"encoding/json"
+	"strconv"
 
 	"github.com/redis/go-redis/v9"
 )
 
-func PublishAuctionEvent(
-	ctx context.Context,
-	rdb *redis.Client,
-	offerID string,
-	envelope *Envelope,
-) error {
+const userChannelPrefix = "user."
+
+//go:generate mockery --name=UserPublisherInterface --output=../../test/mocks --case=snake --with-expecter
+type UserPublisherInterface interface {
+	PublishToUser(ctx context.Context, userID uint, envelope *Envelope) error
+}
+
+// RedisUserPublisher sends envelopes to the user's channel, so they reach the user whichever instance
+// holds their connection.
+type RedisUserPublisher struct {
+	rdb *redis.Client
+}
+
+func NewRedisUserPublisher(rdb *redis.Client) UserPublisherInterface {
+	return &RedisUserPublisher{rdb: rdb}
+}
+
+func (p *RedisUserPublisher) PublishToUser(ctx context.Context, userID uint, envelope *Envelope) error {
 	data, err := json.Marshal(envelope)
 	if err != nil {
 		return err
 	}
-	return rdb.Publish(ctx, "offer."+offerID, data).Err()
+	return p.rdb.Publish(ctx, userChannelPrefix+strconv.FormatUint(uint64(userID), 10), data).Err()
+}
+
+const hubEventsChannel = "hub.events"
+
+type hubEventKind string
+
+const (
+	eventBroadcast         hubEventKind = "broadcast"
+	eventSubscribe         hubEventKind = "subscribe"
+	eventUnsubscribe       hubEventKind = "unsubscribe"
+	eventRemoveRoom        hubEventKind = "remove_room"
+	eventRoomNotifications hubEventKind = "room_notifications"
+	eventUserNotifications hubEventKind = "user_notifications"
+	eventSaveNotification  hubEventKind = "save_notification"
+)
+
+// hubEvent is a fan-out shared between the instances. Every instance applies it to its own clients.
+type hubEvent struct {
+	Kind      hubEventKind `json:"kind"`
+	OfferID   string       `json:"offer_id,omitempty"`
+	UserID    string       `json:"user_id,omitempty"`
+	ExcludeID string       `json:"exclude_id,omitempty"`
+	Data      []byte       `json:"data,omitempty"`
+	// the notification saved for the room members, except the users listed
+	NotificationID  uint   `json:"notification_id,omitempty"`
+	ExcludedUserIDs []uint `json:"excluded_user_ids,omitempty"`
+}
+
+func publishHubEvent(ctx context.Context, rdb *redis.Client, event *hubEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return rdb.Publish(ctx, hubEventsChannel, data).Err()
 }
@@ -0,0 +1,54 @@
+package ws
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+var ErrChatUnavailable = errors.New("messaging is not available")
+
+// ChatHandlerInterface handles the chat frames sent by clients. The messages are persisted and published to
+// the users' channels, so they come back to the participants through the redis fan-in.
+type ChatHandlerInterface interface {
+	SendMessage(senderID uint, payload *SendMessagePayload) error
+	Typing(userID uint, payload *TypingPayload) error
+	MarkRead(readerID uint, payload *ReadReceiptPayload) error
+}
+
+func (c *Client) handleChat(env *Envelope) error {
+	if c.hub.chatHandler == nil {
+		return ErrChatUnavailable
+	}
+	userID, err := strconv.ParseUint(c.userID, 10, 64)
+	if err != nil {
+		return err
+	}
+	switch env.MessageType {
+	case MsgSendMessage:
+		var p SendMessagePayload
+		if err := json.Unmarshal(env.Data, &p); err != nil {
+			return err
+		}
+		return c.hub.chatHandler.SendMessage(uint(userID), &p)
+	case MsgTyping:
+		var p TypingPayload
+		if err := json.Unmarshal(env.Data, &p); err != nil {
+			return err
+		}
+		return c.hub.chatHandler.Typing(uint(userID), &p)
+	default:
+		var p ReadReceiptPayload
+		if err := json.Unmarshal(env.Data, &p); err != nil {
+			return err
+		}
+		return c.hub.chatHandler.MarkRead(uint(userID), &p)
+	}
+}
+
+// sendError tells the client that its message with the given ID was rejected.
+func (c *Client) sendError(id string, err error) {
+	envelope := NewEnvelope(MsgError, ErrorPayload{Message: err.Error()})
+	envelope.ID = id
+	c.sendEnvelope(envelope)
+}
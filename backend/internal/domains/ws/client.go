@@ -2,6 +2,7 @@ package ws
 
 import (
 	"encoding/json"
+	"log"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -14,11 +15,13 @@ const (
 )
 
 type Client struct {
-	conn   *websocket.Conn
-	send   chan []byte
-	userID string
-	hub    *Hub
-	rooms  map[string]bool
+	id      string
+	conn    *websocket.Conn
+	send    chan []byte
+	userID  string
+	version int
+	hub     *Hub
+	rooms   map[string]bool
 }
 
 func (c *Client) readPump() {
@@ -31,6 +34,7 @@ func (c *Client) readPump() {
 	c.conn.SetReadDeadline(time.Now().Add(pongWait))
 	c.conn.SetPongHandler(func(string) error {
 		c.conn.SetReadDeadline(time.Now().Add(pongWait))
+		c.hub.trackPresence(c)
 		return nil
 	})
 
@@ -43,23 +47,89 @@ func (c *Client) readPump() {
 		if err = json.Unmarshal(raw, &env); err != nil {
 			continue
 		}
-		switch env.MessageType {
-		case MsgSubscribe:
-			var p SubscribePayload
-			json.Unmarshal(env.Data, &p)
-			for _, id := range p.Offers {
-				c.hub.subscribe <- subscription{id, c}
-			}
-		case MsgUnsubscribe:
-			var p SubscribePayload
-			json.Unmarshal(env.Data, &p)
-			for _, id := range p.Offers {
-				c.hub.unsubscribe <- subscription{id, c}
-			}
-		case MsgGetNotifications:
-			c.hub.SendFourLatestNotificationsToClient(c)
+		if err := c.handle(&env); err != nil {
+			c.sendError(env.ID, err)
+		} else if env.ID != "" {
+			c.sendEnvelope(&Envelope{MessageType: MsgAck, ID: env.ID})
+		}
+	}
+}
+
+func (c *Client) handle(env *Envelope) error {
+	switch env.MessageType {
+	case MsgSubscribe:
+		var p SubscribePayload
+		if err := json.Unmarshal(env.Data, &p); err != nil {
+			return err
+		}
+		// every connection of the user joins the room, not only this one
+		for _, id := range p.Offers {
+			c.hub.SubscribeUser(c.userID, id)
+		}
+	case MsgUnsubscribe:
+		var p UnsubscribePayload
+		if err := json.Unmarshal(env.Data, &p); err != nil {
+			return err
+		}
+		for _, id := range p.Offers {
+			c.hub.UnsubscribeUser(c.userID, id)
+		}
+	case MsgGetNotifications:
+		c.hub.SendFourLatestNotificationsToClient(c)
+	case MsgResume:
+		if c.version < ProtocolV2 {
+			return ErrResumeNotSupported
+		}
+		var p ResumePayload
+		if err := json.Unmarshal(env.Data, &p); err != nil {
+			return err
 		}
+		c.hub.resume(c, p.Rooms)
+	case MsgSendMessage, MsgTyping, MsgReadReceipt:
+		return c.handleChat(env)
 	}
+	return nil
+}
+
+// deliver queues the data for the client. A client that cannot keep up is disconnected rather than left
+// missing messages - with the second version of the protocol it can reconnect and resume where it stopped.
+func (c *Client) deliver(data []byte) bool {
+	select {
+	case c.send <- data:
+		return true
+	default:
+		log.Printf("ws: send buffer of userID %q is full, disconnecting", c.userID)
+		go c.closeSlow()
+		return false
+	}
+}
+
+// deliverWithin waits for room in the send buffer, for the replays that may not fit in it at once.
+func (c *Client) deliverWithin(data []byte, timeout time.Duration) bool {
+	select {
+	case c.send <- data:
+		return true
+	case <-time.After(timeout):
+		log.Printf("ws: userID %q does not read the replay, disconnecting", c.userID)
+		go c.closeSlow()
+		return false
+	}
+}
+
+func (c *Client) sendEnvelope(env *Envelope) {
+	data, err := json.Marshal(env)
+	if err != nil {
+		log.Printf("ws: cannot marshal %s for userID %q: %v", env.MessageType, c.userID, err)
+		return
+	}
+	c.deliver(data)
+}
+
+func (c *Client) closeSlow() {
+	_ = c.conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "send buffer full, resume to catch up"),
+		time.Now().Add(writeWait))
+	c.conn.Close()
 }
 
 func (c *Client) writePump() {
@@ -4,6 +4,7 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
@@ -19,6 +20,26 @@ func ServeWS(hub *Hub) gin.HandlerFunc {
 		}
 		token := rawToken.(string)
 
+		version, err := ParseProtocolVersion(c.Query("version"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest,
+				gin.H{"error": err.Error()})
+			return
+		}
+
+		uidAny, _ := c.Get("userID")
+		uid := uidAny.(uint)
+		uidStr := strconv.Itoa(int(uid))
+
+		count, err := hub.connectionCount(uidStr)
+		if err != nil {
+			log.Printf("ws: cannot count connections of userID %s: %v", uidStr, err)
+		} else if count >= hub.maxConnections {
+			c.AbortWithStatusJSON(http.StatusTooManyRequests,
+				gin.H{"error": ErrTooManyConnections.Error()})
+			return
+		}
+
 		up := websocket.Upgrader{
 			CheckOrigin:  func(r *http.Request) bool { return true },
 			Subprotocols: []string{token}, // echo
@@ -30,26 +51,36 @@ func ServeWS(hub *Hub) gin.HandlerFunc {
 			return
 		}
 
-		uidAny, _ := c.Get("userID")
-		uid := uidAny.(uint)
-		uidStr := strconv.Itoa(int(uid))
-
 		client := &Client{
-			conn:   conn,
-			send:   make(chan []byte, 128),
-			userID: uidStr,
-			hub:    hub,
-			rooms:  make(map[string]bool),
+			id:      newConnectionID(),
+			conn:    conn,
+			send:    make(chan []byte, 128),
+			userID:  uidStr,
+			version: version,
+			hub:     hub,
+			rooms:   make(map[string]bool),
 		}
 
-		hub.register <- client
-		hub.LoadClientToRooms(uidStr)
+		// the limit is checked again, as other connections may have been opened during the upgrade
+		if err := hub.addClient(client); err != nil {
+			_ = conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.ClosePolicyViolation, err.Error()),
+				time.Now().Add(writeWait))
+			conn.Close()
+			return
+		}
+		hub.trackPresence(client)
+		hub.loadRooms(client)
+		if version >= ProtocolV2 {
+			client.sendEnvelope(NewEnvelope(MsgWelcome, WelcomePayload{Version: version}))
+		}
 
 		go client.writePump()
 		go client.readPump()
 	}
 }
 
+// LoadClientToRooms subscribes all the user's connections to the offers they interacted with.
 func (h *Hub) LoadClientToRooms(userID string) {
 	userIDUint, err := strconv.ParseUint(userID, 10, 64)
 	if err != nil {
@@ -66,3 +97,20 @@ func (h *Hub) LoadClientToRooms(userID string) {
 		log.Printf("User %s subscribed to offer %d\n", userID, record.OfferID)
 	}
 }
+
+// loadRooms subscribes a new connection to the offers its user interacted with.
+func (h *Hub) loadRooms(client *Client) {
+	userID, err := strconv.ParseUint(client.userID, 10, 64)
+	if err != nil {
+		log.Printf("Error parsing userID %s: %v", client.userID, err)
+		return
+	}
+	records, err := h.userOfferRepository.GetUserInteractionsByUserID(uint(userID))
+	if err != nil {
+		log.Println("Error loading client rooms:", err)
+		return
+	}
+	for _, record := range records {
+		h.subscribe <- subscription{strconv.FormatUint(uint64(record.OfferID), 10), client}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"log"
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -18,21 +20,28 @@ import (
 //go:generate mockery --name=HubInterface --output=../../test/mocks --case=snake --with-expecter
 type HubInterface interface {
 	Run()
-	StartRedisFanIn(ctx context.Context, rdb *redis.Client)
+	StartRedisFanIn(ctx context.Context)
 	SubscribeUser(uid, offerID string)
-	BroadcastLocal(offerID string, data []byte, excludeID string)
+	Broadcast(offerID string, envelope *Envelope, excludeID string)
 	SaveNotificationForClients(offerID string, userID uint, n *models.Notification) error
+	SaveNotificationForClientsExcept(offerID string, excludedUserIDs []uint, n *models.Notification) error
 	SendFourLatestNotificationsToClient(client *Client)
 	SendFourLatestNotificationsToClients(offerID, userID string)
+	SendFourLatestNotificationsToUser(userID string)
 	LoadClientToRooms(userID string)
 	UnsubscribeUser(userID, offerID string)
 	RemoveRoom(offerID string)
+	IsOnline(userID string) (bool, error)
 }
 
+// Hub keeps the websocket clients connected to this instance. With a redis client every fan-out is published
+// to redis first and each instance, this one included, applies it to its own clients only - a user may be
+// connected to any of them. Without one the hub runs in a single instance mode and applies it straight away.
+// A user may have several connections at once, e.g. on a phone and a laptop - all of them get the same messages.
 type Hub struct {
 	rooms               map[string]map[*Client]struct{}
-	clients             map[string]*Client
-	register            chan *Client
+	clients             map[string]map[*Client]struct{}
+	maxConnections      int
 	unregister          chan *Client
 	subscribe           chan subscription
 	unsubscribe         chan subscription
@@ -40,43 +49,53 @@ type Hub struct {
 	mu                  sync.RWMutex
 	notificationService notification.NotificationServiceInterface
 	userOfferRepository views.UserOfferRepositoryInterface
+	chatHandler         ChatHandlerInterface
+	rdb                 *redis.Client
+	// sequence numbers of the rooms in the single instance mode, in redis otherwise
+	seqs map[string]uint64
 }
 type subscription struct {
 	offerID string
 	client  *Client
 }
+
+// outbound goes either to an offer room or, when userID is set, to a single user
 type outbound struct {
 	offerID   string
+	userID    string
 	data      []byte
 	excludeID string
+	// removes the room instead, queued behind the broadcasts so the room still gets the ones sent before
+	removeRoom bool
 }
 
-func NewHub(notificationService notification.NotificationServiceInterface, userOfferRepo views.UserOfferRepositoryInterface) HubInterface {
+func NewHub(
+	notificationService notification.NotificationServiceInterface,
+	userOfferRepo views.UserOfferRepositoryInterface,
+	chatHandler ChatHandlerInterface,
+	rdb *redis.Client,
+	maxConnections int,
+) HubInterface {
 	return &Hub{
 		rooms:               make(map[string]map[*Client]struct{}),
-		clients:             make(map[string]*Client),
-		register:            make(chan *Client),
+		clients:             make(map[string]map[*Client]struct{}),
+		maxConnections:      maxConnections,
 		unregister:          make(chan *Client),
 		subscribe:           make(chan subscription),
 		unsubscribe:         make(chan subscription),
 		broadcast:           make(chan outbound, 1024),
 		notificationService: notificationService,
 		userOfferRepository: userOfferRepo,
+		chatHandler:         chatHandler,
+		rdb:                 rdb,
+		seqs:                make(map[string]uint64),
 	}
 }
 
 func (h *Hub) Run() {
 	for {
 		select {
-		case client := <-h.register:
-			h.mu.Lock()
-			h.clients[client.userID] = client
-			h.mu.Unlock()
-			log.Println("Client registered:", client.userID)
 		case client := <-h.unregister:
-			h.mu.Lock()
-			delete(h.clients, client.userID)
-			h.mu.Unlock()
 			h.removeClient(client)
 		case sub := <-h.subscribe:
 			h.addToRoom(sub.offerID, sub.client)
@@ -112,78 +131,217 @@ func (h *Hub) removeFromRoom(offerID string, client *Client) {
 	delete(client.rooms, offerID)
 }
 
+// addClient registers the connection, unless the user already has maxConnections of them on this instance.
+// The new connection joins the rooms the user's other connections are in.
+func (h *Hub) addClient(client *Client) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	sessions := h.clients[client.userID]
+	if len(sessions) >= h.maxConnections {
+		return ErrTooManyConnections
+	}
+	if sessions == nil {
+		sessions = make(map[*Client]struct{})
+		h.clients[client.userID] = sessions
+	}
+	for sibling := range sessions {
+		for offerID := range sibling.rooms {
+			if h.rooms[offerID] == nil {
+				h.rooms[offerID] = make(map[*Client]struct{})
+			}
+			h.rooms[offerID][client] = struct{}{}
+			client.rooms[offerID] = true
+		}
+		break
+	}
+	sessions[client] = struct{}{}
+	log.Printf("hub: client registered: %s (%d connections)", client.userID, len(sessions))
+	return nil
+}
+
 func (h *Hub) removeClient(client *Client) {
-	for offerID := range client.rooms {
+	h.mu.Lock()
+	if sessions, ok := h.clients[client.userID]; ok {
+		delete(sessions, client)
+		if len(sessions) == 0 {
+			delete(h.clients, client.userID)
+		}
+	}
+	offerIDs := slices.Collect(maps.Keys(client.rooms))
+	h.mu.Unlock()
+
+	for _, offerID := range offerIDs {
 		h.removeFromRoom(offerID, client)
 	}
+	h.untrackPresence(client)
 	close(client.send)
 }
 
 func (h *Hub) fanOut(msg outbound) {
+	if msg.userID != "" {
+		h.deliverToUser(msg)
+		return
+	}
+	if msg.removeRoom {
+		h.removeRoom(msg.offerID)
+		return
+	}
 	room, ok := h.getRoom(msg.offerID)
 	if !ok {
 		return
 	}
 
-	for client := range room {
+	for _, client := range room {
 		if msg.excludeID != "" && client.userID == msg.excludeID {
 			continue
 		}
-		select {
-		case client.send <- msg.data:
-		default:
-			go client.conn.Close()
-		}
+		client.deliver(msg.data)
+	}
+}
+
+func (h *Hub) deliverToUser(msg outbound) {
+	for _, client := range h.getUserClients(msg.userID) {
+		client.deliver(msg.data)
 	}
 }
 
-func (h *Hub) StartRedisFanIn(ctx context.Context, rdb *redis.Client) {
+// StartRedisFanIn subscribes to the hub channels and returns once the subscription is confirmed, so that
+// nothing published afterwards is missed. A broken subscription is re-established with a backoff until ctx is done.
+func (h *Hub) StartRedisFanIn(ctx context.Context) {
+	if h.rdb == nil {
+		return
+	}
+	pubsub := h.subscribeToRedis(ctx)
 	go func() {
-		for {
-			pubsub := rdb.PSubscribe(ctx, "offer.*")
-			ch := pubsub.Channel()
-
-			for msg := range ch {
-				id := strings.TrimPrefix(msg.Channel, "offer.")
-				h.broadcast <- outbound{
-					offerID: id,
-					data:    []byte(msg.Payload),
+		for backoff := time.Second; ; {
+			if pubsub != nil {
+				stop := context.AfterFunc(ctx, func() { _ = pubsub.Close() })
+				for msg := range pubsub.Channel() {
+					backoff = time.Second
+					h.handleRedisMessage(msg)
 				}
+				stop()
+				_ = pubsub.Close()
 			}
 
-			_ = pubsub.Close()
-			for backoff := time.Second; ; {
-				select {
-				case <-ctx.Done():
-					return
-				case <-time.After(backoff):
-				}
-
-				if backoff < 30*time.Second {
-					backoff *= 2
-				}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			if backoff < 30*time.Second {
+				backoff *= 2
 			}
+			pubsub = h.subscribeToRedis(ctx)
 		}
 	}()
 }
 
+func (h *Hub) subscribeToRedis(ctx context.Context) *redis.PubSub {
+	pubsub := h.rdb.PSubscribe(ctx, userChannelPrefix+"*", hubEventsChannel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		log.Printf("hub: cannot subscribe to redis: %v", err)
+		_ = pubsub.Close()
+		return nil
+	}
+	return pubsub
+}
+
+func (h *Hub) handleRedisMessage(msg *redis.Message) {
+	if msg.Channel == hubEventsChannel {
+		var event hubEvent
+		if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+			log.Printf("hub: invalid event %q: %v", msg.Payload, err)
+			return
+		}
+		h.apply(&event)
+		return
+	}
+	h.broadcast <- outbound{
+		userID: strings.TrimPrefix(msg.Channel, userChannelPrefix),
+		data:   []byte(msg.Payload),
+	}
+}
+
+// dispatch hands the event to every instance through redis, or applies it locally in the single instance mode.
+// If redis cannot be reached the event is applied locally, so at least this instance's clients get it.
+func (h *Hub) dispatch(event *hubEvent) {
+	if h.rdb == nil {
+		h.apply(event)
+		return
+	}
+	if err := publishHubEvent(context.Background(), h.rdb, event); err != nil {
+		log.Printf("hub: cannot publish %s event, applying it locally: %v", event.Kind, err)
+		h.apply(event)
+	}
+}
+
+func (h *Hub) apply(event *hubEvent) {
+	switch event.Kind {
+	case eventBroadcast:
+		h.broadcast <- outbound{
+			offerID:   event.OfferID,
+			data:      event.Data,
+			excludeID: event.ExcludeID,
+		}
+	case eventSubscribe:
+		for _, client := range h.getUserClients(event.UserID) {
+			h.subscribe <- subscription{event.OfferID, client}
+		}
+	case eventUnsubscribe:
+		for _, client := range h.getUserClients(event.UserID) {
+			h.unsubscribe <- subscription{event.OfferID, client}
+		}
+	case eventRemoveRoom:
+		h.broadcast <- outbound{offerID: event.OfferID, removeRoom: true}
+	case eventRoomNotifications:
+		h.sendLatestNotificationsToRoom(event.OfferID, event.ExcludeID)
+	case eventUserNotifications:
+		for _, client := range h.getUserClients(event.UserID) {
+			h.SendFourLatestNotificationsToClient(client)
+		}
+	case eventSaveNotification:
+		h.saveNotificationForRoom(event.OfferID, event.NotificationID, event.ExcludedUserIDs)
+	default:
+		log.Printf("hub: unknown event kind %q", event.Kind)
+	}
+}
+
 func (h *Hub) SubscribeUser(userID, offerID string) {
-	client, ok := h.getClientFromRoom(userID)
-	if !ok {
+	h.dispatch(&hubEvent{Kind: eventSubscribe, UserID: userID, OfferID: offerID})
+}
+
+// Broadcast sends the envelope to everyone in the offer room except excludeID, on every instance. It gets
+// the next sequence number of the room, and is kept for the clients that resume after a reconnect.
+func (h *Hub) Broadcast(offerID string, envelope *Envelope, excludeID string) {
+	data, err := h.sequence(offerID, envelope, excludeID)
+	if err != nil {
+		log.Printf("hub: cannot broadcast to room %s: %v", offerID, err)
 		return
 	}
-	h.subscribe <- subscription{offerID, client}
+	h.dispatch(&hubEvent{Kind: eventBroadcast, OfferID: offerID, Data: data, ExcludeID: excludeID})
 }
 
-func (h *Hub) BroadcastLocal(offerID string, data []byte, excludeID string) {
-	h.broadcast <- outbound{
-		offerID:   offerID,
-		data:      data,
-		excludeID: excludeID,
+func (h *Hub) sequence(offerID string, envelope *Envelope, excludeID string) ([]byte, error) {
+	if h.rdb != nil {
+		return appendRoomEvent(context.Background(), h.rdb, offerID, envelope, excludeID)
 	}
+	h.mu.Lock()
+	h.seqs[offerID]++
+	seq := h.seqs[offerID]
+	h.mu.Unlock()
+	return sequenced(envelope, offerID, seq)
 }
 
 func (h *Hub) SaveNotificationForClients(offerID string, userID uint, n *models.Notification) error {
+	return h.SaveNotificationForClientsExcept(offerID, []uint{userID}, n)
+}
+
+// SaveNotificationForClientsExcept works like SaveNotificationForClients but skips all the given users,
+// e.g. the ones who opted out of this kind of notification. The notification is saved for everyone who
+// interacted with the offer, and for the members of the offer room on every instance.
+func (h *Hub) SaveNotificationForClientsExcept(offerID string, excludedUserIDs []uint, n *models.Notification) error {
 	offerIDUint, err := strconv.ParseUint(offerID, 10, 64)
 	if err != nil {
 		log.Printf("Failed to convert offerID %s to uint: %v", offerID, err)
@@ -193,55 +351,70 @@ func (h *Hub) SaveNotificationForClients(offerID string, userID uint, n *models.
 		log.Printf("Failed to fetch user offer interactions for offerID %s: %v", offerID, err)
 		return err
 	}
-	unique := h.prepareUniqueUserMap(interactions, offerID)
-	for uid := range unique {
-		if uid == userID {
+	saved := slices.Clone(excludedUserIDs)
+	for _, interaction := range interactions {
+		if slices.Contains(saved, interaction.UserID) {
 			continue
 		}
-		err := h.notificationService.SaveNotificationToClient(n, uid)
-		if err != nil {
-			log.Printf("Failed to save notification for userID %d: %v", uid, err)
-			continue
+		saved = append(saved, interaction.UserID)
+		if err := h.notificationService.SaveNotificationToClient(n, interaction.UserID); err != nil {
+			log.Printf("Failed to save notification for userID %d: %v", interaction.UserID, err)
 		}
 	}
+	// the room members are known only to the instance holding their connection, the users saved above are skipped
+	h.dispatch(&hubEvent{Kind: eventSaveNotification, OfferID: offerID, NotificationID: n.ID, ExcludedUserIDs: saved})
 	return nil
 }
 
-func (h *Hub) prepareUniqueUserMap(interactions []views.UserOfferRecord, offerID string) map[uint]struct{} {
-	unique := make(map[uint]struct{})
-	for _, interaction := range interactions {
-		unique[interaction.UserID] = struct{}{}
+// saveNotificationForRoom saves the notification for the users connected to this instance who are in the
+// offer room. Saving it for a user who already has it does nothing, so instances may overlap.
+func (h *Hub) saveNotificationForRoom(offerID string, notificationID uint, excludedUserIDs []uint) {
+	room, ok := h.getRoom(offerID)
+	if !ok {
+		return
 	}
-
-	h.mu.RLock()
-	if room, ok := h.rooms[offerID]; ok {
-		for client := range room {
-			uid, err := strconv.ParseUint(client.userID, 10, 64)
-			if err != nil {
-				log.Printf("Failed to convert client.userID %s to uint: %v", client.userID, err)
-				continue
-			}
-			unique[uint(uid)] = struct{}{}
+	saved := slices.Clone(excludedUserIDs)
+	for _, client := range room {
+		uid, err := strconv.ParseUint(client.userID, 10, 64)
+		if err != nil {
+			log.Printf("Failed to convert client.userID %s to uint: %v", client.userID, err)
+			continue
+		}
+		// a user with several connections is in the room more than once
+		if slices.Contains(saved, uint(uid)) {
+			continue
+		}
+		saved = append(saved, uint(uid))
+		if err := h.notificationService.SaveNotificationToClient(&models.Notification{ID: notificationID}, uint(uid)); err != nil {
+			log.Printf("Failed to save notification for userID %d: %v", uid, err)
 		}
 	}
-	h.mu.RUnlock()
-	return unique
 }
 
 func (h *Hub) SendFourLatestNotificationsToClients(offerID, userID string) {
+	h.dispatch(&hubEvent{Kind: eventRoomNotifications, OfferID: offerID, ExcludeID: userID})
+}
+
+func (h *Hub) sendLatestNotificationsToRoom(offerID, excludeID string) {
 	room, ok := h.getRoom(offerID)
 	if !ok {
 		return
 	}
 
-	for client := range room {
-		if client.userID == userID {
+	for _, client := range room {
+		if client.userID == excludeID {
 			continue
 		}
 		h.SendFourLatestNotificationsToClient(client)
 	}
 }
 
+// SendFourLatestNotificationsToUser pushes the latest notifications to the user if they are connected,
+// regardless of the rooms they are in and of the instance they are connected to.
+func (h *Hub) SendFourLatestNotificationsToUser(userID string) {
+	h.dispatch(&hubEvent{Kind: eventUserNotifications, UserID: userID})
+}
+
 func (h *Hub) SendFourLatestNotificationsToClient(client *Client) {
 	uid, err := strconv.ParseUint(client.userID, 10, 64)
 	if err != nil {
@@ -253,54 +426,53 @@ func (h *Hub) SendFourLatestNotificationsToClient(client *Client) {
 		log.Printf("hub: cannot get latest notifications for userID %q: %v", client.userID, err)
 		return
 	}
-	payload, err := json.Marshal(bare)
+	// the first version of the protocol sends the notifications as they are, without an envelope
+	var payload []byte
+	if client.version == ProtocolV1 {
+		payload, err = json.Marshal(bare)
+	} else {
+		payload, err = json.Marshal(NewEnvelope(MsgNotifications, bare))
+	}
 	if err != nil {
 		log.Printf("hub: cannot marshal notifications for userID %q: %v", client.userID, err)
 		return
 	}
-	select {
-	case client.send <- payload:
-	default:
-		log.Printf("hub: dropping notifications for userID %q - channel full", client.userID)
-		go client.conn.Close()
-	}
-
+	client.deliver(payload)
 }
 
 func (h *Hub) UnsubscribeUser(userID, offerID string) {
-	client, ok := h.getClientFromRoom(userID)
-	if !ok {
-		return
-	}
-
-	h.unsubscribe <- subscription{offerID, client}
-	log.Printf("hub: removed client %s from room %s", userID, offerID)
+	h.dispatch(&hubEvent{Kind: eventUnsubscribe, UserID: userID, OfferID: offerID})
 }
 
 func (h *Hub) RemoveRoom(offerID string) {
+	h.dispatch(&hubEvent{Kind: eventRemoveRoom, OfferID: offerID})
+}
+
+func (h *Hub) removeRoom(offerID string) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
-	if _, ok := h.rooms[offerID]; ok {
-		delete(h.rooms, offerID)
-		log.Printf("hub: removed room %s", offerID)
-	} else {
-		log.Printf("hub: room %s does not exist", offerID)
+	room, ok := h.rooms[offerID]
+	if !ok {
+		return
+	}
+	for client := range room {
+		delete(client.rooms, offerID)
 	}
+	delete(h.rooms, offerID)
+	log.Printf("hub: removed room %s", offerID)
 }
 
-func (h *Hub) getClientFromRoom(userID string) (*Client, bool) {
+// getUserClients returns all the connections the user has to this instance.
+func (h *Hub) getUserClients(userID string) []*Client {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
-	client, ok := h.clients[userID]
-	if !ok {
-		return nil, false
-	}
-	return client, true
+	return slices.Collect(maps.Keys(h.clients[userID]))
 }
 
-func (h *Hub) getRoom(offerID string) (map[*Client]struct{}, bool) {
+// getRoom returns a copy of the room, as connections may join it while it is iterated over.
+func (h *Hub) getRoom(offerID string) ([]*Client, bool) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
@@ -308,5 +480,5 @@ func (h *Hub) getRoom(offerID string) (map[*Client]struct{}, bool) {
 	if !ok {
 		return nil, false
 	}
-	return room, true
+	return slices.Collect(maps.Keys(room)), true
 }
@@ -2,10 +2,20 @@ package ws
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/susek555/BD2/car-dealer-api/internal/models"
 )
 
+// The protocol version is chosen by the client with the version query parameter of the handshake.
+// Version 1 is the original protocol, kept for the clients that do not ask for a version. Version 2 sends
+// the notifications as typed envelopes too, and lets the client resume after a reconnect.
+const (
+	ProtocolV1            = 1
+	ProtocolV2            = 2
+	LatestProtocolVersion = ProtocolV2
+)
+
 type MsgType string
 
 const (
@@ -14,10 +24,27 @@ const (
 	MsgSubscribe        MsgType = "subscribe"
 	MsgUnsubscribe      MsgType = "unsubscribe"
 	MsgGetNotifications MsgType = "get_notifications"
+	MsgAuctionExtended  MsgType = "auction_extended"
+	MsgNewBid           MsgType = "new_bid"
+	MsgOfferSold        MsgType = "offer_sold"
+	MsgSendMessage      MsgType = "send_message"
+	MsgMessage          MsgType = "message"
+	MsgTyping           MsgType = "typing"
+	MsgReadReceipt      MsgType = "read_receipt"
+	MsgNotifications    MsgType = "notifications"
+	MsgWelcome          MsgType = "welcome"
+	MsgAck              MsgType = "ack"
+	MsgResume           MsgType = "resume"
+	MsgResync           MsgType = "resync"
 )
 
+// Envelope is every message sent either way. A client may give its message an ID, which the server answers
+// with an ack or an error carrying the same ID. Room events carry the room and their sequence number in it.
 type Envelope struct {
 	MessageType MsgType         `json:"type"`
+	ID          string          `json:"id,omitempty"`
+	Room        string          `json:"room,omitempty"`
+	Seq         uint64          `json:"seq,omitempty"`
 	Data        json.RawMessage `json:"data,omitempty"`
 }
 
@@ -27,6 +54,78 @@ type SubscribePayload struct {
 type UnsubscribePayload struct {
 	Offers []string `json:"offers"`
 }
+type ErrorPayload struct {
+	Message string `json:"message"`
+}
+
+type WelcomePayload struct {
+	Version int `json:"version"`
+}
+
+// ResumePayload carries the last sequence number the client has seen in each room. The events it missed are
+// replayed, after which it may still get some of them live - anything not newer than what it has seen is
+// a duplicate and can be skipped.
+type ResumePayload struct {
+	Rooms map[string]uint64 `json:"rooms"`
+}
+
+// ResyncPayload tells the client that the events it missed in the room are no longer kept, so it has to
+// fetch the current state of the offer instead.
+type ResyncPayload struct {
+	Room string `json:"room"`
+	Seq  uint64 `json:"seq"`
+}
+
+// SendMessagePayload is sent by a client to post a message - to an existing conversation, or to the seller
+// of OfferID, which starts the conversation if there is none yet.
+type SendMessagePayload struct {
+	ConversationID *uint  `json:"conversation_id,omitempty"`
+	OfferID        *uint  `json:"offer_id,omitempty"`
+	Body           string `json:"body"`
+}
+
+// TypingPayload is sent by a client while they type; the server fills in UserID before passing it on.
+type TypingPayload struct {
+	ConversationID uint `json:"conversation_id"`
+	UserID         uint `json:"user_id,omitempty"`
+}
+
+// ReadReceiptPayload is sent by a client once they have read a conversation. The server fills in the reader
+// and the time before passing it on to the other participant.
+type ReadReceiptPayload struct {
+	ConversationID uint       `json:"conversation_id"`
+	ReaderID       uint       `json:"reader_id,omitempty"`
+	ReadAt         *time.Time `json:"read_at,omitempty"`
+}
+
+type AuctionExtendedPayload struct {
+	OfferID uint      `json:"offer_id"`
+	DateEnd time.Time `json:"date_end"`
+}
+
+// NewBidPayload tells the room about the leading bid of the auction, after proxy bids answered it.
+type NewBidPayload struct {
+	OfferID uint `json:"offer_id"`
+	Amount  uint `json:"amount"`
+}
+
+// OfferSoldPayload tells the room that the offer is sold - bought, bought out or won at the end of the auction.
+type OfferSoldPayload struct {
+	OfferID uint `json:"offer_id"`
+	Price   uint `json:"price"`
+}
+
+// NewEnvelope wraps any payload, returning nil if it cannot be marshalled.
+func NewEnvelope(messageType MsgType, payload any) *Envelope {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil
+	}
+	return &Envelope{
+		MessageType: messageType,
+		Data:        data,
+	}
+}
 
 func NewNotificationEnvelope(notification *models.Notification) *Envelope {
 	data, err := json.Marshal(notification)
@@ -38,3 +137,22 @@ func NewNotificationEnvelope(notification *models.Notification) *Envelope {
 		Data:        data,
 	}
 }
+
+func NewAuctionExtendedEnvelope(offerID uint, dateEnd time.Time) *Envelope {
+	data, err := json.Marshal(AuctionExtendedPayload{OfferID: offerID, DateEnd: dateEnd})
+	if err != nil {
+		return nil
+	}
+	return &Envelope{
+		MessageType: MsgAuctionExtended,
+		Data:        data,
+	}
+}
+
+func NewBidEnvelope(offerID uint, amount uint) *Envelope {
+	return NewEnvelope(MsgNewBid, NewBidPayload{OfferID: offerID, Amount: amount})
+}
+
+func NewOfferSoldEnvelope(offerID uint, price uint) *Envelope {
+	return NewEnvelope(MsgOfferSold, OfferSoldPayload{OfferID: offerID, Price: price})
+}
@@ -0,0 +1,135 @@
+package ws
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/susek555/BD2/car-dealer-api/pkg/custom_errors"
+)
+
+const (
+	DefaultMaxConnections = 5
+	presenceKeyPrefix     = "presence."
+)
+
+var (
+	ErrTooManyConnections    = errors.New("too many connections")
+	ErrInvalidMaxConnections = errors.New("invalid maximum number of connections")
+)
+
+type OnlineStatusDTO struct {
+	UserID uint `json:"user_id"`
+	Online bool `json:"online"`
+}
+
+// ParseMaxConnections reads how many connections a single user may have, DefaultMaxConnections if empty.
+func ParseMaxConnections(value string) (int, error) {
+	if strings.TrimSpace(value) == "" {
+		return DefaultMaxConnections, nil
+	}
+	limit, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || limit <= 0 {
+		return 0, ErrInvalidMaxConnections
+	}
+	return limit, nil
+}
+
+func newConnectionID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// presenceKey holds the connections of the user in a sorted set, scored by the time they stop counting
+// unless the client answers a ping. This way the connections of a crashed instance expire on their own.
+func presenceKey(userID string) string {
+	return presenceKeyPrefix + userID
+}
+
+// trackPresence records the connection, and is called again on every pong to keep it alive.
+func (h *Hub) trackPresence(client *Client) {
+	if h.rdb == nil {
+		return
+	}
+	ctx := context.Background()
+	key := presenceKey(client.userID)
+	expiresAt := time.Now().Add(pongWait)
+	_, err := h.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, key, redis.Z{Score: float64(expiresAt.UnixMilli()), Member: client.id})
+		pipe.Expire(ctx, key, pongWait)
+		return nil
+	})
+	if err != nil {
+		log.Printf("hub: cannot track presence of userID %q: %v", client.userID, err)
+	}
+}
+
+func (h *Hub) untrackPresence(client *Client) {
+	if h.rdb == nil {
+		return
+	}
+	if err := h.rdb.ZRem(context.Background(), presenceKey(client.userID), client.id).Err(); err != nil {
+		log.Printf("hub: cannot untrack presence of userID %q: %v", client.userID, err)
+	}
+}
+
+// connectionCount counts the live connections of the user on all instances.
+func (h *Hub) connectionCount(userID string) (int, error) {
+	if h.rdb == nil {
+		return len(h.getUserClients(userID)), nil
+	}
+	ctx := context.Background()
+	key := presenceKey(userID)
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	var count *redis.IntCmd
+	_, err := h.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRemRangeByScore(ctx, key, "-inf", now)
+		count = pipe.ZCard(ctx, key)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(count.Val()), nil
+}
+
+func (h *Hub) IsOnline(userID string) (bool, error) {
+	count, err := h.connectionCount(userID)
+	return count > 0, err
+}
+
+// GetOnlineStatus godoc
+//
+//	@Summary		Check if a user is online
+//	@Description	Tells whether the user has at least one open websocket connection, to any instance of the server.
+//	@Tags			users
+//	@Produce		json
+//	@Param			id	path		uint					true	"User ID"
+//	@Success		200	{object}	OnlineStatusDTO			"Online status"
+//	@Failure		400	{object}	custom_errors.HTTPError	"Invalid user ID"
+//	@Failure		500	{object}	custom_errors.HTTPError	"Internal server error"
+//	@Router			/users/{id}/online [get]
+func GetOnlineStatus(hub HubInterface) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, custom_errors.NewHTTPError(err.Error()))
+			return
+		}
+		online, err := hub.IsOnline(strconv.FormatUint(id, 10))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, custom_errors.NewHTTPError(err.Error()))
+			return
+		}
+		c.JSON(http.StatusOK, OnlineStatusDTO{UserID: uint(id), Online: online})
+	}
+}
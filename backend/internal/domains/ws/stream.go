@@ -0,0 +1,154 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// RoomStreamLength is roughly how many of the latest events of a room are kept for replays
+	RoomStreamLength = 1000
+	roomStreamTTL    = 24 * time.Hour
+
+	roomSeqKeyPrefix    = "room.seq."
+	roomStreamKeyPrefix = "room.stream."
+)
+
+var (
+	ErrUnsupportedProtocolVersion = errors.New("unsupported protocol version")
+	ErrResumeNotSupported         = errors.New("resume requires protocol version 2")
+)
+
+// appendScript numbers the event and keeps it in the room's stream in one step, so that the stream IDs
+// follow the sequence numbers: event n is stored as "n-1".
+var appendScript = redis.NewScript(`
+local seq = redis.call('INCR', KEYS[1])
+redis.call('XADD', KEYS[2], 'MAXLEN', '~', ARGV[3], seq .. '-1', 'envelope', ARGV[1], 'exclude', ARGV[2])
+redis.call('EXPIRE', KEYS[1], ARGV[4])
+redis.call('EXPIRE', KEYS[2], ARGV[4])
+return seq
+`)
+
+// roomEvent is an event kept for replays, without the recipient it was not meant for.
+type roomEvent struct {
+	data      []byte
+	excludeID string
+}
+
+// ParseProtocolVersion reads the version asked for in the handshake, ProtocolV1 if none.
+func ParseProtocolVersion(value string) (int, error) {
+	if strings.TrimSpace(value) == "" {
+		return ProtocolV1, nil
+	}
+	version, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || version < ProtocolV1 || version > LatestProtocolVersion {
+		return 0, ErrUnsupportedProtocolVersion
+	}
+	return version, nil
+}
+
+// appendRoomEvent gives the envelope the next sequence number of the room and keeps it for replays,
+// returning the envelope ready to be sent.
+func appendRoomEvent(ctx context.Context, rdb *redis.Client, offerID string, envelope *Envelope, excludeID string) ([]byte, error) {
+	stored, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, err
+	}
+	seq, err := appendScript.Run(ctx, rdb,
+		[]string{roomSeqKeyPrefix + offerID, roomStreamKeyPrefix + offerID},
+		stored, excludeID, RoomStreamLength, int(roomStreamTTL.Seconds()),
+	).Uint64()
+	if err != nil {
+		return nil, err
+	}
+	return sequenced(envelope, offerID, seq)
+}
+
+func sequenced(envelope *Envelope, offerID string, seq uint64) ([]byte, error) {
+	numbered := *envelope
+	numbered.Room = offerID
+	numbered.Seq = seq
+	return json.Marshal(numbered)
+}
+
+// readRoomEvents returns the events of the room newer than after. It also tells whether they are all the
+// events the client missed, which is not the case once the older ones were trimmed or expired.
+func readRoomEvents(ctx context.Context, rdb *redis.Client, offerID string, after uint64) ([]roomEvent, uint64, bool, error) {
+	last, err := rdb.Get(ctx, roomSeqKeyPrefix+offerID).Uint64()
+	if errors.Is(err, redis.Nil) {
+		return nil, 0, after == 0, nil
+	}
+	if err != nil {
+		return nil, 0, false, err
+	}
+	if after >= last {
+		return nil, last, after == last, nil
+	}
+	entries, err := rdb.XRange(ctx, roomStreamKeyPrefix+offerID, strconv.FormatUint(after+1, 10)+"-0", "+").Result()
+	if err != nil {
+		return nil, last, false, err
+	}
+	events := make([]roomEvent, 0, len(entries))
+	complete := true
+	for i, entry := range entries {
+		seq, err := strconv.ParseUint(strings.TrimSuffix(entry.ID, "-1"), 10, 64)
+		if err != nil {
+			return nil, last, false, err
+		}
+		if i == 0 && seq != after+1 {
+			complete = false
+		}
+		var envelope Envelope
+		if err := json.Unmarshal([]byte(entry.Values["envelope"].(string)), &envelope); err != nil {
+			return nil, last, false, err
+		}
+		data, err := sequenced(&envelope, offerID, seq)
+		if err != nil {
+			return nil, last, false, err
+		}
+		excludeID, _ := entry.Values["exclude"].(string)
+		events = append(events, roomEvent{data: data, excludeID: excludeID})
+	}
+	if len(entries) == 0 {
+		complete = false
+	}
+	return events, last, complete, nil
+}
+
+// resume replays the events the client missed in the given rooms while it was disconnected, and refreshes
+// its notifications. The client joins the rooms again, in case it was in them only by subscribing. For the
+// rooms whose missed events are no longer kept it gets a resync instead.
+func (h *Hub) resume(client *Client, rooms map[string]uint64) {
+	for offerID, after := range rooms {
+		h.subscribe <- subscription{offerID, client}
+		if h.rdb == nil {
+			h.mu.RLock()
+			last := h.seqs[offerID]
+			h.mu.RUnlock()
+			if after != last {
+				client.sendEnvelope(NewEnvelope(MsgResync, ResyncPayload{Room: offerID, Seq: last}))
+			}
+			continue
+		}
+		events, last, complete, err := readRoomEvents(context.Background(), h.rdb, offerID, after)
+		if err != nil || !complete {
+			client.sendEnvelope(NewEnvelope(MsgResync, ResyncPayload{Room: offerID, Seq: last}))
+			continue
+		}
+		for _, event := range events {
+			if event.excludeID == client.userID {
+				continue
+			}
+			if !client.deliverWithin(event.data, writeWait) {
+				return
+			}
+		}
+	}
+	h.SendFourLatestNotificationsToClient(client)
+}
@@ -0,0 +1,39 @@
+package enums
+
+import (
+	"database/sql/driver"
+)
+
+type AdminAction string
+
+var (
+	UNPUBLISH_OFFER AdminAction = "Unpublish offer"
+	DELETE_OFFER    AdminAction = "Delete offer"
+	DELETE_REVIEW   AdminAction = "Delete review"
+	BAN_USER        AdminAction = "Ban user"
+	UNBAN_USER      AdminAction = "Unban user"
+	VIEW_BIDS       AdminAction = "View bids"
+	RESOLVE_REPORT  AdminAction = "Resolve report"
+	CHANGE_ROLE     AdminAction = "Change role"
+)
+
+func (a *AdminAction) Scan(value any) error {
+	var sValue string
+	switch v := value.(type) {
+	case string:
+		sValue = v
+	case []byte:
+		sValue = string(v)
+	default:
+		return ErrStringConversion
+	}
+	*a = AdminAction(convertDBFormatToAppFormat(sValue, false))
+	return nil
+}
+
+func (a AdminAction) Value() (driver.Value, error) {
+	return convertAppFormatToDBFormat(string(a)), nil
+}
+
+var AdminActions = []AdminAction{
+	UNPUBLISH_OFFER, DELETE_OFFER, DELETE_REVIEW, BAN_USER, UNBAN_USER, VIEW_BIDS, RESOLVE_REPORT, CHANGE_ROLE}
@@ -0,0 +1,31 @@
+package enums
+
+import (
+	"database/sql/driver"
+)
+
+type OfferEventKind string
+
+var (
+	PRICE_CHANGED       OfferEventKind = "Price changed"
+	STATUS_CHANGED      OfferEventKind = "Status changed"
+	DESCRIPTION_CHANGED OfferEventKind = "Description changed"
+)
+
+func (k *OfferEventKind) Scan(value any) error {
+	var sValue string
+	switch v := value.(type) {
+	case string:
+		sValue = v
+	case []byte:
+		sValue = string(v)
+	default:
+		return ErrStringConversion
+	}
+	*k = OfferEventKind(convertDBFormatToAppFormat(sValue, false))
+	return nil
+}
+
+func (k OfferEventKind) Value() (driver.Value, error) {
+	return convertAppFormatToDBFormat(string(k)), nil
+}
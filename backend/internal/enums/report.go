@@ -0,0 +1,69 @@
+package enums
+
+import (
+	"database/sql/driver"
+)
+
+type ReportReason string
+
+var (
+	SCAM         ReportReason = "Scam"
+	MISLEADING   ReportReason = "Misleading"
+	FAKE_REVIEW  ReportReason = "Fake review"
+	OFFENSIVE    ReportReason = "Offensive"
+	SPAM         ReportReason = "Spam"
+	OTHER_REASON ReportReason = "Other"
+	// filed by the system only
+	DUPLICATE_IMAGES ReportReason = "Duplicate images"
+)
+
+// ReportReasons are the reasons users can report content for
+var ReportReasons = []ReportReason{SCAM, MISLEADING, FAKE_REVIEW, OFFENSIVE, SPAM, OTHER_REASON}
+
+// SystemReportReasons are the reasons of the reports filed by the system
+var SystemReportReasons = []ReportReason{DUPLICATE_IMAGES}
+
+func (r *ReportReason) Scan(value any) error {
+	var sValue string
+	switch v := value.(type) {
+	case string:
+		sValue = v
+	case []byte:
+		sValue = string(v)
+	default:
+		return ErrStringConversion
+	}
+	*r = ReportReason(convertDBFormatToAppFormat(sValue, false))
+	return nil
+}
+
+func (r ReportReason) Value() (driver.Value, error) {
+	return convertAppFormatToDBFormat(string(r)), nil
+}
+
+type ReportOutcome string
+
+var (
+	ACTION_TAKEN ReportOutcome = "Action taken"
+	DISMISSED    ReportOutcome = "Dismissed"
+)
+
+var ReportOutcomes = []ReportOutcome{ACTION_TAKEN, DISMISSED}
+
+func (o *ReportOutcome) Scan(value any) error {
+	var sValue string
+	switch v := value.(type) {
+	case string:
+		sValue = v
+	case []byte:
+		sValue = string(v)
+	default:
+		return ErrStringConversion
+	}
+	*o = ReportOutcome(convertDBFormatToAppFormat(sValue, false))
+	return nil
+}
+
+func (o ReportOutcome) Value() (driver.Value, error) {
+	return convertAppFormatToDBFormat(string(o)), nil
+}
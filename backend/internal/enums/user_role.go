@@ -0,0 +1,33 @@
+package enums
+
+import (
+	"database/sql/driver"
+)
+
+type Role string
+
+var (
+	USER      Role = "User"
+	MODERATOR Role = "Moderator"
+	ADMIN     Role = "Admin"
+)
+
+var Roles = []Role{USER, MODERATOR, ADMIN}
+
+func (r *Role) Scan(value any) error {
+	var sValue string
+	switch v := value.(type) {
+	case string:
+		sValue = v
+	case []byte:
+		sValue = string(v)
+	default:
+		return ErrStringConversion
+	}
+	*r = Role(convertDBFormatToAppFormat(sValue, false))
+	return nil
+}
+
+func (r Role) Value() (driver.Value, error) {
+	return convertAppFormatToDBFormat(string(r)), nil
+}
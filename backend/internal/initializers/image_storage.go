@@ -0,0 +1,77 @@
+package initializers
+
+import (
+	"log"
+	"os"
+
+	"github.com/cloudinary/cloudinary-go/v2"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/image"
+)
+
+const (
+	defaultLocalImageDir = "./uploads"
+	defaultLocalImageURL = "http://localhost:8080" + image.LocalImageRoute
+)
+
+var CloudinaryClient *cloudinary.Cloudinary
+var ImageBucket image.ImageBucketInterface
+
+// LocalImageDir is the directory served under image.LocalImageRoute, empty unless the images are stored locally.
+var LocalImageDir string
+
+func ConnectToImageStorage() {
+	storage, err := image.ParseImageStorage(os.Getenv("IMAGE_STORAGE"))
+	if err != nil {
+		log.Fatalf("invalid IMAGE_STORAGE: %v", err)
+	}
+	switch storage {
+	case image.LocalStorage:
+		connectToLocalStorage()
+	case image.S3Storage:
+		connectToS3()
+	default:
+		ConnectToCloudinary()
+	}
+	log.Printf("Storing images in %s", storage)
+}
+
+func ConnectToCloudinary() {
+	cloudinaryURL := os.Getenv("CLOUDINARY_URL")
+	cld, err := cloudinary.NewFromURL(cloudinaryURL)
+	if err != nil {
+		log.Fatal("Connection with cloudinary couldn't be established.")
+	}
+	CloudinaryClient = cld
+	ImageBucket = image.NewImageBucket(cld)
+}
+
+func connectToLocalStorage() {
+	dir := getEnvOrDefault("LOCAL_IMAGE_DIR", defaultLocalImageDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		log.Fatalf("could not create LOCAL_IMAGE_DIR: %v", err)
+	}
+	LocalImageDir = dir
+	ImageBucket = image.NewLocalImageBucket(dir, getEnvOrDefault("LOCAL_IMAGE_URL", defaultLocalImageURL))
+}
+
+func connectToS3() {
+	bucket, err := image.NewS3ImageBucket(image.S3Config{
+		Endpoint:  os.Getenv("S3_ENDPOINT"),
+		Region:    os.Getenv("S3_REGION"),
+		Bucket:    os.Getenv("S3_BUCKET"),
+		AccessKey: os.Getenv("S3_ACCESS_KEY"),
+		SecretKey: os.Getenv("S3_SECRET_KEY"),
+		PublicURL: os.Getenv("S3_PUBLIC_URL"),
+	})
+	if err != nil {
+		log.Fatalf("invalid s3 image storage: %v", err)
+	}
+	ImageBucket = bucket
+}
+
+func getEnvOrDefault(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,36 @@
+package initializers
+
+import (
+	"errors"
+	"log"
+	"os"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/admin"
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"gorm.io/gorm"
+)
+
+// PromoteInitialAdmin makes the user registered with INITIAL_ADMIN_EMAIL an admin, so that a fresh
+// deployment has someone who can hand out roles through the admin panel.
+func PromoteInitialAdmin() {
+	email := os.Getenv("INITIAL_ADMIN_EMAIL")
+	if email == "" {
+		return
+	}
+	user, err := UserRepo.GetByEmail(email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Printf("initial admin %s has not registered yet, restart the server after they do", email)
+		return
+	}
+	if err != nil {
+		log.Fatalf("cannot load initial admin: %v", err)
+	}
+	if user.Role == enums.ADMIN {
+		return
+	}
+	reason := "INITIAL_ADMIN_EMAIL"
+	if err := AdminRepo.ChangeRole(user.ID, enums.ADMIN, admin.NewAuditLog(user.ID, enums.CHANGE_ROLE, user.ID, &reason)); err != nil {
+		log.Fatalf("cannot promote initial admin: %v", err)
+	}
+	log.Printf("user %s is now an admin", email)
+}
@@ -1,43 +1,60 @@
 package initializers
 
 import (
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/admin"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/auction"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/auth"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/bid"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/car"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/conversation"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/image"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/job"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/liked_offer"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/manufacturer"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/model"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/notification"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/report"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/review"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/saved_search"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/user"
 )
 
+var AdminHandler *admin.Handler
 var AuctionHandler *auction.Handler
 var AuthHandler *auth.Handler
 var BidHandler *bid.Handler
 var CarHandler *car.Handler
+var ConversationHandler *conversation.Handler
 var ImageHandler *image.Handler
+var JobHandler *job.Handler
 var ManufacturerHandler *manufacturer.Handler
 var ModelHandler *model.Handler
+var ReportHandler *report.Handler
 var ReviewHandler *review.Handler
 var SaleOfferHandler *sale_offer.Handler
+var SavedSearchHandler *saved_search.Handler
 var LikedOfferHandler *liked_offer.Handler
 var UserHandler *user.Handler
 var NotificationHandler *notification.Handler
 
 func InitializeHandlers() {
-	AuctionHandler = auction.NewHandler(AuctionService, Sched, Hub, NotificationService)
+	AdminHandler = admin.NewHandler(AdminService)
+	AuctionHandler = auction.NewHandler(AuctionService, Sched, Hub)
 	AuthHandler = auth.NewHandler(AuthService)
-	BidHandler = bid.NewHandler(BidService, RedisClient, Hub, NotificationService, Sched)
+	BidHandler = bid.NewHandler(BidService, Hub, NotificationService, Sched)
 	CarHandler = car.NewHandler(CarService)
-	ImageHandler = image.NewHandler(ImageService, SaleOfferService)
+	ConversationHandler = conversation.NewHandler(ConversationService)
+	ImageHandler = image.NewHandler(ImageService, SaleOfferService, image.NewDuplicateNotifier(NotificationService, Hub, ReportService))
+	JobHandler = job.NewHandler(JobRunner)
 	ManufacturerHandler = manufacturer.NewHandler(ManufacturerService)
 	ModelHandler = model.NewHandler(ModelService)
+	ReportHandler = report.NewHandler(ReportService)
 	ReviewHandler = review.NewHandler(ReviewService)
-	SaleOfferHandler = sale_offer.NewHandler(SaleOfferService, Hub, NotificationService)
+	searchMatcher := saved_search.NewMatcher(SavedSearchRepo, ManufacturerRepo, NotificationService, Hub)
+	priceDropNotifier := liked_offer.NewPriceDropNotifier(LikedOfferRepo, NotificationService, Hub)
+	SaleOfferHandler = sale_offer.NewHandler(SaleOfferService, Hub, searchMatcher, priceDropNotifier)
+	SavedSearchHandler = saved_search.NewHandler(SavedSearchService)
 	LikedOfferHandler = liked_offer.NewHandler(LikedOfferService, Hub)
 	UserHandler = user.NewHandler(UserService)
 	NotificationHandler = notification.NewHandler(NotificationService)
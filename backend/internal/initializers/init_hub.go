@@ -2,6 +2,8 @@ package initializers
 
 import (
 	"context"
+	"log"
+	"os"
 
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/ws"
 )
@@ -9,8 +11,12 @@ import (
 var Hub ws.HubInterface
 
 func InitializeHub() {
-	Hub = ws.NewHub(NotificationService, UserOfferRepo)
+	maxConnections, err := ws.ParseMaxConnections(os.Getenv("WS_MAX_CONNECTIONS_PER_USER"))
+	if err != nil {
+		log.Fatalf("invalid WS_MAX_CONNECTIONS_PER_USER: %v", err)
+	}
+	Hub = ws.NewHub(NotificationService, UserOfferRepo, ConversationService, RedisClient, maxConnections)
 	go Hub.Run()
 	ctx := context.Background()
-	Hub.StartRedisFanIn(ctx, RedisClient)
+	Hub.StartRedisFanIn(ctx)
 }
@@ -1,46 +1,71 @@
 package initializers
 
 import (
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/admin"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/bid"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/conversation"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/image"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/job"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/liked_offer"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/manufacturer"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/model"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/notification"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/outbox"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/purchase"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/refresh_token"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/report"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/review"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/saved_search"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/scheduler"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/user"
 	"github.com/susek555/BD2/car-dealer-api/internal/views"
 )
 
+var AdminRepo admin.AdminRepositoryInterface
+var AuctionSettlementRepo scheduler.AuctionSettlementRepositoryInterface
 var BidRepo bid.BidRepositoryInterface
 var ClientNotificationRepo notification.ClientNotificationRepositoryInterface
+var ConversationRepo conversation.ConversationRepositoryInterface
 var ImageRepo image.ImageRepositoryInterface
+var JobRepo job.JobRepositoryInterface
 var LikedOfferRepo liked_offer.LikedOfferRepositoryInterface
+var ListingExpiryRepo scheduler.ListingExpiryRepositoryInterface
 var ManufacturerRepo manufacturer.ManufacturerRepositoryInterface
 var ModelRepo model.ModelRepositoryInterface
 var NotificationRepo notification.NotificationRepositoryInterface
+var OutboxRepo outbox.OutboxRepositoryInterface
+var ProxyBidRepo bid.ProxyBidRepositoryInterface
 var PurchaseRepo purchase.PurchaseRepositoryInterface
 var RefreshTokenRepo refresh_token.RefreshTokenRepositoryInterface
+var ReportRepo report.ReportRepositoryInterface
 var ReviewRepo review.ReviewRepositoryInterface
 var SaleOfferRepo sale_offer.SaleOfferRepositoryInterface
+var SavedSearchRepo saved_search.SavedSearchRepositoryInterface
 var UserRepo user.UserRepositoryInterface
 var UserOfferRepo views.UserOfferRepositoryInterface
 
 func InitializeRepos() {
+	AdminRepo = admin.NewAdminRepository(DB)
+	AuctionSettlementRepo = scheduler.NewAuctionSettlementRepository(DB)
 	BidRepo = bid.NewBidRepository(DB)
 	ClientNotificationRepo = notification.NewClientNotificationRepository(DB)
+	ConversationRepo = conversation.NewConversationRepository(DB)
 	ImageRepo = image.NewImageRepository(DB)
+	JobRepo = job.NewJobRepository(DB)
 	LikedOfferRepo = liked_offer.NewLikedOfferRepository(DB)
+	ListingExpiryRepo = scheduler.NewListingExpiryRepository(DB)
 	ManufacturerRepo = manufacturer.NewManufacturerRepository(DB)
 	ModelRepo = model.NewModelRepository(DB)
 	NotificationRepo = notification.NewNotificationRepository(DB)
+	OutboxRepo = outbox.NewOutboxRepository(DB)
+	ProxyBidRepo = bid.NewProxyBidRepository(DB)
 	PurchaseRepo = purchase.NewPurchaseRepository(DB)
 	RefreshTokenRepo = refresh_token.NewRefreshTokenRepository(DB)
+	ReportRepo = report.NewReportRepository(DB)
 	ReviewRepo = review.NewReviewRepository(DB)
 	SaleOfferRepo = sale_offer.NewSaleOfferRepository(DB)
+	SavedSearchRepo = saved_search.NewSavedSearchRepository(DB)
 	UserRepo = user.NewUserRepository(DB)
 	UserOfferRepo = views.NewUserOfferRepository(DB)
 }
@@ -2,17 +2,79 @@ package initializers
 
 import (
 	"context"
+	"log"
+	"time"
 
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/bid"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/image"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/job"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/outbox"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/report"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/review"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/scheduler"
 )
 
+var JobRunner *job.Runner
+var OutboxDispatcher *outbox.Dispatcher
 var Sched scheduler.SchedulerInterface
 
 func InitializeScheduler() {
-	Sched = scheduler.NewScheduler(BidRepo, RedisClient, NotificationService, SaleOfferRepo, PurchaseRepo, bid.SaleOfferAdapter{Svc: SaleOfferService}, Hub)
-	if err := Sched.LoadAuctions(); err != nil {
-		panic("failed to load auctions: " + err.Error())
+	// the leadership is taken care of by the job runner, which runs the scheduler as one of its workers
+	Sched = scheduler.NewScheduler(RedisClient, SaleOfferRepo, AuctionSettlementRepo, nil)
+
+	OutboxDispatcher = outbox.NewDispatcher(OutboxRepo, outbox.DispatchInterval)
+	scheduler.NewAuctionResultNotifier(NotificationService, Hub, bid.SaleOfferAdapter{Svc: SaleOfferService}).Register(OutboxDispatcher)
+	scheduler.NewListingExpiryNotifier(NotificationService, Hub, bid.SaleOfferAdapter{Svc: SaleOfferService}).Register(OutboxDispatcher)
+	report.NewReportResolvedNotifier(NotificationService, Hub).Register(OutboxDispatcher)
+	review.NewReviewReplyNotifier(NotificationService, Hub).Register(OutboxDispatcher)
+	sale_offer.NewPurchaseNotifier(NotificationService, Hub, SaleOfferService).Register(OutboxDispatcher)
+
+	listingExpirer := scheduler.NewListingExpirer(ListingExpiryRepo, ListingPolicy.ReminderBefore)
+	tokenPurgeSchedule, err := job.Cron("15 * * * *")
+	if err != nil {
+		log.Fatalf("invalid refresh token purge schedule: %v", err)
+	}
+	imageFolderCleanupSchedule, err := job.Cron("45 3 * * *")
+	if err != nil {
+		log.Fatalf("invalid image folder cleanup schedule: %v", err)
+	}
+	imageFolderCleaner := image.NewOrphanedFolderCleaner(ImageRepo, ImageBucket)
+
+	elector := scheduler.NewRedisLeaderElector(RedisClient, job.LeaderLeaseKey, scheduler.LeaderLeaseTTL)
+	JobRunner = job.NewRunner(JobRepo, elector, job.TickInterval)
+	mustRegister(JobRunner.RegisterWorker(job.Worker{Name: "auction-scheduler", Run: Sched.Lead}))
+	mustRegister(JobRunner.RegisterWorker(job.Worker{Name: "outbox-dispatcher", Run: OutboxDispatcher.Run}))
+	mustRegister(JobRunner.Register(job.Job{
+		Name:     "expire-listings",
+		Schedule: job.Every(10 * time.Minute),
+		Run: func(context.Context) error {
+			return listingExpirer.Sweep(time.Now())
+		},
+	}))
+	mustRegister(JobRunner.Register(job.Job{
+		Name:     "purge-refresh-tokens",
+		Schedule: tokenPurgeSchedule,
+		Run: func(context.Context) error {
+			purged, err := RefreshTokenRepo.DeleteExpired(time.Now())
+			if err == nil && purged > 0 {
+				log.Printf("jobs: purged %d expired refresh tokens", purged)
+			}
+			return err
+		},
+	}))
+	mustRegister(JobRunner.Register(job.Job{
+		Name:     "clean-image-folders",
+		Schedule: imageFolderCleanupSchedule,
+		Run: func(context.Context) error {
+			return imageFolderCleaner.Sweep()
+		},
+	}))
+	go JobRunner.Run(context.Background())
+}
+
+func mustRegister(err error) {
+	if err != nil {
+		log.Fatalf("jobs: %v", err)
 	}
-	go Sched.Run(context.Background())
 }
@@ -4,33 +4,43 @@ import (
 	"log"
 	"os"
 
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/admin"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/auction"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/auth"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/bid"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/car"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/conversation"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/image"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/liked_offer"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/manufacturer"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/model"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/notification"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/refresh_token"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/report"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/review"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/saved_search"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/user"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/ws"
 )
 
+var AdminService admin.AdminServiceInterface
 var AuctionService auction.AuctionServiceInterface
 var AuthService auth.AuthServiceInterface
 var BidService bid.BidServiceInterface
 var CarService car.CarServiceInterface
+var ConversationService conversation.ConversationServiceInterface
 var ImageService image.ImageServiceInterface
 var ManufacturerService manufacturer.ManufacturerServiceInterface
 var ModelService model.ModelServiceInterface
 var NotificationService notification.NotificationServiceInterface
 var RefreshTokenService refresh_token.RefreshTokenServiceInterface
+var ReportService report.ReportServiceInterface
 var ReviewService review.ReviewServiceInterface
 var SaleOfferService sale_offer.SaleOfferServiceInterface
+var SavedSearchService saved_search.SavedSearchServiceInterface
 var LikedOfferService liked_offer.LikedOfferServiceInterface
+var ListingPolicy sale_offer.ListingPolicy
 var AccessEvaluator sale_offer.OfferAccessEvaluatorInterface
 var UserService user.UserServiceInterface
 
@@ -45,12 +55,39 @@ func InitializeServices() {
 	ManufacturerService = manufacturer.NewManufacturerService(ManufacturerRepo)
 	ModelService = model.NewModelService(ModelRepo)
 	NotificationService = notification.NewNotificationService(NotificationRepo, ClientNotificationRepo)
-	ReviewService = review.NewReviewService(ReviewRepo)
+	reviewWindow, err := review.ParseReviewWindow(os.Getenv("REVIEW_WINDOW"))
+	if err != nil {
+		log.Fatalf("invalid REVIEW_WINDOW: %v", err)
+	}
+	ReviewService = review.NewReviewService(ReviewRepo, PurchaseRepo, reviewWindow)
 	AccessEvaluator = sale_offer.NewAccessEvaluator(BidRepo, LikedOfferRepo)
-	ImageService = image.NewImageService(ImageRepo, ImageBucket, SaleOfferRepo, AccessEvaluator)
-	SaleOfferService = sale_offer.NewSaleOfferService(SaleOfferRepo, ManufacturerRepo, ModelRepo, ImageRepo, ImageBucket, AccessEvaluator, PurchaseRepo)
-	AuctionService = auction.NewAuctionService(SaleOfferRepo, SaleOfferService, PurchaseRepo)
-	BidService = bid.NewBidService(BidRepo, bid.SaleOfferAdapter{Svc: SaleOfferService}, AuctionService)
+	ImageService = image.NewImageService(ImageRepo, ImageBucket, image.NewImageProcessor(image.NewPlateDetector()), SaleOfferRepo, AccessEvaluator)
+	listingPolicy, err := sale_offer.ParseListingPolicy(os.Getenv("LISTING_LIFETIME"), os.Getenv("LISTING_EXPIRY_REMINDER"))
+	if err != nil {
+		log.Fatalf("invalid listing expiry settings: %v", err)
+	}
+	ListingPolicy = listingPolicy
+	SaleOfferService = sale_offer.NewSaleOfferService(SaleOfferRepo, ManufacturerRepo, ModelRepo, ImageRepo, ImageBucket, AccessEvaluator, PurchaseRepo, ListingPolicy)
+	incrementTiers, err := auction.ParseIncrementTiers(os.Getenv("BID_INCREMENT_TIERS"))
+	if err != nil {
+		log.Fatalf("invalid BID_INCREMENT_TIERS: %v", err)
+	}
+	extensionPolicy, err := auction.ParseExtensionPolicy(os.Getenv("AUCTION_EXTENSION_WINDOW"), os.Getenv("AUCTION_EXTENSION_DURATION"), os.Getenv("AUCTION_MAX_EXTENSIONS"))
+	if err != nil {
+		log.Fatalf("invalid auction extension settings: %v", err)
+	}
+	// shared by bids and buy now, so that they cannot both win the same auction
+	auctionLocker := bid.NewRedisAuctionLocker(RedisClient, bid.AuctionLockTTL, bid.AuctionLockTimeout)
+	AuctionService = auction.NewAuctionService(SaleOfferRepo, SaleOfferService, auctionLocker, incrementTiers, extensionPolicy)
+	BidService = bid.NewBidService(BidRepo, ProxyBidRepo, bid.SaleOfferAdapter{Svc: SaleOfferService}, AuctionService, incrementTiers, auctionLocker)
 	LikedOfferService = liked_offer.NewLikedOfferService(LikedOfferRepo, SaleOfferRepo)
+	SavedSearchService = saved_search.NewSavedSearchService(SavedSearchRepo, ManufacturerRepo)
 	UserService = user.NewUserService(UserRepo)
+	AdminService = admin.NewAdminService(AdminRepo, SaleOfferRepo, UserRepo, BidRepo, ImageBucket)
+	hideThreshold, err := report.ParseHideThreshold(os.Getenv("REPORTS_HIDE_THRESHOLD"))
+	if err != nil {
+		log.Fatalf("invalid REPORTS_HIDE_THRESHOLD: %v", err)
+	}
+	ReportService = report.NewReportService(ReportRepo, SaleOfferRepo, ReviewRepo, hideThreshold)
+	ConversationService = conversation.NewConversationService(ConversationRepo, SaleOfferRepo, ws.NewRedisUserPublisher(RedisClient))
 }
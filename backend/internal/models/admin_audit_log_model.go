@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+)
+
+// AdminAuditLog records a single action taken by a moderator or an admin. TargetID points to the offer,
+// review or user the action was taken on, depending on the action.
+type AdminAuditLog struct {
+	ID        uint              `json:"id" gorm:"primaryKey"`
+	AdminID   uint              `json:"admin_id"`
+	Action    enums.AdminAction `json:"action" gorm:"type:ADMIN_ACTION"`
+	TargetID  uint              `json:"target_id"`
+	Reason    *string           `json:"reason"`
+	CreatedAt time.Time         `json:"created_at"`
+}
@@ -5,9 +5,11 @@ import (
 )
 
 type Auction struct {
-	OfferID      uint       `json:"id" gorm:"primaryKey"`
-	DateEnd      time.Time  `json:"date_end"`
-	BuyNowPrice  *uint      `json:"buy_now_price,omitempty"`
-	InitialPrice uint       `json:"initial_price"`
-	Offer        *SaleOffer `gorm:"foreignKey:OfferID;references:ID"`
+	OfferID        uint       `json:"id" gorm:"primaryKey"`
+	DateEnd        time.Time  `json:"date_end"`
+	BuyNowPrice    *uint      `json:"buy_now_price,omitempty"`
+	ReservePrice   *uint      `json:"-"`
+	ExtensionCount uint       `json:"-"`
+	InitialPrice   uint       `json:"initial_price"`
+	Offer          *SaleOffer `gorm:"foreignKey:OfferID;references:ID"`
 }
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// Conversation is a thread between a buyer and the seller about a single offer. There is at most one
+// conversation per offer and buyer.
+type Conversation struct {
+	ID            uint       `json:"id" gorm:"primaryKey"`
+	OfferID       uint       `json:"offer_id"`
+	BuyerID       uint       `json:"buyer_id"`
+	Buyer         *User      `gorm:"foreignKey:BuyerID;references:ID"`
+	SellerID      uint       `json:"seller_id"`
+	Seller        *User      `gorm:"foreignKey:SellerID;references:ID"`
+	LastMessageAt *time.Time `json:"last_message_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+func (c *Conversation) HasParticipant(userID uint) bool {
+	return c.BuyerID == userID || c.SellerID == userID
+}
+
+// OtherParticipant returns the ID of the user on the other side of the conversation.
+func (c *Conversation) OtherParticipant(userID uint) uint {
+	if c.BuyerID == userID {
+		return c.SellerID
+	}
+	return c.BuyerID
+}
+
+// Message is unread until the recipient sends a read receipt for its conversation.
+type Message struct {
+	ID             uint       `json:"id" gorm:"primaryKey"`
+	ConversationID uint       `json:"conversation_id"`
+	SenderID       uint       `json:"sender_id"`
+	Body           string     `json:"body"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ReadAt         *time.Time `json:"read_at"`
+}
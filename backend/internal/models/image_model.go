@@ -1,9 +1,49 @@
 package models
 
 type Image struct {
-	ID       uint       `json:"ID" gorm:"primaryKey"`
-	OfferID  uint       `json:"offer_id"`
-	Url      string     `json:"url"`
-	PublicID string     `json:"public_id"`
-	Offer    *SaleOffer `gorm:"foreignKey:OfferID;references:ID"`
+	ID                uint       `json:"ID" gorm:"primaryKey"`
+	OfferID           uint       `json:"offer_id"`
+	Url               string     `json:"url"`
+	PublicID          string     `json:"public_id"`
+	MediumUrl         string     `json:"medium_url"`
+	MediumPublicID    string     `json:"medium_public_id"`
+	ThumbnailUrl      string     `json:"thumbnail_url"`
+	ThumbnailPublicID string     `json:"thumbnail_public_id"`
+	Position          uint       `json:"position"`
+	IsCover           bool       `json:"is_cover"`
+	Caption           *string    `json:"caption"`
+	PerceptualHash    *int64     `json:"-"`
+	Offer             *SaleOffer `gorm:"foreignKey:OfferID;references:ID"`
+}
+
+// PublicIDs lists the stored files of the image, images uploaded before renditions existed only have the original.
+func (i *Image) PublicIDs() []string {
+	ids := []string{i.PublicID}
+	for _, id := range []string{i.MediumPublicID, i.ThumbnailPublicID} {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// ThumbnailOrOriginalUrl is the smallest available rendition of the image.
+func (i *Image) ThumbnailOrOriginalUrl() string {
+	if i.ThumbnailUrl != "" {
+		return i.ThumbnailUrl
+	}
+	return i.Url
+}
+
+// CoverImage picks the cover of an offer's gallery, the first image if none was chosen.
+func CoverImage(images []Image) *Image {
+	for i := range images {
+		if images[i].IsCover {
+			return &images[i]
+		}
+	}
+	if len(images) == 0 {
+		return nil
+	}
+	return &images[0]
 }
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// Job is the persisted state of a scheduled background job. NextRunAt is nil once a one-shot job is done.
+type Job struct {
+	Name      string     `json:"name" gorm:"primaryKey"`
+	NextRunAt *time.Time `json:"next_run_at"`
+	LastRunAt *time.Time `json:"last_run_at"`
+	LastError *string    `json:"last_error"`
+	Attempts  uint       `json:"attempts"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
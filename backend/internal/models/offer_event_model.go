@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+)
+
+// OfferEvent is a single entry of the offer's audit trail. ActorID is nil for changes made by the system.
+type OfferEvent struct {
+	ID        uint                 `json:"id" gorm:"primaryKey"`
+	OfferID   uint                 `json:"offer_id"`
+	ActorID   *uint                `json:"actor_id"`
+	Kind      enums.OfferEventKind `json:"kind" gorm:"type:OFFER_EVENT_KIND"`
+	OldValue  *string              `json:"old_value"`
+	NewValue  *string              `json:"new_value"`
+	CreatedAt time.Time            `json:"created_at"`
+}
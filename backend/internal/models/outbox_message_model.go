@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type OutboxMessage struct {
+	ID            uint       `json:"id"`
+	Kind          string     `json:"kind"`
+	Payload       string     `json:"payload"`
+	Attempts      uint       `json:"attempts"`
+	LastError     *string    `json:"last_error,omitempty"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	DeliveredAt   *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
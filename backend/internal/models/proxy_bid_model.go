@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type ProxyBid struct {
+	ID        uint      `json:"id"`
+	AuctionID uint      `json:"auction_id"`
+	BidderID  uint      `json:"bidder_id"`
+	MaxAmount uint      `json:"max_amount"`
+	Exhausted bool      `json:"exhausted"`
+	CreatedAt time.Time `json:"created_at"`
+	Bidder    *User     `gorm:"foreignKey:BidderID;references:ID"`
+	Auction   *Auction  `gorm:"foreignKey:AuctionID;references:OfferID;"`
+}
@@ -3,7 +3,7 @@ package models
 import "time"
 
 type Purchase struct {
-	OfferID    uint       `json:"offer_id"`
+	OfferID    uint       `json:"offer_id" gorm:"primaryKey"`
 	BuyerID    uint       `json:"buyer_id"`
 	FinalPrice uint       `json:"final_price"`
 	IssueDate  time.Time  `json:"issue_date"`
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+)
+
+// Report is a complaint about an offer or a review - exactly one of OfferID and ReviewID is set.
+// A report is open until a moderator resolves it with an outcome. The reports filed by the system have no ReporterID.
+type Report struct {
+	ID         uint                 `json:"id" gorm:"primaryKey"`
+	ReporterID *uint                `json:"reporter_id"`
+	OfferID    *uint                `json:"offer_id"`
+	ReviewID   *uint                `json:"review_id"`
+	Reason     enums.ReportReason   `json:"reason" gorm:"type:REPORT_REASON"`
+	Comment    *string              `json:"comment"`
+	Outcome    *enums.ReportOutcome `json:"outcome" gorm:"type:REPORT_OUTCOME"`
+	Note       *string              `json:"note"`
+	ResolvedBy *uint                `json:"resolved_by"`
+	ResolvedAt *time.Time           `json:"resolved_at"`
+	CreatedAt  time.Time            `json:"created_at"`
+}
+
+func (r *Report) IsResolved() bool {
+	return r.ResolvedAt != nil
+}
@@ -3,12 +3,31 @@ package models
 import "time"
 
 type Review struct {
-	ID          uint      `json:"id" gorm:"primaryKey"`
-	Description string    `json:"description"`
-	ReviewDate  time.Time `json:"date"`
-	Rating      uint      `json:"rating"`
-	ReviewerID  uint      `json:"reviewer_id"`
-	Reviewer    *User     `gorm:"foreignKey:ReviewerID;references:ID"`
-	RevieweeID  uint      `json:"reviewee_id"`
-	Reviewee    *User     `gorm:"foreignKey:RevieweeID;references:ID"`
+	ID              uint       `json:"id" gorm:"primaryKey"`
+	Description     string     `json:"description"`
+	ReviewDate      time.Time  `json:"date"`
+	Rating          uint       `json:"rating"`
+	ReviewerID      uint       `json:"reviewer_id"`
+	Reviewer        *User      `gorm:"foreignKey:ReviewerID;references:ID"`
+	RevieweeID      uint       `json:"reviewee_id"`
+	Reviewee        *User      `gorm:"foreignKey:RevieweeID;references:ID"`
+	OfferID         *uint      `json:"offer_id"`
+	Reply           *string    `json:"reply"`
+	RepliedAt       *time.Time `json:"replied_at"`
+	HelpfulVotes    uint       `json:"helpful_votes"`
+	NotHelpfulVotes uint       `json:"not_helpful_votes"`
+}
+
+// IsVerified tells whether the review was written for a completed purchase - reviews written before
+// they had to be linked to one are not.
+func (r *Review) IsVerified() bool {
+	return r.OfferID != nil
+}
+
+// ReviewVote is a single user's opinion on whether a review was helpful.
+type ReviewVote struct {
+	ReviewID  uint      `json:"review_id" gorm:"primaryKey"`
+	UserID    uint      `json:"user_id" gorm:"primaryKey"`
+	Helpful   bool      `json:"helpful"`
+	CreatedAt time.Time `json:"created_at"`
 }
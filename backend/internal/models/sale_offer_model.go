@@ -7,17 +7,21 @@ import (
 )
 
 type SaleOffer struct {
-	ID          uint              `json:"id" gorm:"primaryKey"`
-	UserID      uint              `json:"user_id"`
-	Description string            `json:"description"`
-	Price       uint              `json:"price"`
-	DateOfIssue time.Time         `json:"date_of_issue"`
-	Margin      enums.MarginValue `json:"margin" gorm:"type:MARGIN_VALUE"`
-	Status      enums.Status      `json:"status" gorm:"type:OFFER_STATUS"`
-	IsAuction   bool              `json:"is_auction"`
-	User        *User             `gorm:"foreignKey:UserID;references:ID"`
-	Car         *Car              `gorm:"foreignKey:OfferID;references:ID"`
-	Auction     *Auction          `gorm:"foreignKey:OfferID;references:ID"`
+	ID                 uint              `json:"id" gorm:"primaryKey"`
+	UserID             uint              `json:"user_id"`
+	Description        string            `json:"description"`
+	Price              uint              `json:"price"`
+	DateOfIssue        time.Time         `json:"date_of_issue"`
+	Margin             enums.MarginValue `json:"margin" gorm:"type:MARGIN_VALUE"`
+	Status             enums.Status      `json:"status" gorm:"type:OFFER_STATUS"`
+	IsAuction          bool              `json:"is_auction"`
+	ExpiresAt          *time.Time        `json:"expires_at"`
+	ExpiryReminderSent bool              `json:"-"`
+	HiddenAt           *time.Time        `json:"-"`
+	BlurPlates         bool              `json:"blur_plates"`
+	User               *User             `gorm:"foreignKey:UserID;references:ID"`
+	Car                *Car              `gorm:"foreignKey:OfferID;references:ID"`
+	Auction            *Auction          `gorm:"foreignKey:OfferID;references:ID"`
 }
 
 func (o *SaleOffer) GetID() uint {
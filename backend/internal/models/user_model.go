@@ -1,13 +1,22 @@
 package models
 
+import (
+	"time"
+
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+)
+
 type User struct {
-	ID       uint   `json:"id" gorm:"primaryKey"`
-	Username string `json:"username"`
-	Password string `json:"password"`
-	Email    string `json:"email"`
-	Selector string `json:"selector" gorm:"type:SELECTOR"`
-	Person   *Person
-	Company  *Company
+	ID              uint       `json:"id" gorm:"primaryKey"`
+	Username        string     `json:"username"`
+	Password        string     `json:"password"`
+	Email           string     `json:"email"`
+	Selector        string     `json:"selector" gorm:"type:SELECTOR"`
+	PriceDropAlerts bool       `json:"price_drop_alerts" gorm:"default:true"`
+	Role            enums.Role `json:"role" gorm:"type:USER_ROLE;default:user"`
+	BannedAt        *time.Time `json:"banned_at"`
+	Person          *Person
+	Company         *Company
 }
 
 func (user *User) GetSubtype() UserSubtype {
@@ -21,3 +30,12 @@ func (user *User) GetSubtype() UserSubtype {
 	}
 
 }
+
+func (user *User) IsBanned() bool {
+	return user.BannedAt != nil
+}
+
+// IsStaff tells whether the user can moderate the content of other users.
+func (user *User) IsStaff() bool {
+	return user.Role == enums.MODERATOR || user.Role == enums.ADMIN
+}
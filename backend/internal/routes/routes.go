@@ -4,7 +4,9 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/image"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/ws"
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
 	"github.com/susek555/BD2/car-dealer-api/internal/initializers"
 	"github.com/susek555/BD2/car-dealer-api/pkg/middleware"
 )
@@ -28,7 +30,11 @@ func RegisterRoutes(router *gin.Engine) {
 	registerWebsocket(router)
 	registerImageRoutes(router)
 	registerFavouriteRoutes(router)
+	registerSavedSearchRoutes(router)
 	registerNotificationRoutes(router)
+	registerAdminRoutes(router)
+	registerReportRoutes(router)
+	registerConversationRoutes(router)
 }
 
 func registerWebsocket(router *gin.Engine) {
@@ -44,7 +50,10 @@ func registerUserRoutes(router *gin.Engine) {
 		userRoutes.GET("/", initializers.UserHandler.GetAllUsers)
 		userRoutes.GET("/id/:id", initializers.UserHandler.GetUserByID)
 		userRoutes.GET("/email/:email", initializers.UserHandler.GetUserByEmail)
+		userRoutes.GET("/:id/online", ws.GetOnlineStatus(initializers.Hub))
 		userRoutes.DELETE("/:id", middleware.Authenticate(initializers.Verifier), initializers.UserHandler.DeleteUser)
+		userRoutes.GET("/settings", middleware.Authenticate(initializers.Verifier), initializers.UserHandler.GetSettings)
+		userRoutes.PUT("/settings", middleware.Authenticate(initializers.Verifier), initializers.UserHandler.UpdateSettings)
 	}
 }
 
@@ -72,6 +81,9 @@ func registerReviewRoutes(router *gin.Engine) {
 	reviewRoutes.POST("/filtered", initializers.ReviewHandler.GetFilteredReviews)
 	reviewRoutes.GET("/average-rating/:id", initializers.ReviewHandler.GetAverageRatingByRevieweeID)
 	reviewRoutes.GET("/frequency/:id", initializers.ReviewHandler.GetFrequencyOfRatingByRevieweeID)
+	reviewRoutes.PUT("/reply/:id", middleware.Authenticate(initializers.Verifier), initializers.ReviewHandler.ReplyToReview)
+	reviewRoutes.PUT("/vote/:id", middleware.Authenticate(initializers.Verifier), initializers.ReviewHandler.VoteReview)
+	reviewRoutes.DELETE("/vote/:id", middleware.Authenticate(initializers.Verifier), initializers.ReviewHandler.DeleteReviewVote)
 }
 
 func registerCarRoutes(router *gin.Engine) {
@@ -94,11 +106,13 @@ func registerSaleOfferRoutes(router *gin.Engine) {
 		saleOfferRoutes.POST("/", middleware.Authenticate(initializers.Verifier), initializers.SaleOfferHandler.CreateSaleOffer)
 		saleOfferRoutes.PUT("/", middleware.Authenticate(initializers.Verifier), initializers.SaleOfferHandler.UpdateSaleOffer)
 		saleOfferRoutes.PUT("/publish/:id", middleware.Authenticate(initializers.Verifier), initializers.SaleOfferHandler.PublishSaleOffer)
+		saleOfferRoutes.PUT("/renew/:id", middleware.Authenticate(initializers.Verifier), initializers.SaleOfferHandler.RenewSaleOffer)
 		saleOfferRoutes.POST("/filtered", middleware.OptionalAuthenticate(initializers.Verifier), initializers.SaleOfferHandler.GetFilteredSaleOffers)
 		saleOfferRoutes.POST("/my-offers", middleware.Authenticate(initializers.Verifier), initializers.SaleOfferHandler.GetMySaleOffers)
 		saleOfferRoutes.POST("/liked-offers", middleware.Authenticate(initializers.Verifier), initializers.SaleOfferHandler.GetLikedOnlySaleOffers)
 		saleOfferRoutes.POST("/purchased-offers", middleware.Authenticate(initializers.Verifier), initializers.SaleOfferHandler.GetPurchasedOffers)
 		saleOfferRoutes.GET("/id/:id", middleware.OptionalAuthenticate(initializers.Verifier), initializers.SaleOfferHandler.GetDetailedSaleOfferByID)
+		saleOfferRoutes.GET("/id/:id/history", middleware.OptionalAuthenticate(initializers.Verifier), initializers.SaleOfferHandler.GetSaleOfferHistory)
 		saleOfferRoutes.GET("/offer-types", initializers.SaleOfferHandler.GetSaleOfferTypes)
 		saleOfferRoutes.GET("/order-keys", initializers.SaleOfferHandler.GetOrderKeys)
 		saleOfferRoutes.POST("/buy/:id", middleware.Authenticate(initializers.Verifier), initializers.SaleOfferHandler.Buy)
@@ -132,12 +146,29 @@ func registerFavouriteRoutes(router *gin.Engine) {
 	}
 }
 
+func registerSavedSearchRoutes(router *gin.Engine) {
+	savedSearchRoutes := router.Group("/saved-search")
+	{
+		savedSearchRoutes.POST("/", middleware.Authenticate(initializers.Verifier), initializers.SavedSearchHandler.CreateSavedSearch)
+		savedSearchRoutes.PUT("/", middleware.Authenticate(initializers.Verifier), initializers.SavedSearchHandler.UpdateSavedSearch)
+		savedSearchRoutes.GET("/", middleware.Authenticate(initializers.Verifier), initializers.SavedSearchHandler.GetMySavedSearches)
+		savedSearchRoutes.GET("/:id", middleware.Authenticate(initializers.Verifier), initializers.SavedSearchHandler.GetSavedSearchByID)
+		savedSearchRoutes.DELETE("/:id", middleware.Authenticate(initializers.Verifier), initializers.SavedSearchHandler.DeleteSavedSearch)
+	}
+}
+
 func registerImageRoutes(router *gin.Engine) {
 	imageRoutes := router.Group("/image")
 	{
 		imageRoutes.PATCH("/:id", middleware.Authenticate(initializers.Verifier), initializers.ImageHandler.UploadImages)
 		imageRoutes.DELETE("/", middleware.Authenticate(initializers.Verifier), initializers.ImageHandler.DeleteImage)
 		imageRoutes.DELETE("/offer/:id", middleware.Authenticate(initializers.Verifier), initializers.ImageHandler.DeleteImages)
+		imageRoutes.PUT("/offer/:id/order", middleware.Authenticate(initializers.Verifier), initializers.ImageHandler.ReorderImages)
+		imageRoutes.PATCH("/offer/:id/images/:image_id", middleware.Authenticate(initializers.Verifier), initializers.ImageHandler.UpdateImage)
+		imageRoutes.GET("/duplicates/:offerID", middleware.Authenticate(initializers.Verifier), initializers.ImageHandler.GetDuplicates)
+	}
+	if initializers.LocalImageDir != "" {
+		router.Static(image.LocalImageRoute, initializers.LocalImageDir)
 	}
 }
 
@@ -151,3 +182,42 @@ func registerNotificationRoutes(router *gin.Engine) {
 		notificationRoutes.PUT("/unseen", middleware.Authenticate(initializers.Verifier), initializers.NotificationHandler.MarkAllAsUnseen)
 	}
 }
+
+func registerAdminRoutes(router *gin.Engine) {
+	adminRoutes := router.Group("/admin", middleware.Authenticate(initializers.Verifier))
+	staffRoutes := adminRoutes.Group("", middleware.AuthorizeRole(enums.MODERATOR, enums.ADMIN))
+	{
+		staffRoutes.PUT("/sale-offer/unpublish/:id", initializers.AdminHandler.UnpublishSaleOffer)
+		staffRoutes.DELETE("/review/:id", initializers.AdminHandler.DeleteReview)
+		staffRoutes.GET("/bid/auction/:id", initializers.AdminHandler.GetAuctionBids)
+	}
+	adminOnlyRoutes := adminRoutes.Group("", middleware.AuthorizeRole(enums.ADMIN))
+	{
+		adminOnlyRoutes.DELETE("/sale-offer/:id", initializers.AdminHandler.DeleteSaleOffer)
+		adminOnlyRoutes.PUT("/user/ban/:id", initializers.AdminHandler.BanUser)
+		adminOnlyRoutes.PUT("/user/unban/:id", initializers.AdminHandler.UnbanUser)
+		adminOnlyRoutes.PUT("/users/:id/role", initializers.AdminHandler.ChangeUserRole)
+		adminOnlyRoutes.POST("/audit-log", initializers.AdminHandler.GetAuditLog)
+		adminOnlyRoutes.GET("/jobs", initializers.JobHandler.GetJobs)
+	}
+}
+
+func registerReportRoutes(router *gin.Engine) {
+	reportRoutes := router.Group("/report", middleware.Authenticate(initializers.Verifier))
+	{
+		reportRoutes.POST("/", initializers.ReportHandler.CreateReport)
+	}
+	moderatorRoutes := reportRoutes.Group("", middleware.AuthorizeRole(enums.MODERATOR, enums.ADMIN))
+	{
+		moderatorRoutes.POST("/queue", initializers.ReportHandler.GetQueue)
+		moderatorRoutes.PUT("/resolve/:id", initializers.ReportHandler.ResolveReport)
+	}
+}
+
+func registerConversationRoutes(router *gin.Engine) {
+	conversationRoutes := router.Group("/conversation", middleware.Authenticate(initializers.Verifier))
+	{
+		conversationRoutes.POST("/list", initializers.ConversationHandler.GetConversations)
+		conversationRoutes.POST("/messages/:id", initializers.ConversationHandler.GetMessages)
+	}
+}
@@ -0,0 +1,271 @@
+package admin_tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/admin"
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
+	"github.com/susek555/BD2/car-dealer-api/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+const adminID = uint(1)
+
+type imageRemover struct {
+	folders []string
+}
+
+func (r *imageRemover) DeleteByFolderName(folder string) error {
+	r.folders = append(r.folders, folder)
+	return nil
+}
+
+type serviceMocks struct {
+	repo         *mocks.AdminRepositoryInterface
+	saleOffers   *mocks.SaleOfferRepositoryInterface
+	users        *mocks.UserRepositoryInterface
+	bids         *mocks.BidRepositoryInterface
+	imageRemover *imageRemover
+}
+
+func newTestService(t *testing.T) (admin.AdminServiceInterface, *serviceMocks) {
+	m := &serviceMocks{
+		repo:         mocks.NewAdminRepositoryInterface(t),
+		saleOffers:   mocks.NewSaleOfferRepositoryInterface(t),
+		users:        mocks.NewUserRepositoryInterface(t),
+		bids:         mocks.NewBidRepositoryInterface(t),
+		imageRemover: &imageRemover{},
+	}
+	return admin.NewAdminService(m.repo, m.saleOffers, m.users, m.bids, m.imageRemover), m
+}
+
+func auditLog(action enums.AdminAction, targetID uint, reason *string) interface{} {
+	return mock.MatchedBy(func(entry *models.AdminAuditLog) bool {
+		return entry.AdminID == adminID && entry.Action == action && entry.TargetID == targetID && entry.Reason == reason
+	})
+}
+
+func TestAdminService_UnpublishOffer(t *testing.T) {
+	service, m := newTestService(t)
+	reason := "scam"
+
+	m.repo.On("UnpublishOffer", uint(7), auditLog(enums.UNPUBLISH_OFFER, 7, &reason)).Return(nil)
+
+	err := service.UnpublishOffer(adminID, 7, &reason)
+
+	assert.NoError(t, err)
+}
+
+func TestAdminService_UnpublishOffer_NotPublished(t *testing.T) {
+	service, m := newTestService(t)
+
+	m.repo.On("UnpublishOffer", uint(7), mock.Anything).Return(admin.ErrOfferNotPublished)
+
+	err := service.UnpublishOffer(adminID, 7, nil)
+
+	assert.ErrorIs(t, err, admin.ErrOfferNotPublished)
+}
+
+func TestAdminService_DeleteOffer_RemovesImages(t *testing.T) {
+	service, m := newTestService(t)
+
+	m.repo.On("DeleteOffer", uint(7), auditLog(enums.DELETE_OFFER, 7, nil)).Return(nil)
+
+	err := service.DeleteOffer(adminID, 7, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sale-offer-7"}, m.imageRemover.folders)
+}
+
+func TestAdminService_DeleteOffer_SoldOfferKeepsImages(t *testing.T) {
+	service, m := newTestService(t)
+
+	m.repo.On("DeleteOffer", uint(7), mock.Anything).Return(admin.ErrOfferSold)
+
+	err := service.DeleteOffer(adminID, 7, nil)
+
+	assert.ErrorIs(t, err, admin.ErrOfferSold)
+	assert.Empty(t, m.imageRemover.folders)
+}
+
+func TestAdminService_DeleteReview(t *testing.T) {
+	service, m := newTestService(t)
+
+	m.repo.On("DeleteReview", uint(3), auditLog(enums.DELETE_REVIEW, 3, nil)).Return(nil)
+
+	err := service.DeleteReview(adminID, 3, nil)
+
+	assert.NoError(t, err)
+}
+
+func TestAdminService_BanUser(t *testing.T) {
+	service, m := newTestService(t)
+	reason := "spam"
+
+	m.users.On("GetByID", uint(5)).Return(&models.User{ID: 5, Role: enums.USER}, nil)
+	m.repo.On("BanUser", uint(5), mock.AnythingOfType("time.Time"), auditLog(enums.BAN_USER, 5, &reason)).Return(nil)
+
+	err := service.BanUser(adminID, 5, &reason)
+
+	assert.NoError(t, err)
+}
+
+func TestAdminService_BanUser_Rejected(t *testing.T) {
+	bannedAt := time.Now()
+	tests := []struct {
+		name     string
+		userID   uint
+		user     *models.User
+		userErr  error
+		expected error
+	}{
+		{"yourself", adminID, nil, nil, admin.ErrCannotBanYourself},
+		{"unknown user", 5, nil, gorm.ErrRecordNotFound, gorm.ErrRecordNotFound},
+		{"moderator", 5, &models.User{ID: 5, Role: enums.MODERATOR}, nil, admin.ErrCannotBanStaff},
+		{"admin", 5, &models.User{ID: 5, Role: enums.ADMIN}, nil, admin.ErrCannotBanStaff},
+		{"already banned", 5, &models.User{ID: 5, Role: enums.USER, BannedAt: &bannedAt}, nil, admin.ErrUserAlreadyBanned},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newTestService(t)
+			if tt.user != nil || tt.userErr != nil {
+				m.users.On("GetByID", tt.userID).Return(tt.user, tt.userErr)
+			}
+
+			err := service.BanUser(adminID, tt.userID, nil)
+
+			assert.ErrorIs(t, err, tt.expected)
+			m.repo.AssertNotCalled(t, "BanUser", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAdminService_UnbanUser(t *testing.T) {
+	service, m := newTestService(t)
+
+	m.repo.On("UnbanUser", uint(5), auditLog(enums.UNBAN_USER, 5, nil)).Return(admin.ErrUserNotBanned)
+
+	err := service.UnbanUser(adminID, 5, nil)
+
+	assert.ErrorIs(t, err, admin.ErrUserNotBanned)
+}
+
+func TestAdminService_GetBids_NewestFirstAndLogged(t *testing.T) {
+	service, m := newTestService(t)
+	now := time.Now()
+
+	m.saleOffers.On("GetByID", uint(7)).Return(&models.SaleOffer{ID: 7, IsAuction: true}, nil)
+	m.bids.On("GetByAuctionID", uint(7)).Return([]models.Bid{
+		{ID: 1, BidderID: 4, Amount: 1000, CreatedAt: now.Add(-time.Hour)},
+		{ID: 2, BidderID: 5, Amount: 1100, CreatedAt: now},
+	}, nil)
+	m.repo.On("LogAction", auditLog(enums.VIEW_BIDS, 7, nil)).Return(nil)
+
+	bids, err := service.GetBids(adminID, 7)
+
+	assert.NoError(t, err)
+	assert.Len(t, bids, 2)
+	assert.Equal(t, uint(2), bids[0].ID)
+	assert.Equal(t, uint(1), bids[1].ID)
+}
+
+func TestAdminService_GetBids_NotAuction(t *testing.T) {
+	service, m := newTestService(t)
+
+	m.saleOffers.On("GetByID", uint(7)).Return(&models.SaleOffer{ID: 7}, nil)
+
+	_, err := service.GetBids(adminID, 7)
+
+	assert.ErrorIs(t, err, admin.ErrOfferNotAuction)
+	m.repo.AssertNotCalled(t, "LogAction", mock.Anything)
+}
+
+func TestAdminService_GetBids_LoggingFails(t *testing.T) {
+	service, m := newTestService(t)
+	logErr := errors.New("db down")
+
+	m.saleOffers.On("GetByID", uint(7)).Return(&models.SaleOffer{ID: 7, IsAuction: true}, nil)
+	m.bids.On("GetByAuctionID", uint(7)).Return([]models.Bid{}, nil)
+	m.repo.On("LogAction", mock.Anything).Return(logErr)
+
+	_, err := service.GetBids(adminID, 7)
+
+	assert.ErrorIs(t, err, logErr)
+}
+
+func TestAdminService_GetAuditLog(t *testing.T) {
+	service, m := newTestService(t)
+	action := string(enums.BAN_USER)
+	filter := &admin.AuditLogFilter{Action: &action, Pagination: pagination.PaginationRequest{Page: 1, PageSize: 10}}
+	createdAt := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	m.repo.On("GetAuditLog", filter).Return([]models.AdminAuditLog{
+		{ID: 3, AdminID: adminID, Action: enums.BAN_USER, TargetID: 5, CreatedAt: createdAt},
+	}, &pagination.PaginationResponse{TotalPages: 1, TotalRecords: 1}, nil)
+
+	result, err := service.GetAuditLog(filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []admin.RetrieveAuditLogDTO{
+		{ID: 3, AdminID: adminID, Action: "Ban user", TargetID: 5, CreatedAt: "2025-03-10T12:00:00Z"},
+	}, result.Entries)
+	assert.Equal(t, int64(1), result.PaginationResponse.TotalRecords)
+}
+
+func TestAdminService_GetAuditLog_InvalidAction(t *testing.T) {
+	service, m := newTestService(t)
+	action := "Delete everything"
+
+	_, err := service.GetAuditLog(&admin.AuditLogFilter{Action: &action})
+
+	assert.ErrorIs(t, err, admin.ErrInvalidAdminAction)
+	m.repo.AssertNotCalled(t, "GetAuditLog", mock.Anything)
+}
+
+func TestAdminService_ChangeRole(t *testing.T) {
+	service, m := newTestService(t)
+	reason := "new moderator"
+
+	m.users.On("GetByID", uint(5)).Return(&models.User{ID: 5, Role: enums.USER}, nil)
+	m.repo.On("ChangeRole", uint(5), enums.MODERATOR, auditLog(enums.CHANGE_ROLE, 5, &reason)).Return(nil)
+
+	err := service.ChangeRole(adminID, 5, "Moderator", &reason)
+
+	assert.NoError(t, err)
+}
+
+func TestAdminService_ChangeRole_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		userID   uint
+		role     string
+		user     *models.User
+		userErr  error
+		expected error
+	}{
+		{"unknown role", 5, "Owner", nil, nil, admin.ErrInvalidRole},
+		{"database format", 5, "moderator", nil, nil, admin.ErrInvalidRole},
+		{"yourself", adminID, "User", nil, nil, admin.ErrCannotChangeOwnRole},
+		{"unknown user", 5, "Admin", nil, gorm.ErrRecordNotFound, gorm.ErrRecordNotFound},
+		{"same role", 5, "Admin", &models.User{ID: 5, Role: enums.ADMIN}, nil, admin.ErrUserAlreadyHasRole},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newTestService(t)
+			if tt.user != nil || tt.userErr != nil {
+				m.users.On("GetByID", tt.userID).Return(tt.user, tt.userErr)
+			}
+
+			err := service.ChangeRole(adminID, tt.userID, tt.role, nil)
+
+			assert.ErrorIs(t, err, tt.expected)
+			m.repo.AssertNotCalled(t, "ChangeRole", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
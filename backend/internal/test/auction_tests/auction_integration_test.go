@@ -78,7 +78,6 @@ func setupDB(manufacturers []models.Manufacturer, models_ []models.Model, cars [
 	}
 	repo := sale_offer.NewSaleOfferRepository(db)
 	bidRepo := bid.NewBidRepository(db)
-	purchaseRepo := purchase.NewPurchaseRepository(db)
 	likedOfferRepo := liked_offer.NewLikedOfferRepository(db)
 	saleOfferService := sale_offer.NewSaleOfferService(
 		sale_offer.NewSaleOfferRepository(db),
@@ -88,8 +87,9 @@ func setupDB(manufacturers []models.Manufacturer, models_ []models.Model, cars [
 		image.NewImageBucket(initializers.CloudinaryClient),
 		sale_offer.NewAccessEvaluator(bidRepo, likedOfferRepo),
 		purchase.NewPurchaseRepository(db),
+		sale_offer.DefaultListingPolicy,
 	)
-	service := auction.NewAuctionService(repo, saleOfferService.(*sale_offer.SaleOfferService), purchaseRepo)
+	service := auction.NewAuctionService(repo, saleOfferService.(*sale_offer.SaleOfferService), bid.NewLocalAuctionLocker(), auction.DefaultIncrementTiers, auction.DefaultExtensionPolicy)
 	return service, nil
 }
 
@@ -114,8 +114,7 @@ func newTestServer(seedManufacturers []models.Manufacturer, seedModels []models.
 			mock.AnythingOfType("string"),
 		).
 		Return(nil)
-	mn := new(mocks.NotificationServiceInterface)
-	auctionHandler := auction.NewHandler(service, ms, mh, mn)
+	auctionHandler := auction.NewHandler(service, ms, mh)
 	auctionRoutes := r.Group("/auction")
 	auctionRoutes.POST("/", middleware.Authenticate(verifier), auctionHandler.CreateAuction)
 	auctionRoutes.PUT("/", middleware.Authenticate(verifier), auctionHandler.UpdateAuction)
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/auction"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/bid"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
 	"github.com/susek555/BD2/car-dealer-api/internal/enums"
 	"github.com/susek555/BD2/car-dealer-api/internal/models"
@@ -136,8 +137,8 @@ func makeValidUpdateDTO() *auction.UpdateAuctionDTO {
 func TestAuctionService_Create_OK(t *testing.T) {
 	repo := new(mocks.SaleOfferRepositoryInterface)
 	saleOfferSvc := new(mocks.SaleOfferServiceInterface)
-	purchaseCreator := new(mocks.PurchaseCreatorInterface)
-	svc := auction.NewAuctionService(repo, saleOfferSvc, purchaseCreator)
+	auctionLocker := bid.NewLocalAuctionLocker()
+	svc := auction.NewAuctionService(repo, saleOfferSvc, auctionLocker, auction.DefaultIncrementTiers, auction.DefaultExtensionPolicy)
 
 	dtoIn := makeValidCreateDTO()
 
@@ -165,8 +166,8 @@ func TestAuctionService_Create_OK(t *testing.T) {
 func TestAuctionService_Create_Error(t *testing.T) {
 	repo := new(mocks.SaleOfferRepositoryInterface)
 	saleOfferSvc := new(mocks.SaleOfferServiceInterface)
-	purchaseCreator := new(mocks.PurchaseCreatorInterface)
-	svc := auction.NewAuctionService(repo, saleOfferSvc, purchaseCreator)
+	auctionLocker := bid.NewLocalAuctionLocker()
+	svc := auction.NewAuctionService(repo, saleOfferSvc, auctionLocker, auction.DefaultIncrementTiers, auction.DefaultExtensionPolicy)
 
 	dtoIn := makeValidCreateDTO()
 	expected := errors.New("db failure")
@@ -186,8 +187,8 @@ func TestAuctionService_Create_Error(t *testing.T) {
 func TestAuctionService_Update_OK(t *testing.T) {
 	repo := new(mocks.SaleOfferRepositoryInterface)
 	saleOfferSvc := new(mocks.SaleOfferServiceInterface)
-	purchaseCreator := new(mocks.PurchaseCreatorInterface)
-	svc := auction.NewAuctionService(repo, saleOfferSvc, purchaseCreator)
+	auctionLocker := bid.NewLocalAuctionLocker()
+	svc := auction.NewAuctionService(repo, saleOfferSvc, auctionLocker, auction.DefaultIncrementTiers, auction.DefaultExtensionPolicy)
 	update := makeValidUpdateDTO()
 
 	// Mock GetByID which is called before Update
@@ -221,8 +222,8 @@ func TestAuctionService_Update_OK(t *testing.T) {
 func TestAuctionService_Update_Error(t *testing.T) {
 	repo := new(mocks.SaleOfferRepositoryInterface)
 	saleOfferSvc := new(mocks.SaleOfferServiceInterface)
-	purchaseCreator := new(mocks.PurchaseCreatorInterface)
-	svc := auction.NewAuctionService(repo, saleOfferSvc, purchaseCreator)
+	auctionLocker := bid.NewLocalAuctionLocker()
+	svc := auction.NewAuctionService(repo, saleOfferSvc, auctionLocker, auction.DefaultIncrementTiers, auction.DefaultExtensionPolicy)
 	update := makeValidUpdateDTO()
 
 	expected := errors.New("db failure")
@@ -258,8 +259,8 @@ func TestAuctionService_Update_Error(t *testing.T) {
 func TestAuctionService_Delete_OK(t *testing.T) {
 	repo := new(mocks.SaleOfferRepositoryInterface)
 	saleOfferSvc := new(mocks.SaleOfferServiceInterface)
-	purchaseCreator := new(mocks.PurchaseCreatorInterface)
-	svc := auction.NewAuctionService(repo, saleOfferSvc, purchaseCreator)
+	auctionLocker := bid.NewLocalAuctionLocker()
+	svc := auction.NewAuctionService(repo, saleOfferSvc, auctionLocker, auction.DefaultIncrementTiers, auction.DefaultExtensionPolicy)
 
 	full := makeFullAuctionEntity(8, time.Now().Add(time.Hour), 400)
 	saleOfferSvc.On("Delete", uint(8), full.Offer.UserID).Return(nil)
@@ -272,8 +273,8 @@ func TestAuctionService_Delete_OK(t *testing.T) {
 func TestAuctionService_Delete_Unauthorized(t *testing.T) {
 	repo := new(mocks.SaleOfferRepositoryInterface)
 	saleOfferSvc := new(mocks.SaleOfferServiceInterface)
-	purchaseCreator := new(mocks.PurchaseCreatorInterface)
-	svc := auction.NewAuctionService(repo, saleOfferSvc, purchaseCreator)
+	auctionLocker := bid.NewLocalAuctionLocker()
+	svc := auction.NewAuctionService(repo, saleOfferSvc, auctionLocker, auction.DefaultIncrementTiers, auction.DefaultExtensionPolicy)
 
 	full := makeFullAuctionEntity(8, time.Now().Add(time.Hour), 400)
 
@@ -288,8 +289,8 @@ func TestAuctionService_Delete_Unauthorized(t *testing.T) {
 func TestAuctionService_Delete_GetByID_Error(t *testing.T) {
 	repo := new(mocks.SaleOfferRepositoryInterface)
 	saleOfferSvc := new(mocks.SaleOfferServiceInterface)
-	purchaseCreator := new(mocks.PurchaseCreatorInterface)
-	svc := auction.NewAuctionService(repo, saleOfferSvc, purchaseCreator)
+	auctionLocker := bid.NewLocalAuctionLocker()
+	svc := auction.NewAuctionService(repo, saleOfferSvc, auctionLocker, auction.DefaultIncrementTiers, auction.DefaultExtensionPolicy)
 
 	expected := errors.New("auction not found")
 
@@ -305,8 +306,8 @@ func TestAuctionService_Delete_GetByID_Error(t *testing.T) {
 func TestAuctionService_Delete_Error(t *testing.T) {
 	repo := new(mocks.SaleOfferRepositoryInterface)
 	saleOfferSvc := new(mocks.SaleOfferServiceInterface)
-	purchaseCreator := new(mocks.PurchaseCreatorInterface)
-	svc := auction.NewAuctionService(repo, saleOfferSvc, purchaseCreator)
+	auctionLocker := bid.NewLocalAuctionLocker()
+	svc := auction.NewAuctionService(repo, saleOfferSvc, auctionLocker, auction.DefaultIncrementTiers, auction.DefaultExtensionPolicy)
 
 	full := makeFullAuctionEntity(8, time.Now().Add(time.Hour), 400)
 	saleOfferSvc.On("Delete", uint(8), full.Offer.UserID).Return(errors.New("delete failed"))
@@ -317,3 +318,292 @@ func TestAuctionService_Delete_Error(t *testing.T) {
 	repo.AssertExpectations(t)
 	saleOfferSvc.AssertExpectations(t)
 }
+
+// ---------- PRICE INCREMENTS ----------
+
+func makeAuctionOffer(price, initialPrice uint) *models.SaleOffer {
+	offer := &models.SaleOffer{ID: 9, Price: price, Status: enums.PUBLISHED}
+	offer.Auction = &models.Auction{OfferID: 9, InitialPrice: initialPrice, Offer: offer}
+	return offer
+}
+
+func TestAuctionService_ValidateNewPrice_FirstBidAtInitialPrice(t *testing.T) {
+	repo := new(mocks.SaleOfferRepositoryInterface)
+	svc := auction.NewAuctionService(repo, new(mocks.SaleOfferServiceInterface), bid.NewLocalAuctionLocker(), auction.DefaultIncrementTiers, auction.DefaultExtensionPolicy)
+
+	repo.On("GetByID", uint(9)).Return(makeAuctionOffer(100000, 100000), nil)
+	repo.On("HasBids", uint(9)).Return(false, nil)
+
+	err := svc.ValidateNewPrice(9, 100000)
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestAuctionService_ValidateNewPrice_BelowIncrement(t *testing.T) {
+	repo := new(mocks.SaleOfferRepositoryInterface)
+	svc := auction.NewAuctionService(repo, new(mocks.SaleOfferServiceInterface), bid.NewLocalAuctionLocker(), auction.DefaultIncrementTiers, auction.DefaultExtensionPolicy)
+
+	repo.On("GetByID", uint(9)).Return(makeAuctionOffer(100000, 90000), nil)
+	repo.On("HasBids", uint(9)).Return(true, nil)
+
+	err := svc.ValidateNewPrice(9, 100001)
+	assert.ErrorIs(t, err, auction.ErrBidIncrementTooLow)
+}
+
+func TestAuctionService_ValidateNewPrice_AtIncrement(t *testing.T) {
+	repo := new(mocks.SaleOfferRepositoryInterface)
+	svc := auction.NewAuctionService(repo, new(mocks.SaleOfferServiceInterface), bid.NewLocalAuctionLocker(), auction.DefaultIncrementTiers, auction.DefaultExtensionPolicy)
+
+	newPrice := 100000 + auction.DefaultIncrementTiers.MinimumIncrement(100000)
+	repo.On("GetByID", uint(9)).Return(makeAuctionOffer(100000, 90000), nil)
+	repo.On("HasBids", uint(9)).Return(true, nil)
+
+	err := svc.ValidateNewPrice(9, newPrice)
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestAuctionService_ValidateNewPrice_SecondBidAtInitialPrice(t *testing.T) {
+	repo := new(mocks.SaleOfferRepositoryInterface)
+	svc := auction.NewAuctionService(repo, new(mocks.SaleOfferServiceInterface), bid.NewLocalAuctionLocker(), auction.DefaultIncrementTiers, auction.DefaultExtensionPolicy)
+
+	// the first bid was placed at the initial price, so the price did not move
+	repo.On("GetByID", uint(9)).Return(makeAuctionOffer(100000, 100000), nil)
+	repo.On("HasBids", uint(9)).Return(true, nil)
+
+	err := svc.ValidateNewPrice(9, 100000)
+	assert.ErrorIs(t, err, auction.ErrBidIncrementTooLow)
+}
+
+func TestAuctionService_ValidateNewPrice_BelowCurrentPrice(t *testing.T) {
+	repo := new(mocks.SaleOfferRepositoryInterface)
+	svc := auction.NewAuctionService(repo, new(mocks.SaleOfferServiceInterface), bid.NewLocalAuctionLocker(), auction.DefaultIncrementTiers, auction.DefaultExtensionPolicy)
+
+	repo.On("GetByID", uint(9)).Return(makeAuctionOffer(5000, 4000), nil)
+
+	err := svc.ValidateNewPrice(9, 4500)
+	assert.ErrorIs(t, err, auction.ErrNewPriceLessThanOfferPrice)
+}
+
+// ---------- ANTI-SNIPING ----------
+
+func TestAuctionService_ValidateNewPrice_OfferNotPublished(t *testing.T) {
+	repo := new(mocks.SaleOfferRepositoryInterface)
+	svc := auction.NewAuctionService(repo, new(mocks.SaleOfferServiceInterface), bid.NewLocalAuctionLocker(), auction.DefaultIncrementTiers, auction.DefaultExtensionPolicy)
+
+	offer := makeAuctionOffer(100000, 90000)
+	offer.Status = enums.SOLD
+	repo.On("GetByID", uint(9)).Return(offer, nil)
+
+	err := svc.ValidateNewPrice(9, 200000)
+	assert.ErrorIs(t, err, auction.ErrAuctionNotActive)
+}
+
+// ---------- BUY NOW ----------
+
+func mockPurchase(repo *mocks.SaleOfferRepositoryInterface, offer *models.SaleOffer) {
+	repo.On("Purchase", uint(9), uint(7), mock.Anything, mock.MatchedBy(func(message *models.OutboxMessage) bool {
+		return message.Kind == sale_offer.OutboxAuctionBoughtOut
+	})).
+		Return(func(id, buyerID uint, finalPrice func(*models.SaleOffer) (uint, error), message *models.OutboxMessage) (*models.SaleOffer, error) {
+			price, err := finalPrice(offer)
+			if err != nil {
+				return nil, err
+			}
+			offer.Price = price
+			offer.Status = enums.SOLD
+			return offer, nil
+		})
+}
+
+func TestAuctionService_BuyNow_OK(t *testing.T) {
+	repo := new(mocks.SaleOfferRepositoryInterface)
+	saleOfferService := new(mocks.SaleOfferServiceInterface)
+	svc := auction.NewAuctionService(repo, saleOfferService, bid.NewLocalAuctionLocker(), auction.DefaultIncrementTiers, auction.DefaultExtensionPolicy)
+
+	offer := makeAuctionOffer(5000, 4000)
+	offer.UserID = 3
+	buyNowPrice := uint(10000)
+	offer.Auction.BuyNowPrice = &buyNowPrice
+	mockPurchase(repo, offer)
+	saleOfferService.On("GetDetailedByID", uint(9), mock.Anything).Return(&sale_offer.RetrieveDetailedSaleOfferDTO{ID: 9}, nil)
+	repo.On("HasBids", uint(9)).Return(true, nil)
+
+	_, err := svc.BuyNow(9, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, buyNowPrice, offer.Price)
+	assert.Equal(t, enums.SOLD, offer.Status)
+}
+
+func TestAuctionService_BuyNow_PriceReachedByBid(t *testing.T) {
+	repo := new(mocks.SaleOfferRepositoryInterface)
+	svc := auction.NewAuctionService(repo, new(mocks.SaleOfferServiceInterface), bid.NewLocalAuctionLocker(), auction.DefaultIncrementTiers, auction.DefaultExtensionPolicy)
+
+	offer := makeAuctionOffer(10000, 4000)
+	offer.UserID = 3
+	buyNowPrice := uint(10000)
+	offer.Auction.BuyNowPrice = &buyNowPrice
+	mockPurchase(repo, offer)
+	repo.On("HasBids", uint(9)).Return(true, nil)
+
+	_, err := svc.BuyNow(9, 7)
+	assert.ErrorIs(t, err, auction.ErrBuyNowPriceReached)
+	assert.Equal(t, enums.PUBLISHED, offer.Status)
+}
+
+func TestAuctionService_BuyNow_PriceReachedByFirstBid(t *testing.T) {
+	repo := new(mocks.SaleOfferRepositoryInterface)
+	svc := auction.NewAuctionService(repo, new(mocks.SaleOfferServiceInterface), bid.NewLocalAuctionLocker(), auction.DefaultIncrementTiers, auction.DefaultExtensionPolicy)
+
+	// the only bid matched the initial price, which is also the buy now price
+	offer := makeAuctionOffer(10000, 10000)
+	offer.UserID = 3
+	buyNowPrice := uint(10000)
+	offer.Auction.BuyNowPrice = &buyNowPrice
+	mockPurchase(repo, offer)
+	repo.On("HasBids", uint(9)).Return(true, nil)
+
+	_, err := svc.BuyNow(9, 7)
+	assert.ErrorIs(t, err, auction.ErrBuyNowPriceReached)
+	assert.Equal(t, enums.PUBLISHED, offer.Status)
+}
+
+func TestAuctionService_BuyNow_OwnOffer(t *testing.T) {
+	repo := new(mocks.SaleOfferRepositoryInterface)
+	svc := auction.NewAuctionService(repo, new(mocks.SaleOfferServiceInterface), bid.NewLocalAuctionLocker(), auction.DefaultIncrementTiers, auction.DefaultExtensionPolicy)
+
+	offer := makeAuctionOffer(5000, 4000)
+	offer.UserID = 7
+	buyNowPrice := uint(10000)
+	offer.Auction.BuyNowPrice = &buyNowPrice
+	mockPurchase(repo, offer)
+	repo.On("HasBids", uint(9)).Return(false, nil)
+
+	_, err := svc.BuyNow(9, 7)
+	assert.Error(t, err)
+	assert.Equal(t, enums.PUBLISHED, offer.Status)
+}
+
+func makeEndingAuctionOffer(dateEnd time.Time, extensionCount uint) *models.SaleOffer {
+	offer := makeAuctionOffer(5000, 4000)
+	offer.Auction.DateEnd = dateEnd
+	offer.Auction.ExtensionCount = extensionCount
+	return offer
+}
+
+func TestAuctionService_NewDateEnd_BidWithinWindow(t *testing.T) {
+	repo := new(mocks.SaleOfferRepositoryInterface)
+	policy := auction.ExtensionPolicy{Window: 2 * time.Minute, Extension: 5 * time.Minute, MaxExtensions: 3}
+	svc := auction.NewAuctionService(repo, new(mocks.SaleOfferServiceInterface), bid.NewLocalAuctionLocker(), auction.DefaultIncrementTiers, policy)
+
+	bidTime := time.Now()
+	dateEnd := bidTime.Add(time.Minute)
+	repo.On("GetByID", uint(9)).Return(makeEndingAuctionOffer(dateEnd, 1), nil)
+
+	newDateEnd, err := svc.NewDateEnd(9, bidTime)
+	assert.NoError(t, err)
+	assert.NotNil(t, newDateEnd)
+	assert.True(t, newDateEnd.Equal(dateEnd.Add(5*time.Minute)))
+}
+
+func TestAuctionService_NewDateEnd_BidOutsideWindow(t *testing.T) {
+	repo := new(mocks.SaleOfferRepositoryInterface)
+	policy := auction.ExtensionPolicy{Window: 2 * time.Minute, Extension: 5 * time.Minute, MaxExtensions: 3}
+	svc := auction.NewAuctionService(repo, new(mocks.SaleOfferServiceInterface), bid.NewLocalAuctionLocker(), auction.DefaultIncrementTiers, policy)
+
+	bidTime := time.Now()
+	repo.On("GetByID", uint(9)).Return(makeEndingAuctionOffer(bidTime.Add(time.Hour), 0), nil)
+
+	newDateEnd, err := svc.NewDateEnd(9, bidTime)
+	assert.NoError(t, err)
+	assert.Nil(t, newDateEnd)
+}
+
+func TestAuctionService_NewDateEnd_CapReached(t *testing.T) {
+	repo := new(mocks.SaleOfferRepositoryInterface)
+	policy := auction.ExtensionPolicy{Window: 2 * time.Minute, Extension: 5 * time.Minute, MaxExtensions: 3}
+	svc := auction.NewAuctionService(repo, new(mocks.SaleOfferServiceInterface), bid.NewLocalAuctionLocker(), auction.DefaultIncrementTiers, policy)
+
+	bidTime := time.Now()
+	repo.On("GetByID", uint(9)).Return(makeEndingAuctionOffer(bidTime.Add(time.Minute), 3), nil)
+
+	newDateEnd, err := svc.NewDateEnd(9, bidTime)
+	assert.NoError(t, err)
+	assert.Nil(t, newDateEnd)
+}
+
+func TestParseExtensionPolicy(t *testing.T) {
+	policy, err := auction.ParseExtensionPolicy("", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, auction.DefaultExtensionPolicy, policy)
+
+	policy, err = auction.ParseExtensionPolicy("30s", "1m", "5")
+	assert.NoError(t, err)
+	assert.Equal(t, auction.ExtensionPolicy{Window: 30 * time.Second, Extension: time.Minute, MaxExtensions: 5}, policy)
+
+	_, err = auction.ParseExtensionPolicy("abc", "", "")
+	assert.ErrorIs(t, err, auction.ErrInvalidExtensionPolicy)
+	_, err = auction.ParseExtensionPolicy("", "0s", "")
+	assert.ErrorIs(t, err, auction.ErrInvalidExtensionPolicy)
+	_, err = auction.ParseExtensionPolicy("", "", "-1")
+	assert.ErrorIs(t, err, auction.ErrInvalidExtensionPolicy)
+}
+
+func TestIncrementTiers_MinimumIncrement(t *testing.T) {
+	tiers := auction.IncrementTiers{{From: 0, Increment: 10}, {From: 1000, Increment: 100}}
+	assert.Equal(t, uint(10), tiers.MinimumIncrement(0))
+	assert.Equal(t, uint(10), tiers.MinimumIncrement(999))
+	assert.Equal(t, uint(100), tiers.MinimumIncrement(1000))
+	assert.Equal(t, uint(100), tiers.MinimumIncrement(1000000))
+}
+
+func TestParseIncrementTiers_OK(t *testing.T) {
+	tiers, err := auction.ParseIncrementTiers("1000:100, 0:10")
+	assert.NoError(t, err)
+	assert.Equal(t, auction.IncrementTiers{{From: 0, Increment: 10}, {From: 1000, Increment: 100}}, tiers)
+}
+
+func TestParseIncrementTiers_EmptyGivesDefault(t *testing.T) {
+	tiers, err := auction.ParseIncrementTiers("")
+	assert.NoError(t, err)
+	assert.Equal(t, auction.DefaultIncrementTiers, tiers)
+}
+
+func TestParseIncrementTiers_Invalid(t *testing.T) {
+	for _, in := range []string{"100:10", "0:10,0:20", "0:0", "abc", "0-10"} {
+		_, err := auction.ParseIncrementTiers(in)
+		assert.ErrorIs(t, err, auction.ErrInvalidIncrementTiers, in)
+	}
+}
+
+// ---------- RESERVE PRICE ----------
+
+func TestAuctionService_Create_ReserveLowerThanInitialPrice(t *testing.T) {
+	repo := new(mocks.SaleOfferRepositoryInterface)
+	saleOfferSvc := new(mocks.SaleOfferServiceInterface)
+	svc := auction.NewAuctionService(repo, saleOfferSvc, bid.NewLocalAuctionLocker(), auction.DefaultIncrementTiers, auction.DefaultExtensionPolicy)
+
+	dto := makeValidCreateDTO()
+	reserve := dto.Price - 1
+	dto.ReservePrice = &reserve
+	saleOfferSvc.On("PrepareForCreateSaleOffer", &dto.CreateSaleOfferDTO).Return(&models.SaleOffer{}, nil)
+
+	_, err := svc.Create(dto)
+	assert.ErrorIs(t, err, auction.ErrReservePriceLessThanInitial)
+	repo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestAuctionService_Create_ReserveOverBuyNowPrice(t *testing.T) {
+	repo := new(mocks.SaleOfferRepositoryInterface)
+	saleOfferSvc := new(mocks.SaleOfferServiceInterface)
+	svc := auction.NewAuctionService(repo, saleOfferSvc, bid.NewLocalAuctionLocker(), auction.DefaultIncrementTiers, auction.DefaultExtensionPolicy)
+
+	dto := makeValidCreateDTO()
+	reserve := *dto.BuyNowPrice + 1
+	dto.ReservePrice = &reserve
+	saleOfferSvc.On("PrepareForCreateSaleOffer", &dto.CreateSaleOfferDTO).Return(&models.SaleOffer{}, nil)
+
+	_, err := svc.Create(dto)
+	assert.ErrorIs(t, err, auction.ErrReservePriceOverBuyNowPrice)
+	repo.AssertNotCalled(t, "Create", mock.Anything)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/auth"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/user"
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
 	"github.com/susek555/BD2/car-dealer-api/internal/models"
 	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
 	"github.com/susek555/BD2/car-dealer-api/pkg/jwt"
@@ -181,6 +182,40 @@ func TestService_Login(t *testing.T) {
 		_, _, _, err := svc.Login(validIn)
 		assert.EqualError(t, err, "error - create refresh token")
 	})
+
+	t.Run("admin - role carried in the access token", func(t *testing.T) {
+		uRepo := mocks.NewUserRepositoryInterface(t)
+		rtSvc := mocks.NewRefreshTokenServiceInterface(t)
+
+		existing := models.User{ID: 1, Email: validIn.Login, Password: hashPass(t, validIn.Password), Role: enums.ADMIN}
+		uRepo.EXPECT().GetByEmail(validIn.Login).Return(existing, nil)
+		rtSvc.EXPECT().Create(mock.AnythingOfType("*models.RefreshToken")).Return(nil)
+
+		svc := &auth.AuthService{Repo: uRepo, RefreshTokenService: rtSvc, JwtKey: jwtKey}
+
+		access, _, _, err := svc.Login(validIn)
+		assert.NoError(t, err)
+
+		claims, err := jwt.NewJWTVerifier(string(jwtKey)).VerifyTokenClaims(access)
+		assert.NoError(t, err)
+		assert.Equal(t, string(enums.ADMIN), claims.Role)
+	})
+
+	t.Run("banned user - ErrUserBanned", func(t *testing.T) {
+		uRepo := mocks.NewUserRepositoryInterface(t)
+		rtSvc := mocks.NewRefreshTokenServiceInterface(t)
+
+		bannedAt := time.Now()
+		existing := models.User{ID: 1, Email: validIn.Login, Password: hashPass(t, validIn.Password), BannedAt: &bannedAt}
+		uRepo.EXPECT().GetByEmail(validIn.Login).Return(existing, nil)
+
+		svc := &auth.AuthService{Repo: uRepo, RefreshTokenService: rtSvc, JwtKey: jwtKey}
+
+		access, refresh, _, err := svc.Login(validIn)
+		assert.ErrorIs(t, err, auth.ErrUserBanned)
+		assert.Empty(t, access)
+		assert.Empty(t, refresh)
+	})
 }
 
 func TestService_Refresh(t *testing.T) {
@@ -241,6 +276,22 @@ func TestService_Refresh(t *testing.T) {
 		_, err := svc.Refresh(oldToken)
 		assert.EqualError(t, err, expired.Error())
 	})
+
+	t.Run("banned user", func(t *testing.T) {
+		uRepo := mocks.NewUserRepositoryInterface(t)
+		rtSvc := mocks.NewRefreshTokenServiceInterface(t)
+
+		bannedAt := time.Now()
+		bannedRT := baseRT
+		bannedRT.User = &models.User{ID: 1, Email: "john@example.com", BannedAt: &bannedAt}
+		rtSvc.EXPECT().FindByToken(oldToken).Return(&bannedRT, nil)
+		rtSvc.EXPECT().VerifyExpiration(&bannedRT).Return(&bannedRT, nil)
+
+		svc := &auth.AuthService{Repo: uRepo, RefreshTokenService: rtSvc, JwtKey: jwtKey}
+
+		_, err := svc.Refresh(oldToken)
+		assert.ErrorIs(t, err, auth.ErrUserBanned)
+	})
 }
 
 func TestService_Logout(t *testing.T) {
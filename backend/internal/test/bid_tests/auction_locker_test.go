@@ -0,0 +1,107 @@
+package bid_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	bid "github.com/susek555/BD2/car-dealer-api/internal/domains/bid"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
+)
+
+func newTestRedisClient(t *testing.T) *redis.Client {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	return rdb
+}
+
+func TestRedisAuctionLocker_SerializesAcrossInstances(t *testing.T) {
+	rdb := newTestRedisClient(t)
+	// two lockers stand for two backend instances sharing one redis
+	lockers := []bid.AuctionLockerInterface{
+		bid.NewRedisAuctionLocker(rdb, bid.AuctionLockTTL, bid.AuctionLockTimeout),
+		bid.NewRedisAuctionLocker(rdb, bid.AuctionLockTTL, bid.AuctionLockTimeout),
+	}
+	var running int32
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := lockers[i%2].Lock(1)
+			if !assert.NoError(t, err) {
+				return
+			}
+			if atomic.AddInt32(&running, 1) > 1 {
+				t.Errorf("lock did not work")
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			unlock()
+		}()
+	}
+	wg.Wait()
+}
+
+func TestRedisAuctionLocker_TimeoutWhileHeld(t *testing.T) {
+	rdb := newTestRedisClient(t)
+	locker := bid.NewRedisAuctionLocker(rdb, bid.AuctionLockTTL, 100*time.Millisecond)
+
+	unlock, err := locker.Lock(1)
+	assert.NoError(t, err)
+
+	_, err = locker.Lock(1)
+	assert.ErrorIs(t, err, bid.ErrAuctionBusy)
+
+	// other auctions are not affected
+	unlockOther, err := locker.Lock(2)
+	assert.NoError(t, err)
+	unlockOther()
+
+	unlock()
+	unlock, err = locker.Lock(1)
+	assert.NoError(t, err)
+	unlock()
+}
+
+func TestRedisAuctionLocker_RedisDown(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { _ = rdb.Close() })
+	locker := bid.NewRedisAuctionLocker(rdb, bid.AuctionLockTTL, bid.AuctionLockTimeout)
+	mr.Close()
+
+	_, err := locker.Lock(1)
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, bid.ErrAuctionBusy)
+}
+
+func TestBidService_Create_AuctionBusy(t *testing.T) {
+	repo := new(mocks.BidRepositoryInterface)
+	saleOfferRetriever := new(mocks.SaleOfferRetrieverInterface)
+	auctionPriceUpdater := new(mocks.AuctionPriceUpdaterInterface)
+	proxyRepo := new(mocks.ProxyBidRepositoryInterface)
+	locker := new(mocks.AuctionLockerInterface)
+	svc := bid.NewBidService(repo, proxyRepo, saleOfferRetriever, auctionPriceUpdater, testIncrementTiers, locker)
+
+	saleOfferRetriever.On("GetDetailedByID", uint(1), (*uint)(nil)).Return(&sale_offer.RetrieveDetailedSaleOfferDTO{
+		ID:     1,
+		UserID: 1,
+		Status: enums.PUBLISHED,
+	}, nil)
+	locker.On("Lock", uint(1)).Return(nil, bid.ErrAuctionBusy)
+
+	_, err := svc.Create(&bid.CreateBidDTO{AuctionID: 1, Amount: 1000}, 2)
+
+	assert.ErrorIs(t, err, bid.ErrAuctionBusy)
+	repo.AssertNotCalled(t, "Place", mock.Anything)
+}
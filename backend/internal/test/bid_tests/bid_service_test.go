@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/auction"
 	bid "github.com/susek555/BD2/car-dealer-api/internal/domains/bid"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
 	"github.com/susek555/BD2/car-dealer-api/internal/enums"
@@ -16,16 +17,21 @@ import (
 	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
 )
 
+const testIncrement uint = 100
+
+var testIncrementTiers = auction.IncrementTiers{{From: 0, Increment: testIncrement}}
+
 // ---------- CREATE ----------
 
 func TestBidService_Create_OK(t *testing.T) {
 	repo := new(mocks.BidRepositoryInterface)
 	saleOfferRetriever := new(mocks.SaleOfferRetrieverInterface)
 	auctionPriceUpdater := new(mocks.AuctionPriceUpdaterInterface)
-	svc := bid.NewBidService(repo, saleOfferRetriever, auctionPriceUpdater)
+	proxyRepo := new(mocks.ProxyBidRepositoryInterface)
+	svc := bid.NewBidService(repo, proxyRepo, saleOfferRetriever, auctionPriceUpdater, testIncrementTiers, bid.NewLocalAuctionLocker())
 
-	repo.On("Create", mock.MatchedBy(func(b *models.Bid) bool {
-		return b.AuctionID == 1 && b.BidderID == 2
+	repo.On("Place", mock.MatchedBy(func(p *bid.BidPlacement) bool {
+		return p.AuctionID == 1 && len(p.Bids) == 1 && p.Bids[0].BidderID == 2 && p.ProxyBid == nil && p.DateEnd == nil
 	})).Return(nil)
 
 	buyNowPrice := uint(100)
@@ -39,7 +45,9 @@ func TestBidService_Create_OK(t *testing.T) {
 		Status:      enums.PUBLISHED,
 	}, nil)
 
-	auctionPriceUpdater.On("UpdatePrice", uint(1), uint(0)).Return(nil)
+	auctionPriceUpdater.On("ValidateNewPrice", uint(1), uint(0)).Return(nil)
+	auctionPriceUpdater.On("NewDateEnd", uint(1), mock.Anything).Return((*time.Time)(nil), nil)
+	proxyRepo.On("GetActiveByAuctionID", uint(1)).Return([]models.ProxyBid{}, nil)
 
 	dto := &bid.CreateBidDTO{
 		AuctionID: 1,
@@ -47,17 +55,19 @@ func TestBidService_Create_OK(t *testing.T) {
 	}
 	_, err := svc.Create(dto, 2)
 	assert.NoError(t, err)
+	repo.AssertExpectations(t)
 }
 func TestBidService_Create_Error(t *testing.T) {
 	repo := new(mocks.BidRepositoryInterface)
 	saleOfferRetriever := new(mocks.SaleOfferRetrieverInterface)
 	auctionPriceUpdater := new(mocks.AuctionPriceUpdaterInterface)
-	svc := bid.NewBidService(repo, saleOfferRetriever, auctionPriceUpdater)
+	proxyRepo := new(mocks.ProxyBidRepositoryInterface)
+	svc := bid.NewBidService(repo, proxyRepo, saleOfferRetriever, auctionPriceUpdater, testIncrementTiers, bid.NewLocalAuctionLocker())
 
 	expectedErr := errors.New("bidder cannot bid on their own auction")
 
-	repo.On("Create", mock.MatchedBy(func(b *models.Bid) bool {
-		return b.AuctionID == 1
+	repo.On("Place", mock.MatchedBy(func(p *bid.BidPlacement) bool {
+		return p.AuctionID == 1
 	})).Return(expectedErr)
 	buyNowPrice := uint(100)
 	saleOfferRetriever.On("GetDetailedByID", uint(1), (*uint)(nil)).Return(&sale_offer.RetrieveDetailedSaleOfferDTO{
@@ -70,7 +80,8 @@ func TestBidService_Create_Error(t *testing.T) {
 		Status:      enums.PUBLISHED,
 	}, nil)
 
-	auctionPriceUpdater.On("UpdatePrice", uint(1), uint(0)).Return(nil)
+	auctionPriceUpdater.On("ValidateNewPrice", uint(1), uint(0)).Return(nil)
+	auctionPriceUpdater.On("NewDateEnd", uint(1), mock.Anything).Return((*time.Time)(nil), nil)
 
 	dto := &bid.CreateBidDTO{
 		AuctionID: 1,
@@ -80,19 +91,45 @@ func TestBidService_Create_Error(t *testing.T) {
 
 	assert.EqualError(t, err, expectedErr.Error())
 }
+
+func TestBidService_Create_PlacementFails(t *testing.T) {
+	repo := new(mocks.BidRepositoryInterface)
+	saleOfferRetriever := new(mocks.SaleOfferRetrieverInterface)
+	auctionPriceUpdater := new(mocks.AuctionPriceUpdaterInterface)
+	proxyRepo := new(mocks.ProxyBidRepositoryInterface)
+	svc := bid.NewBidService(repo, proxyRepo, saleOfferRetriever, auctionPriceUpdater, testIncrementTiers, bid.NewLocalAuctionLocker())
+
+	repo.On("Place", mock.Anything).Return(bid.ErrBidTooLow)
+	saleOfferRetriever.On("GetDetailedByID", uint(1), (*uint)(nil)).Return(&sale_offer.RetrieveDetailedSaleOfferDTO{
+		ID:     1,
+		UserID: 1,
+		Status: enums.PUBLISHED,
+	}, nil).Once()
+	auctionPriceUpdater.On("ValidateNewPrice", uint(1), uint(1000)).Return(nil)
+	auctionPriceUpdater.On("NewDateEnd", uint(1), mock.Anything).Return((*time.Time)(nil), nil)
+	proxyRepo.On("GetActiveByAuctionID", uint(1)).Return([]models.ProxyBid{}, nil)
+
+	dto, err := svc.Create(&bid.CreateBidDTO{AuctionID: 1, Amount: 1000}, 2)
+
+	assert.ErrorIs(t, err, bid.ErrBidTooLow)
+	assert.Nil(t, dto)
+	saleOfferRetriever.AssertExpectations(t)
+}
+
 func TestBidService_Create_SerializesPerAuction(t *testing.T) {
 	repo := new(mocks.BidRepositoryInterface)
 	saleOfferRetriever := new(mocks.SaleOfferRetrieverInterface)
 	auctionPriceUpdater := new(mocks.AuctionPriceUpdaterInterface)
-	svc := bid.NewBidService(repo, saleOfferRetriever, auctionPriceUpdater)
+	proxyRepo := new(mocks.ProxyBidRepositoryInterface)
+	svc := bid.NewBidService(repo, proxyRepo, saleOfferRetriever, auctionPriceUpdater, testIncrementTiers, bid.NewLocalAuctionLocker())
 
 	const calls = 2
 	const aucID = 777
 	var running int32 // how many inside - 0 or 1
 
-	repo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+	repo.On("Place", mock.Anything).Run(func(args mock.Arguments) {
 		if atomic.AddInt32(&running, 1) > 1 {
-			t.Errorf("mutex did not work - Repo.Create")
+			t.Errorf("mutex did not work - Repo.Place")
 		}
 		time.Sleep(10 * time.Millisecond)
 		atomic.AddInt32(&running, -1)
@@ -106,13 +143,9 @@ func TestBidService_Create_SerializesPerAuction(t *testing.T) {
 		BuyNowPrice: &buyNowPrice,
 	}, nil)
 
-	auctionPriceUpdater.On("UpdatePrice", uint(777), mock.Anything).Run(func(args mock.Arguments) {
-		if atomic.AddInt32(&running, 1) > 1 {
-			t.Errorf("mutex did not work - AuctionService.UpdatePrice")
-		}
-		time.Sleep(10 * time.Millisecond)
-		atomic.AddInt32(&running, -1)
-	}).Return(nil).Times(calls)
+	auctionPriceUpdater.On("ValidateNewPrice", uint(777), mock.Anything).Return(nil)
+	auctionPriceUpdater.On("NewDateEnd", uint(777), mock.Anything).Return((*time.Time)(nil), nil)
+	proxyRepo.On("GetActiveByAuctionID", uint(777)).Return([]models.ProxyBid{}, nil)
 	var wg sync.WaitGroup
 	wg.Add(calls)
 
@@ -129,11 +162,199 @@ func TestBidService_Create_SerializesPerAuction(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestBidService_Create_ReturnsExtendedDateEnd(t *testing.T) {
+	repo := new(mocks.BidRepositoryInterface)
+	saleOfferRetriever := new(mocks.SaleOfferRetrieverInterface)
+	auctionPriceUpdater := new(mocks.AuctionPriceUpdaterInterface)
+	proxyRepo := new(mocks.ProxyBidRepositoryInterface)
+	svc := bid.NewBidService(repo, proxyRepo, saleOfferRetriever, auctionPriceUpdater, testIncrementTiers, bid.NewLocalAuctionLocker())
+
+	dateEnd := time.Now().Add(2 * time.Minute)
+	repo.On("Place", mock.MatchedBy(func(p *bid.BidPlacement) bool {
+		return p.DateEnd == &dateEnd && p.Price == 1000
+	})).Return(nil)
+	saleOfferRetriever.On("GetDetailedByID", uint(1), (*uint)(nil)).Return(&sale_offer.RetrieveDetailedSaleOfferDTO{
+		ID:     1,
+		UserID: 1,
+		Status: enums.PUBLISHED,
+	}, nil)
+	auctionPriceUpdater.On("ValidateNewPrice", uint(1), uint(1000)).Return(nil)
+	auctionPriceUpdater.On("NewDateEnd", uint(1), mock.Anything).Return(&dateEnd, nil)
+	proxyRepo.On("GetActiveByAuctionID", uint(1)).Return([]models.ProxyBid{}, nil)
+
+	dto, err := svc.Create(&bid.CreateBidDTO{AuctionID: 1, Amount: 1000}, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &dateEnd, dto.ExtendedDateEnd)
+	repo.AssertExpectations(t)
+}
+
+// ---------- PROXY BIDS ----------
+
+type placedBid struct {
+	bidderID uint
+	amount   uint
+}
+
+func placedBids(placement *bid.BidPlacement) []placedBid {
+	placed := []placedBid{}
+	for _, b := range placement.Bids {
+		placed = append(placed, placedBid{b.BidderID, b.Amount})
+	}
+	return placed
+}
+
+// newProxyTestService returns the service and the placement it writes, placement.Bids is nil until it does.
+func newProxyTestService(auctionID uint, proxies []models.ProxyBid) (bid.BidServiceInterface, *mocks.BidRepositoryInterface, *bid.BidPlacement) {
+	repo := new(mocks.BidRepositoryInterface)
+	saleOfferRetriever := new(mocks.SaleOfferRetrieverInterface)
+	auctionPriceUpdater := new(mocks.AuctionPriceUpdaterInterface)
+	proxyRepo := new(mocks.ProxyBidRepositoryInterface)
+	svc := bid.NewBidService(repo, proxyRepo, saleOfferRetriever, auctionPriceUpdater, testIncrementTiers, bid.NewLocalAuctionLocker())
+
+	placement := &bid.BidPlacement{}
+	repo.On("Place", mock.Anything).Run(func(args mock.Arguments) {
+		*placement = *args.Get(0).(*bid.BidPlacement)
+	}).Return(nil)
+	saleOfferRetriever.On("GetDetailedByID", auctionID, (*uint)(nil)).Return(&sale_offer.RetrieveDetailedSaleOfferDTO{
+		ID:     auctionID,
+		UserID: 1,
+		Status: enums.PUBLISHED,
+	}, nil)
+	auctionPriceUpdater.On("ValidateNewPrice", auctionID, mock.Anything).Return(nil)
+	auctionPriceUpdater.On("NewDateEnd", auctionID, mock.Anything).Return((*time.Time)(nil), nil)
+	proxyRepo.On("GetActiveByAuctionID", auctionID).Return(proxies, nil)
+	return svc, repo, placement
+}
+
+func TestBidService_Create_ProxyOutbidsManualBid(t *testing.T) {
+	proxies := []models.ProxyBid{{ID: 1, AuctionID: 1, BidderID: 3, MaxAmount: 5000}}
+	svc, repo, placement := newProxyTestService(1, proxies)
+
+	dto, err := svc.Create(&bid.CreateBidDTO{AuctionID: 1, Amount: 2000}, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []placedBid{{2, 2000}, {3, 2000 + testIncrement}}, placedBids(placement))
+	assert.Equal(t, 2000+testIncrement, placement.Price)
+	assert.Empty(t, placement.ExhaustedProxyBidIDs)
+	assert.Equal(t, uint(3), dto.BidderID)
+	assert.Equal(t, 2000+testIncrement, dto.Amount)
+	assert.Empty(t, dto.ExhaustedProxyBids)
+	repo.AssertNumberOfCalls(t, "Place", 1)
+}
+
+func TestBidService_Create_ReturnsOwnBidWhenOutbid(t *testing.T) {
+	proxies := []models.ProxyBid{{ID: 1, AuctionID: 1, BidderID: 3, MaxAmount: 5000}}
+	svc, _, _ := newProxyTestService(1, proxies)
+
+	dto, err := svc.Create(&bid.CreateBidDTO{AuctionID: 1, Amount: 2000}, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &bid.CreatedBidDTO{AuctionID: 1, BidderID: 2, Amount: 2000, Outbid: true}, bid.ProcessingToCreated(dto))
+}
+
+func TestBidService_Create_ReturnsOwnBidWhenLeading(t *testing.T) {
+	svc, _, _ := newProxyTestService(1, nil)
+
+	dto, err := svc.Create(&bid.CreateBidDTO{AuctionID: 1, Amount: 2000}, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &bid.CreatedBidDTO{AuctionID: 1, BidderID: 2, Amount: 2000, Outbid: false}, bid.ProcessingToCreated(dto))
+}
+
+func TestBidService_Create_ManualBidExhaustsProxy(t *testing.T) {
+	proxies := []models.ProxyBid{{ID: 1, AuctionID: 1, BidderID: 3, MaxAmount: 5000}}
+	svc, _, placement := newProxyTestService(1, proxies)
+
+	dto, err := svc.Create(&bid.CreateBidDTO{AuctionID: 1, Amount: 5000}, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []placedBid{{2, 5000}}, placedBids(placement))
+	assert.Equal(t, []uint{1}, placement.ExhaustedProxyBidIDs)
+	assert.Equal(t, uint(2), dto.BidderID)
+	assert.Len(t, dto.ExhaustedProxyBids, 1)
+	assert.Equal(t, uint(3), dto.ExhaustedProxyBids[0].BidderID)
+}
+
+func TestBidService_Create_CompetingProxies(t *testing.T) {
+	now := time.Now()
+	maxAmount := uint(8000)
+	proxies := []models.ProxyBid{
+		{ID: 1, AuctionID: 1, BidderID: 3, MaxAmount: 5000, CreatedAt: now.Add(-time.Hour)},
+	}
+	svc, _, placement := newProxyTestService(1, proxies)
+
+	dto, err := svc.Create(&bid.CreateBidDTO{AuctionID: 1, Amount: 1000, MaxAmount: &maxAmount}, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []placedBid{{2, 1000}, {3, 5000}, {2, 5000 + testIncrement}}, placedBids(placement))
+	assert.Equal(t, 5000+testIncrement, placement.Price)
+	assert.Equal(t, uint(2), dto.BidderID)
+	assert.Equal(t, 5000+testIncrement, dto.Amount)
+	assert.Len(t, dto.ExhaustedProxyBids, 1)
+	assert.Equal(t, uint(3), dto.ExhaustedProxyBids[0].BidderID)
+	assert.Equal(t, []uint{1}, placement.ExhaustedProxyBidIDs)
+	if assert.NotNil(t, placement.ProxyBid) {
+		assert.Equal(t, uint(2), placement.ProxyBid.BidderID)
+		assert.Equal(t, maxAmount, placement.ProxyBid.MaxAmount)
+		assert.False(t, placement.ProxyBid.Exhausted)
+	}
+}
+
+func TestBidService_Create_NewMaximumReplacesOwnProxy(t *testing.T) {
+	now := time.Now()
+	maxAmount := uint(8000)
+	proxies := []models.ProxyBid{
+		{ID: 1, AuctionID: 1, BidderID: 3, MaxAmount: 5000, CreatedAt: now.Add(-time.Hour)},
+		{ID: 2, AuctionID: 1, BidderID: 2, MaxAmount: 3000, CreatedAt: now.Add(-2 * time.Hour)},
+	}
+	svc, _, placement := newProxyTestService(1, proxies)
+
+	dto, err := svc.Create(&bid.CreateBidDTO{AuctionID: 1, Amount: 3000, MaxAmount: &maxAmount}, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []placedBid{{2, 3000}, {3, 5000}, {2, 5000 + testIncrement}}, placedBids(placement))
+	assert.Equal(t, uint(2), dto.BidderID)
+	assert.Equal(t, []uint{1}, placement.ExhaustedProxyBidIDs)
+}
+
+func TestBidService_Create_EqualProxiesEarlierWins(t *testing.T) {
+	now := time.Now()
+	maxAmount := uint(5000)
+	proxies := []models.ProxyBid{
+		{ID: 1, AuctionID: 1, BidderID: 3, MaxAmount: 5000, CreatedAt: now.Add(-time.Hour)},
+	}
+	svc, _, placement := newProxyTestService(1, proxies)
+
+	dto, err := svc.Create(&bid.CreateBidDTO{AuctionID: 1, Amount: 1000, MaxAmount: &maxAmount}, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []placedBid{{2, 1000}, {3, 5000}}, placedBids(placement))
+	assert.Equal(t, uint(3), dto.BidderID)
+	assert.Len(t, dto.ExhaustedProxyBids, 1)
+	assert.Equal(t, uint(2), dto.ExhaustedProxyBids[0].BidderID)
+	// the new proxy bid is saved already exhausted
+	assert.Empty(t, placement.ExhaustedProxyBidIDs)
+	assert.True(t, placement.ProxyBid.Exhausted)
+}
+
+func TestBidService_Create_MaxAmountLowerThanAmount(t *testing.T) {
+	svc, repo, placement := newProxyTestService(1, nil)
+	maxAmount := uint(500)
+
+	_, err := svc.Create(&bid.CreateBidDTO{AuctionID: 1, Amount: 1000, MaxAmount: &maxAmount}, 2)
+
+	assert.ErrorIs(t, err, bid.ErrMaxAmountTooLow)
+	assert.Nil(t, placement.Bids)
+	repo.AssertNotCalled(t, "Place", mock.Anything)
+}
+
 func TestBidService_GetHighestBid_OK(t *testing.T) {
 	repo := new(mocks.BidRepositoryInterface)
 	saleOfferRetriever := new(mocks.SaleOfferRetrieverInterface)
 	auctionPriceUpdater := new(mocks.AuctionPriceUpdaterInterface)
-	svc := bid.NewBidService(repo, saleOfferRetriever, auctionPriceUpdater)
+	proxyRepo := new(mocks.ProxyBidRepositoryInterface)
+	svc := bid.NewBidService(repo, proxyRepo, saleOfferRetriever, auctionPriceUpdater, testIncrementTiers, bid.NewLocalAuctionLocker())
 
 	expected := &bid.RetrieveBidDTO{
 		AuctionID: 10,
@@ -148,7 +369,6 @@ func TestBidService_GetHighestBid_OK(t *testing.T) {
 		Amount:    100,
 	}
 	repo.On("GetHighestBid", uint(10)).Return(modelsBid, nil)
-	auctionPriceUpdater.On("UpdatePrice", uint(1), uint(0)).Return(nil)
 
 	got, err := svc.GetHighestBid(10)
 
@@ -161,12 +381,12 @@ func TestBidService_GetHighestBid_Error(t *testing.T) {
 	repo := new(mocks.BidRepositoryInterface)
 	saleOfferRetriever := new(mocks.SaleOfferRetrieverInterface)
 	auctionPriceUpdater := new(mocks.AuctionPriceUpdaterInterface)
-	svc := bid.NewBidService(repo, saleOfferRetriever, auctionPriceUpdater)
+	proxyRepo := new(mocks.ProxyBidRepositoryInterface)
+	svc := bid.NewBidService(repo, proxyRepo, saleOfferRetriever, auctionPriceUpdater, testIncrementTiers, bid.NewLocalAuctionLocker())
 
 	expectedErr := errors.New("db error")
 
 	repo.On("GetHighestBid", uint(10)).Return(nil, expectedErr)
-	auctionPriceUpdater.On("UpdatePrice", uint(1), uint(0)).Return(nil)
 
 	got, err := svc.GetHighestBid(10)
 
@@ -179,7 +399,8 @@ func TestBidService_GetAll_OK(t *testing.T) {
 	repo := new(mocks.BidRepositoryInterface)
 	saleOfferRetriever := new(mocks.SaleOfferRetrieverInterface)
 	auctionPriceUpdater := new(mocks.AuctionPriceUpdaterInterface)
-	svc := bid.NewBidService(repo, saleOfferRetriever, auctionPriceUpdater)
+	proxyRepo := new(mocks.ProxyBidRepositoryInterface)
+	svc := bid.NewBidService(repo, proxyRepo, saleOfferRetriever, auctionPriceUpdater, testIncrementTiers, bid.NewLocalAuctionLocker())
 
 	modelsBids := []models.Bid{
 		{ID: 1, AuctionID: 10, BidderID: 1, Amount: 100},
@@ -203,7 +424,8 @@ func TestBidService_GetAll_Error(t *testing.T) {
 	repo := new(mocks.BidRepositoryInterface)
 	saleOfferRetriever := new(mocks.SaleOfferRetrieverInterface)
 	auctionPriceUpdater := new(mocks.AuctionPriceUpdaterInterface)
-	svc := bid.NewBidService(repo, saleOfferRetriever, auctionPriceUpdater)
+	proxyRepo := new(mocks.ProxyBidRepositoryInterface)
+	svc := bid.NewBidService(repo, proxyRepo, saleOfferRetriever, auctionPriceUpdater, testIncrementTiers, bid.NewLocalAuctionLocker())
 
 	expectedErr := errors.New("db error")
 
@@ -220,7 +442,8 @@ func TestBidService_GetByID_OK(t *testing.T) {
 	repo := new(mocks.BidRepositoryInterface)
 	saleOfferRetriever := new(mocks.SaleOfferRetrieverInterface)
 	auctionPriceUpdater := new(mocks.AuctionPriceUpdaterInterface)
-	svc := bid.NewBidService(repo, saleOfferRetriever, auctionPriceUpdater)
+	proxyRepo := new(mocks.ProxyBidRepositoryInterface)
+	svc := bid.NewBidService(repo, proxyRepo, saleOfferRetriever, auctionPriceUpdater, testIncrementTiers, bid.NewLocalAuctionLocker())
 
 	modelsBid := &models.Bid{
 		ID:        1,
@@ -248,7 +471,8 @@ func TestBidService_GetByID_NotFound(t *testing.T) {
 	repo := new(mocks.BidRepositoryInterface)
 	saleOfferRetriever := new(mocks.SaleOfferRetrieverInterface)
 	auctionPriceUpdater := new(mocks.AuctionPriceUpdaterInterface)
-	svc := bid.NewBidService(repo, saleOfferRetriever, auctionPriceUpdater)
+	proxyRepo := new(mocks.ProxyBidRepositoryInterface)
+	svc := bid.NewBidService(repo, proxyRepo, saleOfferRetriever, auctionPriceUpdater, testIncrementTiers, bid.NewLocalAuctionLocker())
 
 	expectedErr := errors.New("bid not found")
 
@@ -265,7 +489,8 @@ func TestBidService_GetByBidderID_OK(t *testing.T) {
 	repo := new(mocks.BidRepositoryInterface)
 	saleOfferRetriever := new(mocks.SaleOfferRetrieverInterface)
 	auctionPriceUpdater := new(mocks.AuctionPriceUpdaterInterface)
-	svc := bid.NewBidService(repo, saleOfferRetriever, auctionPriceUpdater)
+	proxyRepo := new(mocks.ProxyBidRepositoryInterface)
+	svc := bid.NewBidService(repo, proxyRepo, saleOfferRetriever, auctionPriceUpdater, testIncrementTiers, bid.NewLocalAuctionLocker())
 
 	modelsBids := []models.Bid{
 		{ID: 1, AuctionID: 10, BidderID: 1, Amount: 100},
@@ -289,7 +514,8 @@ func TestBidService_GetByBidderID_Error(t *testing.T) {
 	repo := new(mocks.BidRepositoryInterface)
 	saleOfferRetriever := new(mocks.SaleOfferRetrieverInterface)
 	auctionPriceUpdater := new(mocks.AuctionPriceUpdaterInterface)
-	svc := bid.NewBidService(repo, saleOfferRetriever, auctionPriceUpdater)
+	proxyRepo := new(mocks.ProxyBidRepositoryInterface)
+	svc := bid.NewBidService(repo, proxyRepo, saleOfferRetriever, auctionPriceUpdater, testIncrementTiers, bid.NewLocalAuctionLocker())
 
 	expectedErr := errors.New("db error")
 
@@ -306,7 +532,8 @@ func TestBidService_GetByAuctionID_OK(t *testing.T) {
 	repo := new(mocks.BidRepositoryInterface)
 	saleOfferRetriever := new(mocks.SaleOfferRetrieverInterface)
 	auctionPriceUpdater := new(mocks.AuctionPriceUpdaterInterface)
-	svc := bid.NewBidService(repo, saleOfferRetriever, auctionPriceUpdater)
+	proxyRepo := new(mocks.ProxyBidRepositoryInterface)
+	svc := bid.NewBidService(repo, proxyRepo, saleOfferRetriever, auctionPriceUpdater, testIncrementTiers, bid.NewLocalAuctionLocker())
 
 	modelsBids := []models.Bid{
 		{ID: 1, AuctionID: 10, BidderID: 1, Amount: 100},
@@ -330,7 +557,8 @@ func TestBidService_GetByAuctionID_Error(t *testing.T) {
 	repo := new(mocks.BidRepositoryInterface)
 	saleOfferRetriever := new(mocks.SaleOfferRetrieverInterface)
 	auctionPriceUpdater := new(mocks.AuctionPriceUpdaterInterface)
-	svc := bid.NewBidService(repo, saleOfferRetriever, auctionPriceUpdater)
+	proxyRepo := new(mocks.ProxyBidRepositoryInterface)
+	svc := bid.NewBidService(repo, proxyRepo, saleOfferRetriever, auctionPriceUpdater, testIncrementTiers, bid.NewLocalAuctionLocker())
 
 	expectedErr := errors.New("db error")
 
@@ -347,7 +575,8 @@ func TestBidService_GetHighestBidByUserID_OK(t *testing.T) {
 	repo := new(mocks.BidRepositoryInterface)
 	saleOfferRetriever := new(mocks.SaleOfferRetrieverInterface)
 	auctionPriceUpdater := new(mocks.AuctionPriceUpdaterInterface)
-	svc := bid.NewBidService(repo, saleOfferRetriever, auctionPriceUpdater)
+	proxyRepo := new(mocks.ProxyBidRepositoryInterface)
+	svc := bid.NewBidService(repo, proxyRepo, saleOfferRetriever, auctionPriceUpdater, testIncrementTiers, bid.NewLocalAuctionLocker())
 
 	modelsBid := &models.Bid{
 		ID:        1,
@@ -374,7 +603,8 @@ func TestBidService_GetHighestBidByUserID_NotFound(t *testing.T) {
 	repo := new(mocks.BidRepositoryInterface)
 	saleOfferRetriever := new(mocks.SaleOfferRetrieverInterface)
 	auctionPriceUpdater := new(mocks.AuctionPriceUpdaterInterface)
-	svc := bid.NewBidService(repo, saleOfferRetriever, auctionPriceUpdater)
+	proxyRepo := new(mocks.ProxyBidRepositoryInterface)
+	svc := bid.NewBidService(repo, proxyRepo, saleOfferRetriever, auctionPriceUpdater, testIncrementTiers, bid.NewLocalAuctionLocker())
 
 	expectedErr := errors.New("no bid found for user")
 
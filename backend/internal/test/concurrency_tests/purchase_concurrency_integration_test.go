@@ -0,0 +1,347 @@
+package concurrency_tests
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/auction"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/bid"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/image"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/liked_offer"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/manufacturer"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/model"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/purchase"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/scheduler"
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	u "github.com/susek555/BD2/car-dealer-api/internal/test/test_utils"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// number of buyers racing for the same offer
+const BUYERS = 20
+
+// ------
+// Setup
+// ------
+
+type services struct {
+	db               *gorm.DB
+	saleOfferService sale_offer.SaleOfferServiceInterface
+	auctionService   auction.AuctionServiceInterface
+	bidService       bid.BidServiceInterface
+}
+
+func setupDB(t *testing.T) *services {
+	dsn := "host=localhost user=bd2_user password=bd2_password dbname=bd2_test port=5432 sslmode=disable TimeZone=UTC"
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	db.Exec("TRUNCATE TABLE purchases, bids, proxy_bids, liked_offers, auctions, sale_offers, cars, models, manufacturers, companies, people, users RESTART IDENTITY CASCADE")
+	users := make([]models.User, 0, BUYERS+1)
+	for i := uint(1); i <= BUYERS+1; i++ {
+		users = append(users, models.User{ID: i, Username: fmt.Sprintf("user%d", i), Email: fmt.Sprintf("user%d@example.com", i), Selector: "P"})
+	}
+	require.NoError(t, u.InsertRecordsIntoDB(db, users))
+	require.NoError(t, u.InsertRecordsIntoDB(db, []models.Manufacturer{{ID: 1, Name: "Audi"}}))
+	require.NoError(t, u.InsertRecordsIntoDB(db, []models.Model{{ID: 1, Name: "A3", ManufacturerID: 1}}))
+
+	saleOfferRepo := sale_offer.NewSaleOfferRepository(db)
+	bidRepo := bid.NewBidRepository(db)
+	saleOfferService := sale_offer.NewSaleOfferService(
+		saleOfferRepo,
+		manufacturer.NewManufacturerRepository(db),
+		model.NewModelRepository(db),
+		image.NewImageRepository(db),
+		image.NewImageBucket(nil),
+		sale_offer.NewAccessEvaluator(bidRepo, liked_offer.NewLikedOfferRepository(db)),
+		purchase.NewPurchaseRepository(db),
+		sale_offer.DefaultListingPolicy,
+	)
+	auctionLocker := bid.NewLocalAuctionLocker()
+	auctionService := auction.NewAuctionService(saleOfferRepo, saleOfferService, auctionLocker, auction.DefaultIncrementTiers, auction.DefaultExtensionPolicy)
+	bidService := bid.NewBidService(bidRepo, bid.NewProxyBidRepository(db), bid.SaleOfferAdapter{Svc: saleOfferService}, auctionService, auction.DefaultIncrementTiers, auctionLocker)
+	return &services{db: db, saleOfferService: saleOfferService, auctionService: auctionService, bidService: bidService}
+}
+
+func createOffer(t *testing.T, db *gorm.DB, auction_ *models.Auction) *models.SaleOffer {
+	offer := &models.SaleOffer{
+		UserID:      1,
+		Description: "offer",
+		Price:       1000,
+		Margin:      enums.LOW_MARGIN,
+		DateOfIssue: time.Now(),
+		Status:      enums.PUBLISHED,
+		IsAuction:   auction_ != nil,
+		Car: &models.Car{
+			Vin:                "vin",
+			ProductionYear:     2025,
+			Mileage:            1000,
+			NumberOfDoors:      4,
+			NumberOfSeats:      5,
+			EnginePower:        100,
+			EngineCapacity:     2000,
+			RegistrationNumber: "default",
+			RegistrationDate:   time.Now(),
+			Color:              enums.BLACK,
+			FuelType:           enums.PETROL,
+			Transmission:       enums.MANUAL,
+			NumberOfGears:      6,
+			Drive:              enums.FWD,
+			ModelID:            1,
+		},
+		Auction: auction_,
+	}
+	require.NoError(t, sale_offer.NewSaleOfferRepository(db).Create(offer))
+	return offer
+}
+
+func createAuction(t *testing.T, db *gorm.DB, buyNowPrice uint) *models.SaleOffer {
+	return createOffer(t, db, &models.Auction{
+		DateEnd:      time.Now().Add(24 * time.Hour),
+		InitialPrice: 1000,
+		BuyNowPrice:  &buyNowPrice,
+	})
+}
+
+// race starts all the attempts at once and returns the errors they finished with.
+func race(n int, attempt func(i int) error) []error {
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	errs := make([]error, n)
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			errs[i] = attempt(i)
+		}()
+	}
+	close(start)
+	wg.Wait()
+	return errs
+}
+
+func countSucceeded(errs []error) int {
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+		}
+	}
+	return succeeded
+}
+
+func getPurchases(t *testing.T, db *gorm.DB, offerID uint) []models.Purchase {
+	var purchases []models.Purchase
+	require.NoError(t, db.Where("offer_id = ?", offerID).Find(&purchases).Error)
+	return purchases
+}
+
+func getOffer(t *testing.T, db *gorm.DB, offerID uint) *models.SaleOffer {
+	var offer models.SaleOffer
+	require.NoError(t, db.First(&offer, offerID).Error)
+	return &offer
+}
+
+// -----
+// Tests
+// -----
+
+func TestConcurrentBuy_ExactlyOneBuyerWins(t *testing.T) {
+	s := setupDB(t)
+	offer := createOffer(t, s.db, nil)
+
+	errs := race(BUYERS, func(i int) error {
+		_, err := s.saleOfferService.Buy(offer.ID, uint(i+2))
+		return err
+	})
+
+	assert.Equal(t, 1, countSucceeded(errs))
+	for _, err := range errs {
+		if err != nil {
+			assert.ErrorIs(t, err, sale_offer.ErrOfferNotPublished)
+		}
+	}
+	purchases := getPurchases(t, s.db, offer.ID)
+	require.Len(t, purchases, 1)
+	assert.Equal(t, offer.Price, purchases[0].FinalPrice)
+	assert.Equal(t, enums.SOLD, getOffer(t, s.db, offer.ID).Status)
+}
+
+func TestConcurrentBuyNow_ExactlyOneBuyerWins(t *testing.T) {
+	s := setupDB(t)
+	offer := createAuction(t, s.db, 5000)
+
+	errs := race(BUYERS, func(i int) error {
+		_, err := s.auctionService.BuyNow(offer.ID, uint(i+2))
+		return err
+	})
+
+	assert.Equal(t, 1, countSucceeded(errs))
+	purchases := getPurchases(t, s.db, offer.ID)
+	require.Len(t, purchases, 1)
+	assert.Equal(t, uint(5000), purchases[0].FinalPrice)
+	assert.Equal(t, enums.SOLD, getOffer(t, s.db, offer.ID).Status)
+}
+
+func TestConcurrentBuyAndBuyNow_ExactlyOneBuyerWins(t *testing.T) {
+	s := setupDB(t)
+	offer := createAuction(t, s.db, 5000)
+
+	errs := race(BUYERS, func(i int) error {
+		if i%2 == 0 {
+			_, err := s.saleOfferService.Buy(offer.ID, uint(i+2))
+			return err
+		}
+		_, err := s.auctionService.BuyNow(offer.ID, uint(i+2))
+		return err
+	})
+
+	assert.Equal(t, 1, countSucceeded(errs))
+	for i, err := range errs {
+		if i%2 == 0 {
+			assert.Error(t, err, "plain buy must never succeed on an auction")
+		}
+	}
+	assert.Len(t, getPurchases(t, s.db, offer.ID), 1)
+}
+
+func TestConcurrentBidAndBuyNow_OnlyOneWins(t *testing.T) {
+	for round := range 10 {
+		t.Run(fmt.Sprintf("round %d", round), func(t *testing.T) {
+			s := setupDB(t)
+			offer := createAuction(t, s.db, 5000)
+
+			errs := race(2, func(i int) error {
+				if i == 0 {
+					_, err := s.bidService.Create(&bid.CreateBidDTO{AuctionID: offer.ID, Amount: 5000}, 2)
+					return err
+				}
+				_, err := s.auctionService.BuyNow(offer.ID, 3)
+				return err
+			})
+
+			bidErr, buyNowErr := errs[0], errs[1]
+			stored := getOffer(t, s.db, offer.ID)
+			purchases := getPurchases(t, s.db, offer.ID)
+			if buyNowErr == nil {
+				// buy-now went first, the bid must have been rejected and the price kept
+				assert.Error(t, bidErr)
+				assert.Equal(t, enums.SOLD, stored.Status)
+				require.Len(t, purchases, 1)
+				assert.Equal(t, uint(3), purchases[0].BuyerID)
+			} else {
+				// the bid reached the buy-now price first, so buying it now is no longer possible
+				assert.NoError(t, bidErr)
+				assert.True(t, errors.Is(buyNowErr, auction.ErrBuyNowPriceReached))
+				assert.Equal(t, uint(5000), stored.Price)
+				assert.Empty(t, purchases)
+			}
+		})
+	}
+}
+
+func TestConcurrentBids_PriceNeverMovesBackwards(t *testing.T) {
+	s := setupDB(t)
+	offer := createAuction(t, s.db, 1000000)
+
+	errs := race(BUYERS, func(i int) error {
+		_, err := s.bidService.Create(&bid.CreateBidDTO{AuctionID: offer.ID, Amount: uint(2000 + i*1000)}, uint(i+2))
+		return err
+	})
+
+	assert.NotZero(t, countSucceeded(errs))
+	var highest models.Bid
+	require.NoError(t, s.db.Where("auction_id = ?", offer.ID).Order("amount DESC").First(&highest).Error)
+	assert.Equal(t, highest.Amount, getOffer(t, s.db, offer.ID).Price)
+}
+
+func TestConcurrentBidsAndClose_HighestCommittedBidWins(t *testing.T) {
+	s := setupDB(t)
+	offer := createAuction(t, s.db, 1000000)
+	settlementRepo := scheduler.NewAuctionSettlementRepository(s.db)
+	afterEnd := offer.Auction.DateEnd.Add(time.Second)
+
+	errs := race(BUYERS+1, func(i int) error {
+		if i == BUYERS {
+			_, err := settlementRepo.Settle(scheduler.CloseCmd{AuctionID: offer.ID, Reason: scheduler.ReasonTimer}, afterEnd)
+			return err
+		}
+		_, err := s.bidService.Create(&bid.CreateBidDTO{AuctionID: offer.ID, Amount: uint(2000 + i*1000)}, uint(i+2))
+		return err
+	})
+
+	require.NoError(t, errs[BUYERS])
+	var highest models.Bid
+	if err := s.db.Where("auction_id = ?", offer.ID).Order("amount DESC").First(&highest).Error; err != nil {
+		// the auction was closed before any bid
+		assert.Equal(t, enums.EXPIRED, getOffer(t, s.db, offer.ID).Status)
+		return
+	}
+	// no bid is saved after the auction closed, so the highest one has won
+	purchases := getPurchases(t, s.db, offer.ID)
+	require.Len(t, purchases, 1)
+	assert.Equal(t, highest.BidderID, purchases[0].BuyerID)
+	assert.Equal(t, highest.Amount, purchases[0].FinalPrice)
+}
+
+func TestSettle_TimerBeforeAuctionEnd(t *testing.T) {
+	s := setupDB(t)
+	offer := createAuction(t, s.db, 5000)
+
+	// the auction was extended after the timer had been set
+	_, err := scheduler.NewAuctionSettlementRepository(s.db).Settle(scheduler.CloseCmd{AuctionID: offer.ID, Reason: scheduler.ReasonTimer}, time.Now())
+
+	assert.ErrorIs(t, err, scheduler.ErrAuctionNotEnded)
+	assert.Equal(t, enums.PUBLISHED, getOffer(t, s.db, offer.ID).Status)
+}
+
+func TestSettle_UnpublishedAuction(t *testing.T) {
+	s := setupDB(t)
+	offer := createAuction(t, s.db, 5000)
+	require.NoError(t, s.db.Model(&models.SaleOffer{}).Where("id = ?", offer.ID).Update("status", enums.READY).Error)
+
+	_, err := scheduler.NewAuctionSettlementRepository(s.db).Settle(scheduler.CloseCmd{AuctionID: offer.ID, Reason: scheduler.ReasonTimer}, offer.Auction.DateEnd)
+
+	assert.ErrorIs(t, err, scheduler.ErrAuctionNotPublished)
+	assert.Empty(t, getPurchases(t, s.db, offer.ID))
+}
+
+func TestUpdate_StaleOfferAfterPurchase(t *testing.T) {
+	s := setupDB(t)
+	offer := createOffer(t, s.db, nil)
+	repo := sale_offer.NewSaleOfferRepository(s.db)
+	stale, err := repo.GetByID(offer.ID)
+	require.NoError(t, err)
+	_, err = s.saleOfferService.Buy(offer.ID, 2)
+	require.NoError(t, err)
+
+	stale.Description = "changed"
+	err = repo.Update(stale)
+
+	assert.ErrorIs(t, err, sale_offer.ErrOfferStatusChanged)
+	assert.Equal(t, enums.SOLD, getOffer(t, s.db, offer.ID).Status)
+}
+
+func TestUpdate_KeepsOfferHidden(t *testing.T) {
+	s := setupDB(t)
+	offer := createOffer(t, s.db, nil)
+	repo := sale_offer.NewSaleOfferRepository(s.db)
+	stale, err := repo.GetByID(offer.ID)
+	require.NoError(t, err)
+	require.NoError(t, s.db.Model(&models.SaleOffer{}).Where("id = ?", offer.ID).Update("hidden_at", time.Now()).Error)
+
+	stale.Description = "changed"
+	require.NoError(t, repo.Update(stale))
+
+	updated := getOffer(t, s.db, offer.ID)
+	assert.Equal(t, "changed", updated.Description)
+	assert.NotNil(t, updated.HiddenAt)
+}
@@ -0,0 +1,268 @@
+package conversation_tests
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/conversation"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/ws"
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
+	"github.com/susek555/BD2/car-dealer-api/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+const (
+	buyerID  = uint(2)
+	sellerID = uint(5)
+	offerID  = uint(7)
+)
+
+type serviceMocks struct {
+	repo       *mocks.ConversationRepositoryInterface
+	saleOffers *mocks.SaleOfferRepositoryInterface
+	publisher  *mocks.UserPublisherInterface
+}
+
+func newTestService(t *testing.T) (conversation.ConversationServiceInterface, *serviceMocks) {
+	m := &serviceMocks{
+		repo:       mocks.NewConversationRepositoryInterface(t),
+		saleOffers: mocks.NewSaleOfferRepositoryInterface(t),
+		publisher:  mocks.NewUserPublisherInterface(t),
+	}
+	return conversation.NewConversationService(m.repo, m.saleOffers, m.publisher), m
+}
+
+func sampleConversation() *models.Conversation {
+	return &models.Conversation{ID: 3, OfferID: offerID, BuyerID: buyerID, SellerID: sellerID}
+}
+
+func uintPtr(v uint) *uint {
+	return &v
+}
+
+func envelopeOfType(msgType ws.MsgType) any {
+	return mock.MatchedBy(func(e *ws.Envelope) bool {
+		return e.MessageType == msgType
+	})
+}
+
+func TestConversationService_SendMessage_StartsConversation(t *testing.T) {
+	service, m := newTestService(t)
+
+	m.saleOffers.On("GetByID", offerID).Return(&models.SaleOffer{ID: offerID, UserID: sellerID, Status: enums.PUBLISHED}, nil)
+	m.repo.On("GetOrCreate", offerID, buyerID, sellerID).Return(sampleConversation(), nil)
+	m.repo.On("SaveMessage", mock.MatchedBy(func(msg *models.Message) bool {
+		return msg.ConversationID == 3 && msg.SenderID == buyerID && msg.Body == "Is it still available?"
+	})).Return(nil)
+	m.publisher.On("PublishToUser", mock.Anything, sellerID, mock.MatchedBy(func(e *ws.Envelope) bool {
+		var dto conversation.RetrieveMessageDTO
+		return e.MessageType == ws.MsgMessage &&
+			json.Unmarshal(e.Data, &dto) == nil &&
+			dto.OfferID == offerID && dto.Body == "Is it still available?"
+	})).Return(nil)
+	m.publisher.On("PublishToUser", mock.Anything, buyerID, envelopeOfType(ws.MsgMessage)).Return(nil)
+
+	err := service.SendMessage(buyerID, &ws.SendMessagePayload{OfferID: uintPtr(offerID), Body: "  Is it still available?  "})
+
+	assert.NoError(t, err)
+}
+
+func TestConversationService_SendMessage_ExistingConversation(t *testing.T) {
+	service, m := newTestService(t)
+
+	m.repo.On("GetByID", uint(3)).Return(sampleConversation(), nil)
+	m.repo.On("SaveMessage", mock.AnythingOfType("*models.Message")).Return(nil)
+	m.publisher.On("PublishToUser", mock.Anything, buyerID, envelopeOfType(ws.MsgMessage)).Return(nil)
+	m.publisher.On("PublishToUser", mock.Anything, sellerID, envelopeOfType(ws.MsgMessage)).Return(nil)
+
+	err := service.SendMessage(sellerID, &ws.SendMessagePayload{ConversationID: uintPtr(3), Body: "Yes"})
+
+	assert.NoError(t, err)
+}
+
+func TestConversationService_SendMessage_PublishFailureDoesNotFail(t *testing.T) {
+	service, m := newTestService(t)
+
+	m.repo.On("GetByID", uint(3)).Return(sampleConversation(), nil)
+	m.repo.On("SaveMessage", mock.AnythingOfType("*models.Message")).Return(nil)
+	m.publisher.On("PublishToUser", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("redis down"))
+
+	err := service.SendMessage(sellerID, &ws.SendMessagePayload{ConversationID: uintPtr(3), Body: "Yes"})
+
+	assert.NoError(t, err)
+}
+
+func TestConversationService_SendMessage_OwnOffer(t *testing.T) {
+	service, m := newTestService(t)
+
+	m.saleOffers.On("GetByID", offerID).Return(&models.SaleOffer{ID: offerID, UserID: sellerID, Status: enums.PUBLISHED}, nil)
+
+	err := service.SendMessage(sellerID, &ws.SendMessagePayload{OfferID: uintPtr(offerID), Body: "Hello"})
+
+	assert.ErrorIs(t, err, conversation.ErrCannotMessageOwnOffer)
+	m.repo.AssertNotCalled(t, "GetOrCreate", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestConversationService_SendMessage_OfferNotAvailable(t *testing.T) {
+	hiddenAt := time.Now()
+	tests := []struct {
+		name  string
+		offer *models.SaleOffer
+	}{
+		{"sold", &models.SaleOffer{ID: offerID, UserID: sellerID, Status: enums.SOLD}},
+		{"not published yet", &models.SaleOffer{ID: offerID, UserID: sellerID, Status: enums.READY}},
+		{"hidden", &models.SaleOffer{ID: offerID, UserID: sellerID, Status: enums.PUBLISHED, HiddenAt: &hiddenAt}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newTestService(t)
+			m.saleOffers.On("GetByID", offerID).Return(tt.offer, nil)
+
+			err := service.SendMessage(buyerID, &ws.SendMessagePayload{OfferID: uintPtr(offerID), Body: "Hello"})
+
+			assert.ErrorIs(t, err, conversation.ErrOfferNotAvailable)
+			m.repo.AssertNotCalled(t, "GetOrCreate", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestConversationService_SendMessage_NotParticipant(t *testing.T) {
+	service, m := newTestService(t)
+
+	m.repo.On("GetByID", uint(3)).Return(sampleConversation(), nil)
+
+	err := service.SendMessage(9, &ws.SendMessagePayload{ConversationID: uintPtr(3), Body: "Hello"})
+
+	assert.ErrorIs(t, err, conversation.ErrNotParticipant)
+}
+
+func TestConversationService_SendMessage_InvalidInput(t *testing.T) {
+	service, _ := newTestService(t)
+
+	errBlank := service.SendMessage(buyerID, &ws.SendMessagePayload{OfferID: uintPtr(offerID), Body: "   "})
+	errTooLong := service.SendMessage(buyerID, &ws.SendMessagePayload{OfferID: uintPtr(offerID), Body: strings.Repeat("a", conversation.MaxMessageLength+1)})
+	errNoTarget := service.SendMessage(buyerID, &ws.SendMessagePayload{Body: "Hello"})
+
+	assert.ErrorIs(t, errBlank, conversation.ErrInvalidMessage)
+	assert.ErrorIs(t, errTooLong, conversation.ErrInvalidMessage)
+	assert.ErrorIs(t, errNoTarget, conversation.ErrMissingConversation)
+}
+
+func TestConversationService_Typing_NotifiesOtherParticipant(t *testing.T) {
+	service, m := newTestService(t)
+
+	m.repo.On("GetByID", uint(3)).Return(sampleConversation(), nil)
+	m.publisher.On("PublishToUser", mock.Anything, sellerID, mock.MatchedBy(func(e *ws.Envelope) bool {
+		var p ws.TypingPayload
+		return e.MessageType == ws.MsgTyping && json.Unmarshal(e.Data, &p) == nil && p.UserID == buyerID
+	})).Return(nil)
+
+	err := service.Typing(buyerID, &ws.TypingPayload{ConversationID: 3})
+
+	assert.NoError(t, err)
+}
+
+func TestConversationService_Typing_ConversationNotFound(t *testing.T) {
+	service, m := newTestService(t)
+
+	m.repo.On("GetByID", uint(3)).Return(nil, gorm.ErrRecordNotFound)
+
+	err := service.Typing(buyerID, &ws.TypingPayload{ConversationID: 3})
+
+	assert.ErrorIs(t, err, conversation.ErrConversationNotFound)
+}
+
+func TestConversationService_MarkRead_SendsReceipt(t *testing.T) {
+	service, m := newTestService(t)
+
+	m.repo.On("GetByID", uint(3)).Return(sampleConversation(), nil)
+	m.repo.On("MarkRead", uint(3), sellerID, mock.AnythingOfType("time.Time")).Return(int64(2), nil)
+	m.publisher.On("PublishToUser", mock.Anything, buyerID, mock.MatchedBy(func(e *ws.Envelope) bool {
+		var p ws.ReadReceiptPayload
+		return e.MessageType == ws.MsgReadReceipt && json.Unmarshal(e.Data, &p) == nil && p.ReaderID == sellerID && p.ReadAt != nil
+	})).Return(nil)
+
+	err := service.MarkRead(sellerID, &ws.ReadReceiptPayload{ConversationID: 3})
+
+	assert.NoError(t, err)
+}
+
+func TestConversationService_MarkRead_NothingUnread(t *testing.T) {
+	service, m := newTestService(t)
+
+	m.repo.On("GetByID", uint(3)).Return(sampleConversation(), nil)
+	m.repo.On("MarkRead", uint(3), sellerID, mock.AnythingOfType("time.Time")).Return(int64(0), nil)
+
+	err := service.MarkRead(sellerID, &ws.ReadReceiptPayload{ConversationID: 3})
+
+	assert.NoError(t, err)
+	m.publisher.AssertNotCalled(t, "PublishToUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestConversationService_MarkRead_MissingConversationID(t *testing.T) {
+	service, _ := newTestService(t)
+
+	err := service.MarkRead(sellerID, &ws.ReadReceiptPayload{})
+
+	assert.ErrorIs(t, err, conversation.ErrConversationIDRequired)
+}
+
+func TestConversationService_GetConversations_WithUnreadCounts(t *testing.T) {
+	service, m := newTestService(t)
+	lastMessageAt := time.Now()
+	other := &models.Conversation{ID: 4, OfferID: 8, BuyerID: buyerID, SellerID: 6, Buyer: &models.User{Username: "buyer"}, Seller: &models.User{Username: "other"}}
+	first := sampleConversation()
+	first.LastMessageAt = &lastMessageAt
+	filter := &conversation.ConversationFilter{Pagination: pagination.PaginationRequest{Page: 1, PageSize: 10}}
+
+	m.repo.On("GetByUserID", buyerID, filter).Return([]models.Conversation{*first, *other}, &pagination.PaginationResponse{TotalPages: 1, TotalRecords: 2}, nil)
+	m.repo.On("CountUnread", buyerID, []uint{3, 4}).Return(map[uint]uint{3: 2}, nil)
+
+	result, err := service.GetConversations(buyerID, filter)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Conversations, 2)
+	assert.Equal(t, uint(2), result.Conversations[0].UnreadCount)
+	assert.NotNil(t, result.Conversations[0].LastMessageAt)
+	assert.Equal(t, uint(0), result.Conversations[1].UnreadCount)
+	assert.Equal(t, "other", result.Conversations[1].SellerUsername)
+}
+
+func TestConversationService_GetMessages_NotParticipant(t *testing.T) {
+	service, m := newTestService(t)
+	filter := &conversation.MessageFilter{Pagination: pagination.PaginationRequest{Page: 1, PageSize: 10}}
+
+	m.repo.On("GetByID", uint(3)).Return(sampleConversation(), nil)
+
+	_, err := service.GetMessages(9, 3, filter)
+
+	assert.ErrorIs(t, err, conversation.ErrNotParticipant)
+	m.repo.AssertNotCalled(t, "GetMessages", mock.Anything, mock.Anything)
+}
+
+func TestConversationService_GetMessages_Success(t *testing.T) {
+	service, m := newTestService(t)
+	filter := &conversation.MessageFilter{Pagination: pagination.PaginationRequest{Page: 1, PageSize: 10}}
+	readAt := time.Now()
+
+	m.repo.On("GetByID", uint(3)).Return(sampleConversation(), nil)
+	m.repo.On("GetMessages", uint(3), filter).Return([]models.Message{
+		{ID: 11, ConversationID: 3, SenderID: sellerID, Body: "Yes", CreatedAt: time.Now()},
+		{ID: 10, ConversationID: 3, SenderID: buyerID, Body: "Available?", CreatedAt: time.Now(), ReadAt: &readAt},
+	}, &pagination.PaginationResponse{TotalPages: 1, TotalRecords: 2}, nil)
+
+	result, err := service.GetMessages(buyerID, 3, filter)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Messages, 2)
+	assert.Equal(t, offerID, result.Messages[0].OfferID)
+	assert.Nil(t, result.Messages[0].ReadAt)
+	assert.NotNil(t, result.Messages[1].ReadAt)
+}
@@ -0,0 +1,64 @@
+package image_tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/image"
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
+)
+
+func TestDuplicateNotifier_NotifiesSellerAndReportsOffer(t *testing.T) {
+	notificationService := mocks.NewNotificationServiceInterface(t)
+	hub := mocks.NewHubInterface(t)
+	reporter := mocks.NewSystemReporterInterface(t)
+	notifier := image.NewDuplicateNotifier(notificationService, hub, reporter)
+
+	notificationService.On("CreateDuplicateImagesNotification", mock.MatchedBy(func(n *models.Notification) bool {
+		return *n.OfferID == offerID
+	}), "Audi A3", 2).Return(nil)
+	notificationService.On("SaveNotificationToClient", mock.AnythingOfType("*models.Notification"), sellerID).Return(nil)
+	hub.On("SendFourLatestNotificationsToUser", "3").Return()
+	reporter.On("CreateSystemReport", offerID, enums.DUPLICATE_IMAGES, "2 photo(s) of the offer look like photos of other sellers' offers").Return(nil)
+
+	err := notifier.NotifyDuplicates(offerID, "Audi A3", sellerID, 2)
+	assert.NoError(t, err)
+}
+
+func TestDuplicateNotifier_NotificationError(t *testing.T) {
+	notificationService := mocks.NewNotificationServiceInterface(t)
+	hub := mocks.NewHubInterface(t)
+	reporter := mocks.NewSystemReporterInterface(t)
+	notifier := image.NewDuplicateNotifier(notificationService, hub, reporter)
+
+	notificationService.On("CreateDuplicateImagesNotification", mock.Anything, "Audi A3", 1).Return(errors.New("db error"))
+	reporter.On("CreateSystemReport", offerID, enums.DUPLICATE_IMAGES, mock.Anything).Return(nil)
+
+	err := notifier.NotifyDuplicates(offerID, "Audi A3", sellerID, 1)
+	assert.Error(t, err)
+	hub.AssertNotCalled(t, "SendFourLatestNotificationsToUser", mock.Anything)
+	// the offer is reported all the same
+	reporter.AssertExpectations(t)
+}
+
+func TestDuplicateNotifier_ReportError(t *testing.T) {
+	notificationService := mocks.NewNotificationServiceInterface(t)
+	hub := mocks.NewHubInterface(t)
+	reporter := mocks.NewSystemReporterInterface(t)
+	notifier := image.NewDuplicateNotifier(notificationService, hub, reporter)
+	reportErr := errors.New("db error")
+
+	reporter.On("CreateSystemReport", offerID, enums.DUPLICATE_IMAGES, mock.Anything).Return(reportErr)
+	notificationService.On("CreateDuplicateImagesNotification", mock.Anything, "Audi A3", 1).Return(nil)
+	notificationService.On("SaveNotificationToClient", mock.AnythingOfType("*models.Notification"), sellerID).Return(nil)
+	hub.On("SendFourLatestNotificationsToUser", "3").Return()
+
+	err := notifier.NotifyDuplicates(offerID, "Audi A3", sellerID, 1)
+
+	// the seller is notified all the same
+	assert.ErrorIs(t, err, reportErr)
+}
@@ -0,0 +1,61 @@
+package image_tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/image"
+	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
+)
+
+func TestOrphanedFolderCleaner_DeletesFoldersOfMissingOffers(t *testing.T) {
+	repo := mocks.NewImageRepositoryInterface(t)
+	bucket := mocks.NewImageBucketInterface(t)
+	bucket.On("ListFolders").Return([]string{"sale-offer-1", "sale-offer-2", "sale-offer-3"}, nil)
+	repo.On("GetExistingOfferIDs", mock.MatchedBy(func(ids []uint) bool {
+		return assert.ElementsMatch(t, []uint{1, 2, 3}, ids)
+	})).Return([]uint{2}, nil)
+	bucket.On("DeleteByFolderName", "sale-offer-1").Return(nil)
+	bucket.On("DeleteByFolderName", "sale-offer-3").Return(nil)
+
+	err := image.NewOrphanedFolderCleaner(repo, bucket).Sweep()
+
+	assert.NoError(t, err)
+	bucket.AssertNotCalled(t, "DeleteByFolderName", "sale-offer-2")
+}
+
+func TestOrphanedFolderCleaner_LeavesOtherFoldersAlone(t *testing.T) {
+	repo := mocks.NewImageRepositoryInterface(t)
+	bucket := mocks.NewImageBucketInterface(t)
+	bucket.On("ListFolders").Return([]string{"samples", "sale-offer-draft"}, nil)
+
+	err := image.NewOrphanedFolderCleaner(repo, bucket).Sweep()
+
+	assert.NoError(t, err)
+	repo.AssertNotCalled(t, "GetExistingOfferIDs", mock.Anything)
+}
+
+func TestOrphanedFolderCleaner_FailedDeleteDoesNotBlockOthers(t *testing.T) {
+	repo := mocks.NewImageRepositoryInterface(t)
+	bucket := mocks.NewImageBucketInterface(t)
+	bucket.On("ListFolders").Return([]string{"sale-offer-1", "sale-offer-2"}, nil)
+	repo.On("GetExistingOfferIDs", mock.Anything).Return([]uint{}, nil)
+	bucket.On("DeleteByFolderName", "sale-offer-1").Return(errors.New("rate limited"))
+	bucket.On("DeleteByFolderName", "sale-offer-2").Return(nil)
+
+	err := image.NewOrphanedFolderCleaner(repo, bucket).Sweep()
+
+	assert.NoError(t, err)
+}
+
+func TestOrphanedFolderCleaner_ListingError(t *testing.T) {
+	repo := mocks.NewImageRepositoryInterface(t)
+	bucket := mocks.NewImageBucketInterface(t)
+	bucket.On("ListFolders").Return(nil, errors.New("unauthorized"))
+
+	err := image.NewOrphanedFolderCleaner(repo, bucket).Sweep()
+
+	assert.EqualError(t, err, "unauthorized")
+}
@@ -0,0 +1,101 @@
+package image_tests
+
+import (
+	"bytes"
+	goimage "image"
+	"image/draw"
+	"image/jpeg"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/image"
+	xdraw "golang.org/x/image/draw"
+)
+
+// resized scales the image onto a white background, as converting the transparent fixture to a JPEG would.
+func resized(img goimage.Image, width int) *goimage.RGBA {
+	bounds := img.Bounds()
+	out := goimage.NewRGBA(goimage.Rect(0, 0, width, bounds.Dy()*width/bounds.Dx()))
+	draw.Draw(out, out.Bounds(), goimage.White, goimage.Point{}, draw.Src)
+	xdraw.CatmullRom.Scale(out, out.Bounds(), img, bounds, xdraw.Over, nil)
+	return out
+}
+
+func mirrored(img goimage.Image) *goimage.NRGBA {
+	bounds := img.Bounds()
+	out := goimage.NewNRGBA(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			out.Set(bounds.Max.X-1-x+bounds.Min.X, y, img.At(x, y))
+		}
+	}
+	return out
+}
+
+func TestDHash_SurvivesResizingAndReencoding(t *testing.T) {
+	_, img := loadFixture(t)
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, resized(img, 640), &jpeg.Options{Quality: 60}))
+	copied, err := jpeg.Decode(&buf)
+	require.NoError(t, err)
+
+	distance := image.HammingDistance(image.DHash(img), image.DHash(copied))
+
+	assert.LessOrEqual(t, distance, image.MaxDuplicateDistance)
+}
+
+func TestDHash_DifferentPhotos(t *testing.T) {
+	_, img := loadFixture(t)
+
+	distance := image.HammingDistance(image.DHash(img), image.DHash(mirrored(img)))
+
+	assert.Greater(t, distance, 3*image.MaxDuplicateDistance)
+}
+
+func TestDHash_IsDeterministic(t *testing.T) {
+	_, img := loadFixture(t)
+
+	assert.Equal(t, image.DHash(img), image.DHash(img))
+}
+
+func TestHammingDistance(t *testing.T) {
+	assert.Equal(t, 0, image.HammingDistance(0xFF00, 0xFF00))
+	assert.Equal(t, 3, image.HammingDistance(0b1011, 0b0000))
+	assert.Equal(t, 64, image.HammingDistance(0, ^uint64(0)))
+}
+
+func TestHashBands_CloseHashesShareABand(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		hash := random.Uint64()
+		other := hash
+		for flip := 0; flip < image.MaxDuplicateDistance; flip++ {
+			other ^= 1 << random.Intn(64)
+		}
+		a, b := image.HashBands(hash), image.HashBands(other)
+		shared := false
+		for band := range a {
+			shared = shared || a[band] == b[band]
+		}
+		assert.True(t, shared, "%x and %x", hash, other)
+	}
+}
+
+func TestHashBands_CoverTheWholeHash(t *testing.T) {
+	hash := uint64(0x0123456789ABCDEF)
+	bands := image.HashBands(hash)
+
+	assert.Equal(t, uint64(0xCDEF), bands[0])
+	assert.Equal(t, uint64(0x0123), bands[len(bands)-1])
+}
+
+func TestImageProcessor_HashesUnblurredPhoto(t *testing.T) {
+	data, img := loadFixture(t)
+
+	processed, err := newProcessor().Process(fileHeader(t, "car.png", data), true)
+
+	require.NoError(t, err)
+	assert.Equal(t, image.DHash(img), processed.Hash)
+}
@@ -0,0 +1,175 @@
+package image_tests
+
+import (
+	"bytes"
+	goimage "image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/image"
+	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
+)
+
+const carFixture = "../sale_offer_tests/images/test1.png"
+
+// the badge on the front bumper of the car in the fixture, where the plate would be, and the lettering on it
+var (
+	fixturePlate     = goimage.Rect(1290, 810, 1640, 890)
+	fixtureLettering = goimage.Rect(1300, 825, 1630, 880)
+)
+
+func loadFixture(t *testing.T) ([]byte, goimage.Image) {
+	t.Helper()
+	data, err := os.ReadFile(carFixture)
+	require.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	return data, img
+}
+
+// syntheticPlate draws a white plate with a black border and character strokes on a grey background.
+func syntheticPlate(plate goimage.Rectangle) *goimage.NRGBA {
+	img := goimage.NewNRGBA(goimage.Rect(0, 0, 800, 600))
+	draw.Draw(img, img.Bounds(), &goimage.Uniform{C: color.NRGBA{R: 120, G: 120, B: 120, A: 255}}, goimage.Point{}, draw.Src)
+	draw.Draw(img, plate, goimage.Black, goimage.Point{}, draw.Src)
+	draw.Draw(img, plate.Inset(3), goimage.White, goimage.Point{}, draw.Src)
+	for x := plate.Min.X + 12; x+8 < plate.Max.X-12; x += 16 {
+		draw.Draw(img, goimage.Rect(x, plate.Min.Y+10, x+8, plate.Max.Y-10), goimage.Black, goimage.Point{}, draw.Src)
+	}
+	return img
+}
+
+func overlap(a, b goimage.Rectangle) float64 {
+	inter := a.Intersect(b)
+	if inter.Empty() {
+		return 0
+	}
+	area := func(r goimage.Rectangle) int { return r.Dx() * r.Dy() }
+	return float64(area(inter)) / float64(area(a)+area(b)-area(inter))
+}
+
+func bestOverlap(found []goimage.Rectangle, want goimage.Rectangle) float64 {
+	best := 0.0
+	for _, r := range found {
+		best = max(best, overlap(r, want))
+	}
+	return best
+}
+
+// variance of the brightness over the region, a sharp plate has a lot of it and a blurred one very little.
+func variance(img goimage.Image, region goimage.Rectangle) float64 {
+	var sum, sumSq float64
+	for y := region.Min.Y; y < region.Max.Y; y++ {
+		for x := region.Min.X; x < region.Max.X; x++ {
+			v := float64(color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y)
+			sum += v
+			sumSq += v * v
+		}
+	}
+	n := float64(region.Dx() * region.Dy())
+	mean := sum / n
+	return sumSq/n - mean*mean
+}
+
+func samePixels(a, b goimage.Image, region goimage.Rectangle) bool {
+	for y := region.Min.Y; y < region.Max.Y; y++ {
+		for x := region.Min.X; x < region.Max.X; x++ {
+			if color.NRGBAModel.Convert(a.At(x, y)) != color.NRGBAModel.Convert(b.At(x, y)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestPlateDetector_FindsPlateInFixture(t *testing.T) {
+	_, img := loadFixture(t)
+
+	found := image.NewPlateDetector().Detect(img)
+
+	assert.Greater(t, bestOverlap(found, fixturePlate), 0.5, "found %v", found)
+}
+
+func TestPlateDetector_IsDeterministic(t *testing.T) {
+	_, img := loadFixture(t)
+	detector := image.NewPlateDetector()
+
+	assert.Equal(t, detector.Detect(img), detector.Detect(img))
+}
+
+func TestPlateDetector_FindsSyntheticPlate(t *testing.T) {
+	plate := goimage.Rect(300, 400, 500, 450)
+
+	found := image.NewPlateDetector().Detect(syntheticPlate(plate))
+
+	require.Len(t, found, 1)
+	assert.Greater(t, overlap(found[0], plate), 0.5, "found %v", found)
+	assert.True(t, plate.In(found[0].Inset(-2)), "the whole plate is covered")
+}
+
+func TestPlateDetector_PlainImage(t *testing.T) {
+	assert.Empty(t, image.NewPlateDetector().Detect(halves(800, 600, 255)))
+}
+
+func TestPlateDetector_TinyImage(t *testing.T) {
+	assert.Empty(t, image.NewPlateDetector().Detect(halves(4, 4, 255)))
+}
+
+func TestBlurRegions_BlursOnlyTheRegions(t *testing.T) {
+	_, img := loadFixture(t)
+	elsewhere := goimage.Rect(100, 900, 400, 1200)
+
+	blurred := image.BlurRegions(img, []goimage.Rectangle{fixturePlate})
+
+	assert.Less(t, variance(blurred, fixturePlate), variance(img, fixturePlate)/4)
+	assert.True(t, samePixels(img, blurred, elsewhere))
+	assert.True(t, samePixels(img, blurred, goimage.Rect(fixturePlate.Min.X, fixturePlate.Max.Y, fixturePlate.Max.X, fixturePlate.Max.Y+5)),
+		"nothing leaks out of the region")
+}
+
+func TestBlurRegions_NoRegions(t *testing.T) {
+	img := halves(10, 10, 255)
+
+	assert.Same(t, img, image.BlurRegions(img, nil))
+}
+
+func TestImageProcessor_BlursPlatesOnRequest(t *testing.T) {
+	data, img := loadFixture(t)
+
+	processed, err := newProcessor().Process(fileHeader(t, "car.png", data), true)
+
+	require.NoError(t, err)
+	original := decodeRendition(t, processed.Original)
+	assert.Less(t, variance(original, fixtureLettering), variance(img, fixtureLettering)/4)
+	assert.True(t, samePixels(img, original, goimage.Rect(100, 900, 400, 1200)))
+}
+
+func TestImageProcessor_KeepsPlatesByDefault(t *testing.T) {
+	data, img := loadFixture(t)
+	// the mock fails the test if the detector is called at all
+	processor := image.NewImageProcessor(mocks.NewPlateDetectorInterface(t))
+
+	processed, err := processor.Process(fileHeader(t, "car.png", data), false)
+
+	require.NoError(t, err)
+	assert.True(t, samePixels(img, decodeRendition(t, processed.Original), fixturePlate))
+}
+
+func TestImageProcessor_BlursDetectedRegions(t *testing.T) {
+	detector := mocks.NewPlateDetectorInterface(t)
+	region := goimage.Rect(10, 0, 30, 20)
+	detector.EXPECT().Detect(mock.Anything).Return([]goimage.Rectangle{region})
+
+	processed, err := image.NewImageProcessor(detector).Process(fileHeader(t, "car.png", encodePNG(t, halves(40, 20, 255))), true)
+
+	require.NoError(t, err)
+	original := decodeRendition(t, processed.Original)
+	assert.True(t, isRed(original.At(2, 10)), "outside the region")
+	assert.False(t, isRed(original.At(19, 10)) || isBlue(original.At(19, 10)), "red and blue are mixed at the seam")
+}
@@ -0,0 +1,198 @@
+package image_tests
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"hash/crc32"
+	goimage "image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"mime/multipart"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/image"
+)
+
+// a 1x1 lossless WebP
+const webpPixel = "UklGRhoAAABXRUJQVlA4TA0AAAAvAAAAEAcQERGIiP4HAA=="
+
+func newProcessor() image.ImageProcessorInterface {
+	return image.NewImageProcessor(image.NewPlateDetector())
+}
+
+func fileHeader(t *testing.T, filename string, content []byte) *multipart.FileHeader {
+	t.Helper()
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("images", filename)
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	form, err := multipart.NewReader(body, writer.Boundary()).ReadForm(1 << 20)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = form.RemoveAll() })
+	return form.File["images"][0]
+}
+
+// halves draws an image with a red left half and a blue right half.
+func halves(w, h int, alpha uint8) *goimage.NRGBA {
+	img := goimage.NewNRGBA(goimage.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.NRGBA{R: 255, A: alpha}
+			if x >= w/2 {
+				c = color.NRGBA{B: 255, A: alpha}
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, img goimage.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// exifJPEG encodes the image as a JPEG carrying an EXIF segment with the orientation tag and a GPS marker.
+func exifJPEG(t *testing.T, img goimage.Image, orientation uint16) []byte {
+	t.Helper()
+	var encoded bytes.Buffer
+	require.NoError(t, jpeg.Encode(&encoded, img, &jpeg.Options{Quality: 95}))
+
+	tiff := []byte("II*\x00\x08\x00\x00\x00")
+	tiff = binary.LittleEndian.AppendUint16(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 3)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+	tiff = append(tiff, "GPSLatitude 52.2297N"...)
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+
+	out := []byte{0xFF, 0xD8, 0xFF, 0xE1}
+	out = binary.BigEndian.AppendUint16(out, uint16(len(segment)+2))
+	out = append(out, segment...)
+	return append(out, encoded.Bytes()[2:]...)
+}
+
+func decodeRendition(t *testing.T, data []byte) goimage.Image {
+	t.Helper()
+	img, _, err := goimage.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	return img
+}
+
+func isRed(c color.Color) bool {
+	r, g, b, _ := c.RGBA()
+	return r > 0xC000 && g < 0x4000 && b < 0x4000
+}
+
+func isBlue(c color.Color) bool {
+	r, g, b, _ := c.RGBA()
+	return b > 0xC000 && r < 0x4000 && g < 0x4000
+}
+
+func TestImageProcessor_StripsMetadataAndAppliesOrientation(t *testing.T) {
+	data := exifJPEG(t, halves(40, 20, 255), 6)
+	require.Contains(t, string(data), "GPSLatitude")
+
+	processed, err := newProcessor().Process(fileHeader(t, "car.jpg", data), false)
+
+	require.NoError(t, err)
+	assert.Equal(t, ".jpg", processed.Extension)
+	for _, rendition := range [][]byte{processed.Original, processed.Medium, processed.Thumbnail} {
+		assert.NotContains(t, string(rendition), "GPSLatitude")
+		assert.NotContains(t, string(rendition), "Exif")
+	}
+	original := decodeRendition(t, processed.Original)
+	assert.Equal(t, goimage.Rect(0, 0, 20, 40), original.Bounds())
+	assert.True(t, isRed(original.At(10, 5)), "the left half is rotated to the top")
+	assert.True(t, isBlue(original.At(10, 35)), "the right half is rotated to the bottom")
+}
+
+func TestImageProcessor_OrientationRotatesCounterClockwise(t *testing.T) {
+	processed, err := newProcessor().Process(fileHeader(t, "car.jpg", exifJPEG(t, halves(40, 20, 255), 8)), false)
+
+	require.NoError(t, err)
+	original := decodeRendition(t, processed.Original)
+	assert.Equal(t, goimage.Rect(0, 0, 20, 40), original.Bounds())
+	assert.True(t, isBlue(original.At(10, 5)))
+	assert.True(t, isRed(original.At(10, 35)))
+}
+
+func TestImageProcessor_GeneratesRenditions(t *testing.T) {
+	processed, err := newProcessor().Process(fileHeader(t, "car.png", encodePNG(t, halves(2000, 1000, 255))), false)
+
+	require.NoError(t, err)
+	assert.Equal(t, ".jpg", processed.Extension, "opaque images are stored as JPEG")
+	assert.Equal(t, goimage.Rect(0, 0, 2000, 1000), decodeRendition(t, processed.Original).Bounds())
+	assert.Equal(t, goimage.Rect(0, 0, image.MediumSize, image.MediumSize/2), decodeRendition(t, processed.Medium).Bounds())
+	assert.Equal(t, goimage.Rect(0, 0, image.ThumbnailSize, image.ThumbnailSize/2), decodeRendition(t, processed.Thumbnail).Bounds())
+}
+
+func TestImageProcessor_KeepsTransparency(t *testing.T) {
+	processed, err := newProcessor().Process(fileHeader(t, "logo.png", encodePNG(t, halves(400, 200, 128))), false)
+
+	require.NoError(t, err)
+	assert.Equal(t, ".png", processed.Extension)
+	pixel := color.NRGBAModel.Convert(decodeRendition(t, processed.Thumbnail).At(0, 0)).(color.NRGBA)
+	assert.InDelta(t, 128, pixel.A, 2)
+}
+
+func TestImageProcessor_DoesNotEnlargeSmallImages(t *testing.T) {
+	processed, err := newProcessor().Process(fileHeader(t, "car.png", encodePNG(t, halves(100, 50, 255))), false)
+
+	require.NoError(t, err)
+	assert.Equal(t, goimage.Rect(0, 0, 100, 50), decodeRendition(t, processed.Medium).Bounds())
+	assert.Equal(t, goimage.Rect(0, 0, 100, 50), decodeRendition(t, processed.Thumbnail).Bounds())
+}
+
+func TestImageProcessor_AcceptsWebP(t *testing.T) {
+	data, err := base64.StdEncoding.DecodeString(webpPixel)
+	require.NoError(t, err)
+
+	processed, err := newProcessor().Process(fileHeader(t, "car.webp", data), false)
+
+	require.NoError(t, err)
+	assert.Equal(t, goimage.Rect(0, 0, 1, 1), decodeRendition(t, processed.Original).Bounds())
+}
+
+func TestImageProcessor_RejectsOtherTypes(t *testing.T) {
+	_, err := newProcessor().Process(fileHeader(t, "car.png", []byte("<svg xmlns=\"http://www.w3.org/2000/svg\"></svg>")), false)
+
+	assert.ErrorIs(t, err, image.ErrUnsupportedImageType)
+}
+
+func TestImageProcessor_RejectsCorruptedImages(t *testing.T) {
+	data := encodePNG(t, halves(100, 50, 255))
+
+	_, err := newProcessor().Process(fileHeader(t, "car.png", data[:len(data)/2]), false)
+
+	assert.ErrorIs(t, err, image.ErrInvalidImage)
+}
+
+func TestImageProcessor_RejectsLargeFiles(t *testing.T) {
+	_, err := newProcessor().Process(&multipart.FileHeader{Filename: "car.jpg", Size: image.MaxImageSize + 1}, false)
+
+	assert.ErrorIs(t, err, image.ErrImageTooLarge)
+}
+
+func TestImageProcessor_RejectsTooManyPixels(t *testing.T) {
+	data := encodePNG(t, halves(1, 1, 255))
+	// the IHDR chunk starts right after the signature, claim 10000x10000 pixels and fix its checksum
+	binary.BigEndian.PutUint32(data[16:], 10000)
+	binary.BigEndian.PutUint32(data[20:], 10000)
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+
+	_, err := newProcessor().Process(fileHeader(t, "car.png", data), false)
+
+	assert.ErrorIs(t, err, image.ErrImageTooLarge)
+}
@@ -0,0 +1,388 @@
+package image_tests
+
+import (
+	"errors"
+	"mime/multipart"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/image"
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
+)
+
+const (
+	sellerID  = uint(3)
+	offerID   = uint(7)
+	photoHash = uint64(0xF0E1D2C3B4A59687)
+)
+
+type serviceMocks struct {
+	repo       *mocks.ImageRepositoryInterface
+	bucket     *mocks.ImageBucketInterface
+	processor  *mocks.ImageProcessorInterface
+	offerRepo  *mocks.OfferRepositoryInterface
+	accessEval *mocks.OfferAccessEvaluatorInterface
+}
+
+func newTestService(t *testing.T) (image.ImageServiceInterface, *serviceMocks) {
+	m := &serviceMocks{
+		repo:       mocks.NewImageRepositoryInterface(t),
+		bucket:     mocks.NewImageBucketInterface(t),
+		processor:  mocks.NewImageProcessorInterface(t),
+		offerRepo:  mocks.NewOfferRepositoryInterface(t),
+		accessEval: mocks.NewOfferAccessEvaluatorInterface(t),
+	}
+	return image.NewImageService(m.repo, m.bucket, m.processor, m.offerRepo, m.accessEval), m
+}
+
+func (m *serviceMocks) ownOffer() *models.SaleOffer {
+	offer := &models.SaleOffer{ID: offerID, UserID: sellerID}
+	m.offerRepo.On("GetByID", offerID).Return(offer, nil)
+	m.accessEval.On("CanBeModifiedByUser", offer, mock.Anything).Return(nil)
+	return offer
+}
+
+// uploadToFakeBucket stores every file under "<folder><name>" and serves it from a fake host.
+func (m *serviceMocks) uploadToFakeBucket() {
+	m.bucket.On("Upload", "sale-offer-7/", mock.Anything, mock.Anything).Return(
+		func(folder, name string, _ []byte) string { return strings.TrimSuffix(folder, "/") + "/" + name },
+		func(folder, name string, _ []byte) string { return "https://img.test/" + folder + name },
+		nil,
+	)
+}
+
+func processed() *image.ProcessedImage {
+	return &image.ProcessedImage{Extension: ".jpg", Original: []byte("o"), Medium: []byte("m"), Thumbnail: []byte("t"), Hash: photoHash}
+}
+
+func TestImageService_Store_UploadsRenditions(t *testing.T) {
+	service, m := newTestService(t)
+	offer := m.ownOffer()
+	file := &multipart.FileHeader{Filename: "car.jpg"}
+	m.repo.On("GetByOfferID", offerID).Return([]models.Image{}, nil).Once()
+	m.processor.On("Process", file, false).Return(processed(), nil)
+	m.uploadToFakeBucket()
+	m.repo.On("GetSimilar", mock.Anything, sellerID).Return([]models.Image{}, nil)
+	var stored *models.Image
+	m.repo.On("Create", mock.Anything).Run(func(args mock.Arguments) { stored = args.Get(0).(*models.Image) }).Return(nil)
+	m.repo.On("GetByOfferID", offerID).Return([]models.Image{{}}, nil).Once()
+	m.offerRepo.On("UpdateStatus", offer, enums.PENDING).Return(nil)
+
+	_, err := service.Store(offerID, []*multipart.FileHeader{file}, sellerID)
+
+	assert.NoError(t, err)
+	m.bucket.AssertNumberOfCalls(t, "Upload", 3)
+	assert.Equal(t, offerID, stored.OfferID)
+	assert.True(t, strings.HasSuffix(stored.PublicID, ".jpg"))
+	assert.Equal(t, strings.TrimSuffix(stored.PublicID, ".jpg")+"_medium.jpg", stored.MediumPublicID)
+	assert.Equal(t, strings.TrimSuffix(stored.PublicID, ".jpg")+"_thumbnail.jpg", stored.ThumbnailPublicID)
+	assert.Equal(t, "https://img.test/"+stored.ThumbnailPublicID, stored.ThumbnailUrl)
+	assert.Equal(t, stored.ThumbnailUrl, stored.ThumbnailOrOriginalUrl())
+	assert.Equal(t, uint(0), stored.Position)
+	assert.True(t, stored.IsCover, "the first image of an offer becomes its cover")
+	assert.Equal(t, photoHash, uint64(*stored.PerceptualHash), "the hash keeps its bits in the signed column")
+}
+
+func TestImageService_Store_AppendsToGallery(t *testing.T) {
+	service, m := newTestService(t)
+	offer := m.ownOffer()
+	first := &multipart.FileHeader{Filename: "a.jpg"}
+	second := &multipart.FileHeader{Filename: "b.jpg"}
+	existing := []models.Image{{ID: 1, Position: 0, IsCover: true}, {ID: 2, Position: 4}}
+	m.repo.On("GetByOfferID", offerID).Return(existing, nil)
+	m.processor.On("Process", mock.Anything, false).Return(processed(), nil)
+	m.uploadToFakeBucket()
+	m.repo.On("GetSimilar", mock.Anything, sellerID).Return([]models.Image{}, nil)
+	var stored []models.Image
+	m.repo.On("Create", mock.Anything).Run(func(args mock.Arguments) { stored = append(stored, *args.Get(0).(*models.Image)) }).Return(nil)
+	m.offerRepo.On("UpdateStatus", offer, enums.PENDING).Return(nil)
+
+	_, err := service.Store(offerID, []*multipart.FileHeader{first, second}, sellerID)
+
+	assert.NoError(t, err)
+	assert.Len(t, stored, 2)
+	assert.Equal(t, uint(5), stored[0].Position)
+	assert.Equal(t, uint(6), stored[1].Position)
+	assert.False(t, stored[0].IsCover)
+	assert.False(t, stored[1].IsCover)
+}
+
+func TestImageService_Store_BlursPlatesWhenOfferAsks(t *testing.T) {
+	service, m := newTestService(t)
+	offer := m.ownOffer()
+	offer.BlurPlates = true
+	file := &multipart.FileHeader{Filename: "car.jpg"}
+	m.repo.On("GetByOfferID", offerID).Return([]models.Image{}, nil)
+	m.processor.On("Process", file, true).Return(processed(), nil)
+	m.uploadToFakeBucket()
+	m.repo.On("GetSimilar", mock.Anything, sellerID).Return([]models.Image{}, nil)
+	m.repo.On("Create", mock.Anything).Return(nil)
+	m.offerRepo.On("UpdateStatus", offer, enums.PENDING).Return(nil)
+
+	_, err := service.Store(offerID, []*multipart.FileHeader{file}, sellerID)
+
+	assert.NoError(t, err)
+	m.processor.AssertCalled(t, "Process", file, true)
+}
+
+func TestImageService_Store_InvalidFileRejectsWholeBatch(t *testing.T) {
+	service, m := newTestService(t)
+	m.ownOffer()
+	good := &multipart.FileHeader{Filename: "car.jpg"}
+	bad := &multipart.FileHeader{Filename: "car.exe"}
+	m.repo.On("GetByOfferID", offerID).Return([]models.Image{}, nil)
+	m.processor.On("Process", good, false).Return(processed(), nil)
+	m.processor.On("Process", bad, false).Return(nil, image.ErrUnsupportedImageType)
+
+	_, err := service.Store(offerID, []*multipart.FileHeader{good, bad}, sellerID)
+
+	assert.ErrorIs(t, err, image.ErrUnsupportedImageType)
+	m.bucket.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImageService_Store_FailedUploadRemovesUploadedRenditions(t *testing.T) {
+	service, m := newTestService(t)
+	m.ownOffer()
+	file := &multipart.FileHeader{Filename: "car.jpg"}
+	m.repo.On("GetByOfferID", offerID).Return([]models.Image{}, nil)
+	m.processor.On("Process", file, false).Return(processed(), nil)
+	uploadErr := errors.New("storage unavailable")
+	m.bucket.On("Upload", "sale-offer-7/", mock.MatchedBy(func(name string) bool { return strings.HasSuffix(name, "_thumbnail.jpg") }), mock.Anything).
+		Return("", "", uploadErr)
+	m.uploadToFakeBucket()
+	m.bucket.On("Delete", mock.Anything).Return(nil)
+
+	_, err := service.Store(offerID, []*multipart.FileHeader{file}, sellerID)
+
+	assert.ErrorIs(t, err, uploadErr)
+	m.bucket.AssertNumberOfCalls(t, "Delete", 2)
+	m.repo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestImageService_DeleteByURL_DeletesRenditions(t *testing.T) {
+	service, m := newTestService(t)
+	offer := m.ownOffer()
+	stored := &models.Image{ID: 1, OfferID: offerID, Url: "https://img.test/a.jpg", PublicID: "a.jpg", MediumPublicID: "a_medium.jpg", ThumbnailPublicID: "a_thumbnail.jpg"}
+	m.repo.On("GetByURL", stored.Url).Return(stored, nil)
+	m.repo.On("Delete", uint(1)).Return(nil)
+	for _, id := range []string{"a.jpg", "a_medium.jpg", "a_thumbnail.jpg"} {
+		m.bucket.On("Delete", id).Return(nil).Once()
+	}
+	m.repo.On("GetByOfferID", offerID).Return([]models.Image{}, nil)
+	m.offerRepo.On("UpdateStatus", offer, enums.PENDING).Return(nil)
+
+	err := service.DeleteByURL(stored.Url, sellerID)
+
+	assert.NoError(t, err)
+}
+
+func TestImageService_DeleteByURL_ImageWithoutRenditions(t *testing.T) {
+	service, m := newTestService(t)
+	offer := m.ownOffer()
+	stored := &models.Image{ID: 1, OfferID: offerID, Url: "https://img.test/a.jpg", PublicID: "a"}
+	m.repo.On("GetByURL", stored.Url).Return(stored, nil)
+	m.repo.On("Delete", uint(1)).Return(nil)
+	m.bucket.On("Delete", "a").Return(nil).Once()
+	m.repo.On("GetByOfferID", offerID).Return([]models.Image{}, nil)
+	m.offerRepo.On("UpdateStatus", offer, enums.PENDING).Return(nil)
+
+	err := service.DeleteByURL(stored.Url, sellerID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, stored.Url, stored.ThumbnailOrOriginalUrl())
+}
+
+func TestImageService_DeleteByURL_PromotesNextCover(t *testing.T) {
+	service, m := newTestService(t)
+	offer := m.ownOffer()
+	stored := &models.Image{ID: 1, OfferID: offerID, Url: "https://img.test/a.jpg", PublicID: "a", IsCover: true}
+	m.repo.On("GetByURL", stored.Url).Return(stored, nil)
+	m.repo.On("Delete", uint(1)).Return(nil)
+	m.bucket.On("Delete", "a").Return(nil)
+	m.repo.On("GetByOfferID", offerID).Return([]models.Image{{ID: 4}, {ID: 2}, {ID: 3}}, nil)
+	m.repo.On("SetCover", offerID, uint(4)).Return(nil).Once()
+	m.offerRepo.On("UpdateStatus", offer, enums.READY).Return(nil)
+
+	err := service.DeleteByURL(stored.Url, sellerID)
+
+	assert.NoError(t, err)
+}
+
+func TestImageService_Reorder(t *testing.T) {
+	service, m := newTestService(t)
+	m.ownOffer()
+	m.repo.On("GetByOfferID", offerID).Return([]models.Image{{ID: 1}, {ID: 2}, {ID: 3}}, nil)
+	m.repo.On("UpdateOrder", offerID, []uint{3, 1, 2}).Return(nil).Once()
+
+	err := service.Reorder(offerID, []uint{3, 1, 2}, sellerID)
+
+	assert.NoError(t, err)
+}
+
+func TestImageService_Reorder_InvalidOrder(t *testing.T) {
+	for name, order := range map[string][]uint{
+		"missing image":   {3, 1},
+		"duplicate image": {3, 1, 1},
+		"foreign image":   {3, 1, 9},
+	} {
+		t.Run(name, func(t *testing.T) {
+			service, m := newTestService(t)
+			m.ownOffer()
+			m.repo.On("GetByOfferID", offerID).Return([]models.Image{{ID: 1}, {ID: 2}, {ID: 3}}, nil)
+
+			err := service.Reorder(offerID, order, sellerID)
+
+			assert.ErrorIs(t, err, image.ErrInvalidImageOrder)
+		})
+	}
+}
+
+func TestImageService_Reorder_NotOwner(t *testing.T) {
+	service, m := newTestService(t)
+	offer := &models.SaleOffer{ID: offerID, UserID: sellerID}
+	m.offerRepo.On("GetByID", offerID).Return(offer, nil)
+	m.accessEval.On("CanBeModifiedByUser", offer, mock.Anything).Return(image.ErrOfferNotOwned)
+
+	err := service.Reorder(offerID, []uint{1}, 99)
+
+	assert.ErrorIs(t, err, image.ErrOfferNotOwned)
+}
+
+func TestImageService_Update_SetsCoverAndCaption(t *testing.T) {
+	service, m := newTestService(t)
+	m.ownOffer()
+	m.repo.On("GetByOfferID", offerID).Return([]models.Image{{ID: 1, IsCover: true}, {ID: 2}}, nil)
+	m.repo.On("UpdateCaption", uint(2), mock.MatchedBy(func(c *string) bool { return c != nil && *c == "Interior" })).Return(nil).Once()
+	m.repo.On("SetCover", offerID, uint(2)).Return(nil).Once()
+	caption, cover := "  Interior ", true
+
+	err := service.Update(offerID, 2, &image.UpdateImageDTO{Caption: &caption, IsCover: &cover}, sellerID)
+
+	assert.NoError(t, err)
+}
+
+func TestImageService_Update_EmptyCaptionRemovesIt(t *testing.T) {
+	service, m := newTestService(t)
+	m.ownOffer()
+	m.repo.On("GetByOfferID", offerID).Return([]models.Image{{ID: 1, IsCover: true}}, nil)
+	m.repo.On("UpdateCaption", uint(1), (*string)(nil)).Return(nil).Once()
+	caption := " "
+
+	err := service.Update(offerID, 1, &image.UpdateImageDTO{Caption: &caption}, sellerID)
+
+	assert.NoError(t, err)
+}
+
+func TestImageService_Update_CaptionTooLong(t *testing.T) {
+	service, m := newTestService(t)
+	m.ownOffer()
+	m.repo.On("GetByOfferID", offerID).Return([]models.Image{{ID: 1}}, nil)
+	caption := strings.Repeat("ż", image.MaxCaptionLength+1)
+
+	err := service.Update(offerID, 1, &image.UpdateImageDTO{Caption: &caption}, sellerID)
+
+	assert.ErrorIs(t, err, image.ErrCaptionTooLong)
+}
+
+func TestImageService_Update_CannotUnsetCover(t *testing.T) {
+	service, m := newTestService(t)
+	m.ownOffer()
+	m.repo.On("GetByOfferID", offerID).Return([]models.Image{{ID: 1, IsCover: true}}, nil)
+	cover := false
+
+	err := service.Update(offerID, 1, &image.UpdateImageDTO{IsCover: &cover}, sellerID)
+
+	assert.ErrorIs(t, err, image.ErrCoverRequired)
+}
+
+func TestImageService_Update_ImageOfOtherOffer(t *testing.T) {
+	service, m := newTestService(t)
+	m.ownOffer()
+	m.repo.On("GetByOfferID", offerID).Return([]models.Image{{ID: 1}}, nil)
+	cover := true
+
+	err := service.Update(offerID, 5, &image.UpdateImageDTO{IsCover: &cover}, sellerID)
+
+	assert.ErrorIs(t, err, image.ErrImageNotInOffer)
+}
+
+func hashOf(hash uint64) *int64 {
+	value := int64(hash)
+	return &value
+}
+
+func TestImageService_Store_ReturnsDuplicates(t *testing.T) {
+	service, m := newTestService(t)
+	offer := m.ownOffer()
+	file := &multipart.FileHeader{Filename: "car.jpg"}
+	m.repo.On("GetByOfferID", offerID).Return([]models.Image{}, nil)
+	m.processor.On("Process", file, false).Return(processed(), nil)
+	m.uploadToFakeBucket()
+	m.repo.On("Create", mock.Anything).Run(func(args mock.Arguments) { args.Get(0).(*models.Image).ID = 11 }).Return(nil)
+	m.offerRepo.On("UpdateStatus", offer, enums.PENDING).Return(nil)
+	m.repo.On("GetSimilar", photoHash, sellerID).Return([]models.Image{
+		{ID: 20, OfferID: 30, Url: "far", PerceptualHash: hashOf(photoHash ^ 0b1111), Offer: &models.SaleOffer{UserID: 4}},
+		{ID: 21, OfferID: 31, Url: "close", PerceptualHash: hashOf(photoHash ^ 0b101), Offer: &models.SaleOffer{UserID: 5}},
+		{ID: 22, OfferID: 32, Url: "same", PerceptualHash: hashOf(photoHash), Offer: &models.SaleOffer{UserID: 6}},
+	}, nil)
+
+	duplicates, err := service.Store(offerID, []*multipart.FileHeader{file}, sellerID)
+
+	assert.NoError(t, err)
+	assert.Len(t, duplicates, 1)
+	assert.Equal(t, uint(11), duplicates[0].ImageID)
+	assert.Equal(t, []image.DuplicateMatchDTO{
+		{ImageID: 22, OfferID: 32, SellerID: 6, Url: "same", Distance: 0},
+		{ImageID: 21, OfferID: 31, SellerID: 5, Url: "close", Distance: 2},
+	}, duplicates[0].Matches, "the closest match comes first, the too distant one is left out")
+}
+
+func TestImageService_GetDuplicates_Owner(t *testing.T) {
+	service, m := newTestService(t)
+	m.offerRepo.On("GetByID", offerID).Return(&models.SaleOffer{ID: offerID, UserID: sellerID}, nil)
+	m.repo.On("GetByOfferID", offerID).Return([]models.Image{
+		{ID: 1, Url: "old"},
+		{ID: 2, Url: "unique", PerceptualHash: hashOf(0xAAAA)},
+		{ID: 3, Url: "copied", PerceptualHash: hashOf(photoHash)},
+	}, nil)
+	m.repo.On("GetSimilar", uint64(0xAAAA), sellerID).Return([]models.Image{}, nil)
+	m.repo.On("GetSimilar", photoHash, sellerID).Return([]models.Image{
+		{ID: 9, OfferID: 8, Url: "original", PerceptualHash: hashOf(photoHash ^ 1), Offer: &models.SaleOffer{UserID: 4}},
+	}, nil)
+
+	duplicates, err := service.GetDuplicates(offerID, sellerID, enums.USER)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []image.ImageDuplicatesDTO{{
+		ImageID: 3,
+		Url:     "copied",
+		Matches: []image.DuplicateMatchDTO{{ImageID: 9, OfferID: 8, SellerID: 4, Url: "original", Distance: 1}},
+	}}, duplicates)
+	m.repo.AssertNumberOfCalls(t, "GetSimilar", 2)
+}
+
+func TestImageService_GetDuplicates_Moderator(t *testing.T) {
+	service, m := newTestService(t)
+	m.offerRepo.On("GetByID", offerID).Return(&models.SaleOffer{ID: offerID, UserID: sellerID}, nil)
+	m.repo.On("GetByOfferID", offerID).Return([]models.Image{{ID: 1, PerceptualHash: hashOf(photoHash)}}, nil)
+	m.repo.On("GetSimilar", photoHash, sellerID).Return([]models.Image{}, nil)
+
+	duplicates, err := service.GetDuplicates(offerID, 99, enums.MODERATOR)
+
+	assert.NoError(t, err)
+	assert.Empty(t, duplicates)
+	assert.NotNil(t, duplicates, "an empty report is an empty list")
+}
+
+func TestImageService_GetDuplicates_OtherUser(t *testing.T) {
+	service, m := newTestService(t)
+	m.offerRepo.On("GetByID", offerID).Return(&models.SaleOffer{ID: offerID, UserID: sellerID}, nil)
+
+	_, err := service.GetDuplicates(offerID, 99, enums.USER)
+
+	assert.ErrorIs(t, err, image.ErrOfferNotOwned)
+}
@@ -0,0 +1,180 @@
+package job_tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/job"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
+)
+
+func newTestRunner(t *testing.T) (*job.Runner, *mocks.JobRepositoryInterface) {
+	repo := mocks.NewJobRepositoryInterface(t)
+	return job.NewRunner(repo, nil, time.Second), repo
+}
+
+func countingJob(name string, schedule job.Schedule, err error, calls *int) job.Job {
+	return job.Job{
+		Name:     name,
+		Schedule: schedule,
+		Run: func(context.Context) error {
+			*calls++
+			return err
+		},
+	}
+}
+
+func TestRunner_RegisterDuplicate(t *testing.T) {
+	runner, _ := newTestRunner(t)
+	calls := 0
+
+	assert.NoError(t, runner.Register(countingJob("cleanup", job.Every(time.Hour), nil, &calls)))
+	assert.ErrorIs(t, runner.Register(countingJob("cleanup", job.Every(time.Hour), nil, &calls)), job.ErrDuplicateJob)
+	assert.ErrorIs(t, runner.RegisterWorker(job.Worker{Name: "cleanup"}), job.ErrDuplicateJob)
+}
+
+func TestRunner_NewJobIsScheduledNotRun(t *testing.T) {
+	runner, repo := newTestRunner(t)
+	calls := 0
+	_ = runner.Register(countingJob("cleanup", job.Every(time.Hour), nil, &calls))
+
+	repo.On("GetAll").Return([]models.Job{}, nil)
+	repo.On("Save", mock.MatchedBy(func(j *models.Job) bool {
+		return j.Name == "cleanup" && j.NextRunAt.Equal(base.Add(time.Hour))
+	})).Return(nil).Once()
+
+	err := runner.RunDue(context.Background(), base)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, calls)
+}
+
+func TestRunner_DueJobRunsAndIsRescheduled(t *testing.T) {
+	runner, repo := newTestRunner(t)
+	calls := 0
+	_ = runner.Register(countingJob("cleanup", job.Every(time.Hour), nil, &calls))
+	lastError := "db down"
+	due := base.Add(-time.Minute)
+
+	repo.On("GetAll").Return([]models.Job{{Name: "cleanup", NextRunAt: &due, Attempts: 2, LastError: &lastError}}, nil)
+	repo.On("Save", mock.MatchedBy(func(j *models.Job) bool {
+		return j.Attempts == 0 && j.LastError == nil && j.LastRunAt != nil && j.NextRunAt.Equal(base.Add(time.Hour))
+	})).Return(nil).Once()
+
+	err := runner.RunDue(context.Background(), base)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRunner_JobNotDueIsSkipped(t *testing.T) {
+	runner, repo := newTestRunner(t)
+	calls := 0
+	_ = runner.Register(countingJob("cleanup", job.Every(time.Hour), nil, &calls))
+	later := base.Add(time.Minute)
+
+	repo.On("GetAll").Return([]models.Job{{Name: "cleanup", NextRunAt: &later}}, nil)
+
+	err := runner.RunDue(context.Background(), base)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, calls)
+	repo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestRunner_FailedJobIsRetriedWithBackoff(t *testing.T) {
+	runner, repo := newTestRunner(t)
+	calls := 0
+	_ = runner.Register(countingJob("cleanup", job.Every(time.Hour), errors.New("db down"), &calls))
+	due := base.Add(-time.Minute)
+	before := time.Now()
+
+	repo.On("GetAll").Return([]models.Job{{Name: "cleanup", NextRunAt: &due, Attempts: 1}}, nil)
+	repo.On("Save", mock.MatchedBy(func(j *models.Job) bool {
+		retryIn := j.NextRunAt.Sub(before)
+		return j.Attempts == 2 && *j.LastError == "db down" && retryIn >= job.Backoff(1) && retryIn < job.Backoff(1)+time.Minute
+	})).Return(nil).Once()
+
+	err := runner.RunDue(context.Background(), base)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRunner_GivesUpAfterMaxAttempts(t *testing.T) {
+	runner, repo := newTestRunner(t)
+	calls := 0
+	_ = runner.Register(countingJob("cleanup", job.Every(time.Hour), errors.New("db down"), &calls))
+	due := base.Add(-time.Minute)
+
+	repo.On("GetAll").Return([]models.Job{{Name: "cleanup", NextRunAt: &due, Attempts: job.MaxAttempts - 1}}, nil)
+	repo.On("Save", mock.MatchedBy(func(j *models.Job) bool {
+		return j.Attempts == 0 && j.LastError != nil && j.NextRunAt.Equal(base.Add(time.Hour))
+	})).Return(nil).Once()
+
+	err := runner.RunDue(context.Background(), base)
+
+	assert.NoError(t, err)
+}
+
+func TestRunner_OneShotJobIsNotRescheduled(t *testing.T) {
+	runner, repo := newTestRunner(t)
+	calls := 0
+	_ = runner.Register(countingJob("migrate", job.Once(base), nil, &calls))
+
+	repo.On("GetAll").Return([]models.Job{{Name: "migrate", NextRunAt: &base}}, nil)
+	repo.On("Save", mock.MatchedBy(func(j *models.Job) bool {
+		return j.NextRunAt == nil && j.LastRunAt != nil
+	})).Return(nil).Once()
+
+	err := runner.RunDue(context.Background(), base)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRunner_LoadingError(t *testing.T) {
+	runner, repo := newTestRunner(t)
+	calls := 0
+	_ = runner.Register(countingJob("cleanup", job.Every(time.Hour), nil, &calls))
+	loadErr := errors.New("db down")
+
+	repo.On("GetAll").Return(nil, loadErr)
+
+	err := runner.RunDue(context.Background(), base)
+
+	assert.ErrorIs(t, err, loadErr)
+	assert.Equal(t, 0, calls)
+}
+
+func TestRunner_GetStatuses(t *testing.T) {
+	runner, repo := newTestRunner(t)
+	calls := 0
+	_ = runner.Register(countingJob("cleanup", job.Every(time.Hour), nil, &calls))
+	_ = runner.Register(countingJob("report", job.Every(time.Minute), nil, &calls))
+	_ = runner.RegisterWorker(job.Worker{Name: "auction-scheduler", Run: func(context.Context) {}})
+	lastError := "db down"
+
+	repo.On("GetAll").Return([]models.Job{{Name: "cleanup", NextRunAt: &base, LastError: &lastError, Attempts: 2}}, nil)
+
+	statuses, err := runner.GetStatuses()
+
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 3)
+	assert.Equal(t, job.JobStatusDTO{
+		Name:      "cleanup",
+		Kind:      job.KindScheduled,
+		Schedule:  "@every 1h0m0s",
+		NextRunAt: func() *string { s := base.Format(time.RFC3339); return &s }(),
+		LastError: &lastError,
+		Attempts:  2,
+	}, statuses[0])
+	assert.Equal(t, "report", statuses[1].Name)
+	assert.Nil(t, statuses[1].NextRunAt)
+	assert.Equal(t, job.JobStatusDTO{Name: "auction-scheduler", Kind: job.KindWorker}, statuses[2])
+}
@@ -0,0 +1,66 @@
+package job_tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/job"
+)
+
+var base = time.Date(2025, 3, 10, 12, 20, 0, 0, time.UTC)
+
+func TestCron_NextRun(t *testing.T) {
+	schedule, err := job.Cron("15 * * * *")
+	assert.NoError(t, err)
+
+	next, ok := schedule.Next(base)
+
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2025, 3, 10, 13, 15, 0, 0, time.UTC), next)
+	assert.Equal(t, "15 * * * *", schedule.String())
+}
+
+func TestCron_Descriptor(t *testing.T) {
+	schedule, err := job.Cron("@daily")
+	assert.NoError(t, err)
+
+	next, ok := schedule.Next(base)
+
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC), next)
+}
+
+func TestCron_InvalidSpec(t *testing.T) {
+	_, err := job.Cron("every monday")
+	assert.ErrorIs(t, err, job.ErrInvalidSchedule)
+}
+
+func TestEvery_NextRun(t *testing.T) {
+	schedule := job.Every(10 * time.Minute)
+
+	next, ok := schedule.Next(base)
+
+	assert.True(t, ok)
+	assert.Equal(t, base.Add(10*time.Minute), next)
+	assert.Equal(t, "@every 10m0s", schedule.String())
+}
+
+func TestOnce_RunsOnlyOnce(t *testing.T) {
+	at := base.Add(time.Hour)
+	schedule := job.Once(at)
+
+	next, ok := schedule.Next(base)
+	assert.True(t, ok)
+	assert.Equal(t, at, next)
+
+	_, ok = schedule.Next(at)
+	assert.False(t, ok)
+}
+
+func TestBackoff_DoublesUpToLimit(t *testing.T) {
+	assert.Equal(t, 30*time.Second, job.Backoff(0))
+	assert.Equal(t, time.Minute, job.Backoff(1))
+	assert.Equal(t, 4*time.Minute, job.Backoff(3))
+	assert.Equal(t, time.Hour, job.Backoff(20))
+}
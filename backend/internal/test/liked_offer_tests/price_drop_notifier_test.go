@@ -0,0 +1,56 @@
+package liked_offer_tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/liked_offer"
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
+)
+
+type offer struct {
+	id     uint
+	userID uint
+}
+
+func (o offer) GetBrand() string               { return "Audi" }
+func (o offer) GetModel() string               { return "A3" }
+func (o offer) GetPrice() uint                 { return 20000 }
+func (o offer) HasBuyNowPrice() bool           { return false }
+func (o offer) GetStatus() enums.Status        { return enums.PUBLISHED }
+func (o offer) BelongsToUser(userID uint) bool { return o.userID == userID }
+func (o offer) GetID() uint                    { return o.id }
+
+func TestPriceDropNotifier_SkipsSellerAndOptedOutUsers(t *testing.T) {
+	repo := mocks.NewLikedOfferRepositoryInterface(t)
+	notificationService := mocks.NewNotificationServiceInterface(t)
+	hub := mocks.NewHubInterface(t)
+	notifier := liked_offer.NewPriceDropNotifier(repo, notificationService, hub)
+
+	repo.On("GetUserIDsWithPriceDropAlertsOff", uint(7)).Return([]uint{4, 5}, nil)
+	notificationService.On("CreatePriceDropNotification", mock.MatchedBy(func(n *models.Notification) bool {
+		return *n.OfferID == 7
+	}), uint(25000), mock.Anything).Return(nil)
+	hub.On("SaveNotificationForClientsExcept", "7", []uint{4, 5, 1}, mock.AnythingOfType("*models.Notification")).Return(nil)
+	hub.On("SendFourLatestNotificationsToClients", "7", "1").Return()
+
+	err := notifier.NotifyPriceDrop(offer{id: 7, userID: 1}, 1, 25000)
+	assert.NoError(t, err)
+}
+
+func TestPriceDropNotifier_RepositoryError(t *testing.T) {
+	repo := mocks.NewLikedOfferRepositoryInterface(t)
+	notificationService := mocks.NewNotificationServiceInterface(t)
+	hub := mocks.NewHubInterface(t)
+	notifier := liked_offer.NewPriceDropNotifier(repo, notificationService, hub)
+
+	repo.On("GetUserIDsWithPriceDropAlertsOff", uint(7)).Return(nil, errors.New("db error"))
+
+	err := notifier.NotifyPriceDrop(offer{id: 7, userID: 1}, 1, 25000)
+	assert.Error(t, err)
+	notificationService.AssertNotCalled(t, "CreatePriceDropNotification", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -2,7 +2,11 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // AuctionPriceUpdaterInterface is an autogenerated mock type for the AuctionPriceUpdaterInterface type
 type AuctionPriceUpdaterInterface struct {
@@ -17,12 +21,71 @@ func (_m *AuctionPriceUpdaterInterface) EXPECT() *AuctionPriceUpdaterInterface_E
 	return &AuctionPriceUpdaterInterface_Expecter{mock: &_m.Mock}
 }
 
-// UpdatePrice provides a mock function with given fields: auctionID, newPrice
-func (_m *AuctionPriceUpdaterInterface) UpdatePrice(auctionID uint, newPrice uint) error {
+// NewDateEnd provides a mock function with given fields: auctionID, bidTime
+func (_m *AuctionPriceUpdaterInterface) NewDateEnd(auctionID uint, bidTime time.Time) (*time.Time, error) {
+	ret := _m.Called(auctionID, bidTime)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewDateEnd")
+	}
+
+	var r0 *time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, time.Time) (*time.Time, error)); ok {
+		return rf(auctionID, bidTime)
+	}
+	if rf, ok := ret.Get(0).(func(uint, time.Time) *time.Time); ok {
+		r0 = rf(auctionID, bidTime)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*time.Time)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, time.Time) error); ok {
+		r1 = rf(auctionID, bidTime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuctionPriceUpdaterInterface_NewDateEnd_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewDateEnd'
+type AuctionPriceUpdaterInterface_NewDateEnd_Call struct {
+	*mock.Call
+}
+
+// NewDateEnd is a helper method to define mock.On call
+//   - auctionID uint
+//   - bidTime time.Time
+func (_e *AuctionPriceUpdaterInterface_Expecter) NewDateEnd(auctionID interface{}, bidTime interface{}) *AuctionPriceUpdaterInterface_NewDateEnd_Call {
+	return &AuctionPriceUpdaterInterface_NewDateEnd_Call{Call: _e.mock.On("NewDateEnd", auctionID, bidTime)}
+}
+
+func (_c *AuctionPriceUpdaterInterface_NewDateEnd_Call) Run(run func(auctionID uint, bidTime time.Time)) *AuctionPriceUpdaterInterface_NewDateEnd_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *AuctionPriceUpdaterInterface_NewDateEnd_Call) Return(_a0 *time.Time, _a1 error) *AuctionPriceUpdaterInterface_NewDateEnd_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuctionPriceUpdaterInterface_NewDateEnd_Call) RunAndReturn(run func(uint, time.Time) (*time.Time, error)) *AuctionPriceUpdaterInterface_NewDateEnd_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateNewPrice provides a mock function with given fields: auctionID, newPrice
+func (_m *AuctionPriceUpdaterInterface) ValidateNewPrice(auctionID uint, newPrice uint) error {
 	ret := _m.Called(auctionID, newPrice)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdatePrice")
+		panic("no return value specified for ValidateNewPrice")
 	}
 
 	var r0 error
@@ -35,31 +98,31 @@ func (_m *AuctionPriceUpdaterInterface) UpdatePrice(auctionID uint, newPrice uin
 	return r0
 }
 
-// AuctionPriceUpdaterInterface_UpdatePrice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePrice'
-type AuctionPriceUpdaterInterface_UpdatePrice_Call struct {
+// AuctionPriceUpdaterInterface_ValidateNewPrice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateNewPrice'
+type AuctionPriceUpdaterInterface_ValidateNewPrice_Call struct {
 	*mock.Call
 }
 
-// UpdatePrice is a helper method to define mock.On call
+// ValidateNewPrice is a helper method to define mock.On call
 //   - auctionID uint
 //   - newPrice uint
-func (_e *AuctionPriceUpdaterInterface_Expecter) UpdatePrice(auctionID interface{}, newPrice interface{}) *AuctionPriceUpdaterInterface_UpdatePrice_Call {
-	return &AuctionPriceUpdaterInterface_UpdatePrice_Call{Call: _e.mock.On("UpdatePrice", auctionID, newPrice)}
+func (_e *AuctionPriceUpdaterInterface_Expecter) ValidateNewPrice(auctionID interface{}, newPrice interface{}) *AuctionPriceUpdaterInterface_ValidateNewPrice_Call {
+	return &AuctionPriceUpdaterInterface_ValidateNewPrice_Call{Call: _e.mock.On("ValidateNewPrice", auctionID, newPrice)}
 }
 
-func (_c *AuctionPriceUpdaterInterface_UpdatePrice_Call) Run(run func(auctionID uint, newPrice uint)) *AuctionPriceUpdaterInterface_UpdatePrice_Call {
+func (_c *AuctionPriceUpdaterInterface_ValidateNewPrice_Call) Run(run func(auctionID uint, newPrice uint)) *AuctionPriceUpdaterInterface_ValidateNewPrice_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(uint), args[1].(uint))
 	})
 	return _c
 }
 
-func (_c *AuctionPriceUpdaterInterface_UpdatePrice_Call) Return(_a0 error) *AuctionPriceUpdaterInterface_UpdatePrice_Call {
+func (_c *AuctionPriceUpdaterInterface_ValidateNewPrice_Call) Return(_a0 error) *AuctionPriceUpdaterInterface_ValidateNewPrice_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *AuctionPriceUpdaterInterface_UpdatePrice_Call) RunAndReturn(run func(uint, uint) error) *AuctionPriceUpdaterInterface_UpdatePrice_Call {
+func (_c *AuctionPriceUpdaterInterface_ValidateNewPrice_Call) RunAndReturn(run func(uint, uint) error) *AuctionPriceUpdaterInterface_ValidateNewPrice_Call {
 	_c.Call.Return(run)
 	return _c
 }
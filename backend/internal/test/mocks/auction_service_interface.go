@@ -3,11 +3,11 @@
 package mocks
 
 import (
+	time "time"
+
 	mock "github.com/stretchr/testify/mock"
 	auction "github.com/susek555/BD2/car-dealer-api/internal/domains/auction"
-
 	notification "github.com/susek555/BD2/car-dealer-api/internal/domains/notification"
-
 	sale_offer "github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
 )
 
@@ -188,6 +188,65 @@ func (_c *AuctionServiceInterface_Delete_Call) RunAndReturn(run func(uint, uint)
 	return _c
 }
 
+// NewDateEnd provides a mock function with given fields: offerID, bidTime
+func (_m *AuctionServiceInterface) NewDateEnd(offerID uint, bidTime time.Time) (*time.Time, error) {
+	ret := _m.Called(offerID, bidTime)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewDateEnd")
+	}
+
+	var r0 *time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, time.Time) (*time.Time, error)); ok {
+		return rf(offerID, bidTime)
+	}
+	if rf, ok := ret.Get(0).(func(uint, time.Time) *time.Time); ok {
+		r0 = rf(offerID, bidTime)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*time.Time)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, time.Time) error); ok {
+		r1 = rf(offerID, bidTime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuctionServiceInterface_NewDateEnd_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewDateEnd'
+type AuctionServiceInterface_NewDateEnd_Call struct {
+	*mock.Call
+}
+
+// NewDateEnd is a helper method to define mock.On call
+//   - offerID uint
+//   - bidTime time.Time
+func (_e *AuctionServiceInterface_Expecter) NewDateEnd(offerID interface{}, bidTime interface{}) *AuctionServiceInterface_NewDateEnd_Call {
+	return &AuctionServiceInterface_NewDateEnd_Call{Call: _e.mock.On("NewDateEnd", offerID, bidTime)}
+}
+
+func (_c *AuctionServiceInterface_NewDateEnd_Call) Run(run func(offerID uint, bidTime time.Time)) *AuctionServiceInterface_NewDateEnd_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *AuctionServiceInterface_NewDateEnd_Call) Return(_a0 *time.Time, _a1 error) *AuctionServiceInterface_NewDateEnd_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuctionServiceInterface_NewDateEnd_Call) RunAndReturn(run func(uint, time.Time) (*time.Time, error)) *AuctionServiceInterface_NewDateEnd_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: _a0, userID
 func (_m *AuctionServiceInterface) Update(_a0 *auction.UpdateAuctionDTO, userID uint) (*sale_offer.RetrieveDetailedSaleOfferDTO, error) {
 	ret := _m.Called(_a0, userID)
@@ -247,12 +306,12 @@ func (_c *AuctionServiceInterface_Update_Call) RunAndReturn(run func(*auction.Up
 	return _c
 }
 
-// UpdatePrice provides a mock function with given fields: offerID, newPrice
-func (_m *AuctionServiceInterface) UpdatePrice(offerID uint, newPrice uint) error {
+// ValidateNewPrice provides a mock function with given fields: offerID, newPrice
+func (_m *AuctionServiceInterface) ValidateNewPrice(offerID uint, newPrice uint) error {
 	ret := _m.Called(offerID, newPrice)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdatePrice")
+		panic("no return value specified for ValidateNewPrice")
 	}
 
 	var r0 error
@@ -265,31 +324,31 @@ func (_m *AuctionServiceInterface) UpdatePrice(offerID uint, newPrice uint) erro
 	return r0
 }
 
-// AuctionServiceInterface_UpdatePrice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePrice'
-type AuctionServiceInterface_UpdatePrice_Call struct {
+// AuctionServiceInterface_ValidateNewPrice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateNewPrice'
+type AuctionServiceInterface_ValidateNewPrice_Call struct {
 	*mock.Call
 }
 
-// UpdatePrice is a helper method to define mock.On call
+// ValidateNewPrice is a helper method to define mock.On call
 //   - offerID uint
 //   - newPrice uint
-func (_e *AuctionServiceInterface_Expecter) UpdatePrice(offerID interface{}, newPrice interface{}) *AuctionServiceInterface_UpdatePrice_Call {
-	return &AuctionServiceInterface_UpdatePrice_Call{Call: _e.mock.On("UpdatePrice", offerID, newPrice)}
+func (_e *AuctionServiceInterface_Expecter) ValidateNewPrice(offerID interface{}, newPrice interface{}) *AuctionServiceInterface_ValidateNewPrice_Call {
+	return &AuctionServiceInterface_ValidateNewPrice_Call{Call: _e.mock.On("ValidateNewPrice", offerID, newPrice)}
 }
 
-func (_c *AuctionServiceInterface_UpdatePrice_Call) Run(run func(offerID uint, newPrice uint)) *AuctionServiceInterface_UpdatePrice_Call {
+func (_c *AuctionServiceInterface_ValidateNewPrice_Call) Run(run func(offerID uint, newPrice uint)) *AuctionServiceInterface_ValidateNewPrice_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(uint), args[1].(uint))
 	})
 	return _c
 }
 
-func (_c *AuctionServiceInterface_UpdatePrice_Call) Return(_a0 error) *AuctionServiceInterface_UpdatePrice_Call {
+func (_c *AuctionServiceInterface_ValidateNewPrice_Call) Return(_a0 error) *AuctionServiceInterface_ValidateNewPrice_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *AuctionServiceInterface_UpdatePrice_Call) RunAndReturn(run func(uint, uint) error) *AuctionServiceInterface_UpdatePrice_Call {
+func (_c *AuctionServiceInterface_ValidateNewPrice_Call) RunAndReturn(run func(uint, uint) error) *AuctionServiceInterface_ValidateNewPrice_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -4,6 +4,7 @@ package mocks
 
 import (
 	mock "github.com/stretchr/testify/mock"
+	bid "github.com/susek555/BD2/car-dealer-api/internal/domains/bid"
 	models "github.com/susek555/BD2/car-dealer-api/internal/models"
 )
 
@@ -414,6 +415,52 @@ func (_c *BidRepositoryInterface_GetHighestBidByUserID_Call) RunAndReturn(run fu
 	return _c
 }
 
+// Place provides a mock function with given fields: placement
+func (_m *BidRepositoryInterface) Place(placement *bid.BidPlacement) error {
+	ret := _m.Called(placement)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Place")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*bid.BidPlacement) error); ok {
+		r0 = rf(placement)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BidRepositoryInterface_Place_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Place'
+type BidRepositoryInterface_Place_Call struct {
+	*mock.Call
+}
+
+// Place is a helper method to define mock.On call
+//   - placement *bid.BidPlacement
+func (_e *BidRepositoryInterface_Expecter) Place(placement interface{}) *BidRepositoryInterface_Place_Call {
+	return &BidRepositoryInterface_Place_Call{Call: _e.mock.On("Place", placement)}
+}
+
+func (_c *BidRepositoryInterface_Place_Call) Run(run func(placement *bid.BidPlacement)) *BidRepositoryInterface_Place_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*bid.BidPlacement))
+	})
+	return _c
+}
+
+func (_c *BidRepositoryInterface_Place_Call) Return(_a0 error) *BidRepositoryInterface_Place_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BidRepositoryInterface_Place_Call) RunAndReturn(run func(*bid.BidPlacement) error) *BidRepositoryInterface_Place_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewBidRepositoryInterface creates a new instance of BidRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewBidRepositoryInterface(t interface {
@@ -6,11 +6,8 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
-	models "github.com/susek555/BD2/car-dealer-api/internal/models"
-
-	redis "github.com/redis/go-redis/v9"
-
 	ws "github.com/susek555/BD2/car-dealer-api/internal/domains/ws"
+	models "github.com/susek555/BD2/car-dealer-api/internal/models"
 )
 
 // HubInterface is an autogenerated mock type for the HubInterface type
@@ -26,41 +23,97 @@ func (_m *HubInterface) EXPECT() *HubInterface_Expecter {
 	return &HubInterface_Expecter{mock: &_m.Mock}
 }
 
-// BroadcastLocal provides a mock function with given fields: offerID, data, excludeID
-func (_m *HubInterface) BroadcastLocal(offerID string, data []byte, excludeID string) {
-	_m.Called(offerID, data, excludeID)
+// Broadcast provides a mock function with given fields: offerID, envelope, excludeID
+func (_m *HubInterface) Broadcast(offerID string, envelope *ws.Envelope, excludeID string) {
+	_m.Called(offerID, envelope, excludeID)
 }
 
-// HubInterface_BroadcastLocal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BroadcastLocal'
-type HubInterface_BroadcastLocal_Call struct {
+// HubInterface_Broadcast_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Broadcast'
+type HubInterface_Broadcast_Call struct {
 	*mock.Call
 }
 
-// BroadcastLocal is a helper method to define mock.On call
+// Broadcast is a helper method to define mock.On call
 //   - offerID string
-//   - data []byte
+//   - envelope *ws.Envelope
 //   - excludeID string
-func (_e *HubInterface_Expecter) BroadcastLocal(offerID interface{}, data interface{}, excludeID interface{}) *HubInterface_BroadcastLocal_Call {
-	return &HubInterface_BroadcastLocal_Call{Call: _e.mock.On("BroadcastLocal", offerID, data, excludeID)}
+func (_e *HubInterface_Expecter) Broadcast(offerID interface{}, envelope interface{}, excludeID interface{}) *HubInterface_Broadcast_Call {
+	return &HubInterface_Broadcast_Call{Call: _e.mock.On("Broadcast", offerID, envelope, excludeID)}
 }
 
-func (_c *HubInterface_BroadcastLocal_Call) Run(run func(offerID string, data []byte, excludeID string)) *HubInterface_BroadcastLocal_Call {
+func (_c *HubInterface_Broadcast_Call) Run(run func(offerID string, envelope *ws.Envelope, excludeID string)) *HubInterface_Broadcast_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].([]byte), args[2].(string))
+		run(args[0].(string), args[1].(*ws.Envelope), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *HubInterface_BroadcastLocal_Call) Return() *HubInterface_BroadcastLocal_Call {
+func (_c *HubInterface_Broadcast_Call) Return() *HubInterface_Broadcast_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *HubInterface_BroadcastLocal_Call) RunAndReturn(run func(string, []byte, string)) *HubInterface_BroadcastLocal_Call {
+func (_c *HubInterface_Broadcast_Call) RunAndReturn(run func(string, *ws.Envelope, string)) *HubInterface_Broadcast_Call {
 	_c.Run(run)
 	return _c
 }
 
+// IsOnline provides a mock function with given fields: userID
+func (_m *HubInterface) IsOnline(userID string) (bool, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsOnline")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HubInterface_IsOnline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsOnline'
+type HubInterface_IsOnline_Call struct {
+	*mock.Call
+}
+
+// IsOnline is a helper method to define mock.On call
+//   - userID string
+func (_e *HubInterface_Expecter) IsOnline(userID interface{}) *HubInterface_IsOnline_Call {
+	return &HubInterface_IsOnline_Call{Call: _e.mock.On("IsOnline", userID)}
+}
+
+func (_c *HubInterface_IsOnline_Call) Run(run func(userID string)) *HubInterface_IsOnline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *HubInterface_IsOnline_Call) Return(_a0 bool, _a1 error) *HubInterface_IsOnline_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HubInterface_IsOnline_Call) RunAndReturn(run func(string) (bool, error)) *HubInterface_IsOnline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // LoadClientToRooms provides a mock function with given fields: userID
 func (_m *HubInterface) LoadClientToRooms(userID string) {
 	_m.Called(userID)
@@ -207,6 +260,54 @@ func (_c *HubInterface_SaveNotificationForClients_Call) RunAndReturn(run func(st
 	return _c
 }
 
+// SaveNotificationForClientsExcept provides a mock function with given fields: offerID, excludedUserIDs, n
+func (_m *HubInterface) SaveNotificationForClientsExcept(offerID string, excludedUserIDs []uint, n *models.Notification) error {
+	ret := _m.Called(offerID, excludedUserIDs, n)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveNotificationForClientsExcept")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []uint, *models.Notification) error); ok {
+		r0 = rf(offerID, excludedUserIDs, n)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HubInterface_SaveNotificationForClientsExcept_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveNotificationForClientsExcept'
+type HubInterface_SaveNotificationForClientsExcept_Call struct {
+	*mock.Call
+}
+
+// SaveNotificationForClientsExcept is a helper method to define mock.On call
+//   - offerID string
+//   - excludedUserIDs []uint
+//   - n *models.Notification
+func (_e *HubInterface_Expecter) SaveNotificationForClientsExcept(offerID interface{}, excludedUserIDs interface{}, n interface{}) *HubInterface_SaveNotificationForClientsExcept_Call {
+	return &HubInterface_SaveNotificationForClientsExcept_Call{Call: _e.mock.On("SaveNotificationForClientsExcept", offerID, excludedUserIDs, n)}
+}
+
+func (_c *HubInterface_SaveNotificationForClientsExcept_Call) Run(run func(offerID string, excludedUserIDs []uint, n *models.Notification)) *HubInterface_SaveNotificationForClientsExcept_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].([]uint), args[2].(*models.Notification))
+	})
+	return _c
+}
+
+func (_c *HubInterface_SaveNotificationForClientsExcept_Call) Return(_a0 error) *HubInterface_SaveNotificationForClientsExcept_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HubInterface_SaveNotificationForClientsExcept_Call) RunAndReturn(run func(string, []uint, *models.Notification) error) *HubInterface_SaveNotificationForClientsExcept_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SendFourLatestNotificationsToClient provides a mock function with given fields: client
 func (_m *HubInterface) SendFourLatestNotificationsToClient(client *ws.Client) {
 	_m.Called(client)
@@ -274,9 +375,42 @@ func (_c *HubInterface_SendFourLatestNotificationsToClients_Call) RunAndReturn(r
 	return _c
 }
 
-// StartRedisFanIn provides a mock function with given fields: ctx, rdb
-func (_m *HubInterface) StartRedisFanIn(ctx context.Context, rdb *redis.Client) {
-	_m.Called(ctx, rdb)
+// SendFourLatestNotificationsToUser provides a mock function with given fields: userID
+func (_m *HubInterface) SendFourLatestNotificationsToUser(userID string) {
+	_m.Called(userID)
+}
+
+// HubInterface_SendFourLatestNotificationsToUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendFourLatestNotificationsToUser'
+type HubInterface_SendFourLatestNotificationsToUser_Call struct {
+	*mock.Call
+}
+
+// SendFourLatestNotificationsToUser is a helper method to define mock.On call
+//   - userID string
+func (_e *HubInterface_Expecter) SendFourLatestNotificationsToUser(userID interface{}) *HubInterface_SendFourLatestNotificationsToUser_Call {
+	return &HubInterface_SendFourLatestNotificationsToUser_Call{Call: _e.mock.On("SendFourLatestNotificationsToUser", userID)}
+}
+
+func (_c *HubInterface_SendFourLatestNotificationsToUser_Call) Run(run func(userID string)) *HubInterface_SendFourLatestNotificationsToUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *HubInterface_SendFourLatestNotificationsToUser_Call) Return() *HubInterface_SendFourLatestNotificationsToUser_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *HubInterface_SendFourLatestNotificationsToUser_Call) RunAndReturn(run func(string)) *HubInterface_SendFourLatestNotificationsToUser_Call {
+	_c.Run(run)
+	return _c
+}
+
+// StartRedisFanIn provides a mock function with given fields: ctx
+func (_m *HubInterface) StartRedisFanIn(ctx context.Context) {
+	_m.Called(ctx)
 }
 
 // HubInterface_StartRedisFanIn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartRedisFanIn'
@@ -286,14 +420,13 @@ type HubInterface_StartRedisFanIn_Call struct {
 
 // StartRedisFanIn is a helper method to define mock.On call
 //   - ctx context.Context
-//   - rdb *redis.Client
-func (_e *HubInterface_Expecter) StartRedisFanIn(ctx interface{}, rdb interface{}) *HubInterface_StartRedisFanIn_Call {
-	return &HubInterface_StartRedisFanIn_Call{Call: _e.mock.On("StartRedisFanIn", ctx, rdb)}
+func (_e *HubInterface_Expecter) StartRedisFanIn(ctx interface{}) *HubInterface_StartRedisFanIn_Call {
+	return &HubInterface_StartRedisFanIn_Call{Call: _e.mock.On("StartRedisFanIn", ctx)}
 }
 
-func (_c *HubInterface_StartRedisFanIn_Call) Run(run func(ctx context.Context, rdb *redis.Client)) *HubInterface_StartRedisFanIn_Call {
+func (_c *HubInterface_StartRedisFanIn_Call) Run(run func(ctx context.Context)) *HubInterface_StartRedisFanIn_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*redis.Client))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
@@ -303,7 +436,7 @@ func (_c *HubInterface_StartRedisFanIn_Call) Return() *HubInterface_StartRedisFa
 	return _c
 }
 
-func (_c *HubInterface_StartRedisFanIn_Call) RunAndReturn(run func(context.Context, *redis.Client)) *HubInterface_StartRedisFanIn_Call {
+func (_c *HubInterface_StartRedisFanIn_Call) RunAndReturn(run func(context.Context)) *HubInterface_StartRedisFanIn_Call {
 	_c.Run(run)
 	return _c
 }
@@ -117,6 +117,54 @@ func (_c *NotificationServiceInterface_CreateBuyNowNotification_Call) RunAndRetu
 	return _c
 }
 
+// CreateDuplicateImagesNotification provides a mock function with given fields: _a0, offerName, count
+func (_m *NotificationServiceInterface) CreateDuplicateImagesNotification(_a0 *models.Notification, offerName string, count int) error {
+	ret := _m.Called(_a0, offerName, count)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDuplicateImagesNotification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Notification, string, int) error); ok {
+		r0 = rf(_a0, offerName, count)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotificationServiceInterface_CreateDuplicateImagesNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDuplicateImagesNotification'
+type NotificationServiceInterface_CreateDuplicateImagesNotification_Call struct {
+	*mock.Call
+}
+
+// CreateDuplicateImagesNotification is a helper method to define mock.On call
+//   - _a0 *models.Notification
+//   - offerName string
+//   - count int
+func (_e *NotificationServiceInterface_Expecter) CreateDuplicateImagesNotification(_a0 interface{}, offerName interface{}, count interface{}) *NotificationServiceInterface_CreateDuplicateImagesNotification_Call {
+	return &NotificationServiceInterface_CreateDuplicateImagesNotification_Call{Call: _e.mock.On("CreateDuplicateImagesNotification", _a0, offerName, count)}
+}
+
+func (_c *NotificationServiceInterface_CreateDuplicateImagesNotification_Call) Run(run func(_a0 *models.Notification, offerName string, count int)) *NotificationServiceInterface_CreateDuplicateImagesNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Notification), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *NotificationServiceInterface_CreateDuplicateImagesNotification_Call) Return(_a0 error) *NotificationServiceInterface_CreateDuplicateImagesNotification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotificationServiceInterface_CreateDuplicateImagesNotification_Call) RunAndReturn(run func(*models.Notification, string, int) error) *NotificationServiceInterface_CreateDuplicateImagesNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateEndAuctionNotification provides a mock function with given fields: _a0, winner, winningBid, offer
 func (_m *NotificationServiceInterface) CreateEndAuctionNotification(_a0 *models.Notification, winner string, winningBid uint, offer notification.SaleOfferInterface) error {
 	ret := _m.Called(_a0, winner, winningBid, offer)
@@ -166,6 +214,101 @@ func (_c *NotificationServiceInterface_CreateEndAuctionNotification_Call) RunAnd
 	return _c
 }
 
+// CreateListingExpiredNotification provides a mock function with given fields: _a0, offer
+func (_m *NotificationServiceInterface) CreateListingExpiredNotification(_a0 *models.Notification, offer notification.SaleOfferInterface) error {
+	ret := _m.Called(_a0, offer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateListingExpiredNotification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Notification, notification.SaleOfferInterface) error); ok {
+		r0 = rf(_a0, offer)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotificationServiceInterface_CreateListingExpiredNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateListingExpiredNotification'
+type NotificationServiceInterface_CreateListingExpiredNotification_Call struct {
+	*mock.Call
+}
+
+// CreateListingExpiredNotification is a helper method to define mock.On call
+//   - _a0 *models.Notification
+//   - offer notification.SaleOfferInterface
+func (_e *NotificationServiceInterface_Expecter) CreateListingExpiredNotification(_a0 interface{}, offer interface{}) *NotificationServiceInterface_CreateListingExpiredNotification_Call {
+	return &NotificationServiceInterface_CreateListingExpiredNotification_Call{Call: _e.mock.On("CreateListingExpiredNotification", _a0, offer)}
+}
+
+func (_c *NotificationServiceInterface_CreateListingExpiredNotification_Call) Run(run func(_a0 *models.Notification, offer notification.SaleOfferInterface)) *NotificationServiceInterface_CreateListingExpiredNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Notification), args[1].(notification.SaleOfferInterface))
+	})
+	return _c
+}
+
+func (_c *NotificationServiceInterface_CreateListingExpiredNotification_Call) Return(_a0 error) *NotificationServiceInterface_CreateListingExpiredNotification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotificationServiceInterface_CreateListingExpiredNotification_Call) RunAndReturn(run func(*models.Notification, notification.SaleOfferInterface) error) *NotificationServiceInterface_CreateListingExpiredNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateListingExpiryReminderNotification provides a mock function with given fields: _a0, daysLeft, offer
+func (_m *NotificationServiceInterface) CreateListingExpiryReminderNotification(_a0 *models.Notification, daysLeft uint, offer notification.SaleOfferInterface) error {
+	ret := _m.Called(_a0, daysLeft, offer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateListingExpiryReminderNotification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Notification, uint, notification.SaleOfferInterface) error); ok {
+		r0 = rf(_a0, daysLeft, offer)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotificationServiceInterface_CreateListingExpiryReminderNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateListingExpiryReminderNotification'
+type NotificationServiceInterface_CreateListingExpiryReminderNotification_Call struct {
+	*mock.Call
+}
+
+// CreateListingExpiryReminderNotification is a helper method to define mock.On call
+//   - _a0 *models.Notification
+//   - daysLeft uint
+//   - offer notification.SaleOfferInterface
+func (_e *NotificationServiceInterface_Expecter) CreateListingExpiryReminderNotification(_a0 interface{}, daysLeft interface{}, offer interface{}) *NotificationServiceInterface_CreateListingExpiryReminderNotification_Call {
+	return &NotificationServiceInterface_CreateListingExpiryReminderNotification_Call{Call: _e.mock.On("CreateListingExpiryReminderNotification", _a0, daysLeft, offer)}
+}
+
+func (_c *NotificationServiceInterface_CreateListingExpiryReminderNotification_Call) Run(run func(_a0 *models.Notification, daysLeft uint, offer notification.SaleOfferInterface)) *NotificationServiceInterface_CreateListingExpiryReminderNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Notification), args[1].(uint), args[2].(notification.SaleOfferInterface))
+	})
+	return _c
+}
+
+func (_c *NotificationServiceInterface_CreateListingExpiryReminderNotification_Call) Return(_a0 error) *NotificationServiceInterface_CreateListingExpiryReminderNotification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotificationServiceInterface_CreateListingExpiryReminderNotification_Call) RunAndReturn(run func(*models.Notification, uint, notification.SaleOfferInterface) error) *NotificationServiceInterface_CreateListingExpiryReminderNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateOutbidNotification provides a mock function with given fields: _a0, amount, offer
 func (_m *NotificationServiceInterface) CreateOutbidNotification(_a0 *models.Notification, amount uint, offer notification.SaleOfferInterface) error {
 	ret := _m.Called(_a0, amount, offer)
@@ -214,6 +357,295 @@ func (_c *NotificationServiceInterface_CreateOutbidNotification_Call) RunAndRetu
 	return _c
 }
 
+// CreatePriceDropNotification provides a mock function with given fields: _a0, oldPrice, offer
+func (_m *NotificationServiceInterface) CreatePriceDropNotification(_a0 *models.Notification, oldPrice uint, offer notification.SaleOfferInterface) error {
+	ret := _m.Called(_a0, oldPrice, offer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePriceDropNotification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Notification, uint, notification.SaleOfferInterface) error); ok {
+		r0 = rf(_a0, oldPrice, offer)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotificationServiceInterface_CreatePriceDropNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePriceDropNotification'
+type NotificationServiceInterface_CreatePriceDropNotification_Call struct {
+	*mock.Call
+}
+
+// CreatePriceDropNotification is a helper method to define mock.On call
+//   - _a0 *models.Notification
+//   - oldPrice uint
+//   - offer notification.SaleOfferInterface
+func (_e *NotificationServiceInterface_Expecter) CreatePriceDropNotification(_a0 interface{}, oldPrice interface{}, offer interface{}) *NotificationServiceInterface_CreatePriceDropNotification_Call {
+	return &NotificationServiceInterface_CreatePriceDropNotification_Call{Call: _e.mock.On("CreatePriceDropNotification", _a0, oldPrice, offer)}
+}
+
+func (_c *NotificationServiceInterface_CreatePriceDropNotification_Call) Run(run func(_a0 *models.Notification, oldPrice uint, offer notification.SaleOfferInterface)) *NotificationServiceInterface_CreatePriceDropNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Notification), args[1].(uint), args[2].(notification.SaleOfferInterface))
+	})
+	return _c
+}
+
+func (_c *NotificationServiceInterface_CreatePriceDropNotification_Call) Return(_a0 error) *NotificationServiceInterface_CreatePriceDropNotification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotificationServiceInterface_CreatePriceDropNotification_Call) RunAndReturn(run func(*models.Notification, uint, notification.SaleOfferInterface) error) *NotificationServiceInterface_CreatePriceDropNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateProxyBidExhaustedNotification provides a mock function with given fields: _a0, maxAmount, amount, offer
+func (_m *NotificationServiceInterface) CreateProxyBidExhaustedNotification(_a0 *models.Notification, maxAmount uint, amount uint, offer notification.SaleOfferInterface) error {
+	ret := _m.Called(_a0, maxAmount, amount, offer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateProxyBidExhaustedNotification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Notification, uint, uint, notification.SaleOfferInterface) error); ok {
+		r0 = rf(_a0, maxAmount, amount, offer)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotificationServiceInterface_CreateProxyBidExhaustedNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateProxyBidExhaustedNotification'
+type NotificationServiceInterface_CreateProxyBidExhaustedNotification_Call struct {
+	*mock.Call
+}
+
+// CreateProxyBidExhaustedNotification is a helper method to define mock.On call
+//   - _a0 *models.Notification
+//   - maxAmount uint
+//   - amount uint
+//   - offer notification.SaleOfferInterface
+func (_e *NotificationServiceInterface_Expecter) CreateProxyBidExhaustedNotification(_a0 interface{}, maxAmount interface{}, amount interface{}, offer interface{}) *NotificationServiceInterface_CreateProxyBidExhaustedNotification_Call {
+	return &NotificationServiceInterface_CreateProxyBidExhaustedNotification_Call{Call: _e.mock.On("CreateProxyBidExhaustedNotification", _a0, maxAmount, amount, offer)}
+}
+
+func (_c *NotificationServiceInterface_CreateProxyBidExhaustedNotification_Call) Run(run func(_a0 *models.Notification, maxAmount uint, amount uint, offer notification.SaleOfferInterface)) *NotificationServiceInterface_CreateProxyBidExhaustedNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Notification), args[1].(uint), args[2].(uint), args[3].(notification.SaleOfferInterface))
+	})
+	return _c
+}
+
+func (_c *NotificationServiceInterface_CreateProxyBidExhaustedNotification_Call) Return(_a0 error) *NotificationServiceInterface_CreateProxyBidExhaustedNotification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotificationServiceInterface_CreateProxyBidExhaustedNotification_Call) RunAndReturn(run func(*models.Notification, uint, uint, notification.SaleOfferInterface) error) *NotificationServiceInterface_CreateProxyBidExhaustedNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateReportResolvedNotification provides a mock function with given fields: _a0, target, actionTaken
+func (_m *NotificationServiceInterface) CreateReportResolvedNotification(_a0 *models.Notification, target string, actionTaken bool) error {
+	ret := _m.Called(_a0, target, actionTaken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReportResolvedNotification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Notification, string, bool) error); ok {
+		r0 = rf(_a0, target, actionTaken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotificationServiceInterface_CreateReportResolvedNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateReportResolvedNotification'
+type NotificationServiceInterface_CreateReportResolvedNotification_Call struct {
+	*mock.Call
+}
+
+// CreateReportResolvedNotification is a helper method to define mock.On call
+//   - _a0 *models.Notification
+//   - target string
+//   - actionTaken bool
+func (_e *NotificationServiceInterface_Expecter) CreateReportResolvedNotification(_a0 interface{}, target interface{}, actionTaken interface{}) *NotificationServiceInterface_CreateReportResolvedNotification_Call {
+	return &NotificationServiceInterface_CreateReportResolvedNotification_Call{Call: _e.mock.On("CreateReportResolvedNotification", _a0, target, actionTaken)}
+}
+
+func (_c *NotificationServiceInterface_CreateReportResolvedNotification_Call) Run(run func(_a0 *models.Notification, target string, actionTaken bool)) *NotificationServiceInterface_CreateReportResolvedNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Notification), args[1].(string), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *NotificationServiceInterface_CreateReportResolvedNotification_Call) Return(_a0 error) *NotificationServiceInterface_CreateReportResolvedNotification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotificationServiceInterface_CreateReportResolvedNotification_Call) RunAndReturn(run func(*models.Notification, string, bool) error) *NotificationServiceInterface_CreateReportResolvedNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateReserveNotMetNotification provides a mock function with given fields: _a0, highestBid, offer
+func (_m *NotificationServiceInterface) CreateReserveNotMetNotification(_a0 *models.Notification, highestBid uint, offer notification.SaleOfferInterface) error {
+	ret := _m.Called(_a0, highestBid, offer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReserveNotMetNotification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Notification, uint, notification.SaleOfferInterface) error); ok {
+		r0 = rf(_a0, highestBid, offer)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotificationServiceInterface_CreateReserveNotMetNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateReserveNotMetNotification'
+type NotificationServiceInterface_CreateReserveNotMetNotification_Call struct {
+	*mock.Call
+}
+
+// CreateReserveNotMetNotification is a helper method to define mock.On call
+//   - _a0 *models.Notification
+//   - highestBid uint
+//   - offer notification.SaleOfferInterface
+func (_e *NotificationServiceInterface_Expecter) CreateReserveNotMetNotification(_a0 interface{}, highestBid interface{}, offer interface{}) *NotificationServiceInterface_CreateReserveNotMetNotification_Call {
+	return &NotificationServiceInterface_CreateReserveNotMetNotification_Call{Call: _e.mock.On("CreateReserveNotMetNotification", _a0, highestBid, offer)}
+}
+
+func (_c *NotificationServiceInterface_CreateReserveNotMetNotification_Call) Run(run func(_a0 *models.Notification, highestBid uint, offer notification.SaleOfferInterface)) *NotificationServiceInterface_CreateReserveNotMetNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Notification), args[1].(uint), args[2].(notification.SaleOfferInterface))
+	})
+	return _c
+}
+
+func (_c *NotificationServiceInterface_CreateReserveNotMetNotification_Call) Return(_a0 error) *NotificationServiceInterface_CreateReserveNotMetNotification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotificationServiceInterface_CreateReserveNotMetNotification_Call) RunAndReturn(run func(*models.Notification, uint, notification.SaleOfferInterface) error) *NotificationServiceInterface_CreateReserveNotMetNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateReviewReplyNotification provides a mock function with given fields: _a0, replier, reply
+func (_m *NotificationServiceInterface) CreateReviewReplyNotification(_a0 *models.Notification, replier string, reply string) error {
+	ret := _m.Called(_a0, replier, reply)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReviewReplyNotification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Notification, string, string) error); ok {
+		r0 = rf(_a0, replier, reply)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotificationServiceInterface_CreateReviewReplyNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateReviewReplyNotification'
+type NotificationServiceInterface_CreateReviewReplyNotification_Call struct {
+	*mock.Call
+}
+
+// CreateReviewReplyNotification is a helper method to define mock.On call
+//   - _a0 *models.Notification
+//   - replier string
+//   - reply string
+func (_e *NotificationServiceInterface_Expecter) CreateReviewReplyNotification(_a0 interface{}, replier interface{}, reply interface{}) *NotificationServiceInterface_CreateReviewReplyNotification_Call {
+	return &NotificationServiceInterface_CreateReviewReplyNotification_Call{Call: _e.mock.On("CreateReviewReplyNotification", _a0, replier, reply)}
+}
+
+func (_c *NotificationServiceInterface_CreateReviewReplyNotification_Call) Run(run func(_a0 *models.Notification, replier string, reply string)) *NotificationServiceInterface_CreateReviewReplyNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Notification), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *NotificationServiceInterface_CreateReviewReplyNotification_Call) Return(_a0 error) *NotificationServiceInterface_CreateReviewReplyNotification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotificationServiceInterface_CreateReviewReplyNotification_Call) RunAndReturn(run func(*models.Notification, string, string) error) *NotificationServiceInterface_CreateReviewReplyNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateSavedSearchMatchNotification provides a mock function with given fields: _a0, searchName, offer
+func (_m *NotificationServiceInterface) CreateSavedSearchMatchNotification(_a0 *models.Notification, searchName string, offer notification.SaleOfferInterface) error {
+	ret := _m.Called(_a0, searchName, offer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSavedSearchMatchNotification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Notification, string, notification.SaleOfferInterface) error); ok {
+		r0 = rf(_a0, searchName, offer)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotificationServiceInterface_CreateSavedSearchMatchNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSavedSearchMatchNotification'
+type NotificationServiceInterface_CreateSavedSearchMatchNotification_Call struct {
+	*mock.Call
+}
+
+// CreateSavedSearchMatchNotification is a helper method to define mock.On call
+//   - _a0 *models.Notification
+//   - searchName string
+//   - offer notification.SaleOfferInterface
+func (_e *NotificationServiceInterface_Expecter) CreateSavedSearchMatchNotification(_a0 interface{}, searchName interface{}, offer interface{}) *NotificationServiceInterface_CreateSavedSearchMatchNotification_Call {
+	return &NotificationServiceInterface_CreateSavedSearchMatchNotification_Call{Call: _e.mock.On("CreateSavedSearchMatchNotification", _a0, searchName, offer)}
+}
+
+func (_c *NotificationServiceInterface_CreateSavedSearchMatchNotification_Call) Run(run func(_a0 *models.Notification, searchName string, offer notification.SaleOfferInterface)) *NotificationServiceInterface_CreateSavedSearchMatchNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.Notification), args[1].(string), args[2].(notification.SaleOfferInterface))
+	})
+	return _c
+}
+
+func (_c *NotificationServiceInterface_CreateSavedSearchMatchNotification_Call) Return(_a0 error) *NotificationServiceInterface_CreateSavedSearchMatchNotification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotificationServiceInterface_CreateSavedSearchMatchNotification_Call) RunAndReturn(run func(*models.Notification, string, notification.SaleOfferInterface) error) *NotificationServiceInterface_CreateSavedSearchMatchNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetFilteredNotifications provides a mock function with given fields: filter
 func (_m *NotificationServiceInterface) GetFilteredNotifications(filter *notification.NotificationFilter) (*notification.RetrieveNotificationsWithPagination, error) {
 	ret := _m.Called(filter)
@@ -3,11 +3,12 @@
 package mocks
 
 import (
+	time "time"
+
 	mock "github.com/stretchr/testify/mock"
+	review "github.com/susek555/BD2/car-dealer-api/internal/domains/review"
 	models "github.com/susek555/BD2/car-dealer-api/internal/models"
 	pagination "github.com/susek555/BD2/car-dealer-api/pkg/pagination"
-
-	review "github.com/susek555/BD2/car-dealer-api/internal/domains/review"
 )
 
 // ReviewRepositoryInterface is an autogenerated mock type for the ReviewRepositoryInterface type
@@ -115,6 +116,53 @@ func (_c *ReviewRepositoryInterface_Delete_Call) RunAndReturn(run func(uint) err
 	return _c
 }
 
+// DeleteVote provides a mock function with given fields: reviewID, userID
+func (_m *ReviewRepositoryInterface) DeleteVote(reviewID uint, userID uint) error {
+	ret := _m.Called(reviewID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteVote")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(reviewID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReviewRepositoryInterface_DeleteVote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteVote'
+type ReviewRepositoryInterface_DeleteVote_Call struct {
+	*mock.Call
+}
+
+// DeleteVote is a helper method to define mock.On call
+//   - reviewID uint
+//   - userID uint
+func (_e *ReviewRepositoryInterface_Expecter) DeleteVote(reviewID interface{}, userID interface{}) *ReviewRepositoryInterface_DeleteVote_Call {
+	return &ReviewRepositoryInterface_DeleteVote_Call{Call: _e.mock.On("DeleteVote", reviewID, userID)}
+}
+
+func (_c *ReviewRepositoryInterface_DeleteVote_Call) Run(run func(reviewID uint, userID uint)) *ReviewRepositoryInterface_DeleteVote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *ReviewRepositoryInterface_DeleteVote_Call) Return(_a0 error) *ReviewRepositoryInterface_DeleteVote_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ReviewRepositoryInterface_DeleteVote_Call) RunAndReturn(run func(uint, uint) error) *ReviewRepositoryInterface_DeleteVote_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAll provides a mock function with no fields
 func (_m *ReviewRepositoryInterface) GetAll() ([]models.Review, error) {
 	ret := _m.Called()
@@ -172,9 +220,9 @@ func (_c *ReviewRepositoryInterface_GetAll_Call) RunAndReturn(run func() ([]mode
 	return _c
 }
 
-// GetAverageRatingByRevieweeID provides a mock function with given fields: revieweeID
-func (_m *ReviewRepositoryInterface) GetAverageRatingByRevieweeID(revieweeID uint) (float64, error) {
-	ret := _m.Called(revieweeID)
+// GetAverageRatingByRevieweeID provides a mock function with given fields: revieweeID, verifiedOnly
+func (_m *ReviewRepositoryInterface) GetAverageRatingByRevieweeID(revieweeID uint, verifiedOnly bool) (float64, error) {
+	ret := _m.Called(revieweeID, verifiedOnly)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAverageRatingByRevieweeID")
@@ -182,17 +230,17 @@ func (_m *ReviewRepositoryInterface) GetAverageRatingByRevieweeID(revieweeID uin
 
 	var r0 float64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint) (float64, error)); ok {
-		return rf(revieweeID)
+	if rf, ok := ret.Get(0).(func(uint, bool) (float64, error)); ok {
+		return rf(revieweeID, verifiedOnly)
 	}
-	if rf, ok := ret.Get(0).(func(uint) float64); ok {
-		r0 = rf(revieweeID)
+	if rf, ok := ret.Get(0).(func(uint, bool) float64); ok {
+		r0 = rf(revieweeID, verifiedOnly)
 	} else {
 		r0 = ret.Get(0).(float64)
 	}
 
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(revieweeID)
+	if rf, ok := ret.Get(1).(func(uint, bool) error); ok {
+		r1 = rf(revieweeID, verifiedOnly)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -207,13 +255,14 @@ type ReviewRepositoryInterface_GetAverageRatingByRevieweeID_Call struct {
 
 // GetAverageRatingByRevieweeID is a helper method to define mock.On call
 //   - revieweeID uint
-func (_e *ReviewRepositoryInterface_Expecter) GetAverageRatingByRevieweeID(revieweeID interface{}) *ReviewRepositoryInterface_GetAverageRatingByRevieweeID_Call {
-	return &ReviewRepositoryInterface_GetAverageRatingByRevieweeID_Call{Call: _e.mock.On("GetAverageRatingByRevieweeID", revieweeID)}
+//   - verifiedOnly bool
+func (_e *ReviewRepositoryInterface_Expecter) GetAverageRatingByRevieweeID(revieweeID interface{}, verifiedOnly interface{}) *ReviewRepositoryInterface_GetAverageRatingByRevieweeID_Call {
+	return &ReviewRepositoryInterface_GetAverageRatingByRevieweeID_Call{Call: _e.mock.On("GetAverageRatingByRevieweeID", revieweeID, verifiedOnly)}
 }
 
-func (_c *ReviewRepositoryInterface_GetAverageRatingByRevieweeID_Call) Run(run func(revieweeID uint)) *ReviewRepositoryInterface_GetAverageRatingByRevieweeID_Call {
+func (_c *ReviewRepositoryInterface_GetAverageRatingByRevieweeID_Call) Run(run func(revieweeID uint, verifiedOnly bool)) *ReviewRepositoryInterface_GetAverageRatingByRevieweeID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint))
+		run(args[0].(uint), args[1].(bool))
 	})
 	return _c
 }
@@ -223,7 +272,7 @@ func (_c *ReviewRepositoryInterface_GetAverageRatingByRevieweeID_Call) Return(_a
 	return _c
 }
 
-func (_c *ReviewRepositoryInterface_GetAverageRatingByRevieweeID_Call) RunAndReturn(run func(uint) (float64, error)) *ReviewRepositoryInterface_GetAverageRatingByRevieweeID_Call {
+func (_c *ReviewRepositoryInterface_GetAverageRatingByRevieweeID_Call) RunAndReturn(run func(uint, bool) (float64, error)) *ReviewRepositoryInterface_GetAverageRatingByRevieweeID_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -528,9 +577,9 @@ func (_c *ReviewRepositoryInterface_GetFiltered_Call) RunAndReturn(run func(*rev
 	return _c
 }
 
-// GetFrequencyOfRatingByRevieweeID provides a mock function with given fields: revieweeID
-func (_m *ReviewRepositoryInterface) GetFrequencyOfRatingByRevieweeID(revieweeID uint) (map[int]int, error) {
-	ret := _m.Called(revieweeID)
+// GetFrequencyOfRatingByRevieweeID provides a mock function with given fields: revieweeID, verifiedOnly
+func (_m *ReviewRepositoryInterface) GetFrequencyOfRatingByRevieweeID(revieweeID uint, verifiedOnly bool) (map[int]int, error) {
+	ret := _m.Called(revieweeID, verifiedOnly)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetFrequencyOfRatingByRevieweeID")
@@ -538,19 +587,19 @@ func (_m *ReviewRepositoryInterface) GetFrequencyOfRatingByRevieweeID(revieweeID
 
 	var r0 map[int]int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint) (map[int]int, error)); ok {
-		return rf(revieweeID)
+	if rf, ok := ret.Get(0).(func(uint, bool) (map[int]int, error)); ok {
+		return rf(revieweeID, verifiedOnly)
 	}
-	if rf, ok := ret.Get(0).(func(uint) map[int]int); ok {
-		r0 = rf(revieweeID)
+	if rf, ok := ret.Get(0).(func(uint, bool) map[int]int); ok {
+		r0 = rf(revieweeID, verifiedOnly)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(map[int]int)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(revieweeID)
+	if rf, ok := ret.Get(1).(func(uint, bool) error); ok {
+		r1 = rf(revieweeID, verifiedOnly)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -565,13 +614,14 @@ type ReviewRepositoryInterface_GetFrequencyOfRatingByRevieweeID_Call struct {
 
 // GetFrequencyOfRatingByRevieweeID is a helper method to define mock.On call
 //   - revieweeID uint
-func (_e *ReviewRepositoryInterface_Expecter) GetFrequencyOfRatingByRevieweeID(revieweeID interface{}) *ReviewRepositoryInterface_GetFrequencyOfRatingByRevieweeID_Call {
-	return &ReviewRepositoryInterface_GetFrequencyOfRatingByRevieweeID_Call{Call: _e.mock.On("GetFrequencyOfRatingByRevieweeID", revieweeID)}
+//   - verifiedOnly bool
+func (_e *ReviewRepositoryInterface_Expecter) GetFrequencyOfRatingByRevieweeID(revieweeID interface{}, verifiedOnly interface{}) *ReviewRepositoryInterface_GetFrequencyOfRatingByRevieweeID_Call {
+	return &ReviewRepositoryInterface_GetFrequencyOfRatingByRevieweeID_Call{Call: _e.mock.On("GetFrequencyOfRatingByRevieweeID", revieweeID, verifiedOnly)}
 }
 
-func (_c *ReviewRepositoryInterface_GetFrequencyOfRatingByRevieweeID_Call) Run(run func(revieweeID uint)) *ReviewRepositoryInterface_GetFrequencyOfRatingByRevieweeID_Call {
+func (_c *ReviewRepositoryInterface_GetFrequencyOfRatingByRevieweeID_Call) Run(run func(revieweeID uint, verifiedOnly bool)) *ReviewRepositoryInterface_GetFrequencyOfRatingByRevieweeID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint))
+		run(args[0].(uint), args[1].(bool))
 	})
 	return _c
 }
@@ -581,7 +631,102 @@ func (_c *ReviewRepositoryInterface_GetFrequencyOfRatingByRevieweeID_Call) Retur
 	return _c
 }
 
-func (_c *ReviewRepositoryInterface_GetFrequencyOfRatingByRevieweeID_Call) RunAndReturn(run func(uint) (map[int]int, error)) *ReviewRepositoryInterface_GetFrequencyOfRatingByRevieweeID_Call {
+func (_c *ReviewRepositoryInterface_GetFrequencyOfRatingByRevieweeID_Call) RunAndReturn(run func(uint, bool) (map[int]int, error)) *ReviewRepositoryInterface_GetFrequencyOfRatingByRevieweeID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveReply provides a mock function with given fields: reviewID, reply, repliedAt, message
+func (_m *ReviewRepositoryInterface) SaveReply(reviewID uint, reply string, repliedAt time.Time, message *models.OutboxMessage) error {
+	ret := _m.Called(reviewID, reply, repliedAt, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveReply")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, string, time.Time, *models.OutboxMessage) error); ok {
+		r0 = rf(reviewID, reply, repliedAt, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReviewRepositoryInterface_SaveReply_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveReply'
+type ReviewRepositoryInterface_SaveReply_Call struct {
+	*mock.Call
+}
+
+// SaveReply is a helper method to define mock.On call
+//   - reviewID uint
+//   - reply string
+//   - repliedAt time.Time
+//   - message *models.OutboxMessage
+func (_e *ReviewRepositoryInterface_Expecter) SaveReply(reviewID interface{}, reply interface{}, repliedAt interface{}, message interface{}) *ReviewRepositoryInterface_SaveReply_Call {
+	return &ReviewRepositoryInterface_SaveReply_Call{Call: _e.mock.On("SaveReply", reviewID, reply, repliedAt, message)}
+}
+
+func (_c *ReviewRepositoryInterface_SaveReply_Call) Run(run func(reviewID uint, reply string, repliedAt time.Time, message *models.OutboxMessage)) *ReviewRepositoryInterface_SaveReply_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(string), args[2].(time.Time), args[3].(*models.OutboxMessage))
+	})
+	return _c
+}
+
+func (_c *ReviewRepositoryInterface_SaveReply_Call) Return(_a0 error) *ReviewRepositoryInterface_SaveReply_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ReviewRepositoryInterface_SaveReply_Call) RunAndReturn(run func(uint, string, time.Time, *models.OutboxMessage) error) *ReviewRepositoryInterface_SaveReply_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveVote provides a mock function with given fields: vote
+func (_m *ReviewRepositoryInterface) SaveVote(vote *models.ReviewVote) error {
+	ret := _m.Called(vote)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveVote")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.ReviewVote) error); ok {
+		r0 = rf(vote)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReviewRepositoryInterface_SaveVote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveVote'
+type ReviewRepositoryInterface_SaveVote_Call struct {
+	*mock.Call
+}
+
+// SaveVote is a helper method to define mock.On call
+//   - vote *models.ReviewVote
+func (_e *ReviewRepositoryInterface_Expecter) SaveVote(vote interface{}) *ReviewRepositoryInterface_SaveVote_Call {
+	return &ReviewRepositoryInterface_SaveVote_Call{Call: _e.mock.On("SaveVote", vote)}
+}
+
+func (_c *ReviewRepositoryInterface_SaveVote_Call) Run(run func(vote *models.ReviewVote)) *ReviewRepositoryInterface_SaveVote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.ReviewVote))
+	})
+	return _c
+}
+
+func (_c *ReviewRepositoryInterface_SaveVote_Call) Return(_a0 error) *ReviewRepositoryInterface_SaveVote_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ReviewRepositoryInterface_SaveVote_Call) RunAndReturn(run func(*models.ReviewVote) error) *ReviewRepositoryInterface_SaveVote_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -4,15 +4,11 @@ package mocks
 
 import (
 	mock "github.com/stretchr/testify/mock"
+	sale_offer "github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
 	enums "github.com/susek555/BD2/car-dealer-api/internal/enums"
-
 	models "github.com/susek555/BD2/car-dealer-api/internal/models"
-
-	pagination "github.com/susek555/BD2/car-dealer-api/pkg/pagination"
-
-	sale_offer "github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
-
 	views "github.com/susek555/BD2/car-dealer-api/internal/views"
+	pagination "github.com/susek555/BD2/car-dealer-api/pkg/pagination"
 )
 
 // SaleOfferRepositoryInterface is an autogenerated mock type for the SaleOfferRepositoryInterface type
@@ -235,6 +231,64 @@ func (_c *SaleOfferRepositoryInterface_GetByID_Call) RunAndReturn(run func(uint)
 	return _c
 }
 
+// GetEvents provides a mock function with given fields: id
+func (_m *SaleOfferRepositoryInterface) GetEvents(id uint) ([]models.OfferEvent, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEvents")
+	}
+
+	var r0 []models.OfferEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) ([]models.OfferEvent, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) []models.OfferEvent); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.OfferEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaleOfferRepositoryInterface_GetEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEvents'
+type SaleOfferRepositoryInterface_GetEvents_Call struct {
+	*mock.Call
+}
+
+// GetEvents is a helper method to define mock.On call
+//   - id uint
+func (_e *SaleOfferRepositoryInterface_Expecter) GetEvents(id interface{}) *SaleOfferRepositoryInterface_GetEvents_Call {
+	return &SaleOfferRepositoryInterface_GetEvents_Call{Call: _e.mock.On("GetEvents", id)}
+}
+
+func (_c *SaleOfferRepositoryInterface_GetEvents_Call) Run(run func(id uint)) *SaleOfferRepositoryInterface_GetEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *SaleOfferRepositoryInterface_GetEvents_Call) Return(_a0 []models.OfferEvent, _a1 error) *SaleOfferRepositoryInterface_GetEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SaleOfferRepositoryInterface_GetEvents_Call) RunAndReturn(run func(uint) ([]models.OfferEvent, error)) *SaleOfferRepositoryInterface_GetEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetFiltered provides a mock function with given fields: filter, pagRequest
 func (_m *SaleOfferRepositoryInterface) GetFiltered(filter sale_offer.OfferFilterInterface, pagRequest *pagination.PaginationRequest) ([]views.SaleOfferView, *pagination.PaginationResponse, error) {
 	ret := _m.Called(filter, pagRequest)
@@ -280,7 +334,7 @@ type SaleOfferRepositoryInterface_GetFiltered_Call struct {
 }
 
 // GetFiltered is a helper method to define mock.On call
-//   - filter sale_offer.OfferFilterIntreface
+//   - filter sale_offer.OfferFilterInterface
 //   - pagRequest *pagination.PaginationRequest
 func (_e *SaleOfferRepositoryInterface_Expecter) GetFiltered(filter interface{}, pagRequest interface{}) *SaleOfferRepositoryInterface_GetFiltered_Call {
 	return &SaleOfferRepositoryInterface_GetFiltered_Call{Call: _e.mock.On("GetFiltered", filter, pagRequest)}
@@ -303,6 +357,64 @@ func (_c *SaleOfferRepositoryInterface_GetFiltered_Call) RunAndReturn(run func(s
 	return _c
 }
 
+// GetPriceHistory provides a mock function with given fields: id
+func (_m *SaleOfferRepositoryInterface) GetPriceHistory(id uint) ([]models.OfferEvent, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPriceHistory")
+	}
+
+	var r0 []models.OfferEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) ([]models.OfferEvent, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) []models.OfferEvent); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.OfferEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaleOfferRepositoryInterface_GetPriceHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPriceHistory'
+type SaleOfferRepositoryInterface_GetPriceHistory_Call struct {
+	*mock.Call
+}
+
+// GetPriceHistory is a helper method to define mock.On call
+//   - id uint
+func (_e *SaleOfferRepositoryInterface_Expecter) GetPriceHistory(id interface{}) *SaleOfferRepositoryInterface_GetPriceHistory_Call {
+	return &SaleOfferRepositoryInterface_GetPriceHistory_Call{Call: _e.mock.On("GetPriceHistory", id)}
+}
+
+func (_c *SaleOfferRepositoryInterface_GetPriceHistory_Call) Run(run func(id uint)) *SaleOfferRepositoryInterface_GetPriceHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *SaleOfferRepositoryInterface_GetPriceHistory_Call) Return(_a0 []models.OfferEvent, _a1 error) *SaleOfferRepositoryInterface_GetPriceHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SaleOfferRepositoryInterface_GetPriceHistory_Call) RunAndReturn(run func(uint) ([]models.OfferEvent, error)) *SaleOfferRepositoryInterface_GetPriceHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetViewByID provides a mock function with given fields: id
 func (_m *SaleOfferRepositoryInterface) GetViewByID(id uint) (*views.SaleOfferView, error) {
 	ret := _m.Called(id)
@@ -361,6 +473,123 @@ func (_c *SaleOfferRepositoryInterface_GetViewByID_Call) RunAndReturn(run func(u
 	return _c
 }
 
+// HasBids provides a mock function with given fields: id
+func (_m *SaleOfferRepositoryInterface) HasBids(id uint) (bool, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasBids")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (bool, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) bool); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaleOfferRepositoryInterface_HasBids_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasBids'
+type SaleOfferRepositoryInterface_HasBids_Call struct {
+	*mock.Call
+}
+
+// HasBids is a helper method to define mock.On call
+//   - id uint
+func (_e *SaleOfferRepositoryInterface_Expecter) HasBids(id interface{}) *SaleOfferRepositoryInterface_HasBids_Call {
+	return &SaleOfferRepositoryInterface_HasBids_Call{Call: _e.mock.On("HasBids", id)}
+}
+
+func (_c *SaleOfferRepositoryInterface_HasBids_Call) Run(run func(id uint)) *SaleOfferRepositoryInterface_HasBids_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *SaleOfferRepositoryInterface_HasBids_Call) Return(_a0 bool, _a1 error) *SaleOfferRepositoryInterface_HasBids_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SaleOfferRepositoryInterface_HasBids_Call) RunAndReturn(run func(uint) (bool, error)) *SaleOfferRepositoryInterface_HasBids_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Purchase provides a mock function with given fields: id, buyerID, finalPrice, message
+func (_m *SaleOfferRepositoryInterface) Purchase(id uint, buyerID uint, finalPrice func(offer *models.SaleOffer) (uint, error), message *models.OutboxMessage) (*models.SaleOffer, error) {
+	ret := _m.Called(id, buyerID, finalPrice, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purchase")
+	}
+
+	var r0 *models.SaleOffer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, uint, func(offer *models.SaleOffer) (uint, error), *models.OutboxMessage) (*models.SaleOffer, error)); ok {
+		return rf(id, buyerID, finalPrice, message)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint, func(offer *models.SaleOffer) (uint, error), *models.OutboxMessage) *models.SaleOffer); ok {
+		r0 = rf(id, buyerID, finalPrice, message)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SaleOffer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint, func(offer *models.SaleOffer) (uint, error), *models.OutboxMessage) error); ok {
+		r1 = rf(id, buyerID, finalPrice, message)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaleOfferRepositoryInterface_Purchase_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Purchase'
+type SaleOfferRepositoryInterface_Purchase_Call struct {
+	*mock.Call
+}
+
+// Purchase is a helper method to define mock.On call
+//   - id uint
+//   - buyerID uint
+//   - finalPrice func(offer *models.SaleOffer) (uint, error)
+//   - message *models.OutboxMessage
+func (_e *SaleOfferRepositoryInterface_Expecter) Purchase(id interface{}, buyerID interface{}, finalPrice interface{}, message interface{}) *SaleOfferRepositoryInterface_Purchase_Call {
+	return &SaleOfferRepositoryInterface_Purchase_Call{Call: _e.mock.On("Purchase", id, buyerID, finalPrice, message)}
+}
+
+func (_c *SaleOfferRepositoryInterface_Purchase_Call) Run(run func(id uint, buyerID uint, finalPrice func(offer *models.SaleOffer) (uint, error), message *models.OutboxMessage)) *SaleOfferRepositoryInterface_Purchase_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint), args[2].(func(offer *models.SaleOffer) (uint, error)), args[3].(*models.OutboxMessage))
+	})
+	return _c
+}
+
+func (_c *SaleOfferRepositoryInterface_Purchase_Call) Return(_a0 *models.SaleOffer, _a1 error) *SaleOfferRepositoryInterface_Purchase_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SaleOfferRepositoryInterface_Purchase_Call) RunAndReturn(run func(uint, uint, func(offer *models.SaleOffer) (uint, error), *models.OutboxMessage) (*models.SaleOffer, error)) *SaleOfferRepositoryInterface_Purchase_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: offer
 func (_m *SaleOfferRepositoryInterface) Update(offer *models.SaleOffer) error {
 	ret := _m.Called(offer)
@@ -454,6 +683,53 @@ func (_c *SaleOfferRepositoryInterface_UpdateStatus_Call) RunAndReturn(run func(
 	return _c
 }
 
+// UpdateWithHistory provides a mock function with given fields: offer, events
+func (_m *SaleOfferRepositoryInterface) UpdateWithHistory(offer *models.SaleOffer, events []models.OfferEvent) error {
+	ret := _m.Called(offer, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWithHistory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.SaleOffer, []models.OfferEvent) error); ok {
+		r0 = rf(offer, events)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaleOfferRepositoryInterface_UpdateWithHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWithHistory'
+type SaleOfferRepositoryInterface_UpdateWithHistory_Call struct {
+	*mock.Call
+}
+
+// UpdateWithHistory is a helper method to define mock.On call
+//   - offer *models.SaleOffer
+//   - events []models.OfferEvent
+func (_e *SaleOfferRepositoryInterface_Expecter) UpdateWithHistory(offer interface{}, events interface{}) *SaleOfferRepositoryInterface_UpdateWithHistory_Call {
+	return &SaleOfferRepositoryInterface_UpdateWithHistory_Call{Call: _e.mock.On("UpdateWithHistory", offer, events)}
+}
+
+func (_c *SaleOfferRepositoryInterface_UpdateWithHistory_Call) Run(run func(offer *models.SaleOffer, events []models.OfferEvent)) *SaleOfferRepositoryInterface_UpdateWithHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*models.SaleOffer), args[1].([]models.OfferEvent))
+	})
+	return _c
+}
+
+func (_c *SaleOfferRepositoryInterface_UpdateWithHistory_Call) Return(_a0 error) *SaleOfferRepositoryInterface_UpdateWithHistory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SaleOfferRepositoryInterface_UpdateWithHistory_Call) RunAndReturn(run func(*models.SaleOffer, []models.OfferEvent) error) *SaleOfferRepositoryInterface_UpdateWithHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewSaleOfferRepositoryInterface creates a new instance of SaleOfferRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewSaleOfferRepositoryInterface(t interface {
@@ -6,6 +6,7 @@ import (
 	mock "github.com/stretchr/testify/mock"
 	sale_offer "github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
 	models "github.com/susek555/BD2/car-dealer-api/internal/models"
+	pagination "github.com/susek555/BD2/car-dealer-api/pkg/pagination"
 )
 
 // SaleOfferServiceInterface is an autogenerated mock type for the SaleOfferServiceInterface type
@@ -21,6 +22,123 @@ func (_m *SaleOfferServiceInterface) EXPECT() *SaleOfferServiceInterface_Expecte
 	return &SaleOfferServiceInterface_Expecter{mock: &_m.Mock}
 }
 
+// Buy provides a mock function with given fields: id, userID
+func (_m *SaleOfferServiceInterface) Buy(id uint, userID uint) (*sale_offer.RetrieveDetailedSaleOfferDTO, error) {
+	ret := _m.Called(id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Buy")
+	}
+
+	var r0 *sale_offer.RetrieveDetailedSaleOfferDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, uint) (*sale_offer.RetrieveDetailedSaleOfferDTO, error)); ok {
+		return rf(id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint) *sale_offer.RetrieveDetailedSaleOfferDTO); ok {
+		r0 = rf(id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sale_offer.RetrieveDetailedSaleOfferDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
+		r1 = rf(id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaleOfferServiceInterface_Buy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Buy'
+type SaleOfferServiceInterface_Buy_Call struct {
+	*mock.Call
+}
+
+// Buy is a helper method to define mock.On call
+//   - id uint
+//   - userID uint
+func (_e *SaleOfferServiceInterface_Expecter) Buy(id interface{}, userID interface{}) *SaleOfferServiceInterface_Buy_Call {
+	return &SaleOfferServiceInterface_Buy_Call{Call: _e.mock.On("Buy", id, userID)}
+}
+
+func (_c *SaleOfferServiceInterface_Buy_Call) Run(run func(id uint, userID uint)) *SaleOfferServiceInterface_Buy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *SaleOfferServiceInterface_Buy_Call) Return(_a0 *sale_offer.RetrieveDetailedSaleOfferDTO, _a1 error) *SaleOfferServiceInterface_Buy_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SaleOfferServiceInterface_Buy_Call) RunAndReturn(run func(uint, uint) (*sale_offer.RetrieveDetailedSaleOfferDTO, error)) *SaleOfferServiceInterface_Buy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: in
+func (_m *SaleOfferServiceInterface) Create(in *sale_offer.CreateSaleOfferDTO) (*sale_offer.RetrieveDetailedSaleOfferDTO, error) {
+	ret := _m.Called(in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *sale_offer.RetrieveDetailedSaleOfferDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*sale_offer.CreateSaleOfferDTO) (*sale_offer.RetrieveDetailedSaleOfferDTO, error)); ok {
+		return rf(in)
+	}
+	if rf, ok := ret.Get(0).(func(*sale_offer.CreateSaleOfferDTO) *sale_offer.RetrieveDetailedSaleOfferDTO); ok {
+		r0 = rf(in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sale_offer.RetrieveDetailedSaleOfferDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*sale_offer.CreateSaleOfferDTO) error); ok {
+		r1 = rf(in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaleOfferServiceInterface_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type SaleOfferServiceInterface_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - in *sale_offer.CreateSaleOfferDTO
+func (_e *SaleOfferServiceInterface_Expecter) Create(in interface{}) *SaleOfferServiceInterface_Create_Call {
+	return &SaleOfferServiceInterface_Create_Call{Call: _e.mock.On("Create", in)}
+}
+
+func (_c *SaleOfferServiceInterface_Create_Call) Run(run func(in *sale_offer.CreateSaleOfferDTO)) *SaleOfferServiceInterface_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*sale_offer.CreateSaleOfferDTO))
+	})
+	return _c
+}
+
+func (_c *SaleOfferServiceInterface_Create_Call) Return(_a0 *sale_offer.RetrieveDetailedSaleOfferDTO, _a1 error) *SaleOfferServiceInterface_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SaleOfferServiceInterface_Create_Call) RunAndReturn(run func(*sale_offer.CreateSaleOfferDTO) (*sale_offer.RetrieveDetailedSaleOfferDTO, error)) *SaleOfferServiceInterface_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Delete provides a mock function with given fields: id, userID
 func (_m *SaleOfferServiceInterface) Delete(id uint, userID uint) error {
 	ret := _m.Called(id, userID)
@@ -68,6 +186,65 @@ func (_c *SaleOfferServiceInterface_Delete_Call) RunAndReturn(run func(uint, uin
 	return _c
 }
 
+// GetByID provides a mock function with given fields: id, userID
+func (_m *SaleOfferServiceInterface) GetByID(id uint, userID *uint) (*sale_offer.RetrieveSaleOfferDTO, error) {
+	ret := _m.Called(id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *sale_offer.RetrieveSaleOfferDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, *uint) (*sale_offer.RetrieveSaleOfferDTO, error)); ok {
+		return rf(id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint, *uint) *sale_offer.RetrieveSaleOfferDTO); ok {
+		r0 = rf(id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sale_offer.RetrieveSaleOfferDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, *uint) error); ok {
+		r1 = rf(id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaleOfferServiceInterface_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type SaleOfferServiceInterface_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - id uint
+//   - userID *uint
+func (_e *SaleOfferServiceInterface_Expecter) GetByID(id interface{}, userID interface{}) *SaleOfferServiceInterface_GetByID_Call {
+	return &SaleOfferServiceInterface_GetByID_Call{Call: _e.mock.On("GetByID", id, userID)}
+}
+
+func (_c *SaleOfferServiceInterface_GetByID_Call) Run(run func(id uint, userID *uint)) *SaleOfferServiceInterface_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(*uint))
+	})
+	return _c
+}
+
+func (_c *SaleOfferServiceInterface_GetByID_Call) Return(_a0 *sale_offer.RetrieveSaleOfferDTO, _a1 error) *SaleOfferServiceInterface_GetByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SaleOfferServiceInterface_GetByID_Call) RunAndReturn(run func(uint, *uint) (*sale_offer.RetrieveSaleOfferDTO, error)) *SaleOfferServiceInterface_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetDetailedByID provides a mock function with given fields: id, userID
 func (_m *SaleOfferServiceInterface) GetDetailedByID(id uint, userID *uint) (*sale_offer.RetrieveDetailedSaleOfferDTO, error) {
 	ret := _m.Called(id, userID)
@@ -127,6 +304,301 @@ func (_c *SaleOfferServiceInterface_GetDetailedByID_Call) RunAndReturn(run func(
 	return _c
 }
 
+// GetFiltered provides a mock function with given fields: filter, pagRequest
+func (_m *SaleOfferServiceInterface) GetFiltered(filter *sale_offer.PublishedOffersOnlyFilter, pagRequest *pagination.PaginationRequest) (*sale_offer.RetrieveOffersWithPagination, error) {
+	ret := _m.Called(filter, pagRequest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFiltered")
+	}
+
+	var r0 *sale_offer.RetrieveOffersWithPagination
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*sale_offer.PublishedOffersOnlyFilter, *pagination.PaginationRequest) (*sale_offer.RetrieveOffersWithPagination, error)); ok {
+		return rf(filter, pagRequest)
+	}
+	if rf, ok := ret.Get(0).(func(*sale_offer.PublishedOffersOnlyFilter, *pagination.PaginationRequest) *sale_offer.RetrieveOffersWithPagination); ok {
+		r0 = rf(filter, pagRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sale_offer.RetrieveOffersWithPagination)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*sale_offer.PublishedOffersOnlyFilter, *pagination.PaginationRequest) error); ok {
+		r1 = rf(filter, pagRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaleOfferServiceInterface_GetFiltered_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFiltered'
+type SaleOfferServiceInterface_GetFiltered_Call struct {
+	*mock.Call
+}
+
+// GetFiltered is a helper method to define mock.On call
+//   - filter *sale_offer.PublishedOffersOnlyFilter
+//   - pagRequest *pagination.PaginationRequest
+func (_e *SaleOfferServiceInterface_Expecter) GetFiltered(filter interface{}, pagRequest interface{}) *SaleOfferServiceInterface_GetFiltered_Call {
+	return &SaleOfferServiceInterface_GetFiltered_Call{Call: _e.mock.On("GetFiltered", filter, pagRequest)}
+}
+
+func (_c *SaleOfferServiceInterface_GetFiltered_Call) Run(run func(filter *sale_offer.PublishedOffersOnlyFilter, pagRequest *pagination.PaginationRequest)) *SaleOfferServiceInterface_GetFiltered_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*sale_offer.PublishedOffersOnlyFilter), args[1].(*pagination.PaginationRequest))
+	})
+	return _c
+}
+
+func (_c *SaleOfferServiceInterface_GetFiltered_Call) Return(_a0 *sale_offer.RetrieveOffersWithPagination, _a1 error) *SaleOfferServiceInterface_GetFiltered_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SaleOfferServiceInterface_GetFiltered_Call) RunAndReturn(run func(*sale_offer.PublishedOffersOnlyFilter, *pagination.PaginationRequest) (*sale_offer.RetrieveOffersWithPagination, error)) *SaleOfferServiceInterface_GetFiltered_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetHistory provides a mock function with given fields: id, userID
+func (_m *SaleOfferServiceInterface) GetHistory(id uint, userID *uint) ([]sale_offer.OfferEventDTO, error) {
+	ret := _m.Called(id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHistory")
+	}
+
+	var r0 []sale_offer.OfferEventDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, *uint) ([]sale_offer.OfferEventDTO, error)); ok {
+		return rf(id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint, *uint) []sale_offer.OfferEventDTO); ok {
+		r0 = rf(id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sale_offer.OfferEventDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, *uint) error); ok {
+		r1 = rf(id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaleOfferServiceInterface_GetHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHistory'
+type SaleOfferServiceInterface_GetHistory_Call struct {
+	*mock.Call
+}
+
+// GetHistory is a helper method to define mock.On call
+//   - id uint
+//   - userID *uint
+func (_e *SaleOfferServiceInterface_Expecter) GetHistory(id interface{}, userID interface{}) *SaleOfferServiceInterface_GetHistory_Call {
+	return &SaleOfferServiceInterface_GetHistory_Call{Call: _e.mock.On("GetHistory", id, userID)}
+}
+
+func (_c *SaleOfferServiceInterface_GetHistory_Call) Run(run func(id uint, userID *uint)) *SaleOfferServiceInterface_GetHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(*uint))
+	})
+	return _c
+}
+
+func (_c *SaleOfferServiceInterface_GetHistory_Call) Return(_a0 []sale_offer.OfferEventDTO, _a1 error) *SaleOfferServiceInterface_GetHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SaleOfferServiceInterface_GetHistory_Call) RunAndReturn(run func(uint, *uint) ([]sale_offer.OfferEventDTO, error)) *SaleOfferServiceInterface_GetHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLikedOffers provides a mock function with given fields: filter, pagRequest
+func (_m *SaleOfferServiceInterface) GetLikedOffers(filter *sale_offer.LikedOffersOnlyFilter, pagRequest *pagination.PaginationRequest) (*sale_offer.RetrieveOffersWithPagination, error) {
+	ret := _m.Called(filter, pagRequest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLikedOffers")
+	}
+
+	var r0 *sale_offer.RetrieveOffersWithPagination
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*sale_offer.LikedOffersOnlyFilter, *pagination.PaginationRequest) (*sale_offer.RetrieveOffersWithPagination, error)); ok {
+		return rf(filter, pagRequest)
+	}
+	if rf, ok := ret.Get(0).(func(*sale_offer.LikedOffersOnlyFilter, *pagination.PaginationRequest) *sale_offer.RetrieveOffersWithPagination); ok {
+		r0 = rf(filter, pagRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sale_offer.RetrieveOffersWithPagination)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*sale_offer.LikedOffersOnlyFilter, *pagination.PaginationRequest) error); ok {
+		r1 = rf(filter, pagRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaleOfferServiceInterface_GetLikedOffers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLikedOffers'
+type SaleOfferServiceInterface_GetLikedOffers_Call struct {
+	*mock.Call
+}
+
+// GetLikedOffers is a helper method to define mock.On call
+//   - filter *sale_offer.LikedOffersOnlyFilter
+//   - pagRequest *pagination.PaginationRequest
+func (_e *SaleOfferServiceInterface_Expecter) GetLikedOffers(filter interface{}, pagRequest interface{}) *SaleOfferServiceInterface_GetLikedOffers_Call {
+	return &SaleOfferServiceInterface_GetLikedOffers_Call{Call: _e.mock.On("GetLikedOffers", filter, pagRequest)}
+}
+
+func (_c *SaleOfferServiceInterface_GetLikedOffers_Call) Run(run func(filter *sale_offer.LikedOffersOnlyFilter, pagRequest *pagination.PaginationRequest)) *SaleOfferServiceInterface_GetLikedOffers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*sale_offer.LikedOffersOnlyFilter), args[1].(*pagination.PaginationRequest))
+	})
+	return _c
+}
+
+func (_c *SaleOfferServiceInterface_GetLikedOffers_Call) Return(_a0 *sale_offer.RetrieveOffersWithPagination, _a1 error) *SaleOfferServiceInterface_GetLikedOffers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SaleOfferServiceInterface_GetLikedOffers_Call) RunAndReturn(run func(*sale_offer.LikedOffersOnlyFilter, *pagination.PaginationRequest) (*sale_offer.RetrieveOffersWithPagination, error)) *SaleOfferServiceInterface_GetLikedOffers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPurchasedOffers provides a mock function with given fields: filter, pagRequest
+func (_m *SaleOfferServiceInterface) GetPurchasedOffers(filter *sale_offer.PurchasedOffersOnlyFilter, pagRequest *pagination.PaginationRequest) (*sale_offer.RetrieveOffersWithPagination, error) {
+	ret := _m.Called(filter, pagRequest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPurchasedOffers")
+	}
+
+	var r0 *sale_offer.RetrieveOffersWithPagination
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*sale_offer.PurchasedOffersOnlyFilter, *pagination.PaginationRequest) (*sale_offer.RetrieveOffersWithPagination, error)); ok {
+		return rf(filter, pagRequest)
+	}
+	if rf, ok := ret.Get(0).(func(*sale_offer.PurchasedOffersOnlyFilter, *pagination.PaginationRequest) *sale_offer.RetrieveOffersWithPagination); ok {
+		r0 = rf(filter, pagRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sale_offer.RetrieveOffersWithPagination)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*sale_offer.PurchasedOffersOnlyFilter, *pagination.PaginationRequest) error); ok {
+		r1 = rf(filter, pagRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaleOfferServiceInterface_GetPurchasedOffers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPurchasedOffers'
+type SaleOfferServiceInterface_GetPurchasedOffers_Call struct {
+	*mock.Call
+}
+
+// GetPurchasedOffers is a helper method to define mock.On call
+//   - filter *sale_offer.PurchasedOffersOnlyFilter
+//   - pagRequest *pagination.PaginationRequest
+func (_e *SaleOfferServiceInterface_Expecter) GetPurchasedOffers(filter interface{}, pagRequest interface{}) *SaleOfferServiceInterface_GetPurchasedOffers_Call {
+	return &SaleOfferServiceInterface_GetPurchasedOffers_Call{Call: _e.mock.On("GetPurchasedOffers", filter, pagRequest)}
+}
+
+func (_c *SaleOfferServiceInterface_GetPurchasedOffers_Call) Run(run func(filter *sale_offer.PurchasedOffersOnlyFilter, pagRequest *pagination.PaginationRequest)) *SaleOfferServiceInterface_GetPurchasedOffers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*sale_offer.PurchasedOffersOnlyFilter), args[1].(*pagination.PaginationRequest))
+	})
+	return _c
+}
+
+func (_c *SaleOfferServiceInterface_GetPurchasedOffers_Call) Return(_a0 *sale_offer.RetrieveOffersWithPagination, _a1 error) *SaleOfferServiceInterface_GetPurchasedOffers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SaleOfferServiceInterface_GetPurchasedOffers_Call) RunAndReturn(run func(*sale_offer.PurchasedOffersOnlyFilter, *pagination.PaginationRequest) (*sale_offer.RetrieveOffersWithPagination, error)) *SaleOfferServiceInterface_GetPurchasedOffers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUsersOffers provides a mock function with given fields: filter, pagRequest
+func (_m *SaleOfferServiceInterface) GetUsersOffers(filter *sale_offer.UsersOffersOnlyFilter, pagRequest *pagination.PaginationRequest) (*sale_offer.RetrieveOffersWithPagination, error) {
+	ret := _m.Called(filter, pagRequest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsersOffers")
+	}
+
+	var r0 *sale_offer.RetrieveOffersWithPagination
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*sale_offer.UsersOffersOnlyFilter, *pagination.PaginationRequest) (*sale_offer.RetrieveOffersWithPagination, error)); ok {
+		return rf(filter, pagRequest)
+	}
+	if rf, ok := ret.Get(0).(func(*sale_offer.UsersOffersOnlyFilter, *pagination.PaginationRequest) *sale_offer.RetrieveOffersWithPagination); ok {
+		r0 = rf(filter, pagRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sale_offer.RetrieveOffersWithPagination)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*sale_offer.UsersOffersOnlyFilter, *pagination.PaginationRequest) error); ok {
+		r1 = rf(filter, pagRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaleOfferServiceInterface_GetUsersOffers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsersOffers'
+type SaleOfferServiceInterface_GetUsersOffers_Call struct {
+	*mock.Call
+}
+
+// GetUsersOffers is a helper method to define mock.On call
+//   - filter *sale_offer.UsersOffersOnlyFilter
+//   - pagRequest *pagination.PaginationRequest
+func (_e *SaleOfferServiceInterface_Expecter) GetUsersOffers(filter interface{}, pagRequest interface{}) *SaleOfferServiceInterface_GetUsersOffers_Call {
+	return &SaleOfferServiceInterface_GetUsersOffers_Call{Call: _e.mock.On("GetUsersOffers", filter, pagRequest)}
+}
+
+func (_c *SaleOfferServiceInterface_GetUsersOffers_Call) Run(run func(filter *sale_offer.UsersOffersOnlyFilter, pagRequest *pagination.PaginationRequest)) *SaleOfferServiceInterface_GetUsersOffers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*sale_offer.UsersOffersOnlyFilter), args[1].(*pagination.PaginationRequest))
+	})
+	return _c
+}
+
+func (_c *SaleOfferServiceInterface_GetUsersOffers_Call) Return(_a0 *sale_offer.RetrieveOffersWithPagination, _a1 error) *SaleOfferServiceInterface_GetUsersOffers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SaleOfferServiceInterface_GetUsersOffers_Call) RunAndReturn(run func(*sale_offer.UsersOffersOnlyFilter, *pagination.PaginationRequest) (*sale_offer.RetrieveOffersWithPagination, error)) *SaleOfferServiceInterface_GetUsersOffers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PrepareForBuySaleOffer provides a mock function with given fields: id, userID
 func (_m *SaleOfferServiceInterface) PrepareForBuySaleOffer(id uint, userID uint) (*models.SaleOffer, error) {
 	ret := _m.Called(id, userID)
@@ -303,6 +775,183 @@ func (_c *SaleOfferServiceInterface_PrepareForUpdateSaleOffer_Call) RunAndReturn
 	return _c
 }
 
+// Publish provides a mock function with given fields: id, userID
+func (_m *SaleOfferServiceInterface) Publish(id uint, userID uint) (*sale_offer.RetrieveDetailedSaleOfferDTO, error) {
+	ret := _m.Called(id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Publish")
+	}
+
+	var r0 *sale_offer.RetrieveDetailedSaleOfferDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, uint) (*sale_offer.RetrieveDetailedSaleOfferDTO, error)); ok {
+		return rf(id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint) *sale_offer.RetrieveDetailedSaleOfferDTO); ok {
+		r0 = rf(id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sale_offer.RetrieveDetailedSaleOfferDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
+		r1 = rf(id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaleOfferServiceInterface_Publish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Publish'
+type SaleOfferServiceInterface_Publish_Call struct {
+	*mock.Call
+}
+
+// Publish is a helper method to define mock.On call
+//   - id uint
+//   - userID uint
+func (_e *SaleOfferServiceInterface_Expecter) Publish(id interface{}, userID interface{}) *SaleOfferServiceInterface_Publish_Call {
+	return &SaleOfferServiceInterface_Publish_Call{Call: _e.mock.On("Publish", id, userID)}
+}
+
+func (_c *SaleOfferServiceInterface_Publish_Call) Run(run func(id uint, userID uint)) *SaleOfferServiceInterface_Publish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *SaleOfferServiceInterface_Publish_Call) Return(_a0 *sale_offer.RetrieveDetailedSaleOfferDTO, _a1 error) *SaleOfferServiceInterface_Publish_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SaleOfferServiceInterface_Publish_Call) RunAndReturn(run func(uint, uint) (*sale_offer.RetrieveDetailedSaleOfferDTO, error)) *SaleOfferServiceInterface_Publish_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Renew provides a mock function with given fields: id, userID
+func (_m *SaleOfferServiceInterface) Renew(id uint, userID uint) (*sale_offer.RetrieveDetailedSaleOfferDTO, error) {
+	ret := _m.Called(id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Renew")
+	}
+
+	var r0 *sale_offer.RetrieveDetailedSaleOfferDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, uint) (*sale_offer.RetrieveDetailedSaleOfferDTO, error)); ok {
+		return rf(id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint) *sale_offer.RetrieveDetailedSaleOfferDTO); ok {
+		r0 = rf(id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sale_offer.RetrieveDetailedSaleOfferDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
+		r1 = rf(id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaleOfferServiceInterface_Renew_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Renew'
+type SaleOfferServiceInterface_Renew_Call struct {
+	*mock.Call
+}
+
+// Renew is a helper method to define mock.On call
+//   - id uint
+//   - userID uint
+func (_e *SaleOfferServiceInterface_Expecter) Renew(id interface{}, userID interface{}) *SaleOfferServiceInterface_Renew_Call {
+	return &SaleOfferServiceInterface_Renew_Call{Call: _e.mock.On("Renew", id, userID)}
+}
+
+func (_c *SaleOfferServiceInterface_Renew_Call) Run(run func(id uint, userID uint)) *SaleOfferServiceInterface_Renew_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *SaleOfferServiceInterface_Renew_Call) Return(_a0 *sale_offer.RetrieveDetailedSaleOfferDTO, _a1 error) *SaleOfferServiceInterface_Renew_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SaleOfferServiceInterface_Renew_Call) RunAndReturn(run func(uint, uint) (*sale_offer.RetrieveDetailedSaleOfferDTO, error)) *SaleOfferServiceInterface_Renew_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: in, userID
+func (_m *SaleOfferServiceInterface) Update(in *sale_offer.UpdateSaleOfferDTO, userID uint) (*sale_offer.RetrieveDetailedSaleOfferDTO, error) {
+	ret := _m.Called(in, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *sale_offer.RetrieveDetailedSaleOfferDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*sale_offer.UpdateSaleOfferDTO, uint) (*sale_offer.RetrieveDetailedSaleOfferDTO, error)); ok {
+		return rf(in, userID)
+	}
+	if rf, ok := ret.Get(0).(func(*sale_offer.UpdateSaleOfferDTO, uint) *sale_offer.RetrieveDetailedSaleOfferDTO); ok {
+		r0 = rf(in, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sale_offer.RetrieveDetailedSaleOfferDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*sale_offer.UpdateSaleOfferDTO, uint) error); ok {
+		r1 = rf(in, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaleOfferServiceInterface_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type SaleOfferServiceInterface_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - in *sale_offer.UpdateSaleOfferDTO
+//   - userID uint
+func (_e *SaleOfferServiceInterface_Expecter) Update(in interface{}, userID interface{}) *SaleOfferServiceInterface_Update_Call {
+	return &SaleOfferServiceInterface_Update_Call{Call: _e.mock.On("Update", in, userID)}
+}
+
+func (_c *SaleOfferServiceInterface_Update_Call) Run(run func(in *sale_offer.UpdateSaleOfferDTO, userID uint)) *SaleOfferServiceInterface_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*sale_offer.UpdateSaleOfferDTO), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *SaleOfferServiceInterface_Update_Call) Return(_a0 *sale_offer.RetrieveDetailedSaleOfferDTO, _a1 error) *SaleOfferServiceInterface_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SaleOfferServiceInterface_Update_Call) RunAndReturn(run func(*sale_offer.UpdateSaleOfferDTO, uint) (*sale_offer.RetrieveDetailedSaleOfferDTO, error)) *SaleOfferServiceInterface_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewSaleOfferServiceInterface creates a new instance of SaleOfferServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewSaleOfferServiceInterface(t interface {
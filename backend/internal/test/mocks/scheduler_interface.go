@@ -4,10 +4,9 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
-
-	time "time"
 )
 
 // SchedulerInterface is an autogenerated mock type for the SchedulerInterface type
@@ -92,6 +91,39 @@ func (_c *SchedulerInterface_ForceCloseAuction_Call) RunAndReturn(run func(strin
 	return _c
 }
 
+// Lead provides a mock function with given fields: ctx
+func (_m *SchedulerInterface) Lead(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// SchedulerInterface_Lead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lead'
+type SchedulerInterface_Lead_Call struct {
+	*mock.Call
+}
+
+// Lead is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *SchedulerInterface_Expecter) Lead(ctx interface{}) *SchedulerInterface_Lead_Call {
+	return &SchedulerInterface_Lead_Call{Call: _e.mock.On("Lead", ctx)}
+}
+
+func (_c *SchedulerInterface_Lead_Call) Run(run func(ctx context.Context)) *SchedulerInterface_Lead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *SchedulerInterface_Lead_Call) Return() *SchedulerInterface_Lead_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *SchedulerInterface_Lead_Call) RunAndReturn(run func(context.Context)) *SchedulerInterface_Lead_Call {
+	_c.Run(run)
+	return _c
+}
+
 // LoadAuctions provides a mock function with no fields
 func (_m *SchedulerInterface) LoadAuctions() error {
 	ret := _m.Called()
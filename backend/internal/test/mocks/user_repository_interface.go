@@ -488,6 +488,53 @@ func (_c *UserRepositoryInterface_UpdatePassword_Call) RunAndReturn(run func(uin
 	return _c
 }
 
+// UpdatePriceDropAlerts provides a mock function with given fields: userID, enabled
+func (_m *UserRepositoryInterface) UpdatePriceDropAlerts(userID uint, enabled bool) error {
+	ret := _m.Called(userID, enabled)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePriceDropAlerts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, bool) error); ok {
+		r0 = rf(userID, enabled)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepositoryInterface_UpdatePriceDropAlerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePriceDropAlerts'
+type UserRepositoryInterface_UpdatePriceDropAlerts_Call struct {
+	*mock.Call
+}
+
+// UpdatePriceDropAlerts is a helper method to define mock.On call
+//   - userID uint
+//   - enabled bool
+func (_e *UserRepositoryInterface_Expecter) UpdatePriceDropAlerts(userID interface{}, enabled interface{}) *UserRepositoryInterface_UpdatePriceDropAlerts_Call {
+	return &UserRepositoryInterface_UpdatePriceDropAlerts_Call{Call: _e.mock.On("UpdatePriceDropAlerts", userID, enabled)}
+}
+
+func (_c *UserRepositoryInterface_UpdatePriceDropAlerts_Call) Run(run func(userID uint, enabled bool)) *UserRepositoryInterface_UpdatePriceDropAlerts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(bool))
+	})
+	return _c
+}
+
+func (_c *UserRepositoryInterface_UpdatePriceDropAlerts_Call) Return(_a0 error) *UserRepositoryInterface_UpdatePriceDropAlerts_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepositoryInterface_UpdatePriceDropAlerts_Call) RunAndReturn(run func(uint, bool) error) *UserRepositoryInterface_UpdatePriceDropAlerts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewUserRepositoryInterface creates a new instance of UserRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserRepositoryInterface(t interface {
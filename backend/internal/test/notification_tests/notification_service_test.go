@@ -2,6 +2,7 @@ package notification_tests
 
 import (
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -127,9 +128,10 @@ func (m *mockClientNotificationRepository) UpdateSeenStatusForAll(userID uint, s
 
 // Helper functions to create test data
 func createSampleNotification() *models.Notification {
+	offerID := uint(1)
 	return &models.Notification{
 		ID:          1,
-		OfferID:     1,
+		OfferID:     &offerID,
 		Title:       "Test Notification",
 		Description: "Test Description",
 		CreatedAt:   time.Now().UTC(),
@@ -323,6 +325,150 @@ func TestNotificationService_CreateBuyNowNotification_Success(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestNotificationService_CreateSavedSearchMatchNotification_Success(t *testing.T) {
+	notificationRepo := &mockNotificationRepository{}
+	clientNotificationRepo := &mockClientNotificationRepository{}
+	service := notification.NewNotificationService(notificationRepo, clientNotificationRepo)
+
+	testNotification := createSampleNotification()
+	testSaleOffer := createSampleSaleOffer_Without_BuyNowPrice()
+
+	notificationRepo.createFunc = func(notif *models.Notification) error {
+		assert.Equal(t, "New offer matching your search \"family car\"", notif.Title)
+		assert.Equal(t, "Test Manufacturer Test Model is now available for 25000", notif.Description)
+		assert.NotZero(t, notif.CreatedAt)
+		return nil
+	}
+
+	err := service.CreateSavedSearchMatchNotification(testNotification, "family car", testSaleOffer)
+
+	assert.NoError(t, err)
+}
+
+func TestNotificationService_CreatePriceDropNotification_Success(t *testing.T) {
+	notificationRepo := &mockNotificationRepository{}
+	clientNotificationRepo := &mockClientNotificationRepository{}
+	service := notification.NewNotificationService(notificationRepo, clientNotificationRepo)
+
+	testNotification := createSampleNotification()
+	testSaleOffer := createSampleSaleOffer_Without_BuyNowPrice()
+
+	notificationRepo.createFunc = func(notif *models.Notification) error {
+		assert.Equal(t, "Price dropped for Test Manufacturer Test Model", notif.Title)
+		assert.Equal(t, "The price dropped from 30000 to 25000", notif.Description)
+		assert.NotZero(t, notif.CreatedAt)
+		return nil
+	}
+
+	err := service.CreatePriceDropNotification(testNotification, 30000, testSaleOffer)
+
+	assert.NoError(t, err)
+}
+
+func TestNotificationService_CreateListingExpiryReminderNotification_Success(t *testing.T) {
+	notificationRepo := &mockNotificationRepository{}
+	clientNotificationRepo := &mockClientNotificationRepository{}
+	service := notification.NewNotificationService(notificationRepo, clientNotificationRepo)
+
+	testNotification := createSampleNotification()
+	testSaleOffer := createSampleSaleOffer_Without_BuyNowPrice()
+
+	notificationRepo.createFunc = func(notif *models.Notification) error {
+		assert.Equal(t, "Your listing of Test Manufacturer Test Model expires soon", notif.Title)
+		assert.Equal(t, "Your listing expires in 3 days", notif.Description)
+		assert.NotZero(t, notif.CreatedAt)
+		return nil
+	}
+
+	err := service.CreateListingExpiryReminderNotification(testNotification, 3, testSaleOffer)
+
+	assert.NoError(t, err)
+}
+
+func TestNotificationService_CreateListingExpiredNotification_Success(t *testing.T) {
+	notificationRepo := &mockNotificationRepository{}
+	clientNotificationRepo := &mockClientNotificationRepository{}
+	service := notification.NewNotificationService(notificationRepo, clientNotificationRepo)
+
+	testNotification := createSampleNotification()
+	testSaleOffer := createSampleSaleOffer_Without_BuyNowPrice()
+
+	notificationRepo.createFunc = func(notif *models.Notification) error {
+		assert.Equal(t, "Your listing of Test Manufacturer Test Model has expired", notif.Title)
+		assert.Equal(t, notification.ListingExpiredDescriptionTemplate, notif.Description)
+		return nil
+	}
+
+	err := service.CreateListingExpiredNotification(testNotification, testSaleOffer)
+
+	assert.NoError(t, err)
+}
+
+func TestNotificationService_CreateReportResolvedNotification_ActionTaken(t *testing.T) {
+	notificationRepo := &mockNotificationRepository{}
+	clientNotificationRepo := &mockClientNotificationRepository{}
+	service := notification.NewNotificationService(notificationRepo, clientNotificationRepo)
+
+	notificationRepo.createFunc = func(notif *models.Notification) error {
+		assert.Equal(t, "Your report about offer #7 has been reviewed", notif.Title)
+		assert.Equal(t, notification.ReportActionTakenDescription, notif.Description)
+		return nil
+	}
+
+	err := service.CreateReportResolvedNotification(&models.Notification{}, "offer #7", true)
+
+	assert.NoError(t, err)
+}
+
+func TestNotificationService_CreateReportResolvedNotification_Dismissed(t *testing.T) {
+	notificationRepo := &mockNotificationRepository{}
+	clientNotificationRepo := &mockClientNotificationRepository{}
+	service := notification.NewNotificationService(notificationRepo, clientNotificationRepo)
+
+	notificationRepo.createFunc = func(notif *models.Notification) error {
+		assert.Equal(t, notification.ReportDismissedDescription, notif.Description)
+		return nil
+	}
+
+	err := service.CreateReportResolvedNotification(&models.Notification{}, "review #3", false)
+
+	assert.NoError(t, err)
+}
+
+func TestNotificationService_CreateReviewReplyNotification_ShortensLongReply(t *testing.T) {
+	notificationRepo := &mockNotificationRepository{}
+	clientNotificationRepo := &mockClientNotificationRepository{}
+	service := notification.NewNotificationService(notificationRepo, clientNotificationRepo)
+
+	notificationRepo.createFunc = func(notif *models.Notification) error {
+		assert.Equal(t, "dealer replied to your review", notif.Title)
+		assert.LessOrEqual(t, len([]rune(notif.Description)), 200)
+		assert.True(t, strings.HasSuffix(notif.Description, "...\""))
+		return nil
+	}
+
+	err := service.CreateReviewReplyNotification(&models.Notification{}, "dealer", strings.Repeat("ą", 500))
+
+	assert.NoError(t, err)
+}
+
+func TestNotificationService_CreateDuplicateImagesNotification(t *testing.T) {
+	notificationRepo := &mockNotificationRepository{}
+	clientNotificationRepo := &mockClientNotificationRepository{}
+	service := notification.NewNotificationService(notificationRepo, clientNotificationRepo)
+
+	notificationRepo.createFunc = func(notif *models.Notification) error {
+		assert.Equal(t, "Photos of your Audi A3 look like photos from other offers", notif.Title)
+		assert.True(t, strings.HasPrefix(notif.Description, "2 of the uploaded photos"))
+		assert.LessOrEqual(t, len([]rune(notif.Description)), 200)
+		return nil
+	}
+
+	err := service.CreateDuplicateImagesNotification(&models.Notification{}, "Audi A3", 2)
+
+	assert.NoError(t, err)
+}
+
 func TestNotificationService_GetNotificationByID_Success(t *testing.T) {
 	notificationRepo := &mockNotificationRepository{}
 	clientNotificationRepo := &mockClientNotificationRepository{}
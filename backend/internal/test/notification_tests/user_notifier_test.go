@@ -0,0 +1,58 @@
+package notification_tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/notification"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
+)
+
+func TestUserNotifier_Notify(t *testing.T) {
+	service := mocks.NewNotificationServiceInterface(t)
+	hub := mocks.NewHubInterface(t)
+	notifier := notification.NewUserNotifier(service, hub)
+	notif := models.Notification{}
+
+	service.On("SaveNotificationToClient", mock.MatchedBy(func(n *models.Notification) bool {
+		return n.Title == "Title"
+	}), uint(4)).Return(nil)
+	hub.On("SendFourLatestNotificationsToUser", "4").Return()
+
+	err := notifier.Notify(&notif, 4, func(n *models.Notification) error {
+		n.Title = "Title"
+		return nil
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestUserNotifier_Notify_CreateFails(t *testing.T) {
+	service := mocks.NewNotificationServiceInterface(t)
+	hub := mocks.NewHubInterface(t)
+	notifier := notification.NewUserNotifier(service, hub)
+	createErr := errors.New("unknown offer")
+
+	err := notifier.Notify(&models.Notification{}, 4, func(n *models.Notification) error {
+		return createErr
+	})
+
+	assert.ErrorIs(t, err, createErr)
+}
+
+func TestUserNotifier_Notify_SaveFails(t *testing.T) {
+	service := mocks.NewNotificationServiceInterface(t)
+	hub := mocks.NewHubInterface(t)
+	notifier := notification.NewUserNotifier(service, hub)
+	dbErr := errors.New("db down")
+
+	service.On("SaveNotificationToClient", mock.Anything, uint(4)).Return(dbErr)
+
+	err := notifier.Notify(&models.Notification{}, 4, func(n *models.Notification) error { return nil })
+
+	assert.ErrorIs(t, err, dbErr)
+	hub.AssertNotCalled(t, "SendFourLatestNotificationsToUser", mock.Anything)
+}
@@ -0,0 +1,95 @@
+package outbox_tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/outbox"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
+)
+
+func TestDispatcher_DeliversPendingMessages(t *testing.T) {
+	repo := new(mocks.OutboxRepositoryInterface)
+	dispatcher := outbox.NewDispatcher(repo, time.Second)
+	var delivered []string
+	var ids []uint
+	dispatcher.Register("greeting", func(messageID uint, payload string) error {
+		ids = append(ids, messageID)
+		delivered = append(delivered, payload)
+		return nil
+	})
+
+	repo.On("GetPending", mock.Anything, uint(outbox.MaxAttempts), outbox.DispatchBatchSize).Return([]models.OutboxMessage{
+		{ID: 1, Kind: "greeting", Payload: `"hello"`},
+		{ID: 2, Kind: "greeting", Payload: `"world"`},
+	}, nil)
+	repo.On("MarkDelivered", uint(1)).Return(nil)
+	repo.On("MarkDelivered", uint(2)).Return(nil)
+
+	err := dispatcher.DispatchPending()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`"hello"`, `"world"`}, delivered)
+	assert.Equal(t, []uint{1, 2}, ids)
+	repo.AssertExpectations(t)
+}
+
+func TestDispatcher_FailedMessageIsRetriedLater(t *testing.T) {
+	repo := new(mocks.OutboxRepositoryInterface)
+	dispatcher := outbox.NewDispatcher(repo, time.Second)
+	dispatcher.Register("greeting", func(messageID uint, payload string) error {
+		return errors.New("hub unavailable")
+	})
+
+	repo.On("GetPending", mock.Anything, mock.Anything, mock.Anything).Return([]models.OutboxMessage{
+		{ID: 1, Kind: "greeting", Payload: `"hello"`, Attempts: 2},
+	}, nil)
+	before := time.Now()
+	repo.On("MarkFailed", uint(1), "hub unavailable", mock.MatchedBy(func(next time.Time) bool {
+		return !next.Before(before.Add(outbox.Backoff(2)))
+	})).Return(nil)
+
+	err := dispatcher.DispatchPending()
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "MarkDelivered", mock.Anything)
+}
+
+func TestDispatcher_UnknownKindFails(t *testing.T) {
+	repo := new(mocks.OutboxRepositoryInterface)
+	dispatcher := outbox.NewDispatcher(repo, time.Second)
+
+	repo.On("GetPending", mock.Anything, mock.Anything, mock.Anything).Return([]models.OutboxMessage{
+		{ID: 1, Kind: "unknown", Payload: `{}`},
+	}, nil)
+	repo.On("MarkFailed", uint(1), mock.Anything, mock.Anything).Return(nil)
+
+	err := dispatcher.DispatchPending()
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestDispatcher_GetPendingError(t *testing.T) {
+	repo := new(mocks.OutboxRepositoryInterface)
+	dispatcher := outbox.NewDispatcher(repo, time.Second)
+	dbErr := errors.New("db down")
+
+	repo.On("GetPending", mock.Anything, mock.Anything, mock.Anything).Return(nil, dbErr)
+
+	err := dispatcher.DispatchPending()
+
+	assert.ErrorIs(t, err, dbErr)
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 5*time.Second, outbox.Backoff(0))
+	assert.Equal(t, 10*time.Second, outbox.Backoff(1))
+	assert.Equal(t, 40*time.Second, outbox.Backoff(3))
+	assert.Equal(t, 30*time.Minute, outbox.Backoff(20))
+}
@@ -0,0 +1,145 @@
+package report_tests
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/admin"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/outbox"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/report"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	u "github.com/susek555/BD2/car-dealer-api/internal/test/test_utils"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// resolverID is the user resolving the reports, it has to exist for the foreign keys
+const resolverID = uint(1)
+
+func setupDB(t *testing.T) (*gorm.DB, *models.SaleOffer) {
+	dsn := "host=localhost user=bd2_user password=bd2_password dbname=bd2_test port=5432 sslmode=disable TimeZone=UTC"
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	db.Exec("TRUNCATE TABLE reports, outbox_messages, admin_audit_logs, sale_offers, cars, models, manufacturers, users RESTART IDENTITY CASCADE")
+	users := make([]models.User, 0, 4)
+	for i := uint(1); i <= 4; i++ {
+		users = append(users, models.User{ID: i, Username: fmt.Sprintf("user%d", i), Email: fmt.Sprintf("user%d@example.com", i), Selector: "P"})
+	}
+	require.NoError(t, u.InsertRecordsIntoDB(db, users))
+	require.NoError(t, u.InsertRecordsIntoDB(db, []models.Manufacturer{{ID: 1, Name: "Audi"}}))
+	require.NoError(t, u.InsertRecordsIntoDB(db, []models.Model{{ID: 1, Name: "A3", ManufacturerID: 1}}))
+	offer := &models.SaleOffer{
+		UserID:      4,
+		Description: "offer",
+		Price:       1000,
+		Margin:      enums.LOW_MARGIN,
+		DateOfIssue: time.Now(),
+		Status:      enums.PUBLISHED,
+		Car: &models.Car{
+			Vin:                "vin",
+			ProductionYear:     2025,
+			Mileage:            1000,
+			NumberOfDoors:      4,
+			NumberOfSeats:      5,
+			EnginePower:        100,
+			EngineCapacity:     2000,
+			RegistrationNumber: "default",
+			RegistrationDate:   time.Now(),
+			Color:              enums.BLACK,
+			FuelType:           enums.PETROL,
+			Transmission:       enums.MANUAL,
+			NumberOfGears:      6,
+			Drive:              enums.FWD,
+			ModelID:            1,
+		},
+	}
+	require.NoError(t, sale_offer.NewSaleOfferRepository(db).Create(offer))
+	return db, offer
+}
+
+func reportOffer(t *testing.T, repo report.ReportRepositoryInterface, reporterID, offerID uint) *models.Report {
+	r := &models.Report{ReporterID: &reporterID, OfferID: &offerID, Reason: enums.SCAM}
+	require.NoError(t, repo.Create(r, 1))
+	return r
+}
+
+func resolve(t *testing.T, repo report.ReportRepositoryInterface, r *models.Report, outcome enums.ReportOutcome) {
+	now := time.Now()
+	r.Outcome = &outcome
+	r.ResolvedBy = ptr(resolverID)
+	r.ResolvedAt = &now
+	message, err := outbox.NewMessage(report.OutboxReportResolved, report.ReportResolvedPayload{ReportID: r.ID})
+	require.NoError(t, err)
+	require.NoError(t, repo.Resolve(r, 1, message, admin.NewAuditLog(resolverID, enums.RESOLVE_REPORT, r.ID, nil)))
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func isHidden(t *testing.T, db *gorm.DB, offerID uint) bool {
+	var offer models.SaleOffer
+	require.NoError(t, db.First(&offer, offerID).Error)
+	return offer.HiddenAt != nil
+}
+
+func TestReportRepository_DismissedReportShowsOfferAgain(t *testing.T) {
+	db, offer := setupDB(t)
+	defer u.CloseDBConnection(db)
+	repo := report.NewReportRepository(db)
+
+	r := reportOffer(t, repo, 2, offer.ID)
+	require.True(t, isHidden(t, db, offer.ID))
+	resolve(t, repo, r, enums.DISMISSED)
+
+	assert.False(t, isHidden(t, db, offer.ID))
+}
+
+func TestReportRepository_ActionTakenKeepsOfferHidden(t *testing.T) {
+	db, offer := setupDB(t)
+	defer u.CloseDBConnection(db)
+	repo := report.NewReportRepository(db)
+
+	resolve(t, repo, reportOffer(t, repo, 2, offer.ID), enums.ACTION_TAKEN)
+	assert.True(t, isHidden(t, db, offer.ID))
+
+	// neither a new report nor dismissing it shows the offer again
+	resolve(t, repo, reportOffer(t, repo, 3, offer.ID), enums.DISMISSED)
+	assert.True(t, isHidden(t, db, offer.ID))
+}
+
+func TestReportRepository_Create_AlreadyReported(t *testing.T) {
+	db, offer := setupDB(t)
+	defer u.CloseDBConnection(db)
+	repo := report.NewReportRepository(db)
+	reportOffer(t, repo, 2, offer.ID)
+
+	err := repo.Create(&models.Report{ReporterID: ptr(uint(2)), OfferID: &offer.ID, Reason: enums.SCAM}, 1)
+
+	assert.ErrorIs(t, err, report.ErrAlreadyReported)
+}
+
+func TestReportRepository_OneOpenSystemReportPerOffer(t *testing.T) {
+	db, offer := setupDB(t)
+	defer u.CloseDBConnection(db)
+	repo := report.NewReportRepository(db)
+	systemReport := func() *models.Report {
+		return &models.Report{OfferID: &offer.ID, Reason: enums.DUPLICATE_IMAGES, CreatedAt: time.Now()}
+	}
+
+	first := systemReport()
+	require.NoError(t, repo.Create(first, 3))
+	assert.ErrorIs(t, repo.Create(systemReport(), 3), report.ErrAlreadyReported)
+
+	// once resolved, the system can report the offer again
+	now := time.Now()
+	outcome := enums.DISMISSED
+	first.Outcome, first.ResolvedBy, first.ResolvedAt = &outcome, ptr(resolverID), &now
+	require.NoError(t, repo.Resolve(first, 3, nil, admin.NewAuditLog(resolverID, enums.RESOLVE_REPORT, first.ID, nil)))
+	assert.NoError(t, repo.Create(systemReport(), 3))
+}
@@ -0,0 +1,39 @@
+package report_tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/report"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
+)
+
+func TestReportResolvedNotifier_NotifiesReporter(t *testing.T) {
+	notificationService := mocks.NewNotificationServiceInterface(t)
+	hub := mocks.NewHubInterface(t)
+	notifier := report.NewReportResolvedNotifier(notificationService, hub)
+
+	notificationService.On("CreateReportResolvedNotification", mock.MatchedBy(func(n *models.Notification) bool {
+		return n.OfferID == nil && n.OutboxMessageID != nil && *n.OutboxMessageID == 11
+	}), "offer #7", true).Return(nil)
+	notificationService.On("SaveNotificationToClient", mock.AnythingOfType("*models.Notification"), reporterID).Return(nil)
+	hub.On("SendFourLatestNotificationsToUser", "2").Return()
+
+	err := notifier.DeliverReportResolved(11, `{"report_id":1,"reporter_id":2,"offer_id":7,"outcome":"Action taken"}`)
+	assert.NoError(t, err)
+}
+
+func TestReportResolvedNotifier_DismissedReviewReport(t *testing.T) {
+	notificationService := mocks.NewNotificationServiceInterface(t)
+	hub := mocks.NewHubInterface(t)
+	notifier := report.NewReportResolvedNotifier(notificationService, hub)
+
+	notificationService.On("CreateReportResolvedNotification", mock.Anything, "review #3", false).Return(nil)
+	notificationService.On("SaveNotificationToClient", mock.Anything, reporterID).Return(nil)
+	hub.On("SendFourLatestNotificationsToUser", "2").Return()
+
+	err := notifier.DeliverReportResolved(11, `{"report_id":1,"reporter_id":2,"review_id":3,"outcome":"Dismissed"}`)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,284 @@
+package report_tests
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/report"
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
+	"gorm.io/gorm"
+)
+
+const (
+	reporterID    = uint(2)
+	moderatorID   = uint(9)
+	hideThreshold = uint(3)
+)
+
+type serviceMocks struct {
+	repo       *mocks.ReportRepositoryInterface
+	saleOffers *mocks.SaleOfferRepositoryInterface
+	reviews    *mocks.ReviewRepositoryInterface
+}
+
+func newTestService(t *testing.T) (report.ReportServiceInterface, *serviceMocks) {
+	m := &serviceMocks{
+		repo:       mocks.NewReportRepositoryInterface(t),
+		saleOffers: mocks.NewSaleOfferRepositoryInterface(t),
+		reviews:    mocks.NewReviewRepositoryInterface(t),
+	}
+	return report.NewReportService(m.repo, m.saleOffers, m.reviews, hideThreshold), m
+}
+
+func uintPtr(v uint) *uint {
+	return &v
+}
+
+func TestReportService_Create_Offer(t *testing.T) {
+	service, m := newTestService(t)
+
+	m.saleOffers.On("GetByID", uint(7)).Return(&models.SaleOffer{ID: 7, UserID: 5}, nil)
+	m.repo.On("Create", mock.MatchedBy(func(r *models.Report) bool {
+		return *r.ReporterID == reporterID && *r.OfferID == 7 && r.ReviewID == nil && r.Reason == enums.SCAM
+	}), hideThreshold).Return(nil)
+
+	result, err := service.Create(reporterID, &report.CreateReportDTO{OfferID: uintPtr(7), Reason: string(enums.SCAM)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Scam", result.Reason)
+	assert.Nil(t, result.Outcome)
+}
+
+func TestReportService_Create_Review(t *testing.T) {
+	service, m := newTestService(t)
+
+	m.reviews.On("GetByID", uint(3)).Return(&models.Review{ID: 3, ReviewerID: 5, RevieweeID: reporterID}, nil)
+	m.repo.On("Create", mock.MatchedBy(func(r *models.Report) bool {
+		return r.OfferID == nil && *r.ReviewID == 3
+	}), hideThreshold).Return(nil)
+
+	_, err := service.Create(reporterID, &report.CreateReportDTO{ReviewID: uintPtr(3), Reason: string(enums.FAKE_REVIEW)})
+
+	assert.NoError(t, err)
+}
+
+func TestReportService_Create_InvalidTarget(t *testing.T) {
+	service, _ := newTestService(t)
+
+	_, errNone := service.Create(reporterID, &report.CreateReportDTO{Reason: string(enums.SPAM)})
+	_, errBoth := service.Create(reporterID, &report.CreateReportDTO{OfferID: uintPtr(7), ReviewID: uintPtr(3), Reason: string(enums.SPAM)})
+
+	assert.ErrorIs(t, errNone, report.ErrInvalidReportTarget)
+	assert.ErrorIs(t, errBoth, report.ErrInvalidReportTarget)
+}
+
+func TestReportService_Create_InvalidReason(t *testing.T) {
+	service, _ := newTestService(t)
+
+	_, err := service.Create(reporterID, &report.CreateReportDTO{OfferID: uintPtr(7), Reason: "Boring"})
+
+	assert.ErrorIs(t, err, report.ErrInvalidReportReason)
+}
+
+func TestReportService_Create_CommentTooLong(t *testing.T) {
+	service, _ := newTestService(t)
+	comment := strings.Repeat("a", report.MaxCommentLength+1)
+
+	_, err := service.Create(reporterID, &report.CreateReportDTO{OfferID: uintPtr(7), Reason: string(enums.OTHER_REASON), Comment: &comment})
+
+	assert.ErrorIs(t, err, report.ErrCommentTooLong)
+}
+
+func TestReportService_Create_OwnOffer(t *testing.T) {
+	service, m := newTestService(t)
+
+	m.saleOffers.On("GetByID", uint(7)).Return(&models.SaleOffer{ID: 7, UserID: reporterID}, nil)
+
+	_, err := service.Create(reporterID, &report.CreateReportDTO{OfferID: uintPtr(7), Reason: string(enums.SCAM)})
+
+	assert.ErrorIs(t, err, report.ErrCannotReportOwnContent)
+}
+
+func TestReportService_Create_OwnReview(t *testing.T) {
+	service, m := newTestService(t)
+
+	m.reviews.On("GetByID", uint(3)).Return(&models.Review{ID: 3, ReviewerID: reporterID}, nil)
+
+	_, err := service.Create(reporterID, &report.CreateReportDTO{ReviewID: uintPtr(3), Reason: string(enums.OFFENSIVE)})
+
+	assert.ErrorIs(t, err, report.ErrCannotReportOwnContent)
+}
+
+func TestReportService_Create_OfferNotFound(t *testing.T) {
+	service, m := newTestService(t)
+
+	m.saleOffers.On("GetByID", uint(7)).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := service.Create(reporterID, &report.CreateReportDTO{OfferID: uintPtr(7), Reason: string(enums.SCAM)})
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestReportService_Create_AlreadyReported(t *testing.T) {
+	service, m := newTestService(t)
+
+	m.saleOffers.On("GetByID", uint(7)).Return(&models.SaleOffer{ID: 7, UserID: 5}, nil)
+	m.repo.On("Create", mock.Anything, hideThreshold).Return(report.ErrAlreadyReported)
+
+	_, err := service.Create(reporterID, &report.CreateReportDTO{OfferID: uintPtr(7), Reason: string(enums.SCAM)})
+
+	assert.ErrorIs(t, err, report.ErrAlreadyReported)
+}
+
+func TestReportService_Create_SystemReasonNotAllowed(t *testing.T) {
+	service, _ := newTestService(t)
+
+	_, err := service.Create(reporterID, &report.CreateReportDTO{OfferID: uintPtr(7), Reason: string(enums.DUPLICATE_IMAGES)})
+
+	assert.ErrorIs(t, err, report.ErrInvalidReportReason)
+}
+
+func TestReportService_CreateSystemReport(t *testing.T) {
+	service, m := newTestService(t)
+
+	m.repo.On("Create", mock.MatchedBy(func(r *models.Report) bool {
+		return r.ReporterID == nil && *r.OfferID == 7 && r.Reason == enums.DUPLICATE_IMAGES && *r.Comment == "copied photos"
+	}), hideThreshold).Return(nil)
+
+	err := service.CreateSystemReport(7, enums.DUPLICATE_IMAGES, "copied photos")
+
+	assert.NoError(t, err)
+}
+
+func TestReportService_CreateSystemReport_AlreadyOpen(t *testing.T) {
+	service, m := newTestService(t)
+
+	m.repo.On("Create", mock.Anything, hideThreshold).Return(report.ErrAlreadyReported)
+
+	err := service.CreateSystemReport(7, enums.DUPLICATE_IMAGES, "copied photos")
+
+	assert.NoError(t, err)
+}
+
+func TestReportService_CreateSystemReport_UserReason(t *testing.T) {
+	service, _ := newTestService(t)
+
+	err := service.CreateSystemReport(7, enums.SCAM, "copied photos")
+
+	assert.ErrorIs(t, err, report.ErrInvalidReportReason)
+}
+
+func TestReportService_GetQueue_SystemReason(t *testing.T) {
+	service, m := newTestService(t)
+	reason := string(enums.DUPLICATE_IMAGES)
+	filter := &report.ReportFilter{Reason: &reason}
+
+	m.repo.On("GetQueue", filter).Return([]models.Report{{ID: 1, OfferID: uintPtr(7), Reason: enums.DUPLICATE_IMAGES}}, nil, nil)
+
+	result, err := service.GetQueue(filter)
+
+	assert.NoError(t, err)
+	assert.Nil(t, result.Reports[0].ReporterID)
+}
+
+func TestReportService_GetQueue_InvalidReason(t *testing.T) {
+	service, _ := newTestService(t)
+	reason := "Boring"
+
+	_, err := service.GetQueue(&report.ReportFilter{Reason: &reason})
+
+	assert.ErrorIs(t, err, report.ErrInvalidReportReason)
+}
+
+func TestReportService_GetQueue(t *testing.T) {
+	service, m := newTestService(t)
+	filter := &report.ReportFilter{}
+
+	m.repo.On("GetQueue", filter).Return([]models.Report{{ID: 1, OfferID: uintPtr(7), Reason: enums.SCAM}}, nil, nil)
+
+	result, err := service.GetQueue(filter)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Reports, 1)
+	assert.Equal(t, uint(7), *result.Reports[0].OfferID)
+}
+
+func TestReportService_Resolve(t *testing.T) {
+	service, m := newTestService(t)
+	note := "offer removed"
+
+	m.repo.On("GetByID", uint(1)).Return(&models.Report{ID: 1, ReporterID: uintPtr(reporterID), OfferID: uintPtr(7)}, nil)
+	m.repo.On("Resolve",
+		mock.MatchedBy(func(r *models.Report) bool {
+			return *r.Outcome == enums.ACTION_TAKEN && *r.ResolvedBy == moderatorID && r.ResolvedAt != nil && r.Note == &note
+		}),
+		hideThreshold,
+		mock.MatchedBy(func(msg *models.OutboxMessage) bool {
+			var p report.ReportResolvedPayload
+			return msg.Kind == report.OutboxReportResolved &&
+				json.Unmarshal([]byte(msg.Payload), &p) == nil &&
+				p.ReporterID == reporterID && *p.OfferID == 7 && p.Outcome == enums.ACTION_TAKEN
+		}),
+		mock.MatchedBy(func(entry *models.AdminAuditLog) bool {
+			return entry.AdminID == moderatorID && entry.Action == enums.RESOLVE_REPORT && entry.TargetID == 1
+		}),
+	).Return(nil)
+
+	err := service.Resolve(moderatorID, 1, &report.ResolveReportDTO{Outcome: string(enums.ACTION_TAKEN), Note: &note})
+
+	assert.NoError(t, err)
+}
+
+func TestReportService_Resolve_SystemReport(t *testing.T) {
+	service, m := newTestService(t)
+
+	m.repo.On("GetByID", uint(1)).Return(&models.Report{ID: 1, OfferID: uintPtr(7), Reason: enums.DUPLICATE_IMAGES}, nil)
+	// there is no reporter to notify
+	m.repo.On("Resolve", mock.Anything, hideThreshold, (*models.OutboxMessage)(nil), mock.Anything).Return(nil)
+
+	err := service.Resolve(moderatorID, 1, &report.ResolveReportDTO{Outcome: string(enums.DISMISSED)})
+
+	assert.NoError(t, err)
+}
+
+func TestReportService_Resolve_InvalidOutcome(t *testing.T) {
+	service, _ := newTestService(t)
+
+	err := service.Resolve(moderatorID, 1, &report.ResolveReportDTO{Outcome: "Ignored"})
+
+	assert.ErrorIs(t, err, report.ErrInvalidReportOutcome)
+}
+
+func TestReportService_Resolve_AlreadyResolved(t *testing.T) {
+	service, m := newTestService(t)
+	outcome := enums.DISMISSED
+	resolvedAt := time.Now()
+
+	m.repo.On("GetByID", uint(1)).Return(&models.Report{ID: 1, Outcome: &outcome, ResolvedAt: &resolvedAt}, nil)
+
+	err := service.Resolve(moderatorID, 1, &report.ResolveReportDTO{Outcome: string(enums.DISMISSED)})
+
+	assert.ErrorIs(t, err, report.ErrReportAlreadyResolved)
+}
+
+func TestParseHideThreshold(t *testing.T) {
+	threshold, err := report.ParseHideThreshold("")
+	assert.NoError(t, err)
+	assert.Equal(t, uint(report.DefaultHideThreshold), threshold)
+
+	threshold, err = report.ParseHideThreshold(" 5 ")
+	assert.NoError(t, err)
+	assert.Equal(t, uint(5), threshold)
+
+	_, err = report.ParseHideThreshold("0")
+	assert.ErrorIs(t, err, report.ErrInvalidHideThreshold)
+
+	_, err = report.ParseHideThreshold("many")
+	assert.ErrorIs(t, err, report.ErrInvalidHideThreshold)
+}
@@ -10,8 +10,10 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/purchase"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/review"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/user"
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
 	"github.com/susek555/BD2/car-dealer-api/internal/models"
 	"github.com/susek555/BD2/car-dealer-api/pkg/jwt"
 	"github.com/susek555/BD2/car-dealer-api/pkg/middleware"
@@ -23,13 +25,14 @@ import (
 // Setup
 // ------
 
+const dsn = "host=localhost user=bd2_user password=bd2_password dbname=bd2_test port=5432 sslmode=disable TimeZone=UTC"
+
 func setupDB(users []models.User, reviews []models.Review) (review.ReviewRepositoryInterface, user.UserRepositoryInterface, error) {
-	dsn := "host=localhost user=bd2_user password=bd2_password dbname=bd2_test port=5432 sslmode=disable TimeZone=UTC"
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	if err != nil {
 		return nil, nil, err
 	}
-	db.Exec("TRUNCATE TABLE reviews, companies, people, users RESTART IDENTITY CASCADE")
+	db.Exec("TRUNCATE TABLE reviews, purchases, sale_offers, companies, people, users RESTART IDENTITY CASCADE")
 	userRepo := user.NewUserRepository(db)
 	for _, u := range users {
 		err = userRepo.Create(&u)
@@ -47,13 +50,31 @@ func setupDB(users []models.User, reviews []models.Review) (review.ReviewReposit
 	return reviewRepo, userRepo, nil
 }
 
+// seedPurchase saves a sold offer of the seller bought by the buyer and returns its ID.
+func seedPurchase(sellerID, buyerID uint) (uint, error) {
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		return 0, err
+	}
+	offer := models.SaleOffer{UserID: sellerID, Description: "sold", Price: 1000, Margin: enums.LOW_MARGIN, Status: enums.SOLD, DateOfIssue: time.Now()}
+	if err := db.Omit("User", "Car", "Auction").Create(&offer).Error; err != nil {
+		return 0, err
+	}
+	err = db.Create(&models.Purchase{OfferID: offer.ID, BuyerID: buyerID, FinalPrice: 1000, IssueDate: time.Now()}).Error
+	return offer.ID, err
+}
+
 func newTestServer(seedUsers []models.User, seedReviews []models.Review) (*gin.Engine, review.ReviewServiceInterface, user.UserServiceInterface, error) {
 	reviewRepo, userRepo, err := setupDB(seedUsers, seedReviews)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 	verifier := jwt.NewJWTVerifier("secret")
-	reviewService := review.NewReviewService(reviewRepo)
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	reviewService := review.NewReviewService(reviewRepo, purchase.NewPurchaseRepository(db), review.DefaultReviewWindow)
 	userService := user.NewUserService(userRepo)
 	reviewHandler := review.NewHandler(reviewService)
 
@@ -434,11 +455,14 @@ func TestCreateReviewSuccess(t *testing.T) {
 	var seedReviews []models.Review
 	server, _, _, err := newTestServer(seedUsers, seedReviews)
 	assert.NoError(t, err)
+	offerID, err := seedPurchase(2, 1)
+	assert.NoError(t, err)
 	wantStatus := http.StatusCreated
 	reviewInput := review.CreateReviewDTO{
 		Rating:      5,
 		Description: "Great service!",
 		RevieweeID:  2,
+		OfferID:     offerID,
 	}
 	reviewInputJSON, err := json.Marshal(reviewInput)
 	assert.NoError(t, err)
@@ -458,6 +482,7 @@ func TestCreateReviewSuccess(t *testing.T) {
 	assert.Equal(t, reviewInput.RevieweeID, got.Reviewee.ID)
 	assert.Equal(t, uint(1), got.Reviewer.ID)
 	assert.Equal(t, uint(1), got.ID)
+	assert.True(t, got.Verified)
 }
 
 func TestCreateReviewInvalidRating(t *testing.T) {
@@ -526,11 +551,14 @@ func TestCreateReviewSelfReview(t *testing.T) {
 	var seedReviews []models.Review
 	server, _, _, err := newTestServer(seedUsers, seedReviews)
 	assert.NoError(t, err)
+	offerID, err := seedPurchase(1, 1)
+	assert.NoError(t, err)
 	wantStatus := http.StatusBadRequest
 	reviewInput := review.CreateReviewDTO{
 		Rating:      5,
 		Description: "Great service!",
 		RevieweeID:  1,
+		OfferID:     offerID,
 	}
 	reviewInputJSON, err := json.Marshal(reviewInput)
 	assert.NoError(t, err)
@@ -545,7 +573,7 @@ func TestCreateReviewSelfReview(t *testing.T) {
 	var got map[string]string
 	err = json.Unmarshal(w.Body.Bytes(), &got)
 	assert.NoError(t, err)
-	assert.Equal(t, "CHECK constraint failed: chk_reviews_reviewer_id", got["error_description"])
+	assert.Equal(t, review.ErrSelfReview.Error(), got["error_description"])
 }
 
 func TestCreateReviewReviewAlreadyExists(t *testing.T) {
@@ -0,0 +1,26 @@
+package review_tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/review"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
+)
+
+func TestReviewReplyNotifier_NotifiesReviewer(t *testing.T) {
+	notificationService := mocks.NewNotificationServiceInterface(t)
+	hub := mocks.NewHubInterface(t)
+	notifier := review.NewReviewReplyNotifier(notificationService, hub)
+
+	notificationService.On("CreateReviewReplyNotification", mock.MatchedBy(func(n *models.Notification) bool {
+		return n.OutboxMessageID != nil && *n.OutboxMessageID == 11
+	}), "dealer", "Thank you!").Return(nil)
+	notificationService.On("SaveNotificationToClient", mock.AnythingOfType("*models.Notification"), uint(1)).Return(nil)
+	hub.On("SendFourLatestNotificationsToUser", "1").Return()
+
+	err := notifier.DeliverReviewReplied(11, `{"review_id":8,"reviewer_id":1,"replier":"dealer","reply":"Thank you!"}`)
+	assert.NoError(t, err)
+}
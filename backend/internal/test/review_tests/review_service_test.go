@@ -1,8 +1,11 @@
 package review_tests
 
 import (
+	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -10,13 +13,32 @@ import (
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/review"
 	"github.com/susek555/BD2/car-dealer-api/internal/models"
 	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
 )
 
+// purchases stands in for the purchase repository, keyed by offer ID
+type purchases map[uint]*models.Purchase
+
+func (p purchases) GetByID(offerID uint) (*models.Purchase, error) {
+	purchase, ok := p[offerID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return purchase, nil
+}
+
 // helper
 func newServiceWithMock() (*review.ReviewService, *mocks.ReviewRepositoryInterface) {
 	repoMock := new(mocks.ReviewRepositoryInterface)
 	svc := &review.ReviewService{
 		Repo: repoMock,
+		// offer 3 was bought by user 1 from user 2 yesterday, offer 4 by user 1 from user 2 a year ago
+		PurchaseRetriever: purchases{
+			3: {OfferID: 3, BuyerID: 1, IssueDate: time.Now().AddDate(0, 0, -1), Offer: &models.SaleOffer{ID: 3, UserID: 2}},
+			4: {OfferID: 4, BuyerID: 1, IssueDate: time.Now().AddDate(-1, 0, 0), Offer: &models.SaleOffer{ID: 4, UserID: 2}},
+		},
+		ReviewWindow: review.DefaultReviewWindow,
 	}
 	return svc, repoMock
 }
@@ -123,7 +145,7 @@ func TestCreate_Success(t *testing.T) {
 	svc, repo := newServiceWithMock()
 	userID := uint(1)
 
-	in := &review.CreateReviewDTO{Description: "ok", Rating: 1, RevieweeID: 2}
+	in := &review.CreateReviewDTO{Description: "ok", Rating: 1, RevieweeID: 2, OfferID: 3}
 
 	repo.
 		On("Create", mock.AnythingOfType("*models.Review")).
@@ -146,13 +168,81 @@ func TestCreate_Success(t *testing.T) {
 	assert.Equal(t, in.Description, got.Description)
 	assert.Equal(t, in.Rating, got.Rating)
 	assert.Equal(t, in.RevieweeID, got.Reviewee.ID)
+	assert.True(t, got.Verified)
+	assert.Equal(t, uint(3), *got.OfferID)
+}
+
+func TestCreate_BySeller(t *testing.T) {
+	svc, repo := newServiceWithMock()
+
+	in := &review.CreateReviewDTO{Description: "quick payment", Rating: 5, RevieweeID: 1, OfferID: 3}
+	repo.
+		On("Create", mock.AnythingOfType("*models.Review")).
+		Run(func(args mock.Arguments) {
+			r := args.Get(0).(*models.Review)
+			r.Reviewer = &models.User{ID: r.ReviewerID}
+			r.Reviewee = &models.User{ID: r.RevieweeID}
+		}).
+		Return(nil).
+		Once()
+
+	_, err := svc.Create(2, in)
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestCreate_PurchaseRequired(t *testing.T) {
+	svc, repo := newServiceWithMock()
+
+	got, err := svc.Create(1, &review.CreateReviewDTO{Description: "ok", Rating: 5, RevieweeID: 2})
+
+	require.ErrorIs(t, err, review.ErrPurchaseRequired)
+	assert.Nil(t, got)
+	repo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestCreate_PurchaseNotFound(t *testing.T) {
+	svc, _ := newServiceWithMock()
+
+	_, err := svc.Create(1, &review.CreateReviewDTO{Description: "ok", Rating: 5, RevieweeID: 2, OfferID: 99})
+
+	require.ErrorIs(t, err, review.ErrPurchaseNotFound)
+}
+
+func TestCreate_NotPurchaseParty(t *testing.T) {
+	svc, repo := newServiceWithMock()
+
+	_, errStranger := svc.Create(7, &review.CreateReviewDTO{Description: "ok", Rating: 5, RevieweeID: 2, OfferID: 3})
+	_, errWrongReviewee := svc.Create(1, &review.CreateReviewDTO{Description: "ok", Rating: 5, RevieweeID: 7, OfferID: 3})
+
+	require.ErrorIs(t, errStranger, review.ErrNotPurchaseParty)
+	require.ErrorIs(t, errWrongReviewee, review.ErrNotPurchaseParty)
+	repo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestCreate_SelfReview(t *testing.T) {
+	svc, repo := newServiceWithMock()
+
+	_, err := svc.Create(1, &review.CreateReviewDTO{Description: "ok", Rating: 5, RevieweeID: 1, OfferID: 3})
+
+	require.ErrorIs(t, err, review.ErrSelfReview)
+	repo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestCreate_ReviewWindowClosed(t *testing.T) {
+	svc, _ := newServiceWithMock()
+
+	_, err := svc.Create(1, &review.CreateReviewDTO{Description: "ok", Rating: 5, RevieweeID: 2, OfferID: 4})
+
+	require.ErrorIs(t, err, review.ErrReviewWindowClosed)
 }
 
 func TestCreate_Error(t *testing.T) {
 	svc, repo := newServiceWithMock()
 	userID := uint(1)
 
-	in := &review.CreateReviewDTO{Description: "bad", Rating: 1}
+	in := &review.CreateReviewDTO{Description: "bad", Rating: 1, RevieweeID: 2, OfferID: 3}
 	repoErr := errors.New("err")
 
 	repo.
@@ -215,6 +305,7 @@ func TestUpdate_Success(t *testing.T) {
 
 	reviewerID := uint(5)
 	reviewID := uint(5)
+	offerID := uint(3)
 
 	upd := &review.UpdateReviewDTO{
 		ID:          reviewID,
@@ -229,6 +320,7 @@ func TestUpdate_Success(t *testing.T) {
 			ID:         reviewID,
 			ReviewerID: reviewerID,
 			RevieweeID: 2,
+			OfferID:    &offerID,
 			Reviewer:   &models.User{ID: reviewerID, Username: "author"},
 			Reviewee:   &models.User{ID: 2, Username: "user"},
 		}, nil).
@@ -267,6 +359,7 @@ func TestUpdate_Success(t *testing.T) {
 	assert.Equal(t, upd.Description, got.Description)
 	assert.Equal(t, upd.Rating, got.Rating)
 	assert.Equal(t, uint(2), got.Reviewee.ID)
+	assert.Equal(t, &offerID, got.OfferID)
 }
 
 func TestUpdate_Error(t *testing.T) {
@@ -331,3 +424,192 @@ func TestDelete_Error(t *testing.T) {
 	require.ErrorIs(t, err, repoErr)
 	repo.AssertExpectations(t)
 }
+
+// --- Verified reviews ---
+
+func TestGetAverageRatingByRevieweeID_VerifiedOnly(t *testing.T) {
+	svc, repo := newServiceWithMock()
+
+	repo.On("GetAverageRatingByRevieweeID", uint(2), true).Return(4.5, nil).Once()
+
+	got, err := svc.GetAverageRatingByRevieweeID(2, true)
+
+	require.NoError(t, err)
+	assert.Equal(t, 4.5, got)
+	repo.AssertExpectations(t)
+}
+
+func TestGetFrequencyOfRatingByRevieweeID_VerifiedOnly(t *testing.T) {
+	svc, repo := newServiceWithMock()
+	frequency := map[int]int{1: 0, 2: 0, 3: 0, 4: 50, 5: 50}
+
+	repo.On("GetFrequencyOfRatingByRevieweeID", uint(2), true).Return(frequency, nil).Once()
+
+	got, err := svc.GetFrequencyOfRatingByRevieweeID(2, true)
+
+	require.NoError(t, err)
+	assert.Equal(t, frequency, got)
+	repo.AssertExpectations(t)
+}
+
+func TestParseReviewWindow(t *testing.T) {
+	window, err := review.ParseReviewWindow("")
+	require.NoError(t, err)
+	assert.Equal(t, review.DefaultReviewWindow, window)
+
+	window, err = review.ParseReviewWindow("72h")
+	require.NoError(t, err)
+	assert.Equal(t, 72*time.Hour, window)
+
+	_, err = review.ParseReviewWindow("-1h")
+	assert.ErrorIs(t, err, review.ErrInvalidReviewWindow)
+}
+
+// --- Replies ---
+
+func reviewAbout(revieweeID uint) *models.Review {
+	return &models.Review{
+		ID:         8,
+		ReviewerID: 1,
+		RevieweeID: revieweeID,
+		Reviewer:   &models.User{ID: 1, Username: "buyer"},
+		Reviewee:   &models.User{ID: revieweeID, Username: "dealer"},
+	}
+}
+
+func TestReply_Success(t *testing.T) {
+	svc, repo := newServiceWithMock()
+	replied := reviewAbout(2)
+	reply := "Thank you!"
+	replied.Reply = &reply
+
+	repo.On("GetByID", uint(8)).Return(reviewAbout(2), nil).Once()
+	repo.On("SaveReply", uint(8), "Thank you!", mock.AnythingOfType("time.Time"), mock.MatchedBy(func(msg *models.OutboxMessage) bool {
+		var p review.ReviewRepliedPayload
+		return msg.Kind == review.OutboxReviewReplied &&
+			json.Unmarshal([]byte(msg.Payload), &p) == nil &&
+			p.ReviewerID == 1 && p.Replier == "dealer" && p.Reply == "Thank you!"
+	})).Return(nil).Once()
+	repo.On("GetByID", uint(8)).Return(replied, nil).Once()
+
+	got, err := svc.Reply(2, 8, &review.ReplyDTO{Reply: "  Thank you!  "})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Thank you!", *got.Reply)
+	repo.AssertExpectations(t)
+}
+
+func TestReply_NotReviewee(t *testing.T) {
+	svc, repo := newServiceWithMock()
+
+	repo.On("GetByID", uint(8)).Return(reviewAbout(2), nil).Once()
+
+	_, err := svc.Reply(3, 8, &review.ReplyDTO{Reply: "Not my review"})
+
+	require.ErrorIs(t, err, review.ErrNotReviewee)
+	repo.AssertNotCalled(t, "SaveReply", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReply_AlreadyReplied(t *testing.T) {
+	svc, repo := newServiceWithMock()
+	replied := reviewAbout(2)
+	reply := "First reply"
+	replied.Reply = &reply
+
+	repo.On("GetByID", uint(8)).Return(replied, nil).Once()
+
+	_, err := svc.Reply(2, 8, &review.ReplyDTO{Reply: "Second reply"})
+
+	require.ErrorIs(t, err, review.ErrAlreadyReplied)
+}
+
+func TestReply_InvalidReply(t *testing.T) {
+	svc, _ := newServiceWithMock()
+
+	_, errBlank := svc.Reply(2, 8, &review.ReplyDTO{Reply: "   "})
+	_, errTooLong := svc.Reply(2, 8, &review.ReplyDTO{Reply: strings.Repeat("a", review.MaxReplyLength+1)})
+
+	require.ErrorIs(t, errBlank, review.ErrInvalidReply)
+	require.ErrorIs(t, errTooLong, review.ErrInvalidReply)
+}
+
+func TestReply_ReviewNotFound(t *testing.T) {
+	svc, repo := newServiceWithMock()
+
+	repo.On("GetByID", uint(8)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+	_, err := svc.Reply(2, 8, &review.ReplyDTO{Reply: "Hello"})
+
+	require.ErrorIs(t, err, review.ErrNoReviewFound)
+}
+
+// --- Votes ---
+
+func TestVote_Success(t *testing.T) {
+	svc, repo := newServiceWithMock()
+	helpful := true
+	voted := reviewAbout(2)
+	voted.HelpfulVotes = 1
+
+	repo.On("GetByID", uint(8)).Return(reviewAbout(2), nil).Once()
+	repo.On("SaveVote", mock.MatchedBy(func(v *models.ReviewVote) bool {
+		return v.ReviewID == 8 && v.UserID == 5 && v.Helpful
+	})).Return(nil).Once()
+	repo.On("GetByID", uint(8)).Return(voted, nil).Once()
+
+	got, err := svc.Vote(5, 8, &review.VoteDTO{Helpful: &helpful})
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), got.HelpfulVotes)
+	repo.AssertExpectations(t)
+}
+
+func TestVote_OwnReview(t *testing.T) {
+	svc, repo := newServiceWithMock()
+	helpful := true
+
+	repo.On("GetByID", uint(8)).Return(reviewAbout(2), nil).Twice()
+
+	_, errReviewer := svc.Vote(1, 8, &review.VoteDTO{Helpful: &helpful})
+	_, errReviewee := svc.Vote(2, 8, &review.VoteDTO{Helpful: &helpful})
+
+	require.ErrorIs(t, errReviewer, review.ErrCannotVoteOwnReview)
+	require.ErrorIs(t, errReviewee, review.ErrCannotVoteOwnReview)
+	repo.AssertNotCalled(t, "SaveVote", mock.Anything)
+}
+
+func TestDeleteVote_NotFound(t *testing.T) {
+	svc, repo := newServiceWithMock()
+
+	repo.On("DeleteVote", uint(8), uint(5)).Return(review.ErrVoteNotFound).Once()
+
+	err := svc.DeleteVote(5, 8)
+
+	require.ErrorIs(t, err, review.ErrVoteNotFound)
+}
+
+func TestApplyReviewFilters_OrderKey(t *testing.T) {
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+	key, desc := review.HelpfulnessOrderKey, true
+	filter := review.NewReviewFilter()
+	filter.OrderKey, filter.IsOrderDesc = &key, &desc
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		query, err := filter.ApplyReviewFilters(tx.Model(&models.Review{}))
+		require.NoError(t, err)
+		return query.Find(&[]models.Review{})
+	})
+
+	assert.Contains(t, sql, "ORDER BY (reviews.helpful_votes - reviews.not_helpful_votes) DESC")
+}
+
+func TestApplyReviewFilters_InvalidOrderKey(t *testing.T) {
+	key := "rating; DROP TABLE reviews"
+	filter := review.NewReviewFilter()
+	filter.OrderKey = &key
+
+	_, err := filter.ApplyReviewFilters(nil)
+
+	require.ErrorIs(t, err, review.ErrInvalidOrderKey)
+}
@@ -91,20 +91,27 @@ func newTestServer(db *gorm.DB, seedOffers []models.SaleOffer) (*gin.Engine, sal
 	imageBucket := image.NewImageBucket(u.GetTestCloudinary())
 	accessEvaluator := sale_offer.NewAccessEvaluator(bidRepository, likedOfferRepository)
 	purchaseCreator := purchase.NewPurchaseRepository(db)
-	saleOfferService := sale_offer.NewSaleOfferService(saleOfferRepo, manufacturerRepo, modelRepo, imageRepo, imageBucket, accessEvaluator, purchaseCreator)
+	saleOfferService := sale_offer.NewSaleOfferService(saleOfferRepo, manufacturerRepo, modelRepo, imageRepo, imageBucket, accessEvaluator, purchaseCreator, sale_offer.DefaultListingPolicy)
 	likedOfferService := liked_offer.NewLikedOfferService(likedOfferRepository, saleOfferRepo)
-	imageService := image.NewImageService(imageRepo, imageBucket, saleOfferRepo, accessEvaluator)
-	imageHandler := image.NewHandler(imageService, saleOfferService)
+	imageService := image.NewImageService(imageRepo, imageBucket, image.NewImageProcessor(image.NewPlateDetector()), saleOfferRepo, accessEvaluator)
+	md := new(mocks.DuplicateNotifierInterface)
+	md.On("NotifyDuplicates", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	imageHandler := image.NewHandler(imageService, saleOfferService, md)
 	mh := new(mocks.HubInterface)
 	mh.On("SubscribeUser", mock.Anything, mock.Anything).Return()
 	mh.On("UnsubscribeUser", mock.Anything, mock.Anything).Return()
 	mh.On("SaveNotificationForClients", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	mh.On("SendFourLatestNotificationsToClients", mock.Anything, mock.Anything).Return(nil)
+	mh.On("Broadcast", mock.Anything, mock.Anything, mock.Anything).Return()
 	mh.On("RemoveRoom", mock.Anything).Return()
 	likedOfferHandler := liked_offer.NewHandler(likedOfferService, mh)
 	mn := new(mocks.NotificationServiceInterface)
 	mn.On("CreateBuyNotification", mock.Anything, mock.Anything, mock.Anything).Return(nil)
-	saleOfferHandler := sale_offer.NewHandler(saleOfferService, mh, mn)
+	mm := new(mocks.PublishedOfferMatcherInterface)
+	mm.On("NotifyMatchingSearches", mock.Anything).Return(nil)
+	mp := new(mocks.PriceDropNotifierInterface)
+	mp.On("NotifyPriceDrop", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	saleOfferHandler := sale_offer.NewHandler(saleOfferService, mh, mm, mp)
 	r := gin.Default()
 	saleOfferRoutes := r.Group("/sale-offer")
 	{
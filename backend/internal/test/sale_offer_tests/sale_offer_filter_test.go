@@ -2493,3 +2493,128 @@ func TestGetFiltered_OrderByDateOfIssueMultipleRecordsAsc(t *testing.T) {
 	}
 	u.CleanDB(DB)
 }
+
+// ------------------
+// Search query tests
+// ------------------
+
+func TestGetFiltered_QueryMatchesModelPrefix(t *testing.T) {
+	offers := []models.SaleOffer{
+		*createOffer(1),
+		*u.Build(createOffer(2), withCarField(u.WithField[models.Car]("ModelID", uint(6)))),
+	}
+	db := DB
+	repo := getRepositoryWithSaleOffers(db, offers)
+	query := "octa"
+	filterRequest := sale_offer.NewOfferFilterRequest()
+	filterRequest.Filter.Query = &query
+	filterRequest.PagRequest = *u.GetDefaultPaginationRequest()
+	result, _, err := repo.GetFiltered(&filterRequest.Filter, &filterRequest.PagRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, len(result), 1)
+	assert.Equal(t, result[0].ID, uint(2))
+	u.CleanDB(DB)
+}
+
+func TestGetFiltered_QueryAllWordsMustMatch(t *testing.T) {
+	offers := []models.SaleOffer{
+		*u.Build(createOffer(1), u.WithField[models.SaleOffer]("Description", "tdi 2018 automatic")),
+		*u.Build(createOffer(2), u.WithField[models.SaleOffer]("Description", "tdi 2018 manual")),
+	}
+	db := DB
+	repo := getRepositoryWithSaleOffers(db, offers)
+	query := "audi TDI 2018 automatic"
+	filterRequest := sale_offer.NewOfferFilterRequest()
+	filterRequest.Filter.Query = &query
+	filterRequest.PagRequest = *u.GetDefaultPaginationRequest()
+	result, _, err := repo.GetFiltered(&filterRequest.Filter, &filterRequest.PagRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, len(result), 1)
+	assert.Equal(t, result[0].ID, uint(1))
+	u.CleanDB(DB)
+}
+
+func TestGetFiltered_QueryIgnoresPolishDiacritics(t *testing.T) {
+	offers := []models.SaleOffer{
+		*u.Build(createOffer(1), u.WithField[models.SaleOffer]("Description", "Zadbany, bezwypadkowy, garażowany")),
+	}
+	db := DB
+	repo := getRepositoryWithSaleOffers(db, offers)
+	for _, query := range []string{"garazowany", "GARAŻOWANY", "bezwypadk"} {
+		filterRequest := sale_offer.NewOfferFilterRequest()
+		filterRequest.Filter.Query = &query
+		filterRequest.PagRequest = *u.GetDefaultPaginationRequest()
+		result, _, err := repo.GetFiltered(&filterRequest.Filter, &filterRequest.PagRequest)
+		assert.NoError(t, err)
+		assert.Equal(t, len(result), 1)
+	}
+	u.CleanDB(DB)
+}
+
+func TestGetFiltered_QueryMatchesVinAndRegistrationNumber(t *testing.T) {
+	offers := []models.SaleOffer{
+		*u.Build(createOffer(1),
+			withCarField(u.WithField[models.Car]("Vin", "WVWZZZ1KZ8W000001")),
+			withCarField(u.WithField[models.Car]("RegistrationNumber", "WA12345"))),
+		*createOffer(2),
+	}
+	db := DB
+	repo := getRepositoryWithSaleOffers(db, offers)
+	for _, query := range []string{"wvwzzz", "WA12345"} {
+		filterRequest := sale_offer.NewOfferFilterRequest()
+		filterRequest.Filter.Query = &query
+		filterRequest.PagRequest = *u.GetDefaultPaginationRequest()
+		result, _, err := repo.GetFiltered(&filterRequest.Filter, &filterRequest.PagRequest)
+		assert.NoError(t, err)
+		assert.Equal(t, len(result), 1)
+		assert.Equal(t, result[0].ID, uint(1))
+	}
+	u.CleanDB(DB)
+}
+
+func TestGetFiltered_QueryNoMatch(t *testing.T) {
+	offers := []models.SaleOffer{*createOffer(1)}
+	db := DB
+	repo := getRepositoryWithSaleOffers(db, offers)
+	query := "supra"
+	filterRequest := sale_offer.NewOfferFilterRequest()
+	filterRequest.Filter.Query = &query
+	filterRequest.PagRequest = *u.GetDefaultPaginationRequest()
+	result, _, err := repo.GetFiltered(&filterRequest.Filter, &filterRequest.PagRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, len(result), 0)
+	u.CleanDB(DB)
+}
+
+func TestGetFiltered_QueryOnlySpecialCharactersIsIgnored(t *testing.T) {
+	offers := []models.SaleOffer{*createOffer(1), *createOffer(2)}
+	db := DB
+	repo := getRepositoryWithSaleOffers(db, offers)
+	query := " & | ! :* "
+	filterRequest := sale_offer.NewOfferFilterRequest()
+	filterRequest.Filter.Query = &query
+	filterRequest.PagRequest = *u.GetDefaultPaginationRequest()
+	result, _, err := repo.GetFiltered(&filterRequest.Filter, &filterRequest.PagRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, len(result), len(offers))
+	u.CleanDB(DB)
+}
+
+func TestGetFiltered_QueryOrdersByRelevanceWithoutOrderKey(t *testing.T) {
+	offers := []models.SaleOffer{
+		*u.Build(createOffer(1), u.WithField[models.SaleOffer]("Description", "audi")),
+		*u.Build(createOffer(2), withCarField(u.WithField[models.Car]("ModelID", uint(6))),
+			u.WithField[models.SaleOffer]("Description", "better than audi")),
+	}
+	db := DB
+	repo := getRepositoryWithSaleOffers(db, offers)
+	query := "audi"
+	filterRequest := sale_offer.NewOfferFilterRequest()
+	filterRequest.Filter.Query = &query
+	filterRequest.PagRequest = *u.GetDefaultPaginationRequest()
+	result, _, err := repo.GetFiltered(&filterRequest.Filter, &filterRequest.PagRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, len(result), 2)
+	assert.Equal(t, result[0].ID, uint(1))
+	u.CleanDB(DB)
+}
@@ -0,0 +1,78 @@
+package sale_offer_tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/ws"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
+)
+
+func newTestPurchaseNotifier(t *testing.T) (*sale_offer.PurchaseNotifier, *mocks.NotificationServiceInterface, *mocks.HubInterface, *mocks.SaleOfferServiceInterface) {
+	notificationService := mocks.NewNotificationServiceInterface(t)
+	hub := mocks.NewHubInterface(t)
+	saleOfferService := mocks.NewSaleOfferServiceInterface(t)
+	return sale_offer.NewPurchaseNotifier(notificationService, hub, saleOfferService), notificationService, hub, saleOfferService
+}
+
+func envelopeOfType(messageType ws.MsgType) any {
+	return mock.MatchedBy(func(e *ws.Envelope) bool { return e.MessageType == messageType })
+}
+
+func TestPurchaseNotifier_DeliverOfferBought(t *testing.T) {
+	notifier, notificationService, hub, saleOfferService := newTestPurchaseNotifier(t)
+	offerDTO := &sale_offer.RetrieveDetailedSaleOfferDTO{ID: 3, UserID: 5, Price: 20000}
+
+	saleOfferService.On("GetDetailedByID", uint(3), (*uint)(nil)).Return(offerDTO, nil)
+	notificationService.On("CreateBuyNotification", mock.MatchedBy(func(n *models.Notification) bool {
+		return n.OutboxMessageID != nil && *n.OutboxMessageID == 11 && *n.OfferID == 3
+	}), "7", offerDTO).Return(nil)
+	hub.On("SaveNotificationForClients", "3", uint(7), mock.Anything).Return(nil)
+	hub.On("Broadcast", "3", envelopeOfType(ws.MsgNotification), "7").Return()
+	hub.On("Broadcast", "3", mock.MatchedBy(func(e *ws.Envelope) bool {
+		return e.MessageType == ws.MsgOfferSold && string(e.Data) == `{"offer_id":3,"price":20000}`
+	}), "").Return()
+	hub.On("SendFourLatestNotificationsToClients", "3", "7").Return()
+	hub.On("RemoveRoom", "3").Return()
+
+	err := notifier.DeliverOfferBought(11, `{"offer_id":3,"buyer_id":7}`)
+
+	assert.NoError(t, err)
+}
+
+func TestPurchaseNotifier_DeliverAuctionBoughtOut(t *testing.T) {
+	notifier, notificationService, hub, saleOfferService := newTestPurchaseNotifier(t)
+	offerDTO := &sale_offer.RetrieveDetailedSaleOfferDTO{ID: 3, UserID: 5}
+
+	saleOfferService.On("GetDetailedByID", uint(3), (*uint)(nil)).Return(offerDTO, nil)
+	notificationService.On("CreateBuyNowNotification", mock.Anything, "7", offerDTO).Return(nil)
+	hub.On("SaveNotificationForClients", "3", uint(7), mock.Anything).Return(nil)
+	hub.On("Broadcast", "3", envelopeOfType(ws.MsgNotification), "7").Return()
+	hub.On("Broadcast", "3", envelopeOfType(ws.MsgOfferSold), "").Return()
+	hub.On("SendFourLatestNotificationsToClients", "3", "7").Return()
+	hub.On("RemoveRoom", "3").Return()
+
+	err := notifier.DeliverAuctionBoughtOut(11, `{"offer_id":3,"buyer_id":7}`)
+
+	assert.NoError(t, err)
+}
+
+func TestPurchaseNotifier_KeepsRoomWhenDeliveryFails(t *testing.T) {
+	notifier, notificationService, hub, saleOfferService := newTestPurchaseNotifier(t)
+	offerDTO := &sale_offer.RetrieveDetailedSaleOfferDTO{ID: 3, UserID: 5}
+	hubErr := errors.New("db down")
+
+	saleOfferService.On("GetDetailedByID", uint(3), (*uint)(nil)).Return(offerDTO, nil)
+	notificationService.On("CreateBuyNotification", mock.Anything, "7", offerDTO).Return(nil)
+	hub.On("SaveNotificationForClients", "3", uint(7), mock.Anything).Return(hubErr)
+
+	err := notifier.DeliverOfferBought(11, `{"offer_id":3,"buyer_id":7}`)
+
+	// the message is retried, so the followers of the offer must still be there
+	assert.ErrorIs(t, err, hubErr)
+	hub.AssertNotCalled(t, "RemoveRoom", mock.Anything)
+}
@@ -20,6 +20,8 @@ type mockSaleOfferRepository struct {
 	getByIDFunc      func(id uint) (*models.SaleOffer, error)
 	getViewByIDFunc  func(id uint) (*views.SaleOfferView, error)
 	updateFunc       func(offer *models.SaleOffer) error
+	events           []models.OfferEvent
+	purchaseMessages []*models.OutboxMessage
 	updateStatusFunc func(offer *models.SaleOffer, status enums.Status) error
 	deleteFunc       func(id uint) error
 	getFilteredFunc  func(filter sale_offer.OfferFilterInterface, pagination *pagination.PaginationRequest) ([]views.SaleOfferView, *pagination.PaginationResponse, error)
@@ -53,6 +55,28 @@ func (m *mockSaleOfferRepository) Update(offer *models.SaleOffer) error {
 	return nil
 }
 
+func (m *mockSaleOfferRepository) UpdateWithHistory(offer *models.SaleOffer, events []models.OfferEvent) error {
+	if err := m.Update(offer); err != nil {
+		return err
+	}
+	m.events = append(m.events, events...)
+	return nil
+}
+
+func (m *mockSaleOfferRepository) GetPriceHistory(id uint) ([]models.OfferEvent, error) {
+	var changes []models.OfferEvent
+	for _, event := range m.events {
+		if event.Kind == enums.PRICE_CHANGED {
+			changes = append(changes, event)
+		}
+	}
+	return changes, nil
+}
+
+func (m *mockSaleOfferRepository) GetEvents(id uint) ([]models.OfferEvent, error) {
+	return m.events, nil
+}
+
 func (m *mockSaleOfferRepository) UpdateStatus(offer *models.SaleOffer, status enums.Status) error {
 	if m.updateStatusFunc != nil {
 		return m.updateStatusFunc(offer, status)
@@ -60,6 +84,26 @@ func (m *mockSaleOfferRepository) UpdateStatus(offer *models.SaleOffer, status e
 	return nil
 }
 
+func (m *mockSaleOfferRepository) HasBids(id uint) (bool, error) {
+	return false, nil
+}
+
+// Purchase mimics the repository - it validates the offer with finalPrice, marks it as sold and records the message.
+func (m *mockSaleOfferRepository) Purchase(id uint, buyerID uint, finalPrice func(offer *models.SaleOffer) (uint, error), message *models.OutboxMessage) (*models.SaleOffer, error) {
+	offer, err := m.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	price, err := finalPrice(offer)
+	if err != nil {
+		return nil, err
+	}
+	offer.Status = enums.SOLD
+	offer.Price = price
+	m.purchaseMessages = append(m.purchaseMessages, message)
+	return offer, nil
+}
+
 func (m *mockSaleOfferRepository) Delete(id uint) error {
 	if m.deleteFunc != nil {
 		return m.deleteFunc(id)
@@ -185,6 +229,7 @@ func createMockSaleOfferService() (*sale_offer.SaleOfferService, *mockSaleOfferR
 		mockImageRemover,
 		mockAccessEvaluator,
 		mockPurchaseCreator,
+		sale_offer.DefaultListingPolicy,
 	).(*sale_offer.SaleOfferService)
 
 	return service, mockRepo, mockManufacturerRetriever, mockModelRetriever, mockImageRetriever, mockImageRemover, mockAccessEvaluator, mockPurchaseCreator
@@ -377,6 +422,97 @@ func TestSaleOfferService_Update_Success(t *testing.T) {
 	assert.Equal(t, uint(1), result.ID)
 }
 
+func TestSaleOfferService_Update_PriceDropRecorded(t *testing.T) {
+	service, mockRepo, _, _, mockImageRetriever, _, mockAccessEvaluator, _ := createMockSaleOfferService()
+
+	price := uint(20000)
+	updateDTO := &sale_offer.UpdateSaleOfferDTO{ID: 1, Price: &price}
+	sampleOffer := createSampleSaleOffer()
+
+	mockRepo.getByIDFunc = func(id uint) (*models.SaleOffer, error) {
+		return sampleOffer, nil
+	}
+	mockRepo.getViewByIDFunc = func(id uint) (*views.SaleOfferView, error) {
+		return createSampleSaleOfferView(), nil
+	}
+	mockAccessEvaluator.canBeModifiedByUserFunc = func(offer sale_offer.SaleOfferEntityInterface, userID *uint) error {
+		return nil
+	}
+	mockAccessEvaluator.isOfferLikedByUserFunc = func(offer sale_offer.SaleOfferEntityInterface, userID *uint) error {
+		return errors.New("")
+	}
+	mockImageRetriever.getByOfferIDFunc = func(offerID uint) ([]models.Image, error) {
+		return []models.Image{}, nil
+	}
+
+	result, err := service.Update(updateDTO, 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.PriceHistory, 1)
+	assert.Equal(t, uint(25000), result.PriceHistory[0].OldPrice)
+	assert.Equal(t, uint(20000), result.PriceHistory[0].NewPrice)
+	assert.Len(t, mockRepo.events, 1)
+	assert.Equal(t, enums.PRICE_CHANGED, mockRepo.events[0].Kind)
+	assert.Equal(t, "20000", *mockRepo.events[0].NewValue)
+	assert.NotNil(t, result.DroppedFromPrice)
+	assert.Equal(t, uint(25000), *result.DroppedFromPrice)
+}
+
+func TestSaleOfferService_Update_PriceRaiseIsNotADrop(t *testing.T) {
+	service, mockRepo, _, _, mockImageRetriever, _, mockAccessEvaluator, _ := createMockSaleOfferService()
+
+	mockRepo.getByIDFunc = func(id uint) (*models.SaleOffer, error) {
+		return createSampleSaleOffer(), nil
+	}
+	mockRepo.getViewByIDFunc = func(id uint) (*views.SaleOfferView, error) {
+		return createSampleSaleOfferView(), nil
+	}
+	mockAccessEvaluator.canBeModifiedByUserFunc = func(offer sale_offer.SaleOfferEntityInterface, userID *uint) error {
+		return nil
+	}
+	mockAccessEvaluator.isOfferLikedByUserFunc = func(offer sale_offer.SaleOfferEntityInterface, userID *uint) error {
+		return errors.New("")
+	}
+	mockImageRetriever.getByOfferIDFunc = func(offerID uint) ([]models.Image, error) {
+		return []models.Image{}, nil
+	}
+
+	result, err := service.Update(createSampleUpdateDTO(), 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.PriceHistory, 1)
+	assert.Nil(t, result.DroppedFromPrice)
+}
+
+func TestSaleOfferService_Update_UnchangedPriceNotRecorded(t *testing.T) {
+	service, mockRepo, _, _, mockImageRetriever, _, mockAccessEvaluator, _ := createMockSaleOfferService()
+
+	description := "Updated description"
+	mockRepo.getByIDFunc = func(id uint) (*models.SaleOffer, error) {
+		return createSampleSaleOffer(), nil
+	}
+	mockRepo.getViewByIDFunc = func(id uint) (*views.SaleOfferView, error) {
+		return createSampleSaleOfferView(), nil
+	}
+	mockAccessEvaluator.canBeModifiedByUserFunc = func(offer sale_offer.SaleOfferEntityInterface, userID *uint) error {
+		return nil
+	}
+	mockAccessEvaluator.isOfferLikedByUserFunc = func(offer sale_offer.SaleOfferEntityInterface, userID *uint) error {
+		return errors.New("")
+	}
+	mockImageRetriever.getByOfferIDFunc = func(offerID uint) ([]models.Image, error) {
+		return []models.Image{}, nil
+	}
+
+	result, err := service.Update(&sale_offer.UpdateSaleOfferDTO{ID: 1, Description: &description}, 1)
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.PriceHistory)
+	assert.Len(t, mockRepo.events, 1)
+	assert.Equal(t, enums.DESCRIPTION_CHANGED, mockRepo.events[0].Kind)
+	assert.Nil(t, result.DroppedFromPrice)
+}
+
 func TestSaleOfferService_Update_NotOwned(t *testing.T) {
 	service, mockRepo, _, _, _, _, mockAccessEvaluator, _ := createMockSaleOfferService()
 
@@ -451,6 +587,8 @@ func TestSaleOfferService_Publish_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, uint(1), result.ID)
+	assert.NotNil(t, sampleOffer.ExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(sale_offer.DefaultListingPolicy.Lifetime), *sampleOffer.ExpiresAt, time.Minute)
 }
 
 func TestSaleOfferService_Publish_NotOwned(t *testing.T) {
@@ -487,6 +625,105 @@ func TestSaleOfferService_Publish_NotReady(t *testing.T) {
 	assert.Equal(t, sale_offer.ErrOfferNotReadyToPublish, err)
 }
 
+func TestSaleOfferService_Renew_Success(t *testing.T) {
+	service, mockRepo, _, _, mockImageRetriever, _, mockAccessEvaluator, _ := createMockSaleOfferService()
+
+	expiredAt := time.Now().Add(-time.Hour)
+	sampleOffer := createSampleSaleOffer()
+	sampleOffer.Status = enums.EXPIRED
+	sampleOffer.ExpiresAt = &expiredAt
+	sampleOffer.ExpiryReminderSent = true
+
+	var newStatus enums.Status
+	mockRepo.getByIDFunc = func(id uint) (*models.SaleOffer, error) {
+		return sampleOffer, nil
+	}
+	mockRepo.updateStatusFunc = func(offer *models.SaleOffer, status enums.Status) error {
+		newStatus = status
+		return nil
+	}
+	mockRepo.getViewByIDFunc = func(id uint) (*views.SaleOfferView, error) {
+		return createSampleSaleOfferView(), nil
+	}
+	mockAccessEvaluator.isOfferLikedByUserFunc = func(offer sale_offer.SaleOfferEntityInterface, userID *uint) error {
+		return errors.New("")
+	}
+	mockImageRetriever.getByOfferIDFunc = func(offerID uint) ([]models.Image, error) {
+		return []models.Image{}, nil
+	}
+
+	result, err := service.Renew(1, 1)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, enums.PUBLISHED, newStatus)
+	assert.True(t, sampleOffer.ExpiresAt.After(time.Now()))
+	assert.False(t, sampleOffer.ExpiryReminderSent)
+}
+
+func TestSaleOfferService_Renew_NotExpired(t *testing.T) {
+	service, mockRepo, _, _, _, _, _, _ := createMockSaleOfferService()
+
+	sampleOffer := createSampleSaleOffer()
+	sampleOffer.Status = enums.PUBLISHED
+	mockRepo.getByIDFunc = func(id uint) (*models.SaleOffer, error) {
+		return sampleOffer, nil
+	}
+
+	result, err := service.Renew(1, 1)
+
+	assert.ErrorIs(t, err, sale_offer.ErrOfferNotExpired)
+	assert.Nil(t, result)
+}
+
+func TestSaleOfferService_Renew_NotOwned(t *testing.T) {
+	service, mockRepo, _, _, _, _, _, _ := createMockSaleOfferService()
+
+	sampleOffer := createSampleSaleOffer()
+	sampleOffer.Status = enums.EXPIRED
+	mockRepo.getByIDFunc = func(id uint) (*models.SaleOffer, error) {
+		return sampleOffer, nil
+	}
+
+	result, err := service.Renew(1, 2)
+
+	assert.ErrorIs(t, err, sale_offer.ErrOfferNotOwned)
+	assert.Nil(t, result)
+}
+
+func TestSaleOfferService_Renew_Auction(t *testing.T) {
+	service, mockRepo, _, _, _, _, _, _ := createMockSaleOfferService()
+
+	sampleOffer := createSampleSaleOffer()
+	sampleOffer.Status = enums.EXPIRED
+	sampleOffer.IsAuction = true
+	mockRepo.getByIDFunc = func(id uint) (*models.SaleOffer, error) {
+		return sampleOffer, nil
+	}
+
+	result, err := service.Renew(1, 1)
+
+	assert.ErrorIs(t, err, sale_offer.ErrOfferIsAuction)
+	assert.Nil(t, result)
+}
+
+func TestParseListingPolicy(t *testing.T) {
+	policy, err := sale_offer.ParseListingPolicy("", "")
+	assert.NoError(t, err)
+	assert.Equal(t, sale_offer.DefaultListingPolicy, policy)
+
+	policy, err = sale_offer.ParseListingPolicy("240h", "24h")
+	assert.NoError(t, err)
+	assert.Equal(t, sale_offer.ListingPolicy{Lifetime: 240 * time.Hour, ReminderBefore: 24 * time.Hour}, policy)
+
+	_, err = sale_offer.ParseListingPolicy("abc", "")
+	assert.ErrorIs(t, err, sale_offer.ErrInvalidListingPolicy)
+	_, err = sale_offer.ParseListingPolicy("0s", "")
+	assert.ErrorIs(t, err, sale_offer.ErrInvalidListingPolicy)
+	_, err = sale_offer.ParseListingPolicy("48h", "72h")
+	assert.ErrorIs(t, err, sale_offer.ErrInvalidListingPolicy)
+}
+
 func TestSaleOfferService_Buy_Success(t *testing.T) {
 	service, mockRepo, _, _, _, _, _, mockPurchaseCreator := createMockSaleOfferService()
 
@@ -517,6 +754,8 @@ func TestSaleOfferService_Buy_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, uint(1), result.ID)
+	assert.Len(t, mockRepo.purchaseMessages, 1)
+	assert.Equal(t, sale_offer.OutboxOfferBought, mockRepo.purchaseMessages[0].Kind)
 }
 
 func TestSaleOfferService_Buy_OwnOffer(t *testing.T) {
@@ -719,6 +958,70 @@ func TestSaleOfferService_GetDetailedByID_Success(t *testing.T) {
 	assert.Equal(t, "http://example.com/image1.jpg", result.ImagesUrls[0])
 }
 
+func createSampleOfferEvents() []models.OfferEvent {
+	sellerID, buyerID := uint(1), uint(2)
+	return []models.OfferEvent{
+		sale_offer.NewStatusChangedEvent(1, &sellerID, enums.PENDING, enums.READY),
+		sale_offer.NewStatusChangedEvent(1, &sellerID, enums.READY, enums.PUBLISHED),
+		sale_offer.NewDescriptionChangedEvent(1, &sellerID, "old", "new"),
+		sale_offer.NewPriceChangedEvent(1, &sellerID, 25000, 20000),
+		sale_offer.NewStatusChangedEvent(1, &buyerID, enums.PUBLISHED, enums.SOLD),
+	}
+}
+
+func TestSaleOfferService_GetHistory_OwnerSeesEverything(t *testing.T) {
+	service, mockRepo, _, _, _, _, _, _ := createMockSaleOfferService()
+
+	mockRepo.getByIDFunc = func(id uint) (*models.SaleOffer, error) {
+		return createSampleSaleOffer(), nil
+	}
+	mockRepo.events = createSampleOfferEvents()
+
+	ownerID := uint(1)
+	history, err := service.GetHistory(1, &ownerID)
+
+	assert.NoError(t, err)
+	assert.Len(t, history, 5)
+	assert.Equal(t, uint(2), *history[4].ActorID)
+}
+
+func TestSaleOfferService_GetHistory_PublicSeesOnlyPublicEvents(t *testing.T) {
+	service, mockRepo, _, _, _, _, _, _ := createMockSaleOfferService()
+
+	mockRepo.getByIDFunc = func(id uint) (*models.SaleOffer, error) {
+		return createSampleSaleOffer(), nil
+	}
+	mockRepo.events = createSampleOfferEvents()
+
+	otherUserID := uint(3)
+	for _, userID := range []*uint{nil, &otherUserID} {
+		history, err := service.GetHistory(1, userID)
+
+		assert.NoError(t, err)
+		assert.Len(t, history, 3)
+		assert.Equal(t, enums.STATUS_CHANGED, history[0].Kind)
+		assert.Equal(t, string(enums.PUBLISHED), *history[0].NewValue)
+		assert.Equal(t, enums.PRICE_CHANGED, history[1].Kind)
+		assert.Equal(t, string(enums.SOLD), *history[2].NewValue)
+		for _, event := range history {
+			assert.Nil(t, event.ActorID)
+		}
+	}
+}
+
+func TestSaleOfferService_GetHistory_OfferNotFound(t *testing.T) {
+	service, mockRepo, _, _, _, _, _, _ := createMockSaleOfferService()
+
+	mockRepo.getByIDFunc = func(id uint) (*models.SaleOffer, error) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	history, err := service.GetHistory(1, nil)
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Nil(t, history)
+}
+
 func TestSaleOfferService_GetFiltered_Success(t *testing.T) {
 	service, mockRepo, mockManufacturerRetriever, _, mockImageRetriever, _, mockAccessEvaluator, _ := createMockSaleOfferService()
 
@@ -755,3 +1058,62 @@ func TestSaleOfferService_GetFiltered_Success(t *testing.T) {
 	assert.Len(t, result.Offers, 1)
 	assert.Equal(t, uint(1), result.Offers[0].ID)
 }
+
+func galleryImages() []models.Image {
+	caption := "Dashboard"
+	return []models.Image{
+		{ID: 3, Url: "https://img.test/3.jpg", ThumbnailUrl: "https://img.test/3_thumbnail.jpg", Position: 0},
+		{ID: 1, Url: "https://img.test/1.jpg", ThumbnailUrl: "https://img.test/1_thumbnail.jpg", Position: 1, IsCover: true, Caption: &caption},
+		{ID: 2, Url: "https://img.test/2.jpg", Position: 2},
+	}
+}
+
+func TestSaleOfferService_GetByID_MainURLIsCoverThumbnail(t *testing.T) {
+	service, mockRepo, _, _, mockImageRetriever, _, _, _ := createMockSaleOfferService()
+	mockRepo.getViewByIDFunc = func(id uint) (*views.SaleOfferView, error) {
+		return createSampleSaleOfferView(), nil
+	}
+	mockImageRetriever.getByOfferIDFunc = func(offerID uint) ([]models.Image, error) {
+		return galleryImages(), nil
+	}
+
+	result, err := service.GetByID(1, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://img.test/1_thumbnail.jpg", result.MainURL)
+}
+
+func TestSaleOfferService_GetByID_MainURLWithoutCover(t *testing.T) {
+	service, mockRepo, _, _, mockImageRetriever, _, _, _ := createMockSaleOfferService()
+	mockRepo.getViewByIDFunc = func(id uint) (*views.SaleOfferView, error) {
+		return createSampleSaleOfferView(), nil
+	}
+	mockImageRetriever.getByOfferIDFunc = func(offerID uint) ([]models.Image, error) {
+		return []models.Image{{ID: 2, Url: "https://img.test/2.jpg"}, {ID: 1, Url: "https://img.test/1.jpg"}}, nil
+	}
+
+	result, err := service.GetByID(1, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://img.test/2.jpg", result.MainURL)
+}
+
+func TestSaleOfferService_GetDetailedByID_KeepsGalleryOrder(t *testing.T) {
+	service, mockRepo, _, _, mockImageRetriever, _, _, _ := createMockSaleOfferService()
+	mockRepo.getViewByIDFunc = func(id uint) (*views.SaleOfferView, error) {
+		return createSampleSaleOfferView(), nil
+	}
+	mockImageRetriever.getByOfferIDFunc = func(offerID uint) ([]models.Image, error) {
+		return galleryImages(), nil
+	}
+
+	result, err := service.GetDetailedByID(1, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"https://img.test/3.jpg", "https://img.test/1.jpg", "https://img.test/2.jpg"}, result.ImagesUrls)
+	assert.Len(t, result.Images, 3)
+	assert.Equal(t, uint(1), result.Images[1].ID)
+	assert.True(t, result.Images[1].IsCover)
+	assert.Equal(t, "Dashboard", *result.Images[1].Caption)
+	assert.False(t, result.Images[0].IsCover)
+}
@@ -0,0 +1,246 @@
+package saved_search_tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/saved_search"
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
+)
+
+type manufacturerRetriever struct{}
+
+func (manufacturerRetriever) GetAll() ([]models.Manufacturer, error) {
+	return []models.Manufacturer{{ID: 1, Name: "Audi"}, {ID: 2, Name: "BMW"}}, nil
+}
+
+func makeFilter(manufacturers ...string) sale_offer.BaseOfferFilter {
+	maxPrice := uint(50000)
+	return sale_offer.BaseOfferFilter{
+		Manufacturers: &manufacturers,
+		PriceRange:    &sale_offer.MinMax[uint]{Max: &maxPrice},
+	}
+}
+
+func makeSavedSearch(id, userID uint, name string, filter sale_offer.BaseOfferFilter) models.SavedSearch {
+	search, _ := (&saved_search.CreateSavedSearchDTO{Name: name, Filter: filter}).MapToObject(userID)
+	search.ID = id
+	return *search
+}
+
+// ---------- SERVICE ----------
+
+func TestSavedSearchService_Create_OK(t *testing.T) {
+	repo := new(mocks.SavedSearchRepositoryInterface)
+	svc := saved_search.NewSavedSearchService(repo, manufacturerRetriever{})
+	userID := uint(99)
+	filter := makeFilter("Audi")
+	filter.UserID = &userID
+
+	repo.On("CountByUserID", uint(3)).Return(int64(0), nil)
+	repo.On("Create", mock.MatchedBy(func(s *models.SavedSearch) bool {
+		return s.UserID == 3 && s.Name == "cheap audi"
+	})).Return(nil)
+
+	dto, err := svc.Create(3, &saved_search.CreateSavedSearchDTO{Name: "cheap audi", Filter: filter})
+	assert.NoError(t, err)
+	assert.Equal(t, "cheap audi", dto.Name)
+	assert.Equal(t, []string{"Audi"}, *dto.Filter.Manufacturers)
+	assert.Nil(t, dto.Filter.UserID, "the owner must not be stored in the filter")
+	repo.AssertExpectations(t)
+}
+
+func TestSavedSearchService_Create_InvalidFilter(t *testing.T) {
+	repo := new(mocks.SavedSearchRepositoryInterface)
+	svc := saved_search.NewSavedSearchService(repo, manufacturerRetriever{})
+
+	_, err := svc.Create(3, &saved_search.CreateSavedSearchDTO{Name: "tesla", Filter: makeFilter("Tesla")})
+	assert.ErrorIs(t, err, sale_offer.ErrInvalidManufacturer)
+	repo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestSavedSearchService_Create_MissingName(t *testing.T) {
+	repo := new(mocks.SavedSearchRepositoryInterface)
+	svc := saved_search.NewSavedSearchService(repo, manufacturerRetriever{})
+	repo.On("CountByUserID", uint(3)).Return(int64(0), nil)
+
+	_, err := svc.Create(3, &saved_search.CreateSavedSearchDTO{Name: "  ", Filter: makeFilter("Audi")})
+	assert.ErrorIs(t, err, saved_search.ErrInvalidSavedSearchDTO)
+	repo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestSavedSearchService_Create_LimitReached(t *testing.T) {
+	repo := new(mocks.SavedSearchRepositoryInterface)
+	svc := saved_search.NewSavedSearchService(repo, manufacturerRetriever{})
+	repo.On("CountByUserID", uint(3)).Return(int64(saved_search.MaxSavedSearchesPerUser), nil)
+
+	_, err := svc.Create(3, &saved_search.CreateSavedSearchDTO{Name: "audi", Filter: makeFilter("Audi")})
+	assert.ErrorIs(t, err, saved_search.ErrTooManySavedSearches)
+	repo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestSavedSearchService_Update_NotOwned(t *testing.T) {
+	repo := new(mocks.SavedSearchRepositoryInterface)
+	svc := saved_search.NewSavedSearchService(repo, manufacturerRetriever{})
+	stored := makeSavedSearch(5, 4, "bmw", makeFilter("BMW"))
+	repo.On("GetByID", uint(5)).Return(&stored, nil)
+
+	_, err := svc.Update(3, &saved_search.UpdateSavedSearchDTO{ID: 5, Name: "audi", Filter: makeFilter("Audi")})
+	assert.ErrorIs(t, err, saved_search.ErrSavedSearchNotOwned)
+	repo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestSavedSearchService_Update_OK(t *testing.T) {
+	repo := new(mocks.SavedSearchRepositoryInterface)
+	svc := saved_search.NewSavedSearchService(repo, manufacturerRetriever{})
+	stored := makeSavedSearch(5, 3, "bmw", makeFilter("BMW"))
+	stored.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo.On("GetByID", uint(5)).Return(&stored, nil)
+	repo.On("Update", mock.MatchedBy(func(s *models.SavedSearch) bool {
+		return s.ID == 5 && s.UserID == 3 && s.Name == "audi"
+	})).Return(nil)
+
+	dto, err := svc.Update(3, &saved_search.UpdateSavedSearchDTO{ID: 5, Name: "audi", Filter: makeFilter("Audi")})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Audi"}, *dto.Filter.Manufacturers)
+	assert.Equal(t, "2025-01-01T00:00:00Z", dto.CreatedAt)
+}
+
+func TestSavedSearchService_Delete_NotOwned(t *testing.T) {
+	repo := new(mocks.SavedSearchRepositoryInterface)
+	svc := saved_search.NewSavedSearchService(repo, manufacturerRetriever{})
+	stored := makeSavedSearch(5, 4, "bmw", makeFilter("BMW"))
+	repo.On("GetByID", uint(5)).Return(&stored, nil)
+
+	err := svc.Delete(3, 5)
+	assert.ErrorIs(t, err, saved_search.ErrSavedSearchNotOwned)
+	repo.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+// ---------- MATCHER ----------
+
+type offer struct {
+	id     uint
+	userID uint
+}
+
+func (o offer) GetBrand() string               { return "Audi" }
+func (o offer) GetModel() string               { return "A3" }
+func (o offer) GetPrice() uint                 { return 30000 }
+func (o offer) HasBuyNowPrice() bool           { return false }
+func (o offer) GetStatus() enums.Status        { return enums.PUBLISHED }
+func (o offer) BelongsToUser(userID uint) bool { return o.userID == userID }
+func (o offer) GetID() uint                    { return o.id }
+
+func TestMatcher_NotifiesOwnersOfMatchingSearches(t *testing.T) {
+	repo := new(mocks.SavedSearchRepositoryInterface)
+	notificationService := new(mocks.NotificationServiceInterface)
+	hub := new(mocks.HubInterface)
+	matcher := saved_search.NewMatcher(repo, manufacturerRetriever{}, notificationService, hub)
+
+	searches := []models.SavedSearch{
+		makeSavedSearch(1, 3, "audi", makeFilter("Audi")),
+		makeSavedSearch(2, 4, "bmw", makeFilter("BMW")),
+	}
+	repo.On("GetAll").Return(searches, nil)
+	repo.On("GetMatchingSearchIDs", mock.MatchedBy(func(filters map[uint]sale_offer.OfferFilterInterface) bool {
+		return len(filters) == 2 && *filters[1].GetBase().UserID == 3 && *filters[2].GetBase().UserID == 4
+	}), uint(7)).Return([]uint{1}, nil).Once()
+	notificationService.On("CreateSavedSearchMatchNotification", mock.AnythingOfType("*models.Notification"), "audi", mock.Anything).Return(nil)
+	notificationService.On("SaveNotificationToClient", mock.AnythingOfType("*models.Notification"), uint(3)).Return(nil)
+	hub.On("SendFourLatestNotificationsToUser", "3").Return()
+
+	err := matcher.NotifyMatchingSearches(offer{id: 7, userID: 1})
+	assert.NoError(t, err)
+	notificationService.AssertNumberOfCalls(t, "SaveNotificationToClient", 1)
+	hub.AssertExpectations(t)
+}
+
+func TestMatcher_NotifiesUserOnceForManyMatchingSearches(t *testing.T) {
+	repo := new(mocks.SavedSearchRepositoryInterface)
+	notificationService := new(mocks.NotificationServiceInterface)
+	hub := new(mocks.HubInterface)
+	matcher := saved_search.NewMatcher(repo, manufacturerRetriever{}, notificationService, hub)
+
+	searches := []models.SavedSearch{
+		makeSavedSearch(1, 3, "audi", makeFilter("Audi")),
+		makeSavedSearch(2, 3, "german", makeFilter("Audi", "BMW")),
+	}
+	repo.On("GetAll").Return(searches, nil)
+	repo.On("GetMatchingSearchIDs", mock.Anything, uint(7)).Return([]uint{1, 2}, nil).Once()
+	notificationService.On("CreateSavedSearchMatchNotification", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	notificationService.On("SaveNotificationToClient", mock.Anything, uint(3)).Return(nil)
+	hub.On("SendFourLatestNotificationsToUser", "3").Return()
+
+	err := matcher.NotifyMatchingSearches(offer{id: 7, userID: 1})
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+	notificationService.AssertNumberOfCalls(t, "SaveNotificationToClient", 1)
+}
+
+func TestMatcher_SkipsSearchesOfOfferOwner(t *testing.T) {
+	repo := new(mocks.SavedSearchRepositoryInterface)
+	notificationService := new(mocks.NotificationServiceInterface)
+	hub := new(mocks.HubInterface)
+	matcher := saved_search.NewMatcher(repo, manufacturerRetriever{}, notificationService, hub)
+
+	repo.On("GetAll").Return([]models.SavedSearch{makeSavedSearch(1, 3, "audi", makeFilter("Audi"))}, nil)
+
+	err := matcher.NotifyMatchingSearches(offer{id: 7, userID: 3})
+	assert.NoError(t, err)
+	repo.AssertNotCalled(t, "GetMatchingSearchIDs", mock.Anything, mock.Anything)
+}
+
+func TestMatcher_BrokenSearchDoesNotBlockOthers(t *testing.T) {
+	repo := new(mocks.SavedSearchRepositoryInterface)
+	notificationService := new(mocks.NotificationServiceInterface)
+	hub := new(mocks.HubInterface)
+	matcher := saved_search.NewMatcher(repo, manufacturerRetriever{}, notificationService, hub)
+
+	searches := []models.SavedSearch{
+		makeSavedSearch(1, 3, "tesla", makeFilter("Tesla")),
+		makeSavedSearch(2, 4, "audi", makeFilter("Audi")),
+	}
+	repo.On("GetAll").Return(searches, nil)
+	// Tesla is not a known manufacturer anymore, so the first search is left out of the query
+	repo.On("GetMatchingSearchIDs", mock.MatchedBy(func(filters map[uint]sale_offer.OfferFilterInterface) bool {
+		_, broken := filters[1]
+		return len(filters) == 1 && !broken
+	}), uint(7)).Return([]uint{2}, nil)
+	notificationService.On("CreateSavedSearchMatchNotification", mock.Anything, "audi", mock.Anything).Return(nil)
+	notificationService.On("SaveNotificationToClient", mock.Anything, uint(4)).Return(nil)
+	hub.On("SendFourLatestNotificationsToUser", "4").Return()
+
+	err := matcher.NotifyMatchingSearches(offer{id: 7, userID: 1})
+	assert.NoError(t, err)
+	hub.AssertExpectations(t)
+}
+
+func TestMatcher_FailedNotificationDoesNotBlockOthers(t *testing.T) {
+	repo := new(mocks.SavedSearchRepositoryInterface)
+	notificationService := new(mocks.NotificationServiceInterface)
+	hub := new(mocks.HubInterface)
+	matcher := saved_search.NewMatcher(repo, manufacturerRetriever{}, notificationService, hub)
+
+	searches := []models.SavedSearch{
+		makeSavedSearch(1, 3, "audi", makeFilter("Audi")),
+		makeSavedSearch(2, 4, "german", makeFilter("Audi", "BMW")),
+	}
+	repo.On("GetAll").Return(searches, nil)
+	repo.On("GetMatchingSearchIDs", mock.Anything, uint(7)).Return([]uint{1, 2}, nil)
+	notificationService.On("CreateSavedSearchMatchNotification", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	notificationService.On("SaveNotificationToClient", mock.Anything, uint(3)).Return(errors.New("db down"))
+	notificationService.On("SaveNotificationToClient", mock.Anything, uint(4)).Return(nil)
+	hub.On("SendFourLatestNotificationsToUser", "4").Return()
+
+	err := matcher.NotifyMatchingSearches(offer{id: 7, userID: 1})
+	assert.NoError(t, err)
+	hub.AssertExpectations(t)
+	hub.AssertNotCalled(t, "SendFourLatestNotificationsToUser", "3")
+}
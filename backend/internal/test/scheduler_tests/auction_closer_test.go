@@ -0,0 +1,186 @@
+package scheduler_tests
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/scheduler"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/ws"
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
+	"gorm.io/gorm"
+)
+
+func newTestCloser() (scheduler.AuctionCloserInterface, *mocks.AuctionSettlementRepositoryInterface) {
+	settlementRepo := new(mocks.AuctionSettlementRepositoryInterface)
+	return scheduler.NewAuctionCloser(settlementRepo), settlementRepo
+}
+
+func makePublishedAuction(id uint, reservePrice *uint) *models.SaleOffer {
+	offer := &models.SaleOffer{ID: id, UserID: 5, Status: enums.PUBLISHED}
+	offer.Auction = &models.Auction{OfferID: id, InitialPrice: 1000, ReservePrice: reservePrice}
+	return offer
+}
+
+func decodePayload[T any](t *testing.T, message *models.OutboxMessage) T {
+	var payload T
+	assert.NoError(t, json.Unmarshal([]byte(message.Payload), &payload))
+	return payload
+}
+
+func TestNewSettlement_ReserveNotMet(t *testing.T) {
+	reserve := uint(5000)
+
+	settlement, err := scheduler.NewSettlement(makePublishedAuction(3, &reserve), &models.Bid{AuctionID: 3, BidderID: 7, Amount: 4000}, time.Now())
+
+	assert.NoError(t, err)
+	assert.Equal(t, enums.EXPIRED, settlement.Status)
+	assert.Nil(t, settlement.Purchase)
+	assert.Len(t, settlement.Messages, 1)
+	assert.Equal(t, scheduler.OutboxReserveNotMet, settlement.Messages[0].Kind)
+	assert.Equal(t, scheduler.ReserveNotMetPayload{OfferID: 3, SellerID: 5, HighestBid: 4000}, decodePayload[scheduler.ReserveNotMetPayload](t, settlement.Messages[0]))
+}
+
+func TestNewSettlement_ReserveMet(t *testing.T) {
+	reserve := uint(5000)
+	now := time.Now()
+
+	settlement, err := scheduler.NewSettlement(makePublishedAuction(3, &reserve), &models.Bid{AuctionID: 3, BidderID: 7, Amount: 5000}, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, enums.SOLD, settlement.Status)
+	assert.Equal(t, &models.Purchase{OfferID: 3, BuyerID: 7, FinalPrice: 5000, IssueDate: now}, settlement.Purchase)
+	assert.Len(t, settlement.Messages, 1)
+	assert.Equal(t, scheduler.OutboxAuctionEnded, settlement.Messages[0].Kind)
+	assert.Equal(t, scheduler.AuctionEndedPayload{OfferID: 3, WinnerID: 7, Amount: 5000}, decodePayload[scheduler.AuctionEndedPayload](t, settlement.Messages[0]))
+}
+
+func TestNewSettlement_NoBids(t *testing.T) {
+	settlement, err := scheduler.NewSettlement(makePublishedAuction(3, nil), nil, time.Now())
+
+	assert.NoError(t, err)
+	assert.Equal(t, &scheduler.Settlement{OfferID: 3, Status: enums.EXPIRED}, settlement)
+}
+
+func TestAuctionCloser_Settles(t *testing.T) {
+	closer, settlementRepo := newTestCloser()
+	winnerID, amount := uint(8), uint(9000)
+	cmd := scheduler.CloseCmd{AuctionID: 3, Reason: scheduler.ReasonBuyNow, WinnerID: &winnerID, Amount: &amount}
+
+	settlementRepo.On("Settle", cmd, mock.AnythingOfType("time.Time")).Return(&scheduler.Settlement{OfferID: 3, Status: enums.SOLD}, nil)
+
+	err := closer.CloseAuction(cmd)
+
+	assert.NoError(t, err)
+	settlementRepo.AssertExpectations(t)
+}
+
+func TestAuctionCloser_SkipsAuctionsNoLongerOpen(t *testing.T) {
+	for _, settleErr := range []error{gorm.ErrRecordNotFound, scheduler.ErrAuctionAlreadyClosed, scheduler.ErrAuctionNotPublished} {
+		closer, settlementRepo := newTestCloser()
+		settlementRepo.On("Settle", mock.Anything, mock.Anything).Return(nil, settleErr)
+
+		err := closer.CloseAuction(scheduler.CloseCmd{AuctionID: 3, Reason: scheduler.ReasonTimer})
+
+		assert.NoError(t, err, settleErr.Error())
+	}
+}
+
+func TestAuctionCloser_ExtendedAuction(t *testing.T) {
+	closer, settlementRepo := newTestCloser()
+
+	settlementRepo.On("Settle", mock.Anything, mock.Anything).Return(nil, scheduler.ErrAuctionNotEnded)
+
+	err := closer.CloseAuction(scheduler.CloseCmd{AuctionID: 3, Reason: scheduler.ReasonTimer})
+
+	// the scheduler closes it again at the new end
+	assert.ErrorIs(t, err, scheduler.ErrAuctionNotEnded)
+}
+
+func TestAuctionCloser_SettleFails(t *testing.T) {
+	closer, settlementRepo := newTestCloser()
+	dbErr := errors.New("connection reset")
+
+	settlementRepo.On("Settle", mock.Anything, mock.Anything).Return(nil, dbErr)
+
+	err := closer.CloseAuction(scheduler.CloseCmd{AuctionID: 3, Reason: scheduler.ReasonTimer})
+
+	assert.ErrorIs(t, err, dbErr)
+}
+
+// ---------- RESULT NOTIFICATIONS ----------
+
+type notifierMocks struct {
+	notificationService *mocks.NotificationServiceInterface
+	hub                 *mocks.HubInterface
+	saleOfferRetriever  *mocks.SaleOfferRetrieverInterface
+}
+
+func newTestNotifier() (*scheduler.AuctionResultNotifier, *notifierMocks) {
+	m := &notifierMocks{
+		notificationService: new(mocks.NotificationServiceInterface),
+		hub:                 new(mocks.HubInterface),
+		saleOfferRetriever:  new(mocks.SaleOfferRetrieverInterface),
+	}
+	return scheduler.NewAuctionResultNotifier(m.notificationService, m.hub, m.saleOfferRetriever), m
+}
+
+func TestAuctionResultNotifier_DeliverAuctionEnded(t *testing.T) {
+	notifier, m := newTestNotifier()
+	offerDTO := &sale_offer.RetrieveDetailedSaleOfferDTO{ID: 3, UserID: 5}
+
+	m.saleOfferRetriever.On("GetDetailedByID", uint(3), (*uint)(nil)).Return(offerDTO, nil)
+	m.notificationService.On("CreateEndAuctionNotification", mock.MatchedBy(func(n *models.Notification) bool {
+		return n.OutboxMessageID != nil && *n.OutboxMessageID == 11
+	}), "7", uint(5000), offerDTO).Return(nil)
+	m.hub.On("SaveNotificationForClients", "3", uint(0), mock.Anything).Return(nil)
+	m.hub.On("Broadcast", "3", mock.MatchedBy(func(e *ws.Envelope) bool {
+		return e.MessageType == ws.MsgNotification
+	}), "").Return()
+	m.hub.On("Broadcast", "3", mock.MatchedBy(func(e *ws.Envelope) bool {
+		return e.MessageType == ws.MsgOfferSold && string(e.Data) == `{"offer_id":3,"price":5000}`
+	}), "").Return()
+	m.hub.On("SendFourLatestNotificationsToClients", "3", "0").Return()
+
+	err := notifier.DeliverAuctionEnded(11, `{"offer_id":3,"winner_id":7,"amount":5000}`)
+
+	assert.NoError(t, err)
+	m.notificationService.AssertExpectations(t)
+	m.hub.AssertExpectations(t)
+}
+
+func TestAuctionResultNotifier_DeliverAuctionEnded_Error(t *testing.T) {
+	notifier, m := newTestNotifier()
+	offerDTO := &sale_offer.RetrieveDetailedSaleOfferDTO{ID: 3, UserID: 5}
+	notifErr := errors.New("db down")
+
+	m.saleOfferRetriever.On("GetDetailedByID", uint(3), (*uint)(nil)).Return(offerDTO, nil)
+	m.notificationService.On("CreateEndAuctionNotification", mock.Anything, "7", uint(5000), offerDTO).Return(notifErr)
+
+	err := notifier.DeliverAuctionEnded(11, `{"offer_id":3,"winner_id":7,"amount":5000}`)
+
+	assert.ErrorIs(t, err, notifErr)
+	m.hub.AssertNotCalled(t, "SaveNotificationForClients", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuctionResultNotifier_DeliverReserveNotMet(t *testing.T) {
+	notifier, m := newTestNotifier()
+	offerDTO := &sale_offer.RetrieveDetailedSaleOfferDTO{ID: 3, UserID: 5}
+
+	m.saleOfferRetriever.On("GetDetailedByID", uint(3), (*uint)(nil)).Return(offerDTO, nil)
+	m.notificationService.On("CreateReserveNotMetNotification", mock.Anything, uint(4000), offerDTO).Return(nil)
+	m.notificationService.On("SaveNotificationToClient", mock.Anything, uint(5)).Return(nil)
+	m.hub.On("SendFourLatestNotificationsToClients", "3", "0").Return()
+
+	err := notifier.DeliverReserveNotMet(11, `{"offer_id":3,"seller_id":5,"highest_bid":4000}`)
+
+	assert.NoError(t, err)
+	m.notificationService.AssertExpectations(t)
+	m.notificationService.AssertNotCalled(t, "CreateEndAuctionNotification", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
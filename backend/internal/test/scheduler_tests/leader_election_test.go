@@ -0,0 +1,160 @@
+package scheduler_tests
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/scheduler"
+	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
+	"github.com/susek555/BD2/car-dealer-api/internal/views"
+)
+
+const (
+	testLeaseKey = "test:leader"
+	testLeaseTTL = 300 * time.Millisecond
+)
+
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	return mr, rdb
+}
+
+// campaign runs the election in the background and reports on the returned channel each time
+// the elector becomes the leader. The leadership is held until its context is done.
+func campaign(ctx context.Context, elector scheduler.LeaderElectorInterface, leading *int32) <-chan struct{} {
+	elected := make(chan struct{}, 8)
+	go elector.Campaign(ctx, func(leaderCtx context.Context) {
+		atomic.AddInt32(leading, 1)
+		elected <- struct{}{}
+		<-leaderCtx.Done()
+		atomic.AddInt32(leading, -1)
+	})
+	return elected
+}
+
+func TestLeaderElector_OnlyOneLeader(t *testing.T) {
+	_, rdb := newTestRedis(t)
+	var leading int32
+	ctxA, cancelA := context.WithCancel(context.Background())
+	defer cancelA()
+	ctxB, cancelB := context.WithCancel(context.Background())
+	defer cancelB()
+
+	electedA := campaign(ctxA, scheduler.NewRedisLeaderElector(rdb, testLeaseKey, testLeaseTTL), &leading)
+	<-electedA
+	electedB := campaign(ctxB, scheduler.NewRedisLeaderElector(rdb, testLeaseKey, testLeaseTTL), &leading)
+
+	// several lease periods pass, the leader keeps renewing the lease
+	select {
+	case <-electedB:
+		t.Fatal("second instance became the leader while the first one was leading")
+	case <-time.After(3 * testLeaseTTL):
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&leading))
+
+	cancelA()
+	select {
+	case <-electedB:
+	case <-time.After(3 * testLeaseTTL):
+		t.Fatal("second instance did not take the leadership over")
+	}
+}
+
+func TestLeaderElector_LeadershipLostWhenLeaseTaken(t *testing.T) {
+	mr, rdb := newTestRedis(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lost := make(chan struct{})
+	go scheduler.NewRedisLeaderElector(rdb, testLeaseKey, testLeaseTTL).Campaign(ctx, func(leaderCtx context.Context) {
+		// the lease expired and someone else took it meanwhile
+		mr.Set(testLeaseKey, "other-instance")
+		<-leaderCtx.Done()
+		close(lost)
+	})
+
+	select {
+	case <-lost:
+	case <-time.After(3 * testLeaseTTL):
+		t.Fatal("leader did not step down after losing the lease")
+	}
+	got, err := mr.Get(testLeaseKey)
+	assert.NoError(t, err)
+	assert.Equal(t, "other-instance", got)
+}
+
+func TestScheduler_EventsReachLeader(t *testing.T) {
+	_, rdb := newTestRedis(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	closer := new(mocks.AuctionCloserInterface)
+	saleRepo := new(mocks.SaleOfferRepositoryInterface)
+	loaded := make(chan struct{}, 1)
+	saleRepo.On("GetAllActiveAuctions").Run(func(args mock.Arguments) {
+		loaded <- struct{}{}
+	}).Return([]views.SaleOfferView{}, nil)
+	closed := make(chan scheduler.CloseCmd, 1)
+	closer.On("CloseAuction", mock.Anything).Run(func(args mock.Arguments) {
+		closed <- args.Get(0).(scheduler.CloseCmd)
+	}).Return(nil)
+
+	leader := scheduler.NewSchedulerWithCloser(closer, saleRepo, rdb, scheduler.NewRedisLeaderElector(rdb, testLeaseKey, testLeaseTTL))
+	go leader.Run(ctx)
+	select {
+	case <-loaded:
+	case <-time.After(3 * testLeaseTTL):
+		t.Fatal("scheduler did not become the leader")
+	}
+
+	// an instance which is not the leader accepts the request and passes it on
+	follower := scheduler.NewSchedulerWithCloser(new(mocks.AuctionCloserInterface), saleRepo, rdb, scheduler.NewRedisLeaderElector(rdb, testLeaseKey, testLeaseTTL))
+	follower.ForceCloseAuction("5", 2, 1000)
+
+	select {
+	case cmd := <-closed:
+		assert.Equal(t, uint(5), cmd.AuctionID)
+		assert.Equal(t, scheduler.ReasonBuyNow, cmd.Reason)
+		assert.Equal(t, uint(2), *cmd.WinnerID)
+		assert.Equal(t, uint(1000), *cmd.Amount)
+	case <-time.After(time.Second):
+		t.Fatal("leader did not receive the event")
+	}
+}
+
+func TestScheduler_EventsWaitForNextLeader(t *testing.T) {
+	_, rdb := newTestRedis(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	saleRepo := new(mocks.SaleOfferRepositoryInterface)
+	saleRepo.On("GetAllActiveAuctions").Return([]views.SaleOfferView{}, nil)
+
+	// nobody leads when the buy now comes in
+	follower := scheduler.NewSchedulerWithCloser(new(mocks.AuctionCloserInterface), saleRepo, rdb, scheduler.NewRedisLeaderElector(rdb, testLeaseKey, testLeaseTTL))
+	follower.ForceCloseAuction("5", 2, 1000)
+
+	closer := new(mocks.AuctionCloserInterface)
+	closed := make(chan scheduler.CloseCmd, 1)
+	closer.On("CloseAuction", mock.Anything).Run(func(args mock.Arguments) {
+		closed <- args.Get(0).(scheduler.CloseCmd)
+	}).Return(nil)
+	leader := scheduler.NewSchedulerWithCloser(closer, saleRepo, rdb, scheduler.NewRedisLeaderElector(rdb, testLeaseKey, testLeaseTTL))
+	go leader.Run(ctx)
+
+	select {
+	case cmd := <-closed:
+		assert.Equal(t, uint(5), cmd.AuctionID)
+		assert.Equal(t, scheduler.ReasonBuyNow, cmd.Reason)
+	case <-time.After(3 * testLeaseTTL):
+		t.Fatal("the new leader did not receive the event")
+	}
+}
@@ -0,0 +1,127 @@
+package scheduler_tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/scheduler"
+	"github.com/susek555/BD2/car-dealer-api/internal/enums"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
+)
+
+func makeListing(id uint, expiresAt time.Time) models.SaleOffer {
+	return models.SaleOffer{ID: id, UserID: 5, Status: enums.PUBLISHED, ExpiresAt: &expiresAt}
+}
+
+func TestListingExpirer_ExpiresDueListings(t *testing.T) {
+	repo := new(mocks.ListingExpiryRepositoryInterface)
+	expirer := scheduler.NewListingExpirer(repo, 72*time.Hour)
+	now := time.Now()
+
+	repo.On("GetListingsToExpire", now).Return([]models.SaleOffer{makeListing(1, now.Add(-time.Hour)), makeListing(2, now)}, nil)
+	repo.On("Expire", uint(1), now, mock.MatchedBy(func(m *models.OutboxMessage) bool {
+		return m.Kind == scheduler.OutboxListingExpired && decodePayload[scheduler.ListingExpiredPayload](t, m) == scheduler.ListingExpiredPayload{OfferID: 1, SellerID: 5}
+	})).Return(nil)
+	repo.On("Expire", uint(2), now, mock.Anything).Return(scheduler.ErrListingNotDue)
+	repo.On("GetListingsToRemind", now.Add(72*time.Hour)).Return([]models.SaleOffer{}, nil)
+
+	err := expirer.Sweep(now)
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestListingExpirer_FailedListingDoesNotBlockOthers(t *testing.T) {
+	repo := new(mocks.ListingExpiryRepositoryInterface)
+	expirer := scheduler.NewListingExpirer(repo, 72*time.Hour)
+	now := time.Now()
+
+	repo.On("GetListingsToExpire", now).Return([]models.SaleOffer{makeListing(1, now), makeListing(2, now)}, nil)
+	repo.On("Expire", uint(1), now, mock.Anything).Return(errors.New("db down"))
+	repo.On("Expire", uint(2), now, mock.Anything).Return(nil)
+	repo.On("GetListingsToRemind", mock.Anything).Return([]models.SaleOffer{}, nil)
+
+	err := expirer.Sweep(now)
+
+	assert.NoError(t, err)
+	repo.AssertNumberOfCalls(t, "Expire", 2)
+}
+
+func TestListingExpirer_RemindsAboutListingsExpiringSoon(t *testing.T) {
+	repo := new(mocks.ListingExpiryRepositoryInterface)
+	expirer := scheduler.NewListingExpirer(repo, 72*time.Hour)
+	now := time.Now()
+
+	repo.On("GetListingsToExpire", now).Return([]models.SaleOffer{}, nil)
+	repo.On("GetListingsToRemind", now.Add(72*time.Hour)).Return([]models.SaleOffer{makeListing(3, now.Add(60*time.Hour))}, nil)
+	repo.On("MarkReminded", uint(3), mock.MatchedBy(func(m *models.OutboxMessage) bool {
+		payload := decodePayload[scheduler.ListingExpiryReminderPayload](t, m)
+		return m.Kind == scheduler.OutboxListingExpiryReminder && payload.SellerID == 5 && payload.DaysLeft == 3
+	})).Return(nil)
+
+	err := expirer.Sweep(now)
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestListingExpirer_LoadingError(t *testing.T) {
+	repo := new(mocks.ListingExpiryRepositoryInterface)
+	expirer := scheduler.NewListingExpirer(repo, 72*time.Hour)
+	loadErr := errors.New("db down")
+
+	repo.On("GetListingsToExpire", mock.Anything).Return(nil, loadErr)
+
+	err := expirer.Sweep(time.Now())
+
+	assert.ErrorIs(t, err, loadErr)
+	repo.AssertNotCalled(t, "GetListingsToRemind", mock.Anything)
+}
+
+// ---------- EXPIRY NOTIFICATIONS ----------
+
+func newTestListingNotifier() (*scheduler.ListingExpiryNotifier, *notifierMocks) {
+	m := &notifierMocks{
+		notificationService: new(mocks.NotificationServiceInterface),
+		hub:                 new(mocks.HubInterface),
+		saleOfferRetriever:  new(mocks.SaleOfferRetrieverInterface),
+	}
+	return scheduler.NewListingExpiryNotifier(m.notificationService, m.hub, m.saleOfferRetriever), m
+}
+
+func TestListingExpiryNotifier_DeliverListingExpired(t *testing.T) {
+	notifier, m := newTestListingNotifier()
+	offerDTO := &sale_offer.RetrieveDetailedSaleOfferDTO{ID: 3, UserID: 5}
+
+	m.saleOfferRetriever.On("GetDetailedByID", uint(3), (*uint)(nil)).Return(offerDTO, nil)
+	m.notificationService.On("CreateListingExpiredNotification", mock.Anything, offerDTO).Return(nil)
+	m.notificationService.On("SaveNotificationToClient", mock.Anything, uint(5)).Return(nil)
+	m.hub.On("SendFourLatestNotificationsToUser", "5").Return()
+
+	err := notifier.DeliverListingExpired(11, `{"offer_id":3,"seller_id":5}`)
+
+	assert.NoError(t, err)
+	m.notificationService.AssertExpectations(t)
+	m.hub.AssertExpectations(t)
+}
+
+func TestListingExpiryNotifier_DeliverListingExpiryReminder(t *testing.T) {
+	notifier, m := newTestListingNotifier()
+	offerDTO := &sale_offer.RetrieveDetailedSaleOfferDTO{ID: 3, UserID: 5}
+
+	m.saleOfferRetriever.On("GetDetailedByID", uint(3), (*uint)(nil)).Return(offerDTO, nil)
+	m.notificationService.On("CreateListingExpiryReminderNotification", mock.Anything, uint(3), offerDTO).Return(nil)
+	m.notificationService.On("SaveNotificationToClient", mock.Anything, uint(5)).Return(nil)
+	m.hub.On("SendFourLatestNotificationsToUser", "5").Return()
+
+	err := notifier.DeliverListingExpiryReminder(11, `{"offer_id":3,"seller_id":5,"days_left":3}`)
+
+	assert.NoError(t, err)
+	m.notificationService.AssertExpectations(t)
+	m.hub.AssertExpectations(t)
+}
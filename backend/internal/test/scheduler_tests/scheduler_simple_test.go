@@ -1,21 +1,23 @@
 package scheduler_tests
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/susek555/BD2/car-dealer-api/internal/domains/scheduler"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
 )
 
 func TestScheduler_Creation(t *testing.T) {
 	scheduler := scheduler.NewScheduler(
-		nil, // repo
 		nil, // redis
-		nil, // notification service
 		nil, // sale offer repo
-		nil, // purchase creator
-		nil, // sale offer service
-		nil, // hub
+		nil, // settlement repo
+		nil, // leader elector
 	)
 
 	if scheduler == nil {
@@ -24,17 +26,17 @@ func TestScheduler_Creation(t *testing.T) {
 }
 
 func TestScheduler_AddAuction(t *testing.T) {
-	scheduler := scheduler.NewScheduler(nil, nil, nil, nil, nil, nil, nil)
+	scheduler := scheduler.NewScheduler(nil, nil, nil, nil)
 	scheduler.AddAuction("123", time.Now().Add(1*time.Hour))
 }
 
 func TestScheduler_ForceCloseAuction(t *testing.T) {
-	scheduler := scheduler.NewScheduler(nil, nil, nil, nil, nil, nil, nil)
+	scheduler := scheduler.NewScheduler(nil, nil, nil, nil)
 	scheduler.ForceCloseAuction("123", 456, 1000)
 }
 
 func TestScheduler_LoadAuctions_NilDependency(t *testing.T) {
-	scheduler := scheduler.NewScheduler(nil, nil, nil, nil, nil, nil, nil)
+	scheduler := scheduler.NewScheduler(nil, nil, nil, nil)
 
 	defer func() {
 		if r := recover(); r == nil {
@@ -46,14 +48,41 @@ func TestScheduler_LoadAuctions_NilDependency(t *testing.T) {
 }
 
 func TestScheduler_AddAuctionPastTime(t *testing.T) {
-	scheduler := scheduler.NewScheduler(nil, nil, nil, nil, nil, nil, nil)
+	scheduler := scheduler.NewScheduler(nil, nil, nil, nil)
 	scheduler.AddAuction("123", time.Now().Add(-1*time.Hour))
 }
 
 func TestScheduler_AddAuctionMultiple(t *testing.T) {
-	scheduler := scheduler.NewScheduler(nil, nil, nil, nil, nil, nil, nil)
+	scheduler := scheduler.NewScheduler(nil, nil, nil, nil)
 
 	scheduler.AddAuction("auction1", time.Now().Add(1*time.Hour))
 	scheduler.AddAuction("auction2", time.Now().Add(2*time.Hour))
 	scheduler.AddAuction("auction3", time.Now().Add(30*time.Minute))
 }
+
+func TestScheduler_ClosesExtendedAuctionAtNewEnd(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	closer := new(mocks.AuctionCloserInterface)
+	saleRepo := new(mocks.SaleOfferRepositoryInterface)
+	newEnd := time.Now().Add(200 * time.Millisecond)
+
+	// the bid extending the auction was placed just before its timer fired
+	closer.On("CloseAuction", mock.Anything).Return(scheduler.ErrAuctionNotEnded).Once()
+	saleRepo.On("GetByID", uint(3)).Return(&models.SaleOffer{ID: 3, Auction: &models.Auction{OfferID: 3, DateEnd: newEnd}}, nil)
+	closed := make(chan time.Time, 1)
+	closer.On("CloseAuction", mock.Anything).Run(func(mock.Arguments) {
+		closed <- time.Now()
+	}).Return(nil).Once()
+
+	sched := scheduler.NewSchedulerWithCloser(closer, saleRepo, nil, nil)
+	go sched.Run(ctx)
+	sched.AddAuction("3", time.Now())
+
+	select {
+	case at := <-closed:
+		assert.False(t, at.Before(newEnd))
+	case <-time.After(2 * time.Second):
+		t.Fatal("the extended auction was not closed")
+	}
+}
@@ -130,3 +130,31 @@ func TestDelete_UserFound(t *testing.T) {
 	err := uService.Delete(1)
 	assert.NoError(t, err)
 }
+
+func TestGetSettings_DefaultsToAlertsOn(t *testing.T) {
+	u := createUser()
+	u.PriceDropAlerts = true
+	uRepo := mocks.NewUserRepositoryInterface(t)
+	uRepo.On("GetByID", uint(1)).Return(u, nil)
+	uService := user.NewUserService(uRepo)
+	settings, err := uService.GetSettings(1)
+	assert.NoError(t, err)
+	assert.True(t, settings.PriceDropAlerts)
+}
+
+func TestUpdateSettings_DisablesPriceDropAlerts(t *testing.T) {
+	uRepo := mocks.NewUserRepositoryInterface(t)
+	uRepo.On("GetByID", uint(1)).Return(createUser(), nil)
+	uRepo.On("UpdatePriceDropAlerts", uint(1), false).Return(nil)
+	uService := user.NewUserService(uRepo)
+	err := uService.UpdateSettings(1, &user.UserSettingsDTO{PriceDropAlerts: false})
+	assert.NoError(t, err)
+}
+
+func TestUpdateSettings_UserNotFound(t *testing.T) {
+	uRepo := mocks.NewUserRepositoryInterface(t)
+	uRepo.On("GetByID", uint(1)).Return(nil, gorm.ErrRecordNotFound)
+	uService := user.NewUserService(uRepo)
+	err := uService.UpdateSettings(1, &user.UserSettingsDTO{PriceDropAlerts: false})
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
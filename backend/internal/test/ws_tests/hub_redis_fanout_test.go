@@ -0,0 +1,273 @@
+package ws_tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/notification"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/ws"
+	"github.com/susek555/BD2/car-dealer-api/internal/models"
+	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
+	"github.com/susek555/BD2/car-dealer-api/internal/views"
+)
+
+const readTimeout = 2 * time.Second
+
+// noInteractions stands in for the user offer view, so that clients join only the rooms they subscribe to
+type noInteractions struct{}
+
+func (noInteractions) GetUserInteractionsByOfferID(uint) ([]views.UserOfferRecord, error) {
+	return nil, nil
+}
+
+func (noInteractions) GetUserInteractionsByUserID(uint) ([]views.UserOfferRecord, error) {
+	return nil, nil
+}
+
+// interactions stands in for the user offer view, every user in it interacted with every offer
+type interactions []uint
+
+func (i interactions) GetUserInteractionsByOfferID(offerID uint) ([]views.UserOfferRecord, error) {
+	records := make([]views.UserOfferRecord, 0, len(i))
+	for _, userID := range i {
+		records = append(records, views.UserOfferRecord{UserID: userID, OfferID: offerID})
+	}
+	return records, nil
+}
+
+func (interactions) GetUserInteractionsByUserID(uint) ([]views.UserOfferRecord, error) {
+	return nil, nil
+}
+
+// instance is a single backend replica - its own hub, websocket endpoint and notification service
+type instance struct {
+	hub           ws.HubInterface
+	notifications *mocks.NotificationServiceInterface
+	url           string
+}
+
+func startInstance(t *testing.T, ctx context.Context, mr *miniredis.Miniredis, maxConnections int) *instance {
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	notifications := mocks.NewNotificationServiceInterface(t)
+	hub := ws.NewHub(notifications, noInteractions{}, nil, rdb, maxConnections)
+	go hub.Run()
+	hub.StartRedisFanIn(ctx)
+
+	return &instance{
+		hub:           hub,
+		notifications: notifications,
+		url:           serve(t, hub),
+	}
+}
+
+// serve exposes the hub's websocket endpoint, authenticating the user given in the user query parameter
+func serve(t *testing.T, hub ws.HubInterface) string {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/ws", func(c *gin.Context) {
+		userID, _ := strconv.ParseUint(c.Query("user"), 10, 64)
+		c.Set("userID", uint(userID))
+		c.Set("wsToken", "token")
+		c.Next()
+	}, ws.ServeWS(hub.(*ws.Hub)))
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return "ws" + strings.TrimPrefix(server.URL, "http") + "/ws"
+}
+
+// connect opens a socket for the user and waits until the hub has registered it - the notifications
+// it asks for come back only once it has.
+func (i *instance) connect(t *testing.T, userID uint) *websocket.Conn {
+	i.notifications.On("GetLatestNotificationsByUserID", userID, uint(4)).Return(&notification.NotificationsDTO{}, nil)
+	conn, _, err := i.dial(userID)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	require.NoError(t, conn.WriteJSON(ws.Envelope{MessageType: ws.MsgGetNotifications}))
+	readMessage(t, conn)
+	return conn
+}
+
+func (i *instance) dial(userID uint) (*websocket.Conn, *http.Response, error) {
+	return i.dialVersion(userID, "")
+}
+
+func (i *instance) dialVersion(userID uint, version string) (*websocket.Conn, *http.Response, error) {
+	dialer := websocket.Dialer{Subprotocols: []string{"token"}}
+	return dialer.Dial(i.url+"?user="+strconv.FormatUint(uint64(userID), 10)+"&version="+version, nil)
+}
+
+func readMessage(t *testing.T, conn *websocket.Conn) []byte {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(readTimeout)))
+	_, data, err := conn.ReadMessage()
+	require.NoError(t, err)
+	return data
+}
+
+// event builds a room event the tests tell apart by n
+func event(n int) *ws.Envelope {
+	return ws.NewEnvelope(ws.MsgAuctionExtended, map[string]int{"n": n})
+}
+
+func readEnvelope(t *testing.T, conn *websocket.Conn) ws.Envelope {
+	var envelope ws.Envelope
+	require.NoError(t, json.Unmarshal(readMessage(t, conn), &envelope))
+	return envelope
+}
+
+func assertEvent(t *testing.T, conn *websocket.Conn, n int) ws.Envelope {
+	envelope := readEnvelope(t, conn)
+	assert.Equal(t, ws.MsgAuctionExtended, envelope.MessageType)
+	assert.JSONEq(t, fmt.Sprintf(`{"n":%d}`, n), string(envelope.Data))
+	return envelope
+}
+
+func newCluster(t *testing.T) (*instance, *instance) {
+	mr := miniredis.RunT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	return startInstance(t, ctx, mr, ws.DefaultMaxConnections), startInstance(t, ctx, mr, ws.DefaultMaxConnections)
+}
+
+func TestHub_SubscribeAndBroadcastAcrossInstances(t *testing.T) {
+	a, b := newCluster(t)
+	conn := a.connect(t, 1)
+
+	b.hub.SubscribeUser("1", "7")
+	b.hub.Broadcast("7", event(1), "")
+
+	assertEvent(t, conn, 1)
+}
+
+func TestHub_BroadcastSkipsExcludedUser(t *testing.T) {
+	a, b := newCluster(t)
+	connA := a.connect(t, 1)
+	connB := b.connect(t, 2)
+	a.hub.SubscribeUser("1", "7")
+	a.hub.SubscribeUser("2", "7")
+
+	b.hub.Broadcast("7", event(1), "1")
+	b.hub.Broadcast("7", event(2), "")
+
+	assertEvent(t, connB, 1)
+	assertEvent(t, connB, 2)
+	assertEvent(t, connA, 2)
+}
+
+func TestHub_UserNotificationsDeliveredByOwningInstanceOnly(t *testing.T) {
+	a, b := newCluster(t)
+	conn := b.connect(t, 2)
+
+	a.hub.SendFourLatestNotificationsToUser("2")
+
+	var payload notification.NotificationsDTO
+	require.NoError(t, json.Unmarshal(readMessage(t, conn), &payload))
+	b.notifications.AssertNumberOfCalls(t, "GetLatestNotificationsByUserID", 2)
+	a.notifications.AssertNotCalled(t, "GetLatestNotificationsByUserID", uint(2), uint(4))
+}
+
+func TestHub_RoomNotificationsAcrossInstances(t *testing.T) {
+	a, b := newCluster(t)
+	connA := a.connect(t, 1)
+	connB := b.connect(t, 2)
+	a.hub.SubscribeUser("1", "7")
+	a.hub.SubscribeUser("2", "7")
+
+	// the bidder on instance A is skipped, the other participant on instance B is refreshed
+	a.hub.SendFourLatestNotificationsToClients("7", "1")
+	readMessage(t, connB)
+
+	a.hub.Broadcast("7", event(1), "")
+	assertEvent(t, connA, 1)
+	a.notifications.AssertNumberOfCalls(t, "GetLatestNotificationsByUserID", 1)
+	b.notifications.AssertNumberOfCalls(t, "GetLatestNotificationsByUserID", 2)
+}
+
+func savedNotification(id uint) any {
+	return mock.MatchedBy(func(n *models.Notification) bool { return n.ID == id })
+}
+
+func TestHub_SaveNotificationForRoomMembersOnEveryInstance(t *testing.T) {
+	a, b := newCluster(t)
+	conn := b.connect(t, 2)
+	a.hub.SubscribeUser("2", "7")
+	a.hub.Broadcast("7", event(1), "")
+	assertEvent(t, conn, 1)
+	b.notifications.On("SaveNotificationToClient", savedNotification(5), uint(2)).Return(nil)
+
+	require.NoError(t, a.hub.SaveNotificationForClients("7", 1, &models.Notification{ID: 5}))
+	// the events are applied in order, so the notification is saved before the room is refreshed
+	a.hub.SendFourLatestNotificationsToClients("7", "1")
+	readMessage(t, conn)
+
+	b.notifications.AssertCalled(t, "SaveNotificationToClient", savedNotification(5), uint(2))
+	a.notifications.AssertNotCalled(t, "SaveNotificationToClient", mock.Anything, mock.Anything)
+}
+
+func TestHub_SaveNotificationOncePerUser(t *testing.T) {
+	notifications := mocks.NewNotificationServiceInterface(t)
+	hub := ws.NewHub(notifications, interactions{2, 3}, nil, nil, ws.DefaultMaxConnections)
+	go hub.Run()
+	conn := (&instance{hub: hub, notifications: notifications, url: serve(t, hub)}).connect(t, 2)
+	hub.SubscribeUser("2", "7")
+	hub.Broadcast("7", event(1), "")
+	assertEvent(t, conn, 1)
+	notifications.On("SaveNotificationToClient", savedNotification(5), uint(2)).Return(nil).Once()
+
+	// user 3 is the buyer, user 2 both interacted with the offer and is in its room
+	require.NoError(t, hub.SaveNotificationForClients("7", 3, &models.Notification{ID: 5}))
+
+	notifications.AssertNumberOfCalls(t, "SaveNotificationToClient", 1)
+}
+
+func TestHub_RemoveRoomAcrossInstances(t *testing.T) {
+	a, b := newCluster(t)
+	conn := a.connect(t, 1)
+	a.hub.SubscribeUser("1", "7")
+
+	b.hub.RemoveRoom("7")
+	b.hub.Broadcast("7", event(7), "")
+	b.hub.SubscribeUser("1", "9")
+	b.hub.Broadcast("9", event(9), "")
+
+	// events are applied in order, so had the room survived its message would come first
+	assertEvent(t, conn, 9)
+}
+
+func TestHub_SingleInstanceWithoutRedis(t *testing.T) {
+	notifications := mocks.NewNotificationServiceInterface(t)
+	hub := ws.NewHub(notifications, noInteractions{}, nil, nil, ws.DefaultMaxConnections)
+	go hub.Run()
+	hub.StartRedisFanIn(context.Background())
+
+	// nothing is connected, so nothing is delivered, but nothing blocks on redis either
+	done := make(chan struct{})
+	go func() {
+		hub.SubscribeUser("1", "7")
+		hub.Broadcast("7", event(0), "")
+		hub.SendFourLatestNotificationsToUser("1")
+		hub.RemoveRoom("7")
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(readTimeout):
+		t.Fatal("hub without redis blocked")
+	}
+}
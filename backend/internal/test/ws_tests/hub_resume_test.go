@@ -0,0 +1,274 @@
+package ws_tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/bid"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/notification"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/sale_offer"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/ws"
+	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
+)
+
+// connectV2 opens a socket speaking the second version of the protocol. The welcome comes once the hub has
+// registered it.
+func (i *instance) connectV2(t *testing.T, userID uint) *websocket.Conn {
+	i.notifications.On("GetLatestNotificationsByUserID", userID, uint(4)).Return(&notification.NotificationsDTO{}, nil).Maybe()
+	conn, _, err := i.dialVersion(userID, "2")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	welcome := readEnvelope(t, conn)
+	require.Equal(t, ws.MsgWelcome, welcome.MessageType)
+	assert.JSONEq(t, `{"version":2}`, string(welcome.Data))
+	return conn
+}
+
+func sendEnvelope(t *testing.T, conn *websocket.Conn, messageType ws.MsgType, id string, payload any) {
+	envelope := ws.NewEnvelope(messageType, payload)
+	envelope.ID = id
+	require.NoError(t, conn.WriteJSON(envelope))
+}
+
+func newInstanceWithRedis(t *testing.T) (*instance, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	return startInstance(t, ctx, mr, ws.DefaultMaxConnections), mr
+}
+
+func TestHub_RoomEventsAreNumberedPerRoom(t *testing.T) {
+	a, b := newCluster(t)
+	conn := a.connectV2(t, 1)
+	a.hub.SubscribeUser("1", "7")
+	a.hub.SubscribeUser("1", "9")
+
+	a.hub.Broadcast("7", event(1), "")
+	b.hub.Broadcast("7", event(2), "")
+	b.hub.Broadcast("9", event(3), "")
+
+	first := assertEvent(t, conn, 1)
+	second := assertEvent(t, conn, 2)
+	other := assertEvent(t, conn, 3)
+	assert.Equal(t, "7", first.Room)
+	assert.Equal(t, uint64(1), first.Seq)
+	assert.Equal(t, uint64(2), second.Seq)
+	assert.Equal(t, "9", other.Room)
+	assert.Equal(t, uint64(1), other.Seq)
+}
+
+func TestHub_ResumeReplaysMissedEvents(t *testing.T) {
+	a, b := newCluster(t)
+	conn := a.connectV2(t, 1)
+	a.hub.SubscribeUser("1", "7")
+	a.hub.Broadcast("7", event(1), "")
+	seen := assertEvent(t, conn, 1)
+	require.NoError(t, conn.Close())
+
+	// while the user is away
+	b.hub.Broadcast("7", event(2), "")
+	b.hub.Broadcast("7", event(3), "")
+
+	conn = b.connectV2(t, 1)
+	sendEnvelope(t, conn, ws.MsgResume, "r1", ws.ResumePayload{Rooms: map[string]uint64{"7": seen.Seq}})
+
+	assert.Equal(t, uint64(2), assertEvent(t, conn, 2).Seq)
+	assert.Equal(t, uint64(3), assertEvent(t, conn, 3).Seq)
+	assert.Equal(t, ws.MsgNotifications, readEnvelope(t, conn).MessageType)
+	ack := readEnvelope(t, conn)
+	assert.Equal(t, ws.MsgAck, ack.MessageType)
+	assert.Equal(t, "r1", ack.ID)
+
+	// the resumed connection is in the room again
+	a.hub.Broadcast("7", event(4), "")
+	assert.Equal(t, uint64(4), assertEvent(t, conn, 4).Seq)
+}
+
+func TestHub_ResumeSkipsEventsNotMeantForTheUser(t *testing.T) {
+	a, _ := newCluster(t)
+	a.hub.Broadcast("7", event(1), "1")
+	a.hub.Broadcast("7", event(2), "")
+
+	conn := a.connectV2(t, 1)
+	sendEnvelope(t, conn, ws.MsgResume, "", ws.ResumePayload{Rooms: map[string]uint64{"7": 0}})
+
+	assert.Equal(t, uint64(2), assertEvent(t, conn, 2).Seq)
+}
+
+func TestHub_ResumeAfterEventsExpired(t *testing.T) {
+	a, mr := newInstanceWithRedis(t)
+	a.hub.Broadcast("7", event(1), "")
+	a.hub.Broadcast("7", event(2), "")
+	mr.Del("room.stream.7")
+
+	conn := a.connectV2(t, 1)
+	sendEnvelope(t, conn, ws.MsgResume, "", ws.ResumePayload{Rooms: map[string]uint64{"7": 1}})
+
+	resync := readEnvelope(t, conn)
+	require.Equal(t, ws.MsgResync, resync.MessageType)
+	var payload ws.ResyncPayload
+	require.NoError(t, json.Unmarshal(resync.Data, &payload))
+	assert.Equal(t, ws.ResyncPayload{Room: "7", Seq: 2}, payload)
+}
+
+func TestHub_ResumeUpToDate(t *testing.T) {
+	a, _ := newInstanceWithRedis(t)
+	a.hub.Broadcast("7", event(1), "")
+
+	conn := a.connectV2(t, 1)
+	sendEnvelope(t, conn, ws.MsgResume, "", ws.ResumePayload{Rooms: map[string]uint64{"7": 1, "8": 0}})
+
+	// nothing to replay in either room, so the notifications come straight away
+	assert.Equal(t, ws.MsgNotifications, readEnvelope(t, conn).MessageType)
+}
+
+func TestHub_ResumeRequiresSecondVersion(t *testing.T) {
+	a, _ := newInstanceWithRedis(t)
+	conn := a.connect(t, 1)
+
+	sendEnvelope(t, conn, ws.MsgResume, "r1", ws.ResumePayload{Rooms: map[string]uint64{"7": 0}})
+
+	reply := readEnvelope(t, conn)
+	assert.Equal(t, ws.MsgError, reply.MessageType)
+	assert.Equal(t, "r1", reply.ID)
+}
+
+func TestHub_AcksMessagesWithID(t *testing.T) {
+	a, _ := newInstanceWithRedis(t)
+	conn := a.connectV2(t, 1)
+
+	sendEnvelope(t, conn, ws.MsgSubscribe, "s1", ws.SubscribePayload{Offers: []string{"7"}})
+	ack := readEnvelope(t, conn)
+	a.hub.Broadcast("7", event(1), "")
+
+	assert.Equal(t, ws.Envelope{MessageType: ws.MsgAck, ID: "s1"}, ack)
+	assertEvent(t, conn, 1)
+}
+
+func TestHub_NotificationsAreTypedInSecondVersion(t *testing.T) {
+	a, _ := newInstanceWithRedis(t)
+	conn := a.connectV2(t, 1)
+
+	sendEnvelope(t, conn, ws.MsgGetNotifications, "", nil)
+
+	envelope := readEnvelope(t, conn)
+	assert.Equal(t, ws.MsgNotifications, envelope.MessageType)
+	var payload notification.NotificationsDTO
+	assert.NoError(t, json.Unmarshal(envelope.Data, &payload))
+}
+
+func TestHub_UnsupportedProtocolVersion(t *testing.T) {
+	a, _ := newInstanceWithRedis(t)
+
+	_, resp, err := a.dialVersion(1, "3")
+
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestHub_ResumeWithoutRedis(t *testing.T) {
+	notifications := mocks.NewNotificationServiceInterface(t)
+	hub := ws.NewHub(notifications, noInteractions{}, nil, nil, ws.DefaultMaxConnections)
+	go hub.Run()
+	hub.Broadcast("7", event(1), "")
+
+	notifications.On("GetLatestNotificationsByUserID", uint(1), uint(4)).Return(&notification.NotificationsDTO{}, nil)
+	single := &instance{hub: hub, notifications: notifications, url: serve(t, hub)}
+	conn := single.connectV2(t, 1)
+
+	// the events are not kept in the single instance mode
+	sendEnvelope(t, conn, ws.MsgResume, "", ws.ResumePayload{Rooms: map[string]uint64{"7": 0}})
+
+	assert.Equal(t, ws.MsgResync, readEnvelope(t, conn).MessageType)
+	assert.Equal(t, ws.MsgNotifications, readEnvelope(t, conn).MessageType)
+}
+
+// placeBid makes the user bid through the bid handler, the way the bid endpoint does
+func placeBid(t *testing.T, hub ws.HubInterface, notifications *mocks.NotificationServiceInterface, bidderID uint, dto *bid.ProcessingBidDTO) {
+	bidService := mocks.NewBidServiceInterface(t)
+	bidService.On("Create", &bid.CreateBidDTO{AuctionID: dto.AuctionID, Amount: dto.Amount}, bidderID).Return(dto, nil)
+	handler := bid.NewHandler(bidService, hub, notifications, mocks.NewSchedulerInterface(t))
+
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	body := fmt.Sprintf(`{"auction_id":%d,"amount":%d}`, dto.AuctionID, dto.Amount)
+	c.Request = httptest.NewRequest(http.MethodPost, "/bid", strings.NewReader(body))
+	c.Set("userID", bidderID)
+	handler.CreateBid(c)
+	require.Equal(t, http.StatusCreated, recorder.Code)
+}
+
+func TestHub_ResumeAfterBid(t *testing.T) {
+	a, b := newCluster(t)
+	conn := a.connectV2(t, 1)
+	watcher := a.connectV2(t, 3)
+	a.hub.SubscribeUser("1", "7")
+	a.hub.SubscribeUser("3", "7")
+	a.hub.Broadcast("7", event(1), "")
+	seen := assertEvent(t, conn, 1)
+	assertEvent(t, watcher, 1)
+	require.NoError(t, conn.Close())
+	a.notifications.On("SaveNotificationToClient", mock.Anything, uint(1)).Return(nil).Maybe()
+	a.notifications.On("SaveNotificationToClient", mock.Anything, uint(3)).Return(nil)
+
+	// while user 1 is away, user 2 outbids them on the other instance
+	offer := &sale_offer.RetrieveDetailedSaleOfferDTO{ID: 7, IsAuction: true}
+	b.notifications.On("CreateOutbidNotification", mock.Anything, uint(5000), offer).Return(nil)
+	placeBid(t, b.hub, b.notifications, 2, &bid.ProcessingBidDTO{
+		AuctionID: 7,
+		BidderID:  2,
+		Amount:    5000,
+		Offer:     offer,
+		PlacedBid: &bid.RetrieveBidDTO{AuctionID: 7, BidderID: 2, Amount: 5000},
+	})
+	// the watcher still in the room gets the bid live, together with the refreshed notifications
+	live := make(map[ws.MsgType]ws.Envelope)
+	for range 3 {
+		envelope := readEnvelope(t, watcher)
+		live[envelope.MessageType] = envelope
+	}
+	require.Contains(t, live, ws.MsgNewBid)
+	require.Contains(t, live, ws.MsgNotification)
+	require.Contains(t, live, ws.MsgNotifications)
+
+	conn = a.connectV2(t, 1)
+	sendEnvelope(t, conn, ws.MsgResume, "r1", ws.ResumePayload{Rooms: map[string]uint64{"7": seen.Seq}})
+
+	newBid := readEnvelope(t, conn)
+	assert.Equal(t, ws.MsgNewBid, newBid.MessageType)
+	assert.Equal(t, seen.Seq+1, newBid.Seq)
+	assert.JSONEq(t, `{"offer_id":7,"amount":5000}`, string(newBid.Data))
+	outbid := readEnvelope(t, conn)
+	assert.Equal(t, ws.MsgNotification, outbid.MessageType)
+	assert.Equal(t, seen.Seq+2, outbid.Seq)
+	assert.Equal(t, ws.MsgNotifications, readEnvelope(t, conn).MessageType)
+	assert.Equal(t, ws.MsgAck, readEnvelope(t, conn).MessageType)
+}
+
+func TestParseProtocolVersion(t *testing.T) {
+	version, err := ws.ParseProtocolVersion("")
+	require.NoError(t, err)
+	assert.Equal(t, ws.ProtocolV1, version)
+
+	version, err = ws.ParseProtocolVersion("2")
+	require.NoError(t, err)
+	assert.Equal(t, ws.ProtocolV2, version)
+
+	_, err = ws.ParseProtocolVersion("0")
+	assert.ErrorIs(t, err, ws.ErrUnsupportedProtocolVersion)
+	_, err = ws.ParseProtocolVersion("v2")
+	assert.ErrorIs(t, err, ws.ErrUnsupportedProtocolVersion)
+}
@@ -0,0 +1,156 @@
+package ws_tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/susek555/BD2/car-dealer-api/internal/domains/ws"
+	"github.com/susek555/BD2/car-dealer-api/internal/test/mocks"
+)
+
+func TestHub_AllConnectionsOfUserGetMessages(t *testing.T) {
+	a, b := newCluster(t)
+	phone := a.connect(t, 1)
+	laptop := a.connect(t, 1)
+	tablet := b.connect(t, 1)
+
+	b.hub.SubscribeUser("1", "7")
+	b.hub.Broadcast("7", event(1), "")
+
+	assertEvent(t, phone, 1)
+	assertEvent(t, laptop, 1)
+	assertEvent(t, tablet, 1)
+
+	b.hub.SendFourLatestNotificationsToUser("1")
+
+	readMessage(t, phone)
+	readMessage(t, laptop)
+	readMessage(t, tablet)
+}
+
+func TestHub_NewConnectionJoinsRoomsOfOtherConnections(t *testing.T) {
+	a, _ := newCluster(t)
+	phone := a.connect(t, 1)
+	a.hub.SubscribeUser("1", "7")
+	a.hub.Broadcast("7", event(1), "")
+	readMessage(t, phone)
+
+	laptop := a.connect(t, 1)
+	a.hub.Broadcast("7", event(2), "")
+
+	assertEvent(t, phone, 2)
+	assertEvent(t, laptop, 2)
+}
+
+func TestHub_ClosingOneConnectionKeepsTheOther(t *testing.T) {
+	mr := miniredis.RunT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	a := startInstance(t, ctx, mr, ws.DefaultMaxConnections)
+	b := startInstance(t, ctx, mr, ws.DefaultMaxConnections)
+	phone := a.connect(t, 1)
+	laptop := a.connect(t, 1)
+	a.hub.SubscribeUser("1", "7")
+
+	require.NoError(t, phone.Close())
+	assert.Eventually(t, func() bool {
+		connections, err := mr.ZMembers("presence.1")
+		return err == nil && len(connections) == 1
+	}, readTimeout, 20*time.Millisecond)
+	b.hub.Broadcast("7", event(1), "")
+
+	assertEvent(t, laptop, 1)
+	online, err := b.hub.IsOnline("1")
+	require.NoError(t, err)
+	assert.True(t, online)
+}
+
+func TestHub_PresenceAcrossInstances(t *testing.T) {
+	a, b := newCluster(t)
+
+	online, err := b.hub.IsOnline("1")
+	require.NoError(t, err)
+	assert.False(t, online)
+
+	conn := a.connect(t, 1)
+	online, err = b.hub.IsOnline("1")
+	require.NoError(t, err)
+	assert.True(t, online)
+
+	require.NoError(t, conn.Close())
+	assert.Eventually(t, func() bool {
+		online, err := b.hub.IsOnline("1")
+		return err == nil && !online
+	}, readTimeout, 20*time.Millisecond)
+}
+
+func TestHub_ConnectionLimit(t *testing.T) {
+	mr := miniredis.RunT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	a := startInstance(t, ctx, mr, 2)
+	b := startInstance(t, ctx, mr, 2)
+	a.connect(t, 1)
+	b.connect(t, 1)
+
+	// the limit counts the connections to all instances
+	_, resp, err := a.dial(1)
+
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	// other users are not affected
+	a.connect(t, 2)
+}
+
+func TestGetOnlineStatus(t *testing.T) {
+	hub := mocks.NewHubInterface(t)
+	hub.On("IsOnline", "3").Return(true, nil)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/users/:id/online", ws.GetOnlineStatus(hub))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/3/online", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var status ws.OnlineStatusDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+	assert.Equal(t, ws.OnlineStatusDTO{UserID: 3, Online: true}, status)
+}
+
+func TestGetOnlineStatus_InvalidID(t *testing.T) {
+	hub := mocks.NewHubInterface(t)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/users/:id/online", ws.GetOnlineStatus(hub))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/abc/online", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestParseMaxConnections(t *testing.T) {
+	limit, err := ws.ParseMaxConnections("")
+	require.NoError(t, err)
+	assert.Equal(t, ws.DefaultMaxConnections, limit)
+
+	limit, err = ws.ParseMaxConnections(" 3 ")
+	require.NoError(t, err)
+	assert.Equal(t, 3, limit)
+
+	_, err = ws.ParseMaxConnections("0")
+	assert.ErrorIs(t, err, ws.ErrInvalidMaxConnections)
+	_, err = ws.ParseMaxConnections("many")
+	assert.ErrorIs(t, err, ws.ErrInvalidMaxConnections)
+}
@@ -5,5 +5,6 @@ import "github.com/golang-jwt/jwt/v5"
 type CustomClaims struct {
 	Email  string `json:"email"`
 	UserID int64  `json:"user_id"`
+	Role   string `json:"role,omitempty"`
 	jwt.RegisteredClaims
 }
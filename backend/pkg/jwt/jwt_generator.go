@@ -7,9 +7,15 @@ import (
 )
 
 func GenerateToken(email string, userID int64, secret []byte, expiryTime time.Time) (string, error) {
+	return GenerateTokenWithRole(email, userID, "", secret, expiryTime)
+}
+
+// GenerateTokenWithRole generates a token carrying the role of the user, which is checked by middleware.AuthorizeRole.
+func GenerateTokenWithRole(email string, userID int64, role string, secret []byte, expiryTime time.Time) (string, error) {
 	claims := CustomClaims{
 		Email:  email,
 		UserID: userID,
+		Role:   role,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expiryTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
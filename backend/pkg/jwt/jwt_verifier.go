@@ -15,6 +15,14 @@ func NewJWTVerifier(secret string) *JWTVerifier {
 }
 
 func (j *JWTVerifier) VerifyToken(token string) (int64, error) {
+	claims, err := j.VerifyTokenClaims(token)
+	if err != nil {
+		return 0, err
+	}
+	return claims.UserID, nil
+}
+
+func (j *JWTVerifier) VerifyTokenClaims(token string) (*CustomClaims, error) {
 	claims := &CustomClaims{}
 
 	parsed, err := jwt.ParseWithClaims(
@@ -25,13 +33,13 @@ func (j *JWTVerifier) VerifyToken(token string) (int64, error) {
 	)
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
-			return 0, fmt.Errorf("token expired: %w", err)
+			return nil, fmt.Errorf("token expired: %w", err)
 		}
-		return 0, fmt.Errorf("token parse error: %w", err)
+		return nil, fmt.Errorf("token parse error: %w", err)
 	}
 
 	if !parsed.Valid {
-		return 0, errors.New("invalid token")
+		return nil, errors.New("invalid token")
 	}
-	return claims.UserID, nil
+	return claims, nil
 }
@@ -17,6 +17,7 @@ const (
 type ctxKey string
 
 const userIDKey ctxKey = "userID"
+const userRoleKey ctxKey = "userRole"
 const ctxTokenKey = "wsToken"
 
 func Authenticate(verify *jwt.JWTVerifier) gin.HandlerFunc {
@@ -34,17 +35,32 @@ func Authenticate(verify *jwt.JWTVerifier) gin.HandlerFunc {
 
 		token := strings.TrimPrefix(rawHeader, BearerPrefix)
 
-		userID, err := verify.VerifyToken(token)
+		claims, err := verify.VerifyTokenClaims(token)
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": "forbidden"})
 			return
 		}
 
-		c.Set(string(userIDKey), uint(userID))
+		c.Set(string(userIDKey), uint(claims.UserID))
+		c.Set(string(userRoleKey), claims.Role)
 		c.Next()
 	}
 }
 
+// AuthorizeRole lets through only the users having one of the given roles. It has to be used after Authenticate.
+func AuthorizeRole[R ~string](roles ...R) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString(string(userRoleKey))
+		for _, allowed := range roles {
+			if role == string(allowed) {
+				c.Next()
+				return
+			}
+		}
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": "forbidden"})
+	}
+}
+
 func AuthenticateWebSocket(verify *jwt.JWTVerifier) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		token := c.GetHeader(SecWebSocketHeader)
@@ -79,13 +95,14 @@ func OptionalAuthenticate(verify *jwt.JWTVerifier) gin.HandlerFunc {
 
 		token := strings.TrimPrefix(rawHeader, BearerPrefix)
 
-		userID, err := verify.VerifyToken(token)
+		claims, err := verify.VerifyTokenClaims(token)
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "invalid token"})
 			return
 		}
 
-		c.Set(string(userIDKey), uint(userID))
+		c.Set(string(userIDKey), uint(claims.UserID))
+		c.Set(string(userRoleKey), claims.Role)
 		c.Next()
 	}
 }
@@ -0,0 +1,93 @@
+package redis_lock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrNotAcquired = errors.New("lock is held by someone else")
+	ErrLockLost    = errors.New("lock is no longer held")
+)
+
+// the lock may only be extended or released by its owner - the one who knows the token
+var renewScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// Lock is a lease on a redis key which expires after ttl unless renewed.
+type Lock struct {
+	rdb   *redis.Client
+	key   string
+	token string
+	ttl   time.Duration
+}
+
+// TryAcquire takes the lock if nobody holds it, otherwise returns ErrNotAcquired.
+func TryAcquire(ctx context.Context, rdb *redis.Client, key string, ttl time.Duration) (*Lock, error) {
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+	ok, err := rdb.SetNX(ctx, key, token, ttl).Result()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrNotAcquired
+	}
+	return &Lock{rdb: rdb, key: key, token: token, ttl: ttl}, nil
+}
+
+// Acquire retries TryAcquire every retryInterval until the lock is taken or the context is done.
+func Acquire(ctx context.Context, rdb *redis.Client, key string, ttl time.Duration, retryInterval time.Duration) (*Lock, error) {
+	for {
+		lock, err := TryAcquire(ctx, rdb, key, ttl)
+		if !errors.Is(err, ErrNotAcquired) {
+			return lock, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ErrNotAcquired
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// Renew resets the expiration of the lock to its ttl.
+func (l *Lock) Renew(ctx context.Context) error {
+	renewed, err := renewScript.Run(ctx, l.rdb, []string{l.key}, l.token, l.ttl.Milliseconds()).Int()
+	if err != nil {
+		return err
+	}
+	if renewed == 0 {
+		return ErrLockLost
+	}
+	return nil
+}
+
+// Release gives the lock up, unless it has already expired and was taken by someone else.
+func (l *Lock) Release(ctx context.Context) error {
+	return releaseScript.Run(ctx, l.rdb, []string{l.key}, l.token).Err()
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -52,6 +52,7 @@ SELECT pgivm.create_immv(
     s.margin,
     s.status,
     s.is_auction,
+    s.hidden_at,
     c.vin,
     c.production_year,
     c.mileage,
@@ -79,6 +80,7 @@ SELECT pgivm.create_immv(
 );
 
 CREATE UNIQUE INDEX ON regular_sale_offer_view(id);
+CREATE INDEX ON regular_sale_offer_view USING GIN (offer_search_vector(description, brand, model, vin, registration_number));
 
 SELECT pgivm.create_immv(
   'auction_sale_offer_view',
@@ -92,6 +94,7 @@ SELECT pgivm.create_immv(
     s.margin,
     s.status,
     s.is_auction,
+    s.hidden_at,
     c.vin,
     c.production_year,
     c.mileage,
@@ -119,6 +122,7 @@ SELECT pgivm.create_immv(
 );
 
 CREATE UNIQUE INDEX ON auction_sale_offer_view(id);
+CREATE INDEX ON auction_sale_offer_view USING GIN (offer_search_vector(description, brand, model, vin, registration_number));
 
 CREATE VIEW sale_offer_view AS
 SELECT * FROM regular_sale_offer_view
@@ -1,3 +1,18 @@
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- unaccent() is only STABLE, so it cannot be used in index expressions directly
+CREATE OR REPLACE FUNCTION immutable_unaccent(TEXT) RETURNS TEXT AS $$
+    SELECT public.unaccent('public.unaccent', $1)
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT;
+
+CREATE OR REPLACE FUNCTION offer_search_vector(
+    description TEXT, brand TEXT, model TEXT, vin TEXT, registration_number TEXT
+) RETURNS TSVECTOR AS $$
+    SELECT setweight(to_tsvector('simple', immutable_unaccent(coalesce(brand, '') || ' ' || coalesce(model, ''))), 'A') ||
+           setweight(to_tsvector('simple', immutable_unaccent(coalesce(vin, '') || ' ' || coalesce(registration_number, ''))), 'A') ||
+           setweight(to_tsvector('simple', immutable_unaccent(coalesce(description, ''))), 'B')
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE;
+
 CREATE TYPE SELECTOR AS ENUM (
     'P', 'C'
 );
@@ -27,12 +42,35 @@ CREATE TYPE DOCUMENT_TYPE AS ENUM (
     'invoice', 'receipt', 'other'
 );
 
+CREATE TYPE OFFER_EVENT_KIND AS ENUM (
+    'price_changed', 'status_changed', 'description_changed'
+);
+
+CREATE TYPE USER_ROLE AS ENUM (
+    'user', 'moderator', 'admin'
+);
+
+CREATE TYPE ADMIN_ACTION AS ENUM (
+    'unpublish_offer', 'delete_offer', 'delete_review', 'ban_user', 'unban_user', 'view_bids', 'resolve_report', 'change_role'
+);
+
+CREATE TYPE REPORT_REASON AS ENUM (
+    'scam', 'misleading', 'fake_review', 'offensive', 'spam', 'other', 'duplicate_images'
+);
+
+CREATE TYPE REPORT_OUTCOME AS ENUM (
+    'action_taken', 'dismissed'
+);
+
 CREATE TABLE users (
     id SERIAL PRIMARY KEY,
     username VARCHAR(50) NOT NULL UNIQUE,
     email VARCHAR(50) NOT NULL UNIQUE,
     password VARCHAR(100) NOT NULL,
-    selector SELECTOR NOT NULL
+    selector SELECTOR NOT NULL,
+    price_drop_alerts BOOLEAN NOT NULL DEFAULT TRUE,
+    role USER_ROLE NOT NULL DEFAULT 'user',
+    banned_at TIMESTAMPTZ
 );
 
 INSERT INTO users (id, username, email, password, selector) VALUES
@@ -75,17 +113,27 @@ CREATE TABLE sale_offers (
     date_of_issue TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     margin INTEGER NOT NULL CHECK (margin in (3, 5, 10)) ,
     status OFFER_STATUS NOT NULL,
-    is_auction BOOLEAN DEFAULT FALSE
+    is_auction BOOLEAN DEFAULT FALSE,
+    expires_at TIMESTAMPTZ,
+    expiry_reminder_sent BOOLEAN NOT NULL DEFAULT FALSE,
+    hidden_at TIMESTAMPTZ,
+    blur_plates BOOLEAN NOT NULL DEFAULT FALSE
 );
 
 CREATE INDEX IF NOT EXISTS idx_sale_offers_user_id
   ON sale_offers (user_id);
 
+CREATE INDEX IF NOT EXISTS idx_sale_offers_expires_at
+  ON sale_offers (expires_at)
+  WHERE status = 'published' AND is_auction IS FALSE;
+
 CREATE TABLE auctions (
     offer_id INTEGER PRIMARY KEY REFERENCES sale_offers(id) ON DELETE CASCADE,
     date_end TIMESTAMPTZ NOT NULL,
     buy_now_price INTEGER,
-    initial_price INTEGER NOT NULL
+    initial_price INTEGER NOT NULL,
+    reserve_price INTEGER,
+    extension_count INTEGER NOT NULL DEFAULT 0
 );
 
 CREATE TABLE bids (
@@ -96,6 +144,16 @@ CREATE TABLE bids (
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 
+CREATE TABLE proxy_bids (
+    id SERIAL PRIMARY KEY,
+    auction_id INTEGER NOT NULL REFERENCES auctions(offer_id) ON DELETE CASCADE,
+    bidder_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    max_amount INTEGER NOT NULL,
+    exhausted BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (auction_id, bidder_id)
+);
+
 CREATE TABLE cars (
     offer_id INTEGER PRIMARY KEY REFERENCES sale_offers(id) ON DELETE CASCADE,
     vin VARCHAR(17) NOT NULL,
@@ -120,9 +178,14 @@ CREATE TABLE notifications (
     offer_id INTEGER REFERENCES sale_offers(id) ON DELETE SET NULL,
     title VARCHAR(100) NOT NULL,
     description VARCHAR(200) NOT NULL,
-    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    -- outbox message the notification was sent for, so that a repeated delivery does not duplicate it
+    outbox_message_id INTEGER
 );
 
+CREATE UNIQUE INDEX uq_notifications_outbox_message
+  ON notifications (outbox_message_id);
+
 CREATE TABLE client_notifications (
     id SERIAL PRIMARY KEY,
     user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
@@ -130,6 +193,9 @@ CREATE TABLE client_notifications (
     seen BOOLEAN DEFAULT FALSE
 );
 
+CREATE UNIQUE INDEX uq_client_notifications_notification_user
+  ON client_notifications (notification_id, user_id);
+
 CREATE TABLE liked_offers (
     id SERIAL PRIMARY KEY,
     user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
@@ -157,17 +223,178 @@ CREATE TABLE reviews (
     description VARCHAR(200) NOT NULL,
     rating INTEGER NOT NULL CHECK (rating BETWEEN 1 AND 5),
     review_date TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    offer_id INTEGER REFERENCES purchases(offer_id) ON DELETE SET NULL,
+    reply VARCHAR(500),
+    replied_at TIMESTAMP,
+    helpful_votes INTEGER NOT NULL DEFAULT 0,
+    not_helpful_votes INTEGER NOT NULL DEFAULT 0,
     CHECK (reviewer_id <> reviewee_id)
 );
 
-CREATE UNIQUE INDEX uq_reviews_pair_active
+-- every party of a purchase can review it once
+CREATE UNIQUE INDEX uq_reviews_offer_reviewer
+    ON reviews(offer_id, reviewer_id);
+
+-- reviews written before they were tied to purchases stay one per pair of users
+CREATE UNIQUE INDEX uq_reviews_pair_legacy
     ON reviews(reviewer_id, reviewee_id)
-    WHERE reviewer_id <> 1
+    WHERE offer_id IS NULL
+    AND reviewer_id <> 1
     AND reviewee_id <> 1;
 
+CREATE TABLE review_votes (
+    review_id INTEGER NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    helpful BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (review_id, user_id)
+);
+
 CREATE TABLE images (
     id SERIAL PRIMARY KEY,
     offer_id INTEGER REFERENCES sale_offers(id) ON DELETE CASCADE,
     url VARCHAR(200) NOT NULL UNIQUE,
-    public_id VARCHAR(200) NOT NULL UNIQUE
+    public_id VARCHAR(200) NOT NULL UNIQUE,
+    medium_url VARCHAR(200) NOT NULL DEFAULT '',
+    medium_public_id VARCHAR(200) NOT NULL DEFAULT '',
+    thumbnail_url VARCHAR(200) NOT NULL DEFAULT '',
+    thumbnail_public_id VARCHAR(200) NOT NULL DEFAULT '',
+    position INTEGER NOT NULL DEFAULT 0,
+    is_cover BOOLEAN NOT NULL DEFAULT FALSE,
+    caption VARCHAR(200),
+    perceptual_hash BIGINT
+);
+
+CREATE INDEX idx_images_offer_position ON images(offer_id, position);
+CREATE UNIQUE INDEX uq_images_offer_cover ON images(offer_id) WHERE is_cover;
+
+-- the 16 bit bands of the perceptual hash, duplicates of a photo share at least one of them
+CREATE INDEX idx_images_hash_band_0 ON images(((perceptual_hash >> 0) & 65535));
+CREATE INDEX idx_images_hash_band_1 ON images(((perceptual_hash >> 16) & 65535));
+CREATE INDEX idx_images_hash_band_2 ON images(((perceptual_hash >> 32) & 65535));
+CREATE INDEX idx_images_hash_band_3 ON images(((perceptual_hash >> 48) & 65535));
+
+CREATE TABLE outbox_messages (
+    id SERIAL PRIMARY KEY,
+    kind VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_pending
+  ON outbox_messages (next_attempt_at)
+  WHERE delivered_at IS NULL;
+
+CREATE TABLE saved_searches (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    filter JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
+
+CREATE INDEX IF NOT EXISTS idx_saved_searches_user_id
+  ON saved_searches (user_id);
+
+CREATE TABLE offer_events (
+    id SERIAL PRIMARY KEY,
+    offer_id INTEGER NOT NULL REFERENCES sale_offers(id) ON DELETE CASCADE,
+    actor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    kind OFFER_EVENT_KIND NOT NULL,
+    old_value TEXT,
+    new_value TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_offer_events_offer_id
+  ON offer_events (offer_id, created_at);
+
+CREATE TABLE jobs (
+    name VARCHAR(100) PRIMARY KEY,
+    next_run_at TIMESTAMPTZ,
+    last_run_at TIMESTAMPTZ,
+    last_error TEXT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE admin_audit_logs (
+    id SERIAL PRIMARY KEY,
+    admin_id INTEGER NOT NULL DEFAULT 1 REFERENCES users(id) ON DELETE SET DEFAULT DEFERRABLE,
+    action ADMIN_ACTION NOT NULL,
+    target_id INTEGER NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_audit_logs_created_at
+  ON admin_audit_logs (created_at);
+
+CREATE TABLE reports (
+    id SERIAL PRIMARY KEY,
+    -- NULL for the reports filed by the system itself
+    reporter_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    offer_id INTEGER REFERENCES sale_offers(id) ON DELETE CASCADE,
+    review_id INTEGER REFERENCES reviews(id) ON DELETE CASCADE,
+    reason REPORT_REASON NOT NULL,
+    comment VARCHAR(500),
+    outcome REPORT_OUTCOME,
+    note VARCHAR(500),
+    resolved_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((offer_id IS NULL) <> (review_id IS NULL))
+);
+
+CREATE UNIQUE INDEX uq_reports_reporter_offer
+  ON reports (reporter_id, offer_id)
+  WHERE offer_id IS NOT NULL;
+
+CREATE UNIQUE INDEX uq_reports_reporter_review
+  ON reports (reporter_id, review_id)
+  WHERE review_id IS NOT NULL;
+
+CREATE UNIQUE INDEX uq_reports_system_offer
+  ON reports (offer_id, reason)
+  WHERE reporter_id IS NULL AND resolved_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_reports_open
+  ON reports (created_at)
+  WHERE resolved_at IS NULL;
+
+CREATE TABLE conversations (
+    id SERIAL PRIMARY KEY,
+    offer_id INTEGER NOT NULL REFERENCES sale_offers(id) ON DELETE CASCADE,
+    buyer_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    seller_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    last_message_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (offer_id, buyer_id),
+    CHECK (buyer_id <> seller_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_conversations_buyer
+  ON conversations (buyer_id, last_message_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_conversations_seller
+  ON conversations (seller_id, last_message_at DESC);
+
+CREATE TABLE messages (
+    id SERIAL PRIMARY KEY,
+    conversation_id INTEGER NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    sender_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    body VARCHAR(1000) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    read_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_messages_conversation
+  ON messages (conversation_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_messages_unread
+  ON messages (conversation_id, sender_id)
+  WHERE read_at IS NULL;